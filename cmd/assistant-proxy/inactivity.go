@@ -32,5 +32,6 @@ func (p *proxy) handleInactivityProxyRequest(task *models.Task) error {
 	}
 
 	responseTask := models.NewProxyInactivityResponseTask(data.Channel, postsAny)
+	responseTask.Network = task.Network
 	return queue.GetDefault().Publish(responseTask)
 }
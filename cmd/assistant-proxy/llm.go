@@ -2,10 +2,10 @@ package main
 
 import (
 	"assistant/pkg/api/wikipedia"
-	"assistant/pkg/firestore"
 	"assistant/pkg/log"
 	"assistant/pkg/models"
 	"assistant/pkg/queue"
+	"assistant/pkg/storage"
 	"bufio"
 	"bytes"
 	"encoding/json"
@@ -44,16 +44,19 @@ func (p *proxy) sessionTimeout() time.Duration {
 	return defaultSessionTimeout
 }
 
-func (p *proxy) getOrCreateSession(channel string) (*session, string, []ollamaMessage) {
+func (p *proxy) getOrCreateSession(network, channel string) (*session, string, []ollamaMessage) {
 	timeout := p.sessionTimeout()
 
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	s, ok := p.sessions[channel]
+	// channels are only unique within a network
+	key := network + "/" + channel
+
+	s, ok := p.sessions[key]
 	if !ok || time.Since(s.lastActive) > timeout {
 		s = &session{id: uuid.NewString()}
-		p.sessions[channel] = s
+		p.sessions[key] = s
 	}
 	s.lastActive = time.Now()
 	return s, s.id, append([]ollamaMessage{}, s.messages...)
@@ -191,11 +194,11 @@ var funSearchDescriptions = []string{
 	"alt-tabbing to Wikipedia",
 }
 
-func (p *proxy) handleLLM(requestID string, data models.ProxyLLMRequestTaskData) error {
+func (p *proxy) handleLLM(request *models.Task, data models.ProxyLLMRequestTaskData) error {
 	logger := log.Logger()
 	logger.Debugf(nil, "LLM request from %s in %s: %s", data.Nick, data.Channel, data.Prompt)
 
-	s, sessionID, history := p.getOrCreateSession(data.Channel)
+	s, sessionID, history := p.getOrCreateSession(request.Network, data.Channel)
 
 	messages := []ollamaMessage{}
 	if p.cfg.Proxy.Ollama.Prompt != "" {
@@ -249,6 +252,7 @@ func (p *proxy) handleLLM(requestID string, data models.ProxyLLMRequestTaskData)
 			notifyTask := models.NewProxySummaryResponseTask(data.Channel, data.Nick, "", "", []string{
 				fmt.Sprintf("%s: one moment, %s...", data.Nick, desc),
 			})
+			notifyTask.Network = request.Network
 			if err := queue.GetDefault().Publish(notifyTask); err != nil {
 				logger.Warningf(nil, "error publishing search notification: %s", err)
 			}
@@ -308,15 +312,15 @@ func (p *proxy) handleLLM(requestID string, data models.ProxyLLMRequestTaskData)
 		p.mu.Unlock()
 	}
 
-	fs := firestore.Get()
-	r := models.NewLLMResponse(requestID, sessionID, data.Channel, data.Nick, p.cfg.Proxy.Ollama.Model, data.Prompt, snapshot, sr.complete)
+	fs := storage.Get()
+	r := models.NewLLMResponse(request.ID, sessionID, data.Channel, data.Nick, p.cfg.Proxy.Ollama.Model, data.Prompt, snapshot, sr.complete)
 	if err = fs.CreateLLMResponse(r); err != nil {
-		return fmt.Errorf("error saving LLM response to firestore: %w", err)
+		return fmt.Errorf("error saving LLM response to storage: %w", err)
 	}
 
-	logger.Debugf(nil, "LLM response saved to firestore for %s in %s [complete: %v]", data.Nick, data.Channel, sr.complete)
+	logger.Debugf(nil, "LLM response saved to storage for %s in %s [complete: %v]", data.Nick, data.Channel, sr.complete)
 
-	if err = p.publishResponse(requestID, data.Channel, data.Nick, r.ID, sessionID, !sr.complete); err != nil {
+	if err = p.publishResponse(request, data.Channel, data.Nick, r.ID, sessionID, !sr.complete); err != nil {
 		return err
 	}
 
@@ -337,7 +341,7 @@ func (p *proxy) handleLLM(requestID string, data models.ProxyLLMRequestTaskData)
 			if err := fs.UpdateLLMResponse(r.ID, final); err != nil {
 				logger.Errorf(nil, "error updating LLM response %s: %s", r.ID, err)
 			} else {
-				logger.Debugf(nil, "LLM response %s completed and updated in firestore", r.ID)
+				logger.Debugf(nil, "LLM response %s completed and updated in storage", r.ID)
 			}
 		}()
 	}
@@ -2,9 +2,9 @@ package main
 
 import (
 	"assistant/pkg/config"
-	"assistant/pkg/firestore"
 	"assistant/pkg/log"
 	"assistant/pkg/queue"
+	"assistant/pkg/storage"
 	"context"
 	"os"
 )
@@ -27,8 +27,8 @@ func main() {
 	initializeLogger(ctx, cfg)
 	defer log.Logger().Close()
 
-	initializeFirestore(ctx, cfg)
-	defer firestore.Get().Close()
+	initializeStorage(ctx, cfg)
+	defer storage.Get().Close()
 
 	initializeQueues(ctx, cfg)
 	defer queue.GetDefault().Close()
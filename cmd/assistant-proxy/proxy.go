@@ -67,15 +67,16 @@ func (p *proxy) handleLLMProxyRequest(task *models.Task) error {
 
 	switch data.Handler {
 	case handlerLLM:
-		return p.handleLLM(task.ID, data)
+		return p.handleLLM(task, data)
 	case handlerRoast:
-		return p.handleRoast(task.ID, data)
+		return p.handleRoast(task, data)
 	default:
 		return fmt.Errorf("unknown handler: %s", data.Handler)
 	}
 }
 
-func (p *proxy) publishResponse(requestID, channel, nick, responseID, sessionID string, processing bool) error {
-	task := models.NewProxyLLMResponseTask(requestID, channel, nick, responseID, sessionID, processing)
+func (p *proxy) publishResponse(request *models.Task, channel, nick, responseID, sessionID string, processing bool) error {
+	task := models.NewProxyLLMResponseTask(request.ID, channel, nick, responseID, sessionID, processing)
+	task.Network = request.Network
 	return queue.GetDefault().Publish(task)
 }
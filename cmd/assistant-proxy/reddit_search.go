@@ -36,5 +36,6 @@ func (p *proxy) handleRedditSearchProxyRequest(task *models.Task) error {
 	}
 
 	responseTask := models.NewProxyRedditSearchResponseTask(data.Channel, data.Nick, data.Subreddit, data.Query, postsAny)
+	responseTask.Network = task.Network
 	return queue.GetDefault().Publish(responseTask)
 }
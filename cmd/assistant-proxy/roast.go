@@ -1,9 +1,9 @@
 package main
 
 import (
-	"assistant/pkg/firestore"
 	"assistant/pkg/log"
 	"assistant/pkg/models"
+	"assistant/pkg/storage"
 	"fmt"
 	"strings"
 	"time"
@@ -16,7 +16,7 @@ a short, witty roast (2-3 sentences max) based on what they've been saying. Be f
 hateful. Avoid anything racist, sexist, homophobic, or otherwise bigoted. Keep it lighthearted — the goal is to make the
 channel laugh, not to hurt anyone. Do not use hashtags or emojis. Respond with only the roast, no preamble.`
 
-func (p *proxy) handleRoast(requestID string, data models.ProxyLLMRequestTaskData) error {
+func (p *proxy) handleRoast(request *models.Task, data models.ProxyLLMRequestTaskData) error {
 	logger := log.Logger()
 	logger.Debugf(nil, "roast request from %s in %s", data.Nick, data.Channel)
 
@@ -46,11 +46,11 @@ func (p *proxy) handleRoast(requestID string, data models.ProxyLLMRequestTaskDat
 
 	sessionID := uuid.NewString()
 
-	fs := firestore.Get()
-	r := models.NewLLMResponse(requestID, sessionID, data.Channel, data.Nick, p.cfg.Proxy.Ollama.Model, data.Prompt, snapshot, true)
+	fs := storage.Get()
+	r := models.NewLLMResponse(request.ID, sessionID, data.Channel, data.Nick, p.cfg.Proxy.Ollama.Model, data.Prompt, snapshot, true)
 	if err = fs.CreateLLMResponse(r); err != nil {
-		return fmt.Errorf("error saving roast response to firestore: %w", err)
+		return fmt.Errorf("error saving roast response to storage: %w", err)
 	}
 
-	return p.publishResponse(requestID, data.Channel, data.Nick, r.ID, sessionID, false)
+	return p.publishResponse(request, data.Channel, data.Nick, r.ID, sessionID, false)
 }
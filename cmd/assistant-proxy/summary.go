@@ -38,6 +38,7 @@ func (p *proxy) handleSummaryProxyRequest(task *models.Task) error {
 		// Still publish an empty response so the waiter can unblock
 		if data.RequestID != "" {
 			responseTask := models.NewProxySummaryResponseTaskWithWaiter(data.RequestID, data.Channel, data.Nick, data.URL, "", nil)
+			responseTask.Network = task.Network
 			if publishErr := queue.GetDefault().Publish(responseTask); publishErr != nil {
 				return fmt.Errorf("summary failed: %v; publishing empty response failed: %w", err, publishErr)
 			}
@@ -49,6 +50,7 @@ func (p *proxy) handleSummaryProxyRequest(task *models.Task) error {
 		logger.Debugf(nil, "no summary content for %s", data.URL)
 		if data.RequestID != "" {
 			responseTask := models.NewProxySummaryResponseTaskWithWaiter(data.RequestID, data.Channel, data.Nick, data.URL, "", nil)
+			responseTask.Network = task.Network
 			return queue.GetDefault().Publish(responseTask)
 		}
 		return nil
@@ -60,6 +62,7 @@ func (p *proxy) handleSummaryProxyRequest(task *models.Task) error {
 	} else {
 		responseTask = models.NewProxySummaryResponseTask(data.Channel, data.Nick, data.URL, title, messages)
 	}
+	responseTask.Network = task.Network
 	return queue.GetDefault().Publish(responseTask)
 }
 
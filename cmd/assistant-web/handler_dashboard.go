@@ -1,8 +1,8 @@
 package main
 
 import (
-	"assistant/pkg/firestore"
 	"assistant/pkg/log"
+	"assistant/pkg/storage"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/base64"
@@ -17,12 +17,13 @@ import (
 const dashboardSessionCookie = "dashboard_session"
 
 type dashboardSession struct {
+	Network string
 	Nick    string
 	Channel string
 }
 
-func (s *server) signSessionCookie(nick, channel string, expiry time.Time) string {
-	payload := fmt.Sprintf("%s|%s|%d", nick, channel, expiry.Unix())
+func (s *server) signSessionCookie(network, nick, channel string, expiry time.Time) string {
+	payload := fmt.Sprintf("%s|%s|%s|%d", network, nick, channel, expiry.Unix())
 	mac := hmac.New(sha256.New, []byte(s.cfg.Web.SessionSecret))
 	mac.Write([]byte(payload))
 	sig := base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
@@ -47,17 +48,17 @@ func (s *server) verifySessionCookie(value string) *dashboardSession {
 		return nil
 	}
 
-	fields := strings.SplitN(string(payload), "|", 3)
-	if len(fields) != 3 {
+	fields := strings.SplitN(string(payload), "|", 4)
+	if len(fields) != 4 {
 		return nil
 	}
 
-	expiry, err := strconv.ParseInt(fields[2], 10, 64)
+	expiry, err := strconv.ParseInt(fields[3], 10, 64)
 	if err != nil || time.Now().Unix() > expiry {
 		return nil
 	}
 
-	return &dashboardSession{Nick: fields[0], Channel: fields[1]}
+	return &dashboardSession{Network: fields[0], Nick: fields[1], Channel: fields[2]}
 }
 
 // dashboardAuthHandler validates a single-use auth token, sets a signed session cookie, and redirects to the dashboard.
@@ -65,7 +66,7 @@ func (s *server) dashboardAuthHandler(w http.ResponseWriter, r *http.Request) {
 	logger := log.Logger()
 	tokenValue := r.PathValue("token")
 
-	fs := firestore.Get()
+	fs := storage.Get()
 	token, err := fs.GetAuthToken(tokenValue)
 	if err != nil {
 		logger.Errorf(nil, "error fetching auth token: %s", err)
@@ -83,7 +84,7 @@ func (s *server) dashboardAuthHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	expiry := time.Now().Add(s.cfg.Web.Dashboard.SessionExpiryDuration())
-	cookieValue := s.signSessionCookie(token.Nick, token.Channel, expiry)
+	cookieValue := s.signSessionCookie(token.Network, token.Nick, token.Channel, expiry)
 
 	http.SetCookie(w, &http.Cookie{
 		Name:     dashboardSessionCookie,
@@ -113,6 +114,7 @@ func (s *server) dashboardHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	args := map[string]any{
+		"network": session.Network,
 		"nick":    session.Nick,
 		"channel": session.Channel,
 		"url":     s.cfg.Web.ExternalRootURL,
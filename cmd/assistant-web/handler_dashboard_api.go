@@ -1,10 +1,10 @@
 package main
 
 import (
-	"assistant/pkg/firestore"
 	"assistant/pkg/log"
 	"assistant/pkg/models"
 	"assistant/pkg/penalty"
+	"assistant/pkg/storage"
 	"encoding/json"
 	"net/http"
 	"strconv"
@@ -21,6 +21,7 @@ func (s *server) dashboardUsersHandler(w http.ResponseWriter, r *http.Request) {
 
 	resp, err := s.dashboardRequest(models.DashboardRequestTaskData{
 		Action:  models.DashboardActionListUsers,
+		Network: session.Network,
 		Channel: session.Channel,
 	})
 	if err != nil {
@@ -45,7 +46,7 @@ func (s *server) dashboardAllUsersHandler(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	users, err := firestore.Get().GetAllUsers(session.Channel)
+	users, err := storage.Network(session.Network).GetAllUsers(session.Channel)
 	if err != nil {
 		log.Logger().Errorf(nil, "dashboard all users query failed: %s", err)
 		http.Error(w, "Query failed", http.StatusInternalServerError)
@@ -103,21 +104,23 @@ func (s *server) dashboardActionHandler(w http.ResponseWriter, r *http.Request)
 	}
 
 	if action == "autovoice" {
-		s.handleAutoVoiceAction(w, session.Channel, req.Nick, true, req.IncludeHost)
+		s.handleAutoVoiceAction(w, session, req.Nick, true, req.IncludeHost)
 		return
 	}
 
 	if action == "removeautovoice" {
-		s.handleAutoVoiceAction(w, session.Channel, req.Nick, false, req.IncludeHost)
+		s.handleAutoVoiceAction(w, session, req.Nick, false, req.IncludeHost)
 		return
 	}
 
 	resp, err := s.dashboardRequest(models.DashboardRequestTaskData{
 		Action:   action,
+		Network:  session.Network,
 		Channel:  session.Channel,
 		Nick:     req.Nick,
 		Duration: req.Duration,
 		Reason:   req.Reason,
+		Actor:    session.Nick,
 	})
 	if err != nil {
 		log.Logger().Errorf(nil, "dashboard action %s failed: %s", action, err)
@@ -129,9 +132,10 @@ func (s *server) dashboardActionHandler(w http.ResponseWriter, r *http.Request)
 	json.NewEncoder(w).Encode(resp)
 }
 
-func (s *server) handleAutoVoiceAction(w http.ResponseWriter, channel, nick string, enable, includeHost bool) {
+func (s *server) handleAutoVoiceAction(w http.ResponseWriter, session *dashboardSession, nick string, enable, includeHost bool) {
+	channel := session.Channel
 	logger := log.Logger()
-	fs := firestore.Get()
+	fs := storage.Network(session.Network)
 
 	user, err := fs.GetUserByNick(channel, nick)
 	if err != nil || user == nil {
@@ -154,6 +158,7 @@ func (s *server) handleAutoVoiceAction(w http.ResponseWriter, channel, nick stri
 			Action:  models.DashboardActionUnmute,
 			Channel: channel,
 			Nick:    nick,
+			Actor:   session.Nick,
 		})
 		if err != nil {
 			logger.Warningf(nil, "dashboard auto-voice unmute failed: %s", err)
@@ -181,6 +186,7 @@ func (s *server) handleAutoVoiceAction(w http.ResponseWriter, channel, nick stri
 						Action:  models.DashboardActionUnmute,
 						Channel: channel,
 						Nick:    hu.Nick,
+						Actor:   session.Nick,
 					})
 					if err != nil {
 						logger.Warningf(nil, "dashboard auto-voice unmute failed for %s: %s", hu.Nick, err)
@@ -188,11 +194,13 @@ func (s *server) handleAutoVoiceAction(w http.ResponseWriter, channel, nick stri
 						logger.Warningf(nil, "dashboard auto-voice unmute failed for %s: %s", hu.Nick, resp.Error)
 					}
 				}
+				s.auditDashboard(session, autoVoiceAuditAction(enable), models.AuditTarget(hu.Nick, hu.Host), "")
 				logger.Infof(nil, "dashboard: set auto-voice %v for %s (host match) in %s", enable, hu.Nick, channel)
 			}
 		}
 	}
 
+	s.auditDashboard(session, autoVoiceAuditAction(enable), models.AuditTarget(user.Nick, user.Host), "")
 	logger.Infof(nil, "dashboard: set auto-voice %v for %s in %s", enable, nick, channel)
 
 	w.Header().Set("Content-Type", "application/json")
@@ -212,7 +220,7 @@ func (s *server) dashboardUserHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user, err := firestore.Get().GetUserByNick(session.Channel, nick)
+	user, err := storage.Network(session.Network).GetUserByNick(session.Channel, nick)
 	if err != nil {
 		log.Logger().Errorf(nil, "dashboard user query failed: %s", err)
 		http.Error(w, "Query failed", http.StatusInternalServerError)
@@ -237,10 +245,19 @@ func (s *server) dashboardUserHandler(w http.ResponseWriter, r *http.Request) {
 		})
 	}
 
+	strikes, err := storage.Network(session.Network).UserStrikes(session.Channel, user.Nick, user.Host, user.Account)
+	if err != nil {
+		log.Logger().Errorf(nil, "dashboard user strikes query failed: %s", err)
+	}
+	if strikes == nil {
+		strikes = make([]*models.Strike, 0)
+	}
+
 	result := map[string]any{
 		"nick":             user.Nick,
 		"user_id":          user.UserID,
 		"host":             user.Host,
+		"account":          user.Account,
 		"karma":            user.Karma,
 		"penalty":          user.Penalty,
 		"extended_penalty": user.ExtendedPenalty,
@@ -249,6 +266,8 @@ func (s *server) dashboardUserHandler(w http.ResponseWriter, r *http.Request) {
 		"is_auto_voiced":   user.IsAutoVoiced,
 		"credibility":      credibilityScore(user),
 		"recent_messages":  messages,
+		"strikes":          strikes,
+		"active_strikes":   models.ActiveStrikes(strikes, time.Now()),
 		"created_at":       user.CreatedAt.Unix(),
 		"updated_at":       user.UpdatedAt.Unix(),
 	}
@@ -275,6 +294,7 @@ func (s *server) dashboardBansHandler(w http.ResponseWriter, r *http.Request) {
 
 	resp, err := s.dashboardRequest(models.DashboardRequestTaskData{
 		Action:  models.DashboardActionListBans,
+		Network: session.Network,
 		Channel: session.Channel,
 	})
 	if err != nil {
@@ -299,7 +319,7 @@ func (s *server) dashboardVoiceRequestsHandler(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	fs := firestore.Get()
+	fs := storage.Network(session.Network)
 	ch, err := fs.Channel(session.Channel)
 	if err != nil {
 		log.Logger().Errorf(nil, "dashboard voice requests: error getting channel: %s", err)
@@ -358,8 +378,10 @@ func (s *server) dashboardVoiceRequestActionHandler(w http.ResponseWriter, r *ht
 
 	resp, err := s.dashboardRequest(models.DashboardRequestTaskData{
 		Action:  dashAction,
+		Network: session.Network,
 		Channel: session.Channel,
 		Nick:    req.Nick,
+		Actor:   session.Nick,
 	})
 	if err != nil {
 		log.Logger().Errorf(nil, "dashboard voice request %s failed: %s", action, err)
@@ -381,6 +403,7 @@ func (s *server) dashboardGetTopicHandler(w http.ResponseWriter, r *http.Request
 
 	resp, err := s.dashboardRequest(models.DashboardRequestTaskData{
 		Action:  models.DashboardActionGetTopic,
+		Network: session.Network,
 		Channel: session.Channel,
 	})
 	if err != nil {
@@ -410,8 +433,10 @@ func (s *server) dashboardSetTopicHandler(w http.ResponseWriter, r *http.Request
 
 	resp, err := s.dashboardRequest(models.DashboardRequestTaskData{
 		Action:  models.DashboardActionSetTopic,
+		Network: session.Network,
 		Channel: session.Channel,
 		Topic:   req.Topic,
+		Actor:   session.Nick,
 	})
 	if err != nil {
 		log.Logger().Errorf(nil, "dashboard set topic failed: %s", err)
@@ -441,8 +466,10 @@ func (s *server) dashboardAddBanHandler(w http.ResponseWriter, r *http.Request)
 
 	resp, err := s.dashboardRequest(models.DashboardRequestTaskData{
 		Action:  models.DashboardActionAddBan,
+		Network: session.Network,
 		Channel: session.Channel,
 		Mask:    req.Mask,
+		Actor:   session.Nick,
 	})
 	if err != nil {
 		log.Logger().Errorf(nil, "dashboard add ban failed: %s", err)
@@ -472,8 +499,10 @@ func (s *server) dashboardRemoveBanHandler(w http.ResponseWriter, r *http.Reques
 
 	resp, err := s.dashboardRequest(models.DashboardRequestTaskData{
 		Action:  models.DashboardActionExpireBan,
+		Network: session.Network,
 		Channel: session.Channel,
 		Mask:    req.Mask,
+		Actor:   session.Nick,
 	})
 	if err != nil {
 		log.Logger().Errorf(nil, "dashboard remove ban failed: %s", err)
@@ -493,7 +522,7 @@ func (s *server) dashboardPenaltiesHandler(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	fs := firestore.Get()
+	fs := storage.Network(session.Network)
 
 	type penalty struct {
 		ID    string `json:"id"`
@@ -561,7 +590,7 @@ func (s *server) dashboardExpirePenaltyHandler(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	fs := firestore.Get()
+	fs := storage.Network(session.Network)
 	var taskType string
 
 	switch req.Type {
@@ -598,7 +627,9 @@ func (s *server) dashboardExpirePenaltyHandler(w http.ResponseWriter, r *http.Re
 
 	// execute the removal action via IRC
 	reqData := models.DashboardRequestTaskData{
+		Network: session.Network,
 		Channel: session.Channel,
+		Actor:   session.Nick,
 	}
 	if req.Type == "ban" {
 		data := task.Data.(models.BanRemovalTaskData)
@@ -662,7 +693,7 @@ func (s *server) dashboardUsersByMaskHandler(w http.ResponseWriter, r *http.Requ
 		nick = mask
 	}
 
-	users, err := firestore.Get().GetUsersByMask(session.Channel, nick, userID, host)
+	users, err := storage.Network(session.Network).GetUsersByMask(session.Channel, nick, userID, host)
 	if err != nil {
 		log.Logger().Errorf(nil, "dashboard users by mask query failed: %s", err)
 		http.Error(w, "Query failed", http.StatusInternalServerError)
@@ -705,7 +736,7 @@ func (s *server) dashboardUsersByHostHandler(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	users, err := firestore.Get().GetUsersByHost(session.Channel, host)
+	users, err := storage.Network(session.Network).GetUsersByHost(session.Channel, host)
 	if err != nil {
 		log.Logger().Errorf(nil, "dashboard users by host query failed: %s", err)
 		http.Error(w, "Query failed", http.StatusInternalServerError)
@@ -742,7 +773,7 @@ func (s *server) dashboardSourcesHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	sources, err := firestore.Get().ListSources()
+	sources, err := storage.Get().ListSources()
 	if err != nil {
 		log.Logger().Errorf(nil, "dashboard sources query failed: %s", err)
 		http.Error(w, "Query failed", http.StatusInternalServerError)
@@ -799,7 +830,7 @@ func (s *server) dashboardSourceHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	source, err := firestore.Get().GetSource(id)
+	source, err := storage.Get().GetSource(id)
 	if err != nil {
 		log.Logger().Errorf(nil, "dashboard source query failed: %s", err)
 		http.Error(w, "Query failed", http.StatusInternalServerError)
@@ -843,7 +874,7 @@ func (s *server) dashboardSourceSaveHandler(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	fs := firestore.Get()
+	fs := storage.Get()
 
 	if req.ID == "" {
 		// create new source
@@ -941,7 +972,7 @@ func (s *server) dashboardSourceDeleteHandler(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	if err := firestore.Get().DeleteSource(req.ID); err != nil {
+	if err := storage.Get().DeleteSource(req.ID); err != nil {
 		log.Logger().Errorf(nil, "dashboard delete source failed: %s", err)
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(map[string]any{"success": false, "error": "delete failed"})
@@ -960,7 +991,7 @@ func (s *server) dashboardTopSourcesHandler(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	sources, err := firestore.Get().ListSources()
+	sources, err := storage.Get().ListSources()
 	if err != nil {
 		log.Logger().Errorf(nil, "dashboard top sources query failed: %s", err)
 		http.Error(w, "Query failed", http.StatusInternalServerError)
@@ -1007,7 +1038,7 @@ func (s *server) dashboardUnknownSourcesHandler(w http.ResponseWriter, r *http.R
 		return
 	}
 
-	unmatched, err := firestore.Get().ListUnknownSources()
+	unmatched, err := storage.Get().ListUnknownSources()
 	if err != nil {
 		log.Logger().Errorf(nil, "dashboard unknown sources query failed: %s", err)
 		http.Error(w, "Query failed", http.StatusInternalServerError)
@@ -1047,24 +1078,21 @@ func (s *server) dashboardBannedWordsHandler(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	words, err := firestore.Get().BannedWords(session.Channel)
+	words, err := storage.Network(session.Network).BannedWords(session.Channel)
 	if err != nil {
 		log.Logger().Errorf(nil, "dashboard banned words query failed: %s", err)
 		http.Error(w, "Query failed", http.StatusInternalServerError)
 		return
 	}
 
-	type bannedWord struct {
-		Word string `json:"word"`
-	}
-
-	result := make([]bannedWord, 0, len(words))
+	// entries stored before kinds and actions existed are listed with the ones they're matched and acted on with
 	for _, bw := range words {
-		result = append(result, bannedWord{Word: bw.Word})
+		bw.Kind = bw.MatchKind()
+		bw.Action = bw.ModerationAction()
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(result)
+	json.NewEncoder(w).Encode(words)
 }
 
 func (s *server) dashboardBannedWordAddHandler(w http.ResponseWriter, r *http.Request) {
@@ -1074,21 +1102,29 @@ func (s *server) dashboardBannedWordAddHandler(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	var req struct {
-		Word string `json:"word"`
-	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Word == "" {
+	var bw models.BannedWord
+	if err := json.NewDecoder(r.Body).Decode(&bw); err != nil || bw.Word == "" {
 		http.Error(w, "Word is required", http.StatusBadRequest)
 		return
 	}
 
-	if err := firestore.Get().AddBannedWord(session.Channel, req.Word); err != nil {
+	bw.Kind = bw.MatchKind()
+	bw.Action = bw.ModerationAction()
+	bw.Normalize()
+	if err := bw.Validate(); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"success": false, "error": err.Error()})
+		return
+	}
+
+	if err := storage.Network(session.Network).AddBannedWord(session.Channel, &bw); err != nil {
 		log.Logger().Errorf(nil, "dashboard add banned word failed: %s", err)
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(map[string]any{"success": false, "error": "add failed"})
 		return
 	}
 
+	s.auditDashboard(session, models.AuditActionBannedWordAdd, bw.Word, bw.Settings())
 	log.Logger().Infof(nil, "dashboard: added banned word in %s", session.Channel)
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]any{"success": true})
@@ -1109,13 +1145,14 @@ func (s *server) dashboardBannedWordRemoveHandler(w http.ResponseWriter, r *http
 		return
 	}
 
-	if err := firestore.Get().RemoveBannedWord(session.Channel, req.Word); err != nil {
+	if err := storage.Network(session.Network).RemoveBannedWord(session.Channel, req.Word); err != nil {
 		log.Logger().Errorf(nil, "dashboard remove banned word failed: %s", err)
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(map[string]any{"success": false, "error": "remove failed"})
 		return
 	}
 
+	s.auditDashboard(session, models.AuditActionBannedWordRemove, req.Word, "")
 	log.Logger().Infof(nil, "dashboard: removed banned word in %s", session.Channel)
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]any{"success": true})
@@ -1136,7 +1173,7 @@ func (s *server) dashboardStatsHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	since := time.Now().Add(-time.Duration(hours) * time.Hour)
-	fs := firestore.Get()
+	fs := storage.Network(session.Network)
 	stats, err := fs.GetChannelStats(session.Channel, since)
 	if err != nil {
 		log.Logger().Errorf(nil, "error getting channel stats: %s", err)
@@ -1157,6 +1194,7 @@ func (s *server) dashboardCommandsHandler(w http.ResponseWriter, r *http.Request
 
 	resp, err := s.dashboardRequest(models.DashboardRequestTaskData{
 		Action:  models.DashboardActionListCommands,
+		Network: session.Network,
 		Channel: session.Channel,
 	})
 	if err != nil {
@@ -1182,7 +1220,7 @@ func (s *server) dashboardCommandsHandler(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	ch, err := firestore.Get().Channel(session.Channel)
+	ch, err := storage.Network(session.Network).Channel(session.Channel)
 	if err != nil {
 		log.Logger().Errorf(nil, "error getting channel: %s", err)
 		http.Error(w, "Failed to get channel", http.StatusInternalServerError)
@@ -1190,20 +1228,26 @@ func (s *server) dashboardCommandsHandler(w http.ResponseWriter, r *http.Request
 	}
 
 	disabled := make(map[string]bool)
+	limits := make(map[string][]models.CommandLimit)
 	if ch != nil {
 		for _, d := range ch.DisabledCommands {
 			disabled[d] = true
 		}
+		for _, l := range ch.CommandLimits {
+			limits[l.Command] = append(limits[l.Command], l)
+		}
 	}
 
 	type commandResponse struct {
-		Name         string   `json:"name"`
-		Description  string   `json:"description"`
-		Triggers     []string `json:"triggers"`
-		Usages       []string `json:"usages"`
-		RequiresAuth bool     `json:"requires_auth"`
-		AllowDM      bool     `json:"allow_dm"`
-		Enabled      bool     `json:"enabled"`
+		Name         string                   `json:"name"`
+		Description  string                   `json:"description"`
+		Triggers     []string                 `json:"triggers"`
+		Usages       []string                 `json:"usages"`
+		Arguments    []models.CommandArgument `json:"arguments,omitempty"`
+		RequiresAuth bool                     `json:"requires_auth"`
+		AllowDM      bool                     `json:"allow_dm"`
+		Enabled      bool                     `json:"enabled"`
+		Limits       []models.CommandLimit    `json:"limits"`
 	}
 
 	result := make([]commandResponse, 0, len(commands))
@@ -1213,9 +1257,11 @@ func (s *server) dashboardCommandsHandler(w http.ResponseWriter, r *http.Request
 			Description:  cmd.Description,
 			Triggers:     cmd.Triggers,
 			Usages:       cmd.Usages,
+			Arguments:    cmd.Arguments,
 			RequiresAuth: cmd.RequiresAuth,
 			AllowDM:      cmd.AllowDM,
 			Enabled:      !disabled[cmd.Name],
+			Limits:       limits[cmd.Name],
 		})
 	}
 
@@ -1239,7 +1285,7 @@ func (s *server) dashboardCommandToggleHandler(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	fs := firestore.Get()
+	fs := storage.Network(session.Network)
 	ch, err := fs.Channel(session.Channel)
 	if err != nil || ch == nil {
 		http.Error(w, "Failed to get channel", http.StatusInternalServerError)
@@ -1281,6 +1327,57 @@ func (s *server) dashboardCommandToggleHandler(w http.ResponseWriter, r *http.Re
 	json.NewEncoder(w).Encode(map[string]any{"success": true})
 }
 
+func (s *server) dashboardCommandLimitHandler(w http.ResponseWriter, r *http.Request) {
+	session := s.validateDashboardSession(r)
+	if session == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req struct {
+		models.CommandLimit
+		Remove bool `json:"remove"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Command == "" {
+		http.Error(w, "Command is required", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if !req.Remove {
+		if err := req.CommandLimit.Validate(); err != nil {
+			json.NewEncoder(w).Encode(map[string]any{"success": false, "error": err.Error()})
+			return
+		}
+	}
+
+	fs := storage.Network(session.Network)
+	ch, err := fs.Channel(session.Channel)
+	if err != nil || ch == nil {
+		http.Error(w, "Failed to get channel", http.StatusInternalServerError)
+		return
+	}
+
+	if req.Remove {
+		if !ch.RemoveCommandLimit(req.Command, req.Scope) {
+			json.NewEncoder(w).Encode(map[string]any{"success": false, "error": "no such limit"})
+			return
+		}
+	} else {
+		ch.SetCommandLimit(req.CommandLimit)
+	}
+
+	if err := fs.UpdateChannel(session.Channel, map[string]any{"command_limits": ch.CommandLimits, "updated_at": time.Now()}); err != nil {
+		log.Logger().Errorf(nil, "error updating channel command limits: %s", err)
+		json.NewEncoder(w).Encode(map[string]any{"success": false, "error": "update failed"})
+		return
+	}
+
+	log.Logger().Infof(nil, "dashboard: updated %s limit on %s (remove=%v) in %s", req.Scope, req.Command, req.Remove, session.Channel)
+	json.NewEncoder(w).Encode(map[string]any{"success": true, "limits": ch.CommandLimitsFor(req.Command)})
+}
+
 func (s *server) dashboardCommandUsageHandler(w http.ResponseWriter, r *http.Request) {
 	session := s.validateDashboardSession(r)
 	if session == nil {
@@ -1288,7 +1385,7 @@ func (s *server) dashboardCommandUsageHandler(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	usage, err := firestore.Get().ListCommandUsage(session.Channel)
+	usage, err := storage.Network(session.Network).ListCommandUsage(session.Channel)
 	if err != nil {
 		log.Logger().Errorf(nil, "error listing command usage: %s", err)
 		http.Error(w, "Failed to list command usage", http.StatusInternalServerError)
@@ -1306,7 +1403,7 @@ func (s *server) dashboardDisinfoSourcesHandler(w http.ResponseWriter, r *http.R
 		return
 	}
 
-	sources, err := firestore.Get().DisinformationSources(session.Channel)
+	sources, err := storage.Network(session.Network).DisinformationSources(session.Channel)
 	if err != nil {
 		log.Logger().Errorf(nil, "error listing disinfo sources: %s", err)
 		http.Error(w, "Failed to list sources", http.StatusInternalServerError)
@@ -1345,13 +1442,14 @@ func (s *server) dashboardDisinfoSourceAddHandler(w http.ResponseWriter, r *http
 		return
 	}
 
-	if err := firestore.Get().AddDisinformationSource(session.Channel, req.Source); err != nil {
+	if err := storage.Network(session.Network).AddDisinformationSource(session.Channel, req.Source); err != nil {
 		log.Logger().Errorf(nil, "dashboard add disinfo source failed: %s", err)
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(map[string]any{"success": false, "error": "add failed"})
 		return
 	}
 
+	s.auditDashboard(session, models.AuditActionDisinfoSourceAdd, req.Source, "")
 	log.Logger().Infof(nil, "dashboard: added disinfo source %s in %s", req.Source, session.Channel)
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]any{"success": true})
@@ -1372,13 +1470,14 @@ func (s *server) dashboardDisinfoSourceRemoveHandler(w http.ResponseWriter, r *h
 		return
 	}
 
-	if err := firestore.Get().DeleteDisinformationSource(session.Channel, req.Source); err != nil {
+	if err := storage.Network(session.Network).DeleteDisinformationSource(session.Channel, req.Source); err != nil {
 		log.Logger().Errorf(nil, "dashboard remove disinfo source failed: %s", err)
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(map[string]any{"success": false, "error": "remove failed"})
 		return
 	}
 
+	s.auditDashboard(session, models.AuditActionDisinfoSourceRemove, req.Source, "")
 	log.Logger().Infof(nil, "dashboard: removed disinfo source %s from %s", req.Source, session.Channel)
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]any{"success": true})
@@ -1391,7 +1490,7 @@ func (s *server) dashboardCommunityNotesHandler(w http.ResponseWriter, r *http.R
 		return
 	}
 
-	notes, err := firestore.Get().CommunityNotes(session.Channel)
+	notes, err := storage.Network(session.Network).CommunityNotes(session.Channel)
 	if err != nil {
 		log.Logger().Errorf(nil, "error listing community notes: %s", err)
 		http.Error(w, "Failed to list notes", http.StatusInternalServerError)
@@ -1446,7 +1545,7 @@ func (s *server) dashboardCommunityNoteSaveHandler(w http.ResponseWriter, r *htt
 		note := models.NewCommunityNote(req.Content, "", req.Author)
 		note.Sources = req.Sources
 		note.CounterSources = req.CounterSources
-		if err := firestore.Get().CreateCommunityNote(session.Channel, note); err != nil {
+		if err := storage.Network(session.Network).CreateCommunityNote(session.Channel, note); err != nil {
 			log.Logger().Errorf(nil, "dashboard create community note failed: %s", err)
 			w.Header().Set("Content-Type", "application/json")
 			json.NewEncoder(w).Encode(map[string]any{"success": false, "error": "create failed"})
@@ -1454,7 +1553,7 @@ func (s *server) dashboardCommunityNoteSaveHandler(w http.ResponseWriter, r *htt
 		}
 		log.Logger().Infof(nil, "dashboard: created community note in %s", session.Channel)
 	} else {
-		existing, err := firestore.Get().CommunityNote(session.Channel, req.ID)
+		existing, err := storage.Network(session.Network).CommunityNote(session.Channel, req.ID)
 		if err != nil || existing == nil {
 			w.Header().Set("Content-Type", "application/json")
 			json.NewEncoder(w).Encode(map[string]any{"success": false, "error": "note not found"})
@@ -1464,7 +1563,7 @@ func (s *server) dashboardCommunityNoteSaveHandler(w http.ResponseWriter, r *htt
 		existing.Author = req.Author
 		existing.Sources = req.Sources
 		existing.CounterSources = req.CounterSources
-		if err := firestore.Get().SetCommunityNote(session.Channel, existing); err != nil {
+		if err := storage.Network(session.Network).SetCommunityNote(session.Channel, existing); err != nil {
 			log.Logger().Errorf(nil, "dashboard update community note failed: %s", err)
 			w.Header().Set("Content-Type", "application/json")
 			json.NewEncoder(w).Encode(map[string]any{"success": false, "error": "update failed"})
@@ -1492,7 +1591,7 @@ func (s *server) dashboardCommunityNoteDeleteHandler(w http.ResponseWriter, r *h
 		return
 	}
 
-	if err := firestore.Get().DeleteCommunityNote(session.Channel, req.ID); err != nil {
+	if err := storage.Network(session.Network).DeleteCommunityNote(session.Channel, req.ID); err != nil {
 		log.Logger().Errorf(nil, "dashboard delete community note failed: %s", err)
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(map[string]any{"success": false, "error": "delete failed"})
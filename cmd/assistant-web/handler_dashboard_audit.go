@@ -0,0 +1,136 @@
+package main
+
+import (
+	"assistant/pkg/log"
+	"assistant/pkg/models"
+	"assistant/pkg/storage"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxListedAuditEntries caps the entries the dashboard shows at once. Exports include every matching entry.
+const maxListedAuditEntries = 200
+
+// auditDashboard records a change made directly from the dashboard, without going through the bot, in the channel's
+// audit log.
+func (s *server) auditDashboard(session *dashboardSession, action, target, reason string) {
+	entry := models.NewAuditEntry(action, session.Nick, models.AuditSourceDashboard, target, reason, "")
+	if err := storage.Network(session.Network).AddAuditEntry(session.Channel, entry); err != nil {
+		log.Logger().Errorf(nil, "error adding audit entry for %s of %s in %s: %s", action, target, session.Channel, err)
+	}
+}
+
+func autoVoiceAuditAction(enable bool) string {
+	if enable {
+		return models.AuditActionAutoVoice
+	}
+	return models.AuditActionAutoVoiceRemove
+}
+
+// auditEntries returns the channel's audit log entries matching the request's q, action, source and days parameters,
+// newest first.
+func auditEntries(session *dashboardSession, r *http.Request) ([]*models.AuditEntry, error) {
+	entries, err := storage.Network(session.Network).AuditEntries(session.Channel)
+	if err != nil {
+		return nil, err
+	}
+
+	q := r.URL.Query()
+	query := q.Get("q")
+	action := q.Get("action")
+	source := q.Get("source")
+
+	var since time.Time
+	if days, err := strconv.Atoi(q.Get("days")); err == nil && days > 0 {
+		since = time.Now().AddDate(0, 0, -days)
+	}
+
+	matches := make([]*models.AuditEntry, 0)
+	for _, entry := range entries {
+		if len(action) > 0 && entry.Action != action {
+			continue
+		}
+		if len(source) > 0 && entry.Source != source {
+			continue
+		}
+		if entry.CreatedAt.Before(since) || !entry.Matches(query) {
+			continue
+		}
+		matches = append(matches, entry)
+	}
+
+	return matches, nil
+}
+
+func (s *server) dashboardAuditHandler(w http.ResponseWriter, r *http.Request) {
+	session := s.validateDashboardSession(r)
+	if session == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	entries, err := auditEntries(session, r)
+	if err != nil {
+		log.Logger().Errorf(nil, "error listing audit entries: %s", err)
+		http.Error(w, "Failed to list audit log", http.StatusInternalServerError)
+		return
+	}
+
+	total := len(entries)
+	if total > maxListedAuditEntries {
+		entries = entries[:maxListedAuditEntries]
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{
+		"entries": entries,
+		"total":   total,
+		"actions": models.AuditActions,
+		"sources": models.AuditSources,
+	})
+}
+
+func (s *server) dashboardAuditExportHandler(w http.ResponseWriter, r *http.Request) {
+	session := s.validateDashboardSession(r)
+	if session == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format != "csv" && format != "json" {
+		http.Error(w, "Format must be csv or json", http.StatusBadRequest)
+		return
+	}
+
+	entries, err := auditEntries(session, r)
+	if err != nil {
+		log.Logger().Errorf(nil, "error exporting audit entries: %s", err)
+		http.Error(w, "Failed to export audit log", http.StatusInternalServerError)
+		return
+	}
+
+	filename := fmt.Sprintf("audit-%s-%s.%s", strings.TrimLeft(session.Channel, "#&"), time.Now().Format("2006-01-02"), format)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+
+	log.Logger().Infof(nil, "dashboard: %s exported %d audit entries from %s as %s", session.Nick, len(entries), session.Channel, format)
+
+	if format == "json" {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(entries)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"time", "action", "actor", "source", "target", "reason", "duration"})
+	for _, entry := range entries {
+		cw.Write([]string{entry.CreatedAt.UTC().Format(time.RFC3339), entry.Action, entry.Actor, entry.Source, entry.Target, entry.Reason, entry.Duration})
+	}
+	cw.Flush()
+}
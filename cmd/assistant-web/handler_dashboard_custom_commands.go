@@ -0,0 +1,129 @@
+package main
+
+import (
+	"assistant/pkg/log"
+	"assistant/pkg/models"
+	"assistant/pkg/storage"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+)
+
+func (s *server) dashboardCustomCommandsHandler(w http.ResponseWriter, r *http.Request) {
+	session := s.validateDashboardSession(r)
+	if session == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	ch, err := storage.Network(session.Network).Channel(session.Channel)
+	if err != nil || ch == nil {
+		http.Error(w, "Failed to get channel", http.StatusInternalServerError)
+		return
+	}
+
+	result := ch.CustomCommands
+	if result == nil {
+		result = make([]models.CustomCommand, 0)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+func (s *server) dashboardCustomCommandAddHandler(w http.ResponseWriter, r *http.Request) {
+	session := s.validateDashboardSession(r)
+	if session == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req struct {
+		Trigger string `json:"trigger"`
+		Type    string `json:"type"`
+		Value   string `json:"value"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Trigger == "" || req.Value == "" {
+		http.Error(w, "Trigger and value are required", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	value := strings.TrimSpace(req.Value)
+	switch req.Type {
+	case models.CustomCommandTypeAlias:
+		if !strings.HasPrefix(value, s.cfg.Commands.Prefix) {
+			value = s.cfg.Commands.Prefix + value
+		}
+	case models.CustomCommandTypeReddit:
+		value = strings.TrimPrefix(value, "r/")
+	}
+
+	cc := models.NewCustomCommand(strings.TrimPrefix(req.Trigger, s.cfg.Commands.Prefix), req.Type, value, "dashboard")
+	if err := cc.Validate(); err != nil {
+		json.NewEncoder(w).Encode(map[string]any{"success": false, "error": err.Error()})
+		return
+	}
+
+	fs := storage.Network(session.Network)
+	ch, err := fs.Channel(session.Channel)
+	if err != nil || ch == nil {
+		http.Error(w, "Failed to get channel", http.StatusInternalServerError)
+		return
+	}
+
+	if !ch.AddCustomCommand(cc) {
+		json.NewEncoder(w).Encode(map[string]any{"success": false, "error": "trigger already exists"})
+		return
+	}
+
+	if err := fs.UpdateChannel(session.Channel, map[string]any{"custom_commands": ch.CustomCommands, "updated_at": time.Now()}); err != nil {
+		log.Logger().Errorf(nil, "error updating channel custom commands: %s", err)
+		json.NewEncoder(w).Encode(map[string]any{"success": false, "error": "update failed"})
+		return
+	}
+
+	log.Logger().Infof(nil, "dashboard: added %s %s in %s", cc.Type, cc.Trigger, session.Channel)
+	json.NewEncoder(w).Encode(map[string]any{"success": true})
+}
+
+func (s *server) dashboardCustomCommandRemoveHandler(w http.ResponseWriter, r *http.Request) {
+	session := s.validateDashboardSession(r)
+	if session == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req struct {
+		Trigger string `json:"trigger"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Trigger == "" {
+		http.Error(w, "Trigger is required", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	fs := storage.Network(session.Network)
+	ch, err := fs.Channel(session.Channel)
+	if err != nil || ch == nil {
+		http.Error(w, "Failed to get channel", http.StatusInternalServerError)
+		return
+	}
+
+	if !ch.RemoveCustomCommand(req.Trigger, models.CustomCommandTypeAlias, models.CustomCommandTypeReddit, models.CustomCommandTypeReply) {
+		json.NewEncoder(w).Encode(map[string]any{"success": false, "error": "no such trigger"})
+		return
+	}
+
+	if err := fs.UpdateChannel(session.Channel, map[string]any{"custom_commands": ch.CustomCommands, "updated_at": time.Now()}); err != nil {
+		log.Logger().Errorf(nil, "error updating channel custom commands: %s", err)
+		json.NewEncoder(w).Encode(map[string]any{"success": false, "error": "update failed"})
+		return
+	}
+
+	log.Logger().Infof(nil, "dashboard: removed %s in %s", req.Trigger, session.Channel)
+	json.NewEncoder(w).Encode(map[string]any{"success": true})
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"assistant/pkg/log"
+	"assistant/pkg/models"
+	"assistant/pkg/storage"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+)
+
+func (s *server) dashboardEvasionHandler(w http.ResponseWriter, r *http.Request) {
+	session := s.validateDashboardSession(r)
+	if session == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	ch, err := storage.Network(session.Network).Channel(session.Channel)
+	if err != nil {
+		log.Logger().Errorf(nil, "error getting channel for evasion detection: %s", err)
+		http.Error(w, "Failed to get channel", http.StatusInternalServerError)
+		return
+	}
+
+	detection := models.EvasionDetection{}
+	opsChannel := ""
+	if ch != nil {
+		detection = ch.EvasionDetection
+		opsChannel = ch.OpsChannel
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{
+		"detection":         detection,
+		"ops_channel":       opsChannel,
+		"default_threshold": models.DefaultEvasionThreshold,
+	})
+}
+
+func (s *server) dashboardEvasionUpdateHandler(w http.ResponseWriter, r *http.Request) {
+	session := s.validateDashboardSession(r)
+	if session == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req struct {
+		Detection  models.EvasionDetection `json:"detection"`
+		OpsChannel string                  `json:"ops_channel"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	req.Detection.QuietDuration = strings.TrimSpace(req.Detection.QuietDuration)
+	req.OpsChannel = strings.TrimSpace(req.OpsChannel)
+	if err := req.Detection.Validate(); err != nil {
+		json.NewEncoder(w).Encode(map[string]any{"success": false, "error": err.Error()})
+		return
+	}
+	if err := models.ValidateOpsChannel(req.OpsChannel); err != nil {
+		json.NewEncoder(w).Encode(map[string]any{"success": false, "error": err.Error()})
+		return
+	}
+
+	fs := storage.Network(session.Network)
+	if err := fs.UpdateChannel(session.Channel, map[string]any{"evasion_detection": req.Detection, "ops_channel": req.OpsChannel, "updated_at": time.Now()}); err != nil {
+		log.Logger().Errorf(nil, "error updating channel evasion detection: %s", err)
+		json.NewEncoder(w).Encode(map[string]any{"success": false, "error": "update failed"})
+		return
+	}
+
+	log.Logger().Infof(nil, "dashboard: %s updated evasion detection in %s (enabled=%v, threshold=%d, auto_quiet=%v)", session.Nick, session.Channel, req.Detection.Enabled, req.Detection.ScoreThreshold(), req.Detection.AutoQuiet)
+	json.NewEncoder(w).Encode(map[string]any{"success": true, "detection": req.Detection, "ops_channel": req.OpsChannel})
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"assistant/pkg/log"
+	"assistant/pkg/models"
+	"assistant/pkg/storage"
+	"encoding/json"
+	"net/http"
+)
+
+func (s *server) dashboardFactoidsHandler(w http.ResponseWriter, r *http.Request) {
+	session := s.validateDashboardSession(r)
+	if session == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	factoids, err := storage.Network(session.Network).Factoids(session.Channel)
+	if err != nil {
+		log.Logger().Errorf(nil, "error listing factoids: %s", err)
+		http.Error(w, "Failed to list factoids", http.StatusInternalServerError)
+		return
+	}
+
+	if factoids == nil {
+		factoids = make([]*models.Factoid, 0)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(factoids)
+}
+
+func (s *server) dashboardFactoidSaveHandler(w http.ResponseWriter, r *http.Request) {
+	session := s.validateDashboardSession(r)
+	if session == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req struct {
+		Key     string `json:"key"`
+		Value   string `json:"value"`
+		AliasOf string `json:"alias_of"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Key == "" || (req.Value == "" && req.AliasOf == "") {
+		http.Error(w, "Key and value or alias are required", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	key := models.NormalizeFactoidKey(req.Key)
+	if !models.IsValidFactoidKey(key) {
+		json.NewEncoder(w).Encode(map[string]any{"success": false, "error": "invalid key"})
+		return
+	}
+
+	fs := storage.Network(session.Network)
+	f, err := fs.Factoid(session.Channel, key)
+	if err != nil {
+		http.Error(w, "Failed to get factoid", http.StatusInternalServerError)
+		return
+	}
+
+	if f == nil {
+		f = models.NewFactoid(key, session.Nick)
+	}
+
+	if req.AliasOf != "" {
+		aliasOf := models.NormalizeFactoidKey(req.AliasOf)
+		target, err := fs.Factoid(session.Channel, aliasOf)
+		if err != nil {
+			http.Error(w, "Failed to get factoid", http.StatusInternalServerError)
+			return
+		}
+		if target == nil || aliasOf == key {
+			json.NewEncoder(w).Encode(map[string]any{"success": false, "error": "no such factoid to alias"})
+			return
+		}
+		f.SetAlias(aliasOf, session.Nick)
+	} else {
+		f.SetValue(req.Value, session.Nick)
+	}
+
+	if err := fs.SetFactoid(session.Channel, f); err != nil {
+		log.Logger().Errorf(nil, "error saving factoid: %s", err)
+		json.NewEncoder(w).Encode(map[string]any{"success": false, "error": "save failed"})
+		return
+	}
+
+	log.Logger().Infof(nil, "dashboard: %s saved factoid %s in %s", session.Nick, key, session.Channel)
+	json.NewEncoder(w).Encode(map[string]any{"success": true})
+}
+
+func (s *server) dashboardFactoidLockHandler(w http.ResponseWriter, r *http.Request) {
+	session := s.validateDashboardSession(r)
+	if session == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req struct {
+		Key    string `json:"key"`
+		Locked bool   `json:"locked"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Key == "" {
+		http.Error(w, "Key is required", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	fs := storage.Network(session.Network)
+	f, err := fs.Factoid(session.Channel, models.NormalizeFactoidKey(req.Key))
+	if err != nil {
+		http.Error(w, "Failed to get factoid", http.StatusInternalServerError)
+		return
+	}
+	if f == nil {
+		json.NewEncoder(w).Encode(map[string]any{"success": false, "error": "no such factoid"})
+		return
+	}
+
+	f.Locked = req.Locked
+	f.LockedBy = ""
+	if req.Locked {
+		f.LockedBy = session.Nick
+	}
+
+	if err := fs.SetFactoid(session.Channel, f); err != nil {
+		log.Logger().Errorf(nil, "error saving factoid: %s", err)
+		json.NewEncoder(w).Encode(map[string]any{"success": false, "error": "update failed"})
+		return
+	}
+
+	log.Logger().Infof(nil, "dashboard: %s set factoid %s locked=%v in %s", session.Nick, f.Key, req.Locked, session.Channel)
+	json.NewEncoder(w).Encode(map[string]any{"success": true})
+}
+
+func (s *server) dashboardFactoidDeleteHandler(w http.ResponseWriter, r *http.Request) {
+	session := s.validateDashboardSession(r)
+	if session == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req struct {
+		Key string `json:"key"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Key == "" {
+		http.Error(w, "Key is required", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	key := models.NormalizeFactoidKey(req.Key)
+	if err := storage.Network(session.Network).DeleteFactoid(session.Channel, key); err != nil {
+		log.Logger().Errorf(nil, "error deleting factoid: %s", err)
+		json.NewEncoder(w).Encode(map[string]any{"success": false, "error": "delete failed"})
+		return
+	}
+
+	log.Logger().Infof(nil, "dashboard: %s deleted factoid %s in %s", session.Nick, key, session.Channel)
+	json.NewEncoder(w).Encode(map[string]any{"success": true})
+}
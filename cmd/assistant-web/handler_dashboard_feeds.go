@@ -0,0 +1,202 @@
+package main
+
+import (
+	"assistant/pkg/api/elapse"
+	"assistant/pkg/api/feed"
+	"assistant/pkg/log"
+	"assistant/pkg/models"
+	"assistant/pkg/storage"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+func (s *server) dashboardFeedsHandler(w http.ResponseWriter, r *http.Request) {
+	session := s.validateDashboardSession(r)
+	if session == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	feeds, err := storage.Network(session.Network).Feeds(session.Channel)
+	if err != nil {
+		log.Logger().Errorf(nil, "error listing feeds: %s", err)
+		http.Error(w, "Failed to list feeds", http.StatusInternalServerError)
+		return
+	}
+
+	if feeds == nil {
+		feeds = make([]*models.Feed, 0)
+	}
+
+	// the items a feed has seen are only needed by the poller
+	for _, f := range feeds {
+		f.Seen = nil
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(feeds)
+}
+
+func (s *server) dashboardFeedAddHandler(w http.ResponseWriter, r *http.Request) {
+	session := s.validateDashboardSession(r)
+	if session == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req struct {
+		URL    string `json:"url"`
+		Filter string `json:"filter"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || strings.TrimSpace(req.URL) == "" {
+		http.Error(w, "URL is required", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	input := strings.TrimSpace(req.URL)
+	source, err := feed.Resolve(input)
+	if err != nil {
+		json.NewEncoder(w).Encode(map[string]any{"success": false, "error": "not a feed, subreddit, Mastodon or Bluesky URL"})
+		return
+	}
+
+	fs := storage.Network(session.Network)
+	feeds, err := fs.Feeds(session.Channel)
+	if err != nil {
+		http.Error(w, "Failed to list feeds", http.StatusInternalServerError)
+		return
+	}
+
+	if slices.ContainsFunc(feeds, func(f *models.Feed) bool { return f.FeedURL == source.URL }) {
+		json.NewEncoder(w).Encode(map[string]any{"success": false, "error": "already subscribed"})
+		return
+	}
+
+	if len(feeds) >= models.MaxFeedsPerChannel {
+		json.NewEncoder(w).Encode(map[string]any{"success": false, "error": "too many feeds"})
+		return
+	}
+
+	f, err := feed.Subscribe(source, input, req.Filter, session.Nick)
+	if err != nil {
+		log.Logger().Warningf(nil, "error fetching feed %s: %s", source.URL, err)
+		json.NewEncoder(w).Encode(map[string]any{"success": false, "error": "couldn't read a feed from that URL"})
+		return
+	}
+
+	if err := fs.SetFeed(session.Channel, f); err != nil {
+		log.Logger().Errorf(nil, "error adding feed: %s", err)
+		json.NewEncoder(w).Encode(map[string]any{"success": false, "error": "add failed"})
+		return
+	}
+
+	log.Logger().Infof(nil, "dashboard: %s subscribed %s to feed %s", session.Nick, session.Channel, f.FeedURL)
+	json.NewEncoder(w).Encode(map[string]any{"success": true})
+}
+
+func (s *server) dashboardFeedUpdateHandler(w http.ResponseWriter, r *http.Request) {
+	session := s.validateDashboardSession(r)
+	if session == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req struct {
+		ID              string `json:"id"`
+		Filter          string `json:"filter"`
+		MaxPostsPerHour int    `json:"max_posts_per_hour"`
+		QuietFrom       string `json:"quiet_from"`
+		QuietUntil      string `json:"quiet_until"`
+		TimeZone        string `json:"time_zone"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.ID == "" {
+		http.Error(w, "ID is required", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if req.MaxPostsPerHour < 1 || req.MaxPostsPerHour > models.MaxFeedMaxPostsPerHour {
+		json.NewEncoder(w).Encode(map[string]any{"success": false, "error": "invalid items per hour"})
+		return
+	}
+
+	fs := storage.Network(session.Network)
+	f, err := fs.Feed(session.Channel, req.ID)
+	if err != nil {
+		http.Error(w, "Failed to get feed", http.StatusInternalServerError)
+		return
+	}
+	if f == nil {
+		json.NewEncoder(w).Encode(map[string]any{"success": false, "error": "no such feed"})
+		return
+	}
+
+	f.Filter = strings.TrimSpace(req.Filter)
+	f.MaxPostsPerHour = req.MaxPostsPerHour
+
+	if req.QuietFrom == "" && req.QuietUntil == "" {
+		f.ClearQuietHours()
+	} else {
+		fromHour, fromMinute, err := elapse.ParseTimeOfDay(req.QuietFrom)
+		if err != nil {
+			json.NewEncoder(w).Encode(map[string]any{"success": false, "error": "invalid quiet hours"})
+			return
+		}
+		untilHour, untilMinute, err := elapse.ParseTimeOfDay(req.QuietUntil)
+		if err != nil {
+			json.NewEncoder(w).Encode(map[string]any{"success": false, "error": "invalid quiet hours"})
+			return
+		}
+		loc, err := time.LoadLocation(req.TimeZone)
+		if err != nil {
+			json.NewEncoder(w).Encode(map[string]any{"success": false, "error": "invalid time zone"})
+			return
+		}
+		if err := f.SetQuietHours(fromHour, fromMinute, untilHour, untilMinute, loc); err != nil {
+			json.NewEncoder(w).Encode(map[string]any{"success": false, "error": err.Error()})
+			return
+		}
+	}
+
+	if err := fs.SetFeed(session.Channel, f); err != nil {
+		log.Logger().Errorf(nil, "error updating feed: %s", err)
+		json.NewEncoder(w).Encode(map[string]any{"success": false, "error": "update failed"})
+		return
+	}
+
+	log.Logger().Infof(nil, "dashboard: %s updated feed %s in %s", session.Nick, f.ID, session.Channel)
+	json.NewEncoder(w).Encode(map[string]any{"success": true})
+}
+
+func (s *server) dashboardFeedDeleteHandler(w http.ResponseWriter, r *http.Request) {
+	session := s.validateDashboardSession(r)
+	if session == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req struct {
+		ID string `json:"id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.ID == "" {
+		http.Error(w, "ID is required", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := storage.Network(session.Network).DeleteFeed(session.Channel, req.ID); err != nil {
+		log.Logger().Errorf(nil, "error deleting feed: %s", err)
+		json.NewEncoder(w).Encode(map[string]any{"success": false, "error": "delete failed"})
+		return
+	}
+
+	log.Logger().Infof(nil, "dashboard: %s deleted feed %s in %s", session.Nick, req.ID, session.Channel)
+	json.NewEncoder(w).Encode(map[string]any{"success": true})
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"assistant/pkg/log"
+	"assistant/pkg/models"
+	"assistant/pkg/storage"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+)
+
+func (s *server) dashboardRolesHandler(w http.ResponseWriter, r *http.Request) {
+	session := s.validateDashboardSession(r)
+	if session == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	ch, err := storage.Network(session.Network).Channel(session.Channel)
+	if err != nil || ch == nil {
+		http.Error(w, "Failed to get channel", http.StatusInternalServerError)
+		return
+	}
+
+	type roleResponse struct {
+		models.ChannelRole
+		BuiltIn bool `json:"built_in"`
+	}
+
+	result := make([]roleResponse, 0)
+	for _, role := range ch.AllRoles() {
+		result = append(result, roleResponse{ChannelRole: role, BuiltIn: role.IsBuiltIn()})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+func (s *server) dashboardRoleChangesHandler(w http.ResponseWriter, r *http.Request) {
+	session := s.validateDashboardSession(r)
+	if session == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	changes, err := storage.Network(session.Network).RoleChanges(session.Channel)
+	if err != nil {
+		log.Logger().Errorf(nil, "error listing role changes: %s", err)
+		http.Error(w, "Failed to list role changes", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(changes)
+}
+
+func (s *server) dashboardRoleUpdateHandler(w http.ResponseWriter, r *http.Request) {
+	session := s.validateDashboardSession(r)
+	if session == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req struct {
+		Action  string `json:"action"`
+		Role    string `json:"role"`
+		Subject string `json:"subject"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Action == "" || req.Role == "" {
+		http.Error(w, "Action and role are required", http.StatusBadRequest)
+		return
+	}
+
+	fs := storage.Network(session.Network)
+	ch, err := fs.Channel(session.Channel)
+	if err != nil || ch == nil {
+		http.Error(w, "Failed to get channel", http.StatusInternalServerError)
+		return
+	}
+
+	role := strings.ToLower(req.Role)
+	subject := req.Subject
+	changed := false
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if role == models.ChannelRoleOwner && (req.Action == models.RoleChangeAssign || req.Action == models.RoleChangeUnassign) {
+		if cfg := s.cfg.Network(session.Network); cfg == nil || !cfg.IRC.IsOwnerOrAdmin(session.Nick) {
+			json.NewEncoder(w).Encode(map[string]any{"success": false, "error": "only the bot's owner and admins can change the owner role"})
+			return
+		}
+	}
+
+	switch req.Action {
+	case models.RoleChangeCreate:
+		changed = ch.CreateRole(role)
+	case models.RoleChangeDelete:
+		changed = ch.DeleteRole(role)
+	case models.RoleChangeAssign:
+		subject = s.dashboardRoleMember(session, subject)
+		changed = len(subject) > 0 && ch.HasRole(role) && ch.AssignRole(role, subject)
+	case models.RoleChangeUnassign:
+		changed = ch.UnassignRole(role, subject)
+	case models.RoleChangeGrant:
+		changed = len(subject) > 0 && ch.HasRole(role) && ch.GrantCommand(role, subject)
+	case models.RoleChangeRevoke:
+		changed = len(subject) > 0 && ch.HasRole(role) && ch.RevokeCommand(role, subject)
+	case models.RoleChangeReset:
+		changed = ch.ResetCommand(role, subject)
+	default:
+		http.Error(w, "Unknown action", http.StatusBadRequest)
+		return
+	}
+
+	if !changed {
+		json.NewEncoder(w).Encode(map[string]any{"success": false, "error": "nothing changed"})
+		return
+	}
+
+	if err := fs.UpdateChannel(session.Channel, map[string]any{"roles": ch.Roles, "updated_at": time.Now()}); err != nil {
+		log.Logger().Errorf(nil, "error updating channel roles: %s", err)
+		json.NewEncoder(w).Encode(map[string]any{"success": false, "error": "update failed"})
+		return
+	}
+
+	log.Logger().Infof(nil, "dashboard: %s %s %s in %s by %s", req.Action, role, subject, session.Channel, session.Nick)
+
+	if err := fs.AddRoleChange(session.Channel, models.NewRoleChange(session.Nick, req.Action, role, subject)); err != nil {
+		log.Logger().Errorf(nil, "error adding role change: %s", err)
+		json.NewEncoder(w).Encode(map[string]any{"success": true, "error": "the change couldn't be recorded in the role history"})
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]any{"success": true})
+}
+
+// dashboardRoleMember returns how a user is stored as a role member: accounts and host masks as given, and nicks as the
+// services account they were last seen logged in to, otherwise the host they were last seen on. It returns an empty
+// string for a nick that hasn't been seen, since nicks alone aren't stored.
+func (s *server) dashboardRoleMember(session *dashboardSession, nick string) string {
+	if len(nick) == 0 || strings.HasPrefix(nick, models.AccountMemberPrefix) || models.IsRoleMemberMask(nick) {
+		return nick
+	}
+
+	user, err := storage.Network(session.Network).GetUserByNick(session.Channel, nick)
+	if err != nil || user == nil {
+		return ""
+	}
+	if len(user.Account) > 0 {
+		return models.AccountMemberPrefix + user.Account
+	}
+	if len(user.Host) > 0 {
+		return "*!*@" + user.Host
+	}
+
+	return ""
+}
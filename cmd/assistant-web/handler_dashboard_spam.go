@@ -0,0 +1,72 @@
+package main
+
+import (
+	"assistant/pkg/log"
+	"assistant/pkg/models"
+	"assistant/pkg/storage"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+func (s *server) dashboardSpamRulesHandler(w http.ResponseWriter, r *http.Request) {
+	session := s.validateDashboardSession(r)
+	if session == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	ch, err := storage.Network(session.Network).Channel(session.Channel)
+	if err != nil {
+		log.Logger().Errorf(nil, "error getting channel for spam rules: %s", err)
+		http.Error(w, "Failed to get channel", http.StatusInternalServerError)
+		return
+	}
+
+	rules := models.DefaultSpamRules()
+	if ch != nil {
+		rules = ch.SpamRuleSettings()
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(rules)
+}
+
+func (s *server) dashboardSpamRuleUpdateHandler(w http.ResponseWriter, r *http.Request) {
+	session := s.validateDashboardSession(r)
+	if session == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var rule models.SpamRule
+	if err := json.NewDecoder(r.Body).Decode(&rule); err != nil || rule.Rule == "" {
+		http.Error(w, "Rule is required", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := rule.Validate(); err != nil {
+		json.NewEncoder(w).Encode(map[string]any{"success": false, "error": err.Error()})
+		return
+	}
+
+	fs := storage.Network(session.Network)
+	ch, err := fs.Channel(session.Channel)
+	if err != nil || ch == nil {
+		http.Error(w, "Failed to get channel", http.StatusInternalServerError)
+		return
+	}
+
+	ch.SetSpamRule(rule)
+
+	if err := fs.UpdateChannel(session.Channel, map[string]any{"spam_rules": ch.SpamRules, "updated_at": time.Now()}); err != nil {
+		log.Logger().Errorf(nil, "error updating channel spam rules: %s", err)
+		json.NewEncoder(w).Encode(map[string]any{"success": false, "error": "update failed"})
+		return
+	}
+
+	log.Logger().Infof(nil, "dashboard: %s updated %s spam rule (enabled=%v, action=%s) in %s", session.Nick, rule.Rule, rule.Enabled, rule.Action, session.Channel)
+	json.NewEncoder(w).Encode(map[string]any{"success": true, "rules": ch.SpamRuleSettings()})
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"assistant/pkg/api/elapse"
+	"assistant/pkg/log"
+	"assistant/pkg/models"
+	"assistant/pkg/storage"
+	"cmp"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+func (s *server) dashboardStrikesHandler(w http.ResponseWriter, r *http.Request) {
+	session := s.validateDashboardSession(r)
+	if session == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	strikes, err := storage.Network(session.Network).Strikes(session.Channel)
+	if err != nil {
+		log.Logger().Errorf(nil, "error listing strikes: %s", err)
+		http.Error(w, "Failed to list strikes", http.StatusInternalServerError)
+		return
+	}
+
+	now := time.Now()
+	active := make([]*models.Strike, 0)
+	for _, strike := range strikes {
+		if strike.IsActive(now) {
+			active = append(active, strike)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(active)
+}
+
+func (s *server) dashboardStrikeDeleteHandler(w http.ResponseWriter, r *http.Request) {
+	session := s.validateDashboardSession(r)
+	if session == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req struct {
+		ID string `json:"id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.ID == "" {
+		http.Error(w, "ID is required", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	fs := storage.Network(session.Network)
+	strikes, err := fs.Strikes(session.Channel)
+	if err != nil {
+		log.Logger().Errorf(nil, "error listing strikes: %s", err)
+		json.NewEncoder(w).Encode(map[string]any{"success": false, "error": "delete failed"})
+		return
+	}
+
+	i := slices.IndexFunc(strikes, func(strike *models.Strike) bool { return strike.ID == req.ID })
+	if i < 0 {
+		json.NewEncoder(w).Encode(map[string]any{"success": false, "error": "strike not found"})
+		return
+	}
+
+	if err := fs.DeleteStrike(session.Channel, req.ID); err != nil {
+		log.Logger().Errorf(nil, "error deleting strike: %s", err)
+		json.NewEncoder(w).Encode(map[string]any{"success": false, "error": "delete failed"})
+		return
+	}
+
+	strike := strikes[i]
+	s.auditDashboard(session, models.AuditActionPardon, models.AuditTarget(strike.Nick, strike.Host), strike.Reason)
+	log.Logger().Infof(nil, "dashboard: %s pardoned strike %s in %s", session.Nick, req.ID, session.Channel)
+	json.NewEncoder(w).Encode(map[string]any{"success": true})
+}
+
+func (s *server) dashboardStrikeLadderHandler(w http.ResponseWriter, r *http.Request) {
+	session := s.validateDashboardSession(r)
+	if session == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	ch, err := storage.Network(session.Network).Channel(session.Channel)
+	if err != nil {
+		log.Logger().Errorf(nil, "error getting channel for strike ladder: %s", err)
+		http.Error(w, "Failed to get channel", http.StatusInternalServerError)
+		return
+	}
+
+	steps := make([]models.StrikeStep, 0)
+	expiry := ""
+	if ch != nil {
+		if ch.StrikeLadder != nil {
+			steps = ch.StrikeLadder
+		}
+		expiry = ch.StrikeExpiry
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{
+		"steps":          steps,
+		"expiry":         expiry,
+		"default_steps":  models.DefaultStrikeLadder(),
+		"default_expiry": models.DefaultStrikeExpiry,
+	})
+}
+
+func (s *server) dashboardStrikeLadderUpdateHandler(w http.ResponseWriter, r *http.Request) {
+	session := s.validateDashboardSession(r)
+	if session == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req struct {
+		Steps  []models.StrikeStep `json:"steps"`
+		Expiry string              `json:"expiry"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if req.Steps == nil {
+		req.Steps = make([]models.StrikeStep, 0)
+	}
+	if err := models.ValidateStrikeLadder(req.Steps); err != nil {
+		json.NewEncoder(w).Encode(map[string]any{"success": false, "error": err.Error()})
+		return
+	}
+	slices.SortFunc(req.Steps, func(a, b models.StrikeStep) int { return cmp.Compare(a.Strikes, b.Strikes) })
+
+	req.Expiry = strings.TrimSpace(req.Expiry)
+	if len(req.Expiry) > 0 && !elapse.IsDuration(req.Expiry) {
+		json.NewEncoder(w).Encode(map[string]any{"success": false, "error": "invalid expiry"})
+		return
+	}
+
+	fs := storage.Network(session.Network)
+	if err := fs.UpdateChannel(session.Channel, map[string]any{"strike_ladder": req.Steps, "strike_expiry": req.Expiry, "updated_at": time.Now()}); err != nil {
+		log.Logger().Errorf(nil, "error updating channel strike ladder: %s", err)
+		json.NewEncoder(w).Encode(map[string]any{"success": false, "error": "update failed"})
+		return
+	}
+
+	log.Logger().Infof(nil, "dashboard: %s updated the strike ladder in %s (%d steps)", session.Nick, session.Channel, len(req.Steps))
+	json.NewEncoder(w).Encode(map[string]any{"success": true, "steps": req.Steps, "expiry": req.Expiry})
+}
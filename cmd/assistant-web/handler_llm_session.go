@@ -1,8 +1,8 @@
 package main
 
 import (
-	"assistant/pkg/firestore"
 	"assistant/pkg/log"
+	"assistant/pkg/storage"
 	"encoding/json"
 	"fmt"
 	"html/template"
@@ -38,7 +38,7 @@ func (s *server) llmSessionPollHandler(w http.ResponseWriter, r *http.Request) {
 	logger := log.Logger()
 	id := r.PathValue("id")
 
-	fs := firestore.Get()
+	fs := storage.Get()
 	responses, err := fs.LLMResponsesBySession(id)
 	if err != nil {
 		logger.Rawf(log.Error, "error fetching session %s, %s", id, err)
@@ -69,7 +69,7 @@ func (s *server) llmSessionHandler(w http.ResponseWriter, r *http.Request) {
 	logger := log.Logger()
 	id := r.PathValue("id")
 
-	fs := firestore.Get()
+	fs := storage.Get()
 	responses, err := fs.LLMResponsesBySession(id)
 	if err != nil {
 		logger.Rawf(log.Error, "error fetching session %s, %s", id, err)
@@ -32,6 +32,7 @@ func (s *server) triviaSetupHandler(w http.ResponseWriter, r *http.Request) {
 
 	args := map[string]any{
 		"channel":      channel,
+		"network":      r.URL.Query().Get("network"),
 		"categories":   trivia.Categories,
 		"maxQuestions": s.cfg.Trivia.MaxQuestions,
 		"defaultCount": s.cfg.Trivia.DefaultCount,
@@ -54,6 +55,7 @@ func (s *server) triviaStartHandler(w http.ResponseWriter, r *http.Request) {
 	logger := log.Logger()
 
 	channel := r.FormValue("channel")
+	network := r.FormValue("network")
 	category := r.FormValue("category")
 	difficulty := r.FormValue("difficulty")
 	countStr := r.FormValue("count")
@@ -101,6 +103,7 @@ func (s *server) triviaStartHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	task := models.NewTriviaStartTask(channel, taskQuestions, firstAnswerOnly)
+	task.Network = network
 	if err := queue.GetDefault().Publish(task); err != nil {
 		logger.Errorf(nil, "error publishing trivia start task: %s", err)
 		http.Error(w, "Failed to start trivia game. Try again later.", http.StatusInternalServerError)
@@ -2,24 +2,29 @@ package main
 
 import (
 	"assistant/pkg/config"
-	"assistant/pkg/firestore"
 	"assistant/pkg/log"
 	"assistant/pkg/queue"
+	"assistant/pkg/storage"
 	"context"
 	"fmt"
 )
 
 func initializeLogger(ctx context.Context, cfg *config.Config) {
+	if len(cfg.GoogleCloud.ProjectID) == 0 {
+		log.InitializeConsoleLogger()
+		return
+	}
+
 	_, err := log.InitializeGCPLogger(ctx, cfg, cfg.Web.Domain)
 	if err != nil {
 		panic(fmt.Errorf("error initializing logger, %s", err))
 	}
 }
 
-func initializeFirestore(ctx context.Context, cfg *config.Config) {
-	_, err := firestore.Initialize(ctx, cfg)
+func initializeStorage(ctx context.Context, cfg *config.Config) {
+	_, err := storage.Initialize(ctx, cfg)
 	if err != nil {
-		panic(fmt.Errorf("error initializing firestore, %s", err))
+		panic(fmt.Errorf("error initializing storage, %s", err))
 	}
 }
 
@@ -2,10 +2,10 @@ package main
 
 import (
 	"assistant/pkg/config"
-	"assistant/pkg/firestore"
 	"assistant/pkg/log"
 	"assistant/pkg/models"
 	"assistant/pkg/queue"
+	"assistant/pkg/storage"
 	"context"
 	"os"
 )
@@ -28,8 +28,8 @@ func main() {
 	initializeLogger(ctx, cfg)
 	defer log.Logger().Close()
 
-	initializeFirestore(ctx, cfg)
-	defer firestore.Get().Close()
+	initializeStorage(ctx, cfg)
+	defer storage.Get().Close()
 
 	initializeQueues(ctx, cfg)
 	defer queue.GetDefault().Close()
@@ -84,9 +84,34 @@ func (s *server) start() {
 	http.HandleFunc("POST /dashboard/api/communitynotes/delete", s.dashboardCommunityNoteDeleteHandler)
 	http.HandleFunc("/dashboard/api/commands", s.dashboardCommandsHandler)
 	http.HandleFunc("POST /dashboard/api/commands/toggle", s.dashboardCommandToggleHandler)
+	http.HandleFunc("POST /dashboard/api/commands/limits", s.dashboardCommandLimitHandler)
 	http.HandleFunc("/dashboard/api/commands/usage", s.dashboardCommandUsageHandler)
+	http.HandleFunc("/dashboard/api/customcommands", s.dashboardCustomCommandsHandler)
+	http.HandleFunc("POST /dashboard/api/customcommands/add", s.dashboardCustomCommandAddHandler)
+	http.HandleFunc("POST /dashboard/api/customcommands/remove", s.dashboardCustomCommandRemoveHandler)
+	http.HandleFunc("/dashboard/api/factoids", s.dashboardFactoidsHandler)
+	http.HandleFunc("POST /dashboard/api/factoids/save", s.dashboardFactoidSaveHandler)
+	http.HandleFunc("POST /dashboard/api/factoids/lock", s.dashboardFactoidLockHandler)
+	http.HandleFunc("POST /dashboard/api/factoids/delete", s.dashboardFactoidDeleteHandler)
+	http.HandleFunc("/dashboard/api/feeds", s.dashboardFeedsHandler)
+	http.HandleFunc("POST /dashboard/api/feeds/add", s.dashboardFeedAddHandler)
+	http.HandleFunc("POST /dashboard/api/feeds/update", s.dashboardFeedUpdateHandler)
+	http.HandleFunc("POST /dashboard/api/feeds/delete", s.dashboardFeedDeleteHandler)
+	http.HandleFunc("/dashboard/api/spam", s.dashboardSpamRulesHandler)
+	http.HandleFunc("POST /dashboard/api/spam/update", s.dashboardSpamRuleUpdateHandler)
+	http.HandleFunc("/dashboard/api/roles", s.dashboardRolesHandler)
+	http.HandleFunc("POST /dashboard/api/roles/update", s.dashboardRoleUpdateHandler)
+	http.HandleFunc("/dashboard/api/roles/changes", s.dashboardRoleChangesHandler)
 	http.HandleFunc("/dashboard/api/penalties", s.dashboardPenaltiesHandler)
 	http.HandleFunc("POST /dashboard/api/penalties/expire", s.dashboardExpirePenaltyHandler)
+	http.HandleFunc("/dashboard/api/strikes", s.dashboardStrikesHandler)
+	http.HandleFunc("POST /dashboard/api/strikes/delete", s.dashboardStrikeDeleteHandler)
+	http.HandleFunc("/dashboard/api/strikes/ladder", s.dashboardStrikeLadderHandler)
+	http.HandleFunc("POST /dashboard/api/strikes/ladder/update", s.dashboardStrikeLadderUpdateHandler)
+	http.HandleFunc("/dashboard/api/evasion", s.dashboardEvasionHandler)
+	http.HandleFunc("POST /dashboard/api/evasion/update", s.dashboardEvasionUpdateHandler)
+	http.HandleFunc("/dashboard/api/audit", s.dashboardAuditHandler)
+	http.HandleFunc("/dashboard/api/audit/export", s.dashboardAuditExportHandler)
 
 	nativeLog.Fatal(http.ListenAndServe(fmt.Sprintf(":%d", s.cfg.Web.Port), nil))
 }
@@ -1,8 +1,8 @@
 package main
 
 import (
-	"assistant/pkg/firestore"
 	"assistant/pkg/log"
+	"assistant/pkg/storage"
 	"net/http"
 	"strings"
 )
@@ -19,7 +19,7 @@ func (s *server) shortcutHandler(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	fs := firestore.Get()
+	fs := storage.Get()
 	sc, err := fs.Shortcut(id)
 	if err != nil {
 		logger.Rawf(log.Error, "error searching for shortcut %s, %s", id, err)
@@ -8,14 +8,14 @@ import (
 	"assistant/pkg/api/repository"
 	"assistant/pkg/api/style"
 	"assistant/pkg/config"
-	"assistant/pkg/firestore"
 	"assistant/pkg/log"
 	"assistant/pkg/models"
 	"assistant/pkg/queue"
+	"assistant/pkg/storage"
 	"fmt"
 )
 
-func processDashboardRequests(ctx context.Context, cfg *config.Config, ircs irc.IRC) {
+func processDashboardRequests(ns *networks) {
 	logger := log.Logger()
 	dashboardQueue := queue.GetDashboardRequest()
 	startedAt := dashboardQueue.StartedAt()
@@ -33,6 +33,16 @@ func processDashboardRequests(ctx context.Context, cfg *config.Config, ircs irc.
 			data := task.Data.(models.DashboardRequestTaskData)
 			logger.Debugf(nil, "dashboard request: %s [%s]", data.Action, data.Channel)
 
+			n, err := ns.get(data.Network)
+			if err != nil {
+				resp := models.NewDashboardResponseTask(data.RequestID, data.Action, false, err.Error(), nil)
+				if err = queue.GetDashboardResponse().Publish(resp); err != nil {
+					return fmt.Errorf("error publishing dashboard response: %w", err)
+				}
+				return nil
+			}
+			ctx, cfg, ircs := n.ctx, n.cfg, n.irc
+
 			var resp *models.Task
 
 			switch data.Action {
@@ -83,6 +93,11 @@ func processDashboardRequests(ctx context.Context, cfg *config.Config, ircs irc.
 	}()
 }
 
+// dashboardActor attributes a dashboard request to the user signed in to the dashboard, for the audit log.
+func dashboardActor(data models.DashboardRequestTaskData) actions.Actor {
+	return actions.Actor{Nick: data.Actor, Source: models.AuditSourceDashboard}
+}
+
 func handleDashboardListUsers(ircs irc.IRC, data models.DashboardRequestTaskData) *models.Task {
 	done := make(chan []*irc.User, 1)
 	ircs.ListUsersByMask(data.Channel, "*!*@*", func(users []*irc.User) {
@@ -123,30 +138,33 @@ func handleDashboardUserAction(ircs irc.IRC, data models.DashboardRequestTaskDat
 			fmt.Sprintf("%s is %s and cannot be targeted", data.Nick, irc.StatusName(user.Status)), nil)
 	}
 
+	by := dashboardActor(data)
+
 	switch data.Action {
 	case models.DashboardActionKick:
-		ircs.Kick(data.Channel, data.Nick, data.Reason)
+		actions.Kick(ircs, data.Channel, data.Nick, user.Mask.Host, data.Reason, by)
 		logger.Infof(nil, "dashboard: kicked %s from %s", data.Nick, data.Channel)
 
 	case models.DashboardActionBan:
 		mask := fmt.Sprintf("*!*@%s", user.Mask.Host)
-		actions.Ban(ircs, data.Channel, mask, data.Duration, data.Reason)
+		actions.Ban(ircs, data.Channel, mask, data.Duration, data.Reason, by)
 
 	case models.DashboardActionMute:
 		if data.Reason == "" && data.Duration == "" {
 			ircs.Mute(data.Channel, data.Nick)
+			actions.Audit(ircs, data.Channel, by, models.AuditActionMute, models.AuditTarget(data.Nick, user.Mask.Host), "", "")
 			logger.Infof(nil, "dashboard: quietly muted %s in %s", data.Nick, data.Channel)
 		} else {
-			actions.Mute(ircs, data.Channel, data.Nick, user.Mask.Host, data.Duration, data.Reason)
+			actions.Mute(ircs, data.Channel, data.Nick, user.Mask.Host, data.Duration, data.Reason, by)
 		}
 
 	case models.DashboardActionUnban:
 		mask := fmt.Sprintf("*!*@%s", user.Mask.Host)
-		ircs.Unban(data.Channel, mask)
+		actions.Unban(ircs, data.Channel, mask, by)
 		logger.Infof(nil, "dashboard: unbanned %s (%s) from %s", data.Nick, mask, data.Channel)
 
 	case models.DashboardActionUnmute:
-		ircs.Voice(data.Channel, data.Nick)
+		actions.Unmute(ircs, data.Channel, data.Nick, by)
 		logger.Infof(nil, "dashboard: unmuted %s in %s", data.Nick, data.Channel)
 	}
 
@@ -161,6 +179,7 @@ func handleDashboardAddBan(ircs irc.IRC, data models.DashboardRequestTaskData) *
 	}
 
 	ircs.Ban(data.Channel, data.Mask)
+	actions.Audit(ircs, data.Channel, dashboardActor(data), models.AuditActionBan, data.Mask, "", "")
 	logger.Infof(nil, "dashboard: added ban %s in %s", data.Mask, data.Channel)
 
 	return models.NewDashboardResponseTask(data.RequestID, data.Action, true, "", nil)
@@ -180,6 +199,7 @@ func handleDashboardSetTopic(ircs irc.IRC, data models.DashboardRequestTaskData)
 	logger := log.Logger()
 
 	ircs.SetTopic(data.Channel, data.Topic)
+	actions.Audit(ircs, data.Channel, dashboardActor(data), models.AuditActionTopic, data.Channel, data.Topic, "")
 	logger.Infof(nil, "dashboard: set topic in %s", data.Channel)
 
 	return models.NewDashboardResponseTask(data.RequestID, data.Action, true, "", nil)
@@ -192,7 +212,7 @@ func handleDashboardApproveVoiceRequest(ircs irc.IRC, data models.DashboardReque
 		return models.NewDashboardResponseTask(data.RequestID, data.Action, false, "nick is required", nil)
 	}
 
-	fs := firestore.Get()
+	fs := storage.Network(ircs.Network())
 	ch, err := fs.Channel(data.Channel)
 	if err != nil || ch == nil {
 		return models.NewDashboardResponseTask(data.RequestID, data.Action, false, "channel not found", nil)
@@ -210,22 +230,23 @@ func handleDashboardApproveVoiceRequest(ircs irc.IRC, data models.DashboardReque
 		return models.NewDashboardResponseTask(data.RequestID, data.Action, false, "voice request not found", nil)
 	}
 
-	repository.RemoveChannelVoiceRequest(nil, ch, data.Nick, "")
-	if err = repository.UpdateChannelVoiceRequests(nil, ch); err != nil {
+	repository.RemoveChannelVoiceRequest(networkEvent(ircs), ch, data.Nick, "")
+	if err = repository.UpdateChannelVoiceRequests(networkEvent(ircs), ch); err != nil {
 		logger.Errorf(nil, "dashboard: error updating voice requests: %s", err)
 	}
 
 	// voice the user and set auto-voice
 	ircs.Voice(data.Channel, data.Nick)
 
-	u, err := repository.GetUserByNick(nil, data.Channel, data.Nick, true)
+	u, err := repository.GetUserByNick(networkEvent(ircs), data.Channel, data.Nick, true)
 	if err != nil {
 		logger.Errorf(nil, "dashboard: error getting user for auto-voice: %s", err)
 	} else if u != nil {
 		u.IsAutoVoiced = true
-		if err = repository.UpdateUserIsAutoVoiced(nil, data.Channel, u); err != nil {
+		if err = repository.UpdateUserIsAutoVoiced(networkEvent(ircs), data.Channel, u); err != nil {
 			logger.Errorf(nil, "dashboard: error updating auto-voice: %s", err)
 		}
+		actions.Audit(ircs, data.Channel, dashboardActor(data), models.AuditActionAutoVoice, models.AuditTarget(u.Nick, u.Host), "voice request approved", "")
 	}
 
 	// send welcome message
@@ -242,7 +263,7 @@ func handleDashboardDenyVoiceRequest(ircs irc.IRC, data models.DashboardRequestT
 		return models.NewDashboardResponseTask(data.RequestID, data.Action, false, "nick is required", nil)
 	}
 
-	fs := firestore.Get()
+	fs := storage.Network(ircs.Network())
 	ch, err := fs.Channel(data.Channel)
 	if err != nil || ch == nil {
 		return models.NewDashboardResponseTask(data.RequestID, data.Action, false, "channel not found", nil)
@@ -259,8 +280,8 @@ func handleDashboardDenyVoiceRequest(ircs irc.IRC, data models.DashboardRequestT
 		return models.NewDashboardResponseTask(data.RequestID, data.Action, false, "voice request not found", nil)
 	}
 
-	repository.RemoveChannelVoiceRequest(nil, ch, data.Nick, "")
-	if err = repository.UpdateChannelVoiceRequests(nil, ch); err != nil {
+	repository.RemoveChannelVoiceRequest(networkEvent(ircs), ch, data.Nick, "")
+	if err = repository.UpdateChannelVoiceRequests(networkEvent(ircs), ch); err != nil {
 		logger.Errorf(nil, "dashboard: error updating voice requests: %s", err)
 	}
 
@@ -275,7 +296,7 @@ func handleDashboardExpireBan(ircs irc.IRC, data models.DashboardRequestTaskData
 		return models.NewDashboardResponseTask(data.RequestID, data.Action, false, "mask is required", nil)
 	}
 
-	ircs.Unban(data.Channel, data.Mask)
+	actions.Unban(ircs, data.Channel, data.Mask, dashboardActor(data))
 	logger.Infof(nil, "dashboard: expired ban %s from %s", data.Mask, data.Channel)
 
 	return models.NewDashboardResponseTask(data.RequestID, data.Action, true, "", nil)
@@ -288,7 +309,7 @@ func handleDashboardExpireMute(ircs irc.IRC, data models.DashboardRequestTaskDat
 		return models.NewDashboardResponseTask(data.RequestID, data.Action, false, "nick is required", nil)
 	}
 
-	ircs.Voice(data.Channel, data.Nick)
+	actions.Unmute(ircs, data.Channel, data.Nick, dashboardActor(data))
 	logger.Infof(nil, "dashboard: expired mute for %s in %s", data.Nick, data.Channel)
 
 	return models.NewDashboardResponseTask(data.RequestID, data.Action, true, "", nil)
@@ -5,35 +5,40 @@ import (
 	"assistant/pkg/api/elapse"
 	"assistant/pkg/api/irc"
 	"assistant/pkg/api/repository"
-	"assistant/pkg/cloudtasks"
 	"assistant/pkg/config"
-	"assistant/pkg/firestore"
 	"assistant/pkg/log"
 	"assistant/pkg/models"
 	"assistant/pkg/queue"
+	"assistant/pkg/scheduler"
+	"assistant/pkg/storage"
 	"fmt"
 	"slices"
 	"time"
 )
 
 func initializeLogger(ctx context.Context, cfg *config.Config) {
+	if len(cfg.GoogleCloud.ProjectID) == 0 {
+		log.InitializeConsoleLogger()
+		return
+	}
+
 	_, err := log.InitializeGCPLogger(ctx, cfg, cfg.IRC.Nick)
 	if err != nil {
 		panic(fmt.Errorf("error initializing logger, %s", err))
 	}
 }
 
-func initializeFirestore(ctx context.Context, cfg *config.Config) {
-	_, err := firestore.Initialize(ctx, cfg)
+func initializeStorage(ctx context.Context, cfg *config.Config) {
+	_, err := storage.Initialize(ctx, cfg)
 	if err != nil {
-		panic(fmt.Errorf("error initializing firestore, %s", err))
+		panic(fmt.Errorf("error initializing storage, %s", err))
 	}
 }
 
-func initializeCloudTasks(ctx context.Context, cfg *config.Config) {
-	_, err := cloudtasks.Initialize(ctx, cfg)
+func initializeScheduler(ctx context.Context, cfg *config.Config) {
+	_, err := scheduler.Initialize(ctx, cfg)
 	if err != nil {
-		panic(fmt.Errorf("error initializing cloud tasks, %s", err))
+		panic(fmt.Errorf("error initializing scheduler, %s", err))
 	}
 }
 
@@ -61,7 +66,7 @@ func initializeQueues(ctx context.Context, cfg *config.Config) {
 
 func initializeAssistant(ctx context.Context, cfg *config.Config, irc irc.IRC) {
 	logger := log.Logger()
-	fs := firestore.Get()
+	fs := storage.Network(cfg.IRC.Name)
 
 	assistant, err := fs.Assistant()
 	if err != nil {
@@ -78,9 +83,6 @@ func initializeAssistant(ctx context.Context, cfg *config.Config, irc irc.IRC) {
 		}
 		logger.Debugf(nil, "assistant created")
 	}
-
-	processTasks(ctx, cfg, irc)
-	processDashboardRequests(ctx, cfg, irc)
 }
 
 func initializeChannel(ctx context.Context, cfg *config.Config, irc irc.IRC, channel string) {
@@ -92,7 +94,7 @@ func initializeChannel(ctx context.Context, cfg *config.Config, irc irc.IRC, cha
 		return
 	}
 
-	fs := firestore.Get()
+	fs := storage.Network(cfg.IRC.Name)
 	logger.Rawf(log.Debug, "loading banned words for channel %s", channel)
 
 	ch, err := fs.Channel(channel)
@@ -144,7 +146,7 @@ func initializeChannel(ctx context.Context, cfg *config.Config, irc irc.IRC, cha
 
 	if task != nil {
 		task.Data = models.PersistentTaskData{Channel: channel}
-		if _, err := cloudtasks.Get().CreateTask(task); err != nil {
+		if _, err := scheduler.Get().CreateTask(task); err != nil {
 			logger.Errorf(nil, "error scheduling cloud task for channel %s inactivity: %s", channel, err)
 		}
 	}
@@ -165,14 +167,24 @@ func initializeChannel(ctx context.Context, cfg *config.Config, irc irc.IRC, cha
 	}
 
 	statsTask.Data = models.PersistentTaskData{Channel: channel}
-	if _, err := cloudtasks.Get().CreateTask(statsTask); err != nil {
+	if _, err := scheduler.Get().CreateTask(statsTask); err != nil {
 		logger.Errorf(nil, "error scheduling cloud task for channel %s stats: %s", channel, err)
 	}
+
+	// feeds are polled only while the channel has some, the first feed added starts polling otherwise
+	feeds, err := fs.Feeds(channel)
+	if err != nil {
+		logger.Errorf(nil, "error retrieving channel feeds: %s", err)
+	} else if len(feeds) > 0 {
+		if err := repository.ScheduleFeedsTask(networkEvent(irc), channel); err != nil {
+			logger.Errorf(nil, "error scheduling cloud task for channel %s feeds: %s", channel, err)
+		}
+	}
 }
 
 func initializeChannelUser(cfg *config.Config, irc irc.IRC, channel string, mask *irc.Mask) {
 	logger := log.Logger()
-	fs := firestore.Get()
+	fs := storage.Network(cfg.IRC.Name)
 	if mask == nil {
 		logger.Warningf(nil, "ignoring channel user initialization with an invalid mask in %s", channel)
 		return
@@ -192,7 +204,16 @@ func initializeChannelUser(cfg *config.Config, irc irc.IRC, channel string, mask
 		}
 	}
 
-	specifiedUser, err := repository.GetUserByNick(nil, channel, mask.Nick, false)
+	// a user logged in to services inherits auto-voice from any nick previously linked to the same account
+	accountUser, err := repository.GetUserByAccount(networkEvent(irc), channel, mask.Account)
+	if err != nil {
+		logger.Errorf(nil, "error retrieving user by account, %s", err)
+		return
+	}
+
+	isAccountAutoVoiced := accountUser != nil && (accountUser.IsAutoVoiced || slices.Contains(ch.AutoVoiced, accountUser.Nick))
+
+	specifiedUser, err := repository.GetUserByNick(networkEvent(irc), channel, mask.Nick, false)
 	if err != nil {
 		logger.Errorf(nil, "error retrieving user, %s", err)
 		return
@@ -201,21 +222,24 @@ func initializeChannelUser(cfg *config.Config, irc irc.IRC, channel string, mask
 	if specifiedUser != nil {
 		specifiedUser.UserID = mask.UserID
 		specifiedUser.Host = mask.Host
+		if len(mask.Account) > 0 {
+			specifiedUser.Account = mask.Account
+		}
 		specifiedUser.UpdatedAt = time.Now()
-		specifiedUser.IsAutoVoiced = specifiedUser.IsAutoVoiced || slices.Contains(ch.AutoVoiced, mask.Nick)
+		specifiedUser.IsAutoVoiced = specifiedUser.IsAutoVoiced || isAccountAutoVoiced || slices.Contains(ch.AutoVoiced, mask.Nick)
 
 		if specifiedUser.IsAutoVoiced {
 			irc.Voice(channel, mask.Nick)
 		}
 
-		if err = fs.UpdateUser(channel, specifiedUser, map[string]any{"is_auto_voiced": specifiedUser.IsAutoVoiced, "user_id": specifiedUser.UserID, "host": specifiedUser.Host, "updated_at": specifiedUser.UpdatedAt}); err != nil {
+		if err = fs.UpdateUser(channel, specifiedUser, map[string]any{"is_auto_voiced": specifiedUser.IsAutoVoiced, "user_id": specifiedUser.UserID, "host": specifiedUser.Host, "account": specifiedUser.Account, "updated_at": specifiedUser.UpdatedAt}); err != nil {
 			panic(fmt.Errorf("error updating user, %s", err))
 		}
 
 		return
 	}
 
-	users, err := repository.GetUsersByHost(nil, channel, mask.Host)
+	users, err := repository.GetUsersByHost(networkEvent(irc), channel, mask.Host)
 	if err != nil {
 		logger.Errorf(nil, "error getting users by host: %v", err)
 		return
@@ -225,7 +249,7 @@ func initializeChannelUser(cfg *config.Config, irc irc.IRC, channel string, mask
 		logger.Debugf(nil, "user %s not found, creating", mask.Nick)
 
 		u := models.NewUser(mask)
-		u.IsAutoVoiced = slices.Contains(ch.AutoVoiced, mask.Nick)
+		u.IsAutoVoiced = isAccountAutoVoiced || slices.Contains(ch.AutoVoiced, mask.Nick)
 		err = fs.CreateUser(channel, u)
 		if err != nil {
 			panic(fmt.Errorf("error creating user, %s", err))
@@ -254,7 +278,7 @@ func initializeChannelUser(cfg *config.Config, irc irc.IRC, channel string, mask
 		}
 	}
 
-	isAutoVoiced := slices.Contains(ch.AutoVoiced, mask.Nick)
+	isAutoVoiced := isAccountAutoVoiced || slices.Contains(ch.AutoVoiced, mask.Nick)
 	if alternateUser != nil {
 		isAutoVoiced = isAutoVoiced || alternateUser.IsAutoVoiced
 	}
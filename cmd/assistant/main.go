@@ -4,12 +4,15 @@ import (
 	"assistant/pkg/api/context"
 	"assistant/pkg/api/events"
 	"assistant/pkg/api/irc"
-	"assistant/pkg/cloudtasks"
 	"assistant/pkg/config"
-	"assistant/pkg/firestore"
 	"assistant/pkg/log"
 	"assistant/pkg/queue"
+	"assistant/pkg/scheduler"
+	"assistant/pkg/storage"
 	"os"
+
+	// users' time zones are resolved by name, which shouldn't depend on the host having zone data installed
+	_ "time/tzdata"
 )
 
 const defaultConfigFilename = "config.yaml"
@@ -30,8 +33,8 @@ func main() {
 	initializeLogger(ctx, cfg)
 	defer log.Logger().Close()
 
-	initializeFirestore(ctx, cfg)
-	defer firestore.Get().Close()
+	initializeStorage(ctx, cfg)
+	defer storage.Get().Close()
 
 	initializeQueues(ctx, cfg)
 	defer queue.GetDefault().Close()
@@ -39,28 +42,38 @@ func main() {
 	defer queue.GetDashboardRequest().Close()
 	defer queue.GetDashboardResponse().Close()
 
-	initializeCloudTasks(ctx, cfg)
-	defer cloudtasks.Get().Close()
+	initializeScheduler(ctx, cfg)
+	defer scheduler.Get().Close()
 
-	svc := irc.NewIRC(ctx)
-	err = connect(ctx, svc, cfg)
-	if err != nil {
-		panic(err)
+	ns := newNetworks(cfg)
+	for _, n := range ns.list {
+		if err = connect(n.ctx, n.irc, n.cfg); err != nil {
+			panic(err)
+		}
 	}
 
-	ech := make(chan *irc.Event)
-	go svc.Listen(ech)
+	processTasks(ns)
+	processDashboardRequests(ns)
+
+	for _, n := range ns.list {
+		ech := make(chan *irc.Event)
+		go n.irc.Listen(ech)
 
-	h := events.NewHandler(ctx, cfg, svc)
-	for {
-		e := <-ech
-		h.Handle(e)
+		h := events.NewHandler(n.ctx, n.cfg, n.irc)
+		go func() {
+			for {
+				e := <-ech
+				h.Handle(e)
+			}
+		}()
 	}
+
+	select {}
 }
 
 func connect(ctx context.Context, svc irc.IRC, cfg *config.Config) error {
 	logger := log.Logger()
-	logger.Debug(nil, "processing reconnect task")
+	logger.Debugf(nil, "connecting to %s", cfg.IRC.Server)
 
 	return svc.Connect(cfg, initializeAssistant, func(channel string, mask *irc.Mask) {
 		if mask.Nick == cfg.IRC.Nick {
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"assistant/pkg/api/context"
+	"assistant/pkg/api/irc"
+	"assistant/pkg/config"
+	"fmt"
+)
+
+// network is a connection to one IRC network along with the config and context its commands run with.
+type network struct {
+	ctx context.Context
+	cfg *config.Config
+	irc irc.IRC
+}
+
+// networks holds every configured network by name. Tasks and dashboard requests name the network they belong to so
+// that replies go out on the connection they came from.
+type networks struct {
+	byName map[string]*network
+	list   []*network
+}
+
+func newNetworks(cfg *config.Config) *networks {
+	ns := &networks{byName: make(map[string]*network)}
+
+	for _, c := range cfg.Networks() {
+		ctx := context.NewContext()
+		n := &network{
+			ctx: ctx,
+			cfg: c,
+			irc: irc.NewIRC(ctx),
+		}
+
+		ns.byName[c.IRC.Name] = n
+		ns.list = append(ns.list, n)
+	}
+
+	return ns
+}
+
+// get returns the named network. Tasks queued before networks were configured have no network and go to the first one.
+func (ns *networks) get(name string) (*network, error) {
+	if n, ok := ns.byName[name]; ok {
+		return n, nil
+	}
+
+	if len(name) == 0 {
+		return ns.list[0], nil
+	}
+
+	return nil, fmt.Errorf("unknown network %s", name)
+}
+
+// networkEvent returns an event that only identifies the network, for repository calls made outside of an IRC event.
+func networkEvent(ircs irc.IRC) *irc.Event {
+	return &irc.Event{Network: ircs.Network()}
+}
@@ -1,10 +1,12 @@
 package main
 
 import (
+	"assistant/pkg/api/actions"
 	"assistant/pkg/api/commands"
 	"assistant/pkg/api/context"
 	"assistant/pkg/api/drudge"
 	"assistant/pkg/api/elapse"
+	"assistant/pkg/api/feed"
 	"assistant/pkg/api/irc"
 	"assistant/pkg/api/modes"
 	"assistant/pkg/api/reddit"
@@ -13,12 +15,12 @@ import (
 	"assistant/pkg/api/style"
 	"assistant/pkg/api/summary"
 	"assistant/pkg/api/trivia"
-	"assistant/pkg/cloudtasks"
 	"assistant/pkg/config"
-	"assistant/pkg/firestore"
 	"assistant/pkg/log"
 	"assistant/pkg/models"
 	"assistant/pkg/queue"
+	"assistant/pkg/scheduler"
+	"assistant/pkg/storage"
 	"encoding/json"
 	"fmt"
 	"slices"
@@ -28,8 +30,7 @@ import (
 	stripmd "github.com/writeas/go-strip-markdown/v2"
 )
 
-func processTasks(ctx context.Context, cfg *config.Config, irc irc.IRC) {
-	fs := firestore.Get()
+func processTasks(ns *networks) {
 	logger := log.Logger()
 	defaultQueue := queue.GetDefault()
 	startedAt := defaultQueue.StartedAt()
@@ -38,6 +39,14 @@ func processTasks(ctx context.Context, cfg *config.Config, irc irc.IRC) {
 		err := defaultQueue.Receive(func(task *models.Task) error {
 			logger.Debugf(nil, "received task %s: %s [%d runs]", task.ID, task.Type, task.Runs)
 
+			n, err := ns.get(task.Network)
+			if err != nil {
+				logger.Warningf(nil, "discarding task %s: %s", task.ID, err)
+				return nil
+			}
+			ctx, cfg, irc := n.ctx, n.cfg, n.irc
+			fs := storage.Network(cfg.IRC.Name)
+
 			isScheduledTask := isTrackedScheduledTask(task.Type)
 
 			// Refresh tracked task state because a redelivered Pub/Sub message still
@@ -52,6 +61,11 @@ func processTasks(ctx context.Context, cfg *config.Config, irc irc.IRC) {
 					logger.Debugf(nil, "skipping %s task %s: %s", current.Status, task.ID, task.Type)
 					return nil
 				}
+				// edited, snoozed and recurring reminders are rescheduled, so an earlier delivery of one is stale
+				if current != nil && task.Type == models.TaskTypeReminder && !current.DueAt.Truncate(time.Second).Equal(task.DueAt.Truncate(time.Second)) {
+					logger.Debugf(nil, "skipping rescheduled task %s: %s", task.ID, task.Type)
+					return nil
+				}
 				if current != nil {
 					task.Runs = current.Runs
 					task.Status = current.Status
@@ -75,7 +89,7 @@ func processTasks(ctx context.Context, cfg *config.Config, irc irc.IRC) {
 				// Persistent channel and stats tasks still pass through their
 				// rescheduling paths below so clearing a stale occurrence does not
 				// stop the recurring task entirely.
-				if task.Type != models.TaskTypePersistentChannel && task.Type != models.TaskTypePersistentChannelStats {
+				if task.Type != models.TaskTypePersistentChannel && task.Type != models.TaskTypePersistentChannelStats && task.Type != models.TaskTypePersistentChannelFeeds {
 					return nil
 				}
 			}
@@ -83,13 +97,13 @@ func processTasks(ctx context.Context, cfg *config.Config, irc irc.IRC) {
 			var processingErr error
 			switch task.Type {
 			case models.TaskTypeReconnect:
-				processingErr = connect(ctx, irc, cfg)
+				irc.Reconnect(0)
 			case models.TaskTypeReminder:
 				processingErr = processReminder(irc, task)
 			case models.TaskTypeBanRemoval:
-				processingErr = processBanRemoval(irc, task)
+				processingErr = processBanRemoval(cfg, irc, task)
 			case models.TaskTypeMuteRemoval:
-				processingErr = processMuteRemoval(irc, task)
+				processingErr = processMuteRemoval(cfg, irc, task)
 			case models.TaskTypeNotifyVoiceRequests:
 				processingErr = processNotifyVoiceRequests(irc, task)
 			case models.TaskTypePersistentChannel:
@@ -98,6 +112,10 @@ func processTasks(ctx context.Context, cfg *config.Config, irc irc.IRC) {
 				if !staleAtStartup {
 					processingErr = processChannelStats(irc, task)
 				}
+			case models.TaskTypePersistentChannelFeeds:
+				if !staleAtStartup {
+					processingErr = processChannelFeeds(cfg, irc, task)
+				}
 			case models.TaskTypeDisinformationMutePenaltyRemoval:
 				processingErr = processDisinformationMutePenaltyRemoval(ctx, cfg, irc, task)
 			case models.TaskTypeDisinformationBanPenaltyRemoval:
@@ -112,6 +130,8 @@ func processTasks(ctx context.Context, cfg *config.Config, irc irc.IRC) {
 				processingErr = processProxyRedditSearchResponse(cfg, irc, task)
 			case models.TaskTypeTriviaStart:
 				processingErr = processTriviaStart(cfg, irc, task)
+			case models.TaskTypePollClose:
+				processingErr = processPollClose(irc, task)
 			default:
 				return fmt.Errorf("unknown task type %s", task.Type)
 			}
@@ -119,6 +139,13 @@ func processTasks(ctx context.Context, cfg *config.Config, irc irc.IRC) {
 			task.Runs++
 
 			if isScheduledTask {
+				if task.Type == models.TaskTypeReminder && processingErr == nil {
+					if err := recordReminderDelivery(fs, task); err != nil {
+						return fmt.Errorf("error recording reminder %s delivery: %w", task.ID, err)
+					}
+					return nil
+				}
+
 				if processingErr != nil {
 					if task.Runs >= models.ScheduledTaskMaxRuns {
 						task.Status = models.TaskStatusCancelled
@@ -166,7 +193,7 @@ func processTasks(ctx context.Context, cfg *config.Config, irc irc.IRC) {
 					return fmt.Errorf("error updating %s: %w", task.ID, err)
 				}
 
-				if _, err := cloudtasks.Get().CreateTask(task); err != nil {
+				if _, err := scheduler.Get().CreateTask(task); err != nil {
 					return fmt.Errorf("error rescheduling cloud task %s: %w", task.ID, err)
 				}
 
@@ -182,7 +209,7 @@ func processTasks(ctx context.Context, cfg *config.Config, irc irc.IRC) {
 					return fmt.Errorf("error updating %s: %w", task.ID, err)
 				}
 
-				if _, err := cloudtasks.Get().CreateTask(task); err != nil {
+				if _, err := scheduler.Get().CreateTask(task); err != nil {
 					return fmt.Errorf("error rescheduling cloud task %s: %w", task.ID, err)
 				}
 
@@ -192,6 +219,29 @@ func processTasks(ctx context.Context, cfg *config.Config, irc irc.IRC) {
 				return nil
 			}
 
+			if task.Type == models.TaskTypePersistentChannelFeeds {
+				// polling stops with the channel's last feed, adding one starts it again
+				channelName := task.Data.(models.PersistentTaskData).Channel
+				if feeds, err := fs.Feeds(channelName); err == nil && len(feeds) == 0 {
+					logger.Debugf(nil, "channel %s has no feeds, not rescheduling %s", channelName, task.ID)
+					return processingErr
+				}
+
+				task.DueAt = time.Now().Add(models.FeedPollInterval)
+				if err := fs.SetTask(task); err != nil {
+					return fmt.Errorf("error updating %s: %w", task.ID, err)
+				}
+
+				if _, err := scheduler.Get().CreateTask(task); err != nil {
+					return fmt.Errorf("error rescheduling cloud task %s: %w", task.ID, err)
+				}
+
+				if processingErr != nil {
+					logger.Errorf(nil, "channel feeds task %s failed but was rescheduled: %s", task.ID, processingErr)
+				}
+				return nil
+			}
+
 			return processingErr
 		})
 
@@ -209,7 +259,8 @@ func isTrackedScheduledTask(taskType string) bool {
 		models.TaskTypeMuteRemoval,
 		models.TaskTypeNotifyVoiceRequests,
 		models.TaskTypeDisinformationMutePenaltyRemoval,
-		models.TaskTypeDisinformationBanPenaltyRemoval:
+		models.TaskTypeDisinformationBanPenaltyRemoval,
+		models.TaskTypePollClose:
 		return true
 	default:
 		return false
@@ -223,9 +274,16 @@ func processReminder(ircs irc.IRC, task *models.Task) error {
 	logger.Debugf(nil, "processing reminder for %s: %s", data.User, data.Content)
 
 	message := ""
-	if irc.IsChannel(data.Destination) {
+	switch {
+	case data.ForChannel:
+		message = fmt.Sprintf("Reminder from %s: %s", data.User, style.Bold(data.Content))
+	case data.IsRecurring() && irc.IsChannel(data.Destination):
+		message = fmt.Sprintf("%s: here's your reminder (%s): %s", data.User, data.Recurrence, style.Bold(data.Content))
+	case data.IsRecurring():
+		message = fmt.Sprintf("Here's your reminder (%s): %s", data.Recurrence, style.Bold(data.Content))
+	case irc.IsChannel(data.Destination):
 		message = fmt.Sprintf("%s: here's the reminder you set %s: %s", data.User, elapse.TimeDescription(task.CreatedAt), style.Bold(data.Content))
-	} else {
+	default:
 		message = fmt.Sprintf("Here's the reminder you set %s: %s", elapse.TimeDescription(task.CreatedAt), style.Bold(data.Content))
 	}
 
@@ -234,28 +292,110 @@ func processReminder(ircs irc.IRC, task *models.Task) error {
 	return nil
 }
 
-func processBanRemoval(irc irc.IRC, task *models.Task) error {
+// recordReminderDelivery records when a reminder was delivered, so that it can be snoozed, and then schedules the next
+// occurrence of a recurring reminder or completes a one-off one.
+func recordReminderDelivery(fs storage.Store, task *models.Task) error {
+	data := task.Data.(models.ReminderTaskData)
+	data.DeliveredAt = time.Now()
+	task.Data = data
+
+	if data.IsRecurring() {
+		next, err := nextReminderDue(task)
+		if err != nil {
+			log.Logger().Errorf(nil, "error scheduling next occurrence of reminder %s, %s", task.ID, err)
+		} else if !next.IsZero() {
+			task.DueAt = next
+			task.Runs = 0
+			task.Status = models.TaskStatusPending
+
+			// the cloud task that delivered this occurrence has already run
+			task.CloudTaskName = ""
+			return fs.RescheduleTask(task)
+		}
+	}
+
+	task.Status = models.TaskStatusComplete
+	return fs.SetTask(task)
+}
+
+// nextReminderDue returns when a recurring reminder is next due, in its time zone, skipping occurrences missed while
+// the bot was down.
+func nextReminderDue(task *models.Task) (time.Time, error) {
+	data := task.Data.(models.ReminderTaskData)
+
+	schedule, err := elapse.ParseSchedule(data.Recurrence)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	loc := time.UTC
+	if len(data.TimeZone) > 0 {
+		if loc, err = time.LoadLocation(data.TimeZone); err != nil {
+			return time.Time{}, err
+		}
+	}
+
+	from := task.DueAt
+	if now := time.Now(); now.After(from) {
+		from = now
+	}
+
+	return schedule.Next(from.In(loc)), nil
+}
+
+func processPollClose(ircs irc.IRC, task *models.Task) error {
+	data := task.Data.(models.PollCloseTaskData)
+
+	logger := log.Logger()
+	logger.Debugf(nil, "processing close of poll %s in %s", data.PollID, data.Channel)
+
+	e := networkEvent(ircs)
+	poll, err := repository.GetPoll(e, data.Channel, data.PollID)
+	if err != nil {
+		return fmt.Errorf("error getting poll %s: %w", data.PollID, err)
+	}
+
+	if poll == nil || !poll.IsOpen() {
+		return nil
+	}
+
+	poll.Close("")
+	if err := repository.ClosePoll(e, data.Channel, poll); err != nil {
+		return fmt.Errorf("error closing poll %s: %w", data.PollID, err)
+	}
+
+	ircs.SendMessage(data.Channel, commands.FormatPollResults(poll))
+
+	return nil
+}
+
+func processBanRemoval(cfg *config.Config, irc irc.IRC, task *models.Task) error {
 	data := task.Data.(models.BanRemovalTaskData)
 
 	logger := log.Logger()
 	logger.Debugf(nil, "processing ban removal for %s in %s", data.Mask, data.Channel)
 
-	irc.Unban(data.Channel, data.Mask)
+	actions.Unban(irc, data.Channel, data.Mask, actions.Actor{Nick: cfg.IRC.Nick, Source: models.AuditSourceSchedule})
 
 	return nil
 }
 
-func processMuteRemoval(irc irc.IRC, task *models.Task) error {
+func processMuteRemoval(cfg *config.Config, irc irc.IRC, task *models.Task) error {
 	data := task.Data.(models.MuteRemovalTaskData)
 
 	logger := log.Logger()
 	logger.Debugf(nil, "processing mute removal for %s in %s", data.Nick, data.Channel)
 
+	if len(data.Quiet) > 0 {
+		actions.Unquiet(irc, data.Channel, data.Quiet, actions.Actor{Nick: cfg.IRC.Nick, Source: models.AuditSourceSchedule})
+		return nil
+	}
+
 	users := make([]*models.User, 0)
 
 	// find user by nick
 	if len(data.Nick) > 0 {
-		u, err := repository.GetUserByNick(nil, data.Channel, data.Nick, false)
+		u, err := repository.GetUserByNick(networkEvent(irc), data.Channel, data.Nick, false)
 		if err != nil {
 			return fmt.Errorf("error getting user by nick: %v", err)
 		}
@@ -267,7 +407,7 @@ func processMuteRemoval(irc irc.IRC, task *models.Task) error {
 
 	// find users with matching host
 	if len(data.Host) > 0 {
-		us, err := repository.GetUsersByHost(nil, data.Channel, data.Host)
+		us, err := repository.GetUsersByHost(networkEvent(irc), data.Channel, data.Host)
 		if err != nil {
 			return fmt.Errorf("error getting users by host: %v", err)
 		}
@@ -280,11 +420,10 @@ func processMuteRemoval(irc irc.IRC, task *models.Task) error {
 	}
 
 	for _, u := range users {
-		irc.Voice(data.Channel, u.Nick)
-		logger.Debugf(nil, "unmuted %s in %s", u.Nick, data.Channel)
+		actions.Unmute(irc, data.Channel, u.Nick, actions.Actor{Nick: cfg.IRC.Nick, Source: models.AuditSourceSchedule})
 
 		if data.AutoVoice {
-			fs := firestore.Get()
+			fs := storage.Network(irc.Network())
 			u.IsAutoVoiced = true
 			if err := fs.UpdateUser(data.Channel, u, map[string]any{"is_auto_voiced": u.IsAutoVoiced, "updated_at": time.Now()}); err != nil {
 				return fmt.Errorf("error updating user isAutoVoiced, %s", err)
@@ -302,7 +441,7 @@ func processNotifyVoiceRequests(irc irc.IRC, task *models.Task) error {
 	logger := log.Logger()
 	logger.Debugf(nil, "processing notify voice requests in %s", data.Channel)
 
-	ch, err := repository.GetChannel(nil, data.Channel)
+	ch, err := repository.GetChannel(networkEvent(irc), data.Channel)
 	if err != nil {
 		return fmt.Errorf("error retrieving channel, %s", err)
 	}
@@ -340,7 +479,7 @@ func processPersistentChannel(ctx context.Context, cfg *config.Config, irc irc.I
 	logger := log.Logger()
 
 	// stale guard: reload from Firestore to check if activity has pushed due_at forward
-	fs := firestore.Get()
+	fs := storage.Network(irc.Network())
 	channelName := task.Data.(models.PersistentTaskData).Channel
 	path := fs.PersistentChannelTaskPath(channelName, task.ID)
 	current, err := fs.Task(path)
@@ -350,7 +489,7 @@ func processPersistentChannel(ctx context.Context, cfg *config.Config, irc irc.I
 	if current != nil && !current.IsDue() {
 		logger.Debugf(nil, "stale cloud task for %s, actual due at %s — rescheduling", task.ID, current.DueAt)
 		current.Data = models.PersistentTaskData{Channel: channelName}
-		if _, err := cloudtasks.Get().CreateTask(current); err != nil {
+		if _, err := scheduler.Get().CreateTask(current); err != nil {
 			logger.Errorf(nil, "error rescheduling stale cloud task %s: %s", task.ID, err)
 		}
 		return errStaleTask
@@ -380,7 +519,7 @@ var drudgeHeadlinesURLHistory = make(map[string]bool)
 
 func processInactivityTaskUsingDrudgeModel(ctx context.Context, cfg *config.Config, irc irc.IRC, task *models.Task) error {
 	logger := log.Logger()
-	fs := firestore.Get()
+	fs := storage.Network(irc.Network())
 
 	channelName := task.Data.(models.PersistentTaskData).Channel
 	if len(channelName) == 0 {
@@ -448,6 +587,7 @@ func processInactivityTaskUsingSubredditModel(ctx context.Context, cfg *config.C
 	}
 
 	proxyTask := models.NewProxyInactivityRequestTask(channelName, cfg.IRC.Inactivity.Subreddit, cfg.IRC.Inactivity.Category, cfg.IRC.Inactivity.Posts+inactivityPostsBuffer)
+	proxyTask.Network = irc.Network()
 	if err := queue.GetProxy().Publish(proxyTask); err != nil {
 		logger.Errorf(nil, "error publishing proxy inactivity request, %s", err)
 		return err
@@ -459,7 +599,7 @@ func processInactivityTaskUsingSubredditModel(ctx context.Context, cfg *config.C
 func processProxyInactivityResponse(cfg *config.Config, ircs irc.IRC, task *models.Task) error {
 	data := task.Data.(models.ProxyInactivityResponseTaskData)
 	logger := log.Logger()
-	fs := firestore.Get()
+	fs := storage.Network(ircs.Network())
 
 	if len(data.Posts) == 0 {
 		logger.Debugf(nil, "no inactivity posts received for %s", data.Channel)
@@ -561,7 +701,7 @@ func processDisinformationMutePenaltyRemoval(ctx context.Context, cfg *config.Co
 	var user *models.User
 
 	if len(data.Nick) > 0 {
-		u, err := repository.GetUserByNick(nil, data.Channel, data.Nick, false)
+		u, err := repository.GetUserByNick(networkEvent(irc), data.Channel, data.Nick, false)
 		if err != nil {
 			return fmt.Errorf("error getting user by nick: %v", err)
 		}
@@ -581,7 +721,7 @@ func processDisinformationMutePenaltyRemoval(ctx context.Context, cfg *config.Co
 		user.Penalty = 0
 	}
 
-	fs := firestore.Get()
+	fs := storage.Network(irc.Network())
 	return fs.UpdateUser(data.Channel, user, map[string]any{"penalty": user.Penalty, "updated_at": time.Now()})
 }
 
@@ -593,7 +733,7 @@ func processDisinformationBanPenaltyRemoval(ctx context.Context, cfg *config.Con
 
 	var user *models.User
 	if len(data.Nick) > 0 {
-		u, err := repository.GetUserByNick(nil, data.Channel, data.Nick, false)
+		u, err := repository.GetUserByNick(networkEvent(irc), data.Channel, data.Nick, false)
 		if err != nil {
 			return fmt.Errorf("error getting user by nick: %w", err)
 		}
@@ -613,7 +753,7 @@ func processDisinformationBanPenaltyRemoval(ctx context.Context, cfg *config.Con
 		user.ExtendedPenalty = 0
 	}
 
-	fs := firestore.Get()
+	fs := storage.Network(irc.Network())
 	return fs.UpdateUser(data.Channel, user, map[string]any{"extended_penalty": user.ExtendedPenalty, "updated_at": time.Now()})
 }
 
@@ -626,7 +766,7 @@ func processProxyLLMResponse(cfg *config.Config, ircs irc.IRC, task *models.Task
 	logger := log.Logger()
 	logger.Debugf(nil, "processing LLM response for %s in %s [response: %s]", data.Nick, data.Channel, data.ResponseID)
 
-	fs := firestore.Get()
+	fs := storage.Get()
 	r, err := fs.LLMResponse(data.ResponseID)
 	if err != nil {
 		return fmt.Errorf("error fetching LLM response %s: %w", data.ResponseID, err)
@@ -814,7 +954,7 @@ func processTriviaStart(cfg *config.Config, ircs irc.IRC, task *models.Task) err
 		}
 	}
 
-	if err := modes.GetManager().Activate(mode, cooldown); err != nil {
+	if err := modes.GetManager(ircs.Network()).Activate(mode, cooldown); err != nil {
 		ircs.SendMessage(data.Channel, fmt.Sprintf("Cannot start trivia: %s", err))
 		return err
 	}
@@ -824,7 +964,7 @@ func processTriviaStart(cfg *config.Config, ircs irc.IRC, task *models.Task) err
 
 func processChannelStats(ircs irc.IRC, task *models.Task) error {
 	logger := log.Logger()
-	fs := firestore.Get()
+	fs := storage.Network(ircs.Network())
 
 	channelName := task.Data.(models.PersistentTaskData).Channel
 
@@ -842,7 +982,7 @@ func processChannelStats(ircs irc.IRC, task *models.Task) error {
 		}
 	}
 
-	messageCount := stats.ReadAndResetMessages(channelName)
+	messageCount := stats.ReadAndResetMessages(ircs.Network(), channelName)
 
 	channelStats := &models.ChannelStats{
 		TotalUsers:   total,
@@ -859,3 +999,67 @@ func processChannelStats(ircs irc.IRC, task *models.Task) error {
 	logger.Debugf(nil, "channel stats for %s: %d total, %d voiced, %d messages", channelName, total, voiced, messageCount)
 	return nil
 }
+
+// processChannelFeeds shares new items from the channel's feeds, recording what was shared or skipped so nothing is
+// shared twice.
+func processChannelFeeds(cfg *config.Config, ircs irc.IRC, task *models.Task) error {
+	logger := log.Logger()
+	fs := storage.Network(ircs.Network())
+
+	channelName := task.Data.(models.PersistentTaskData).Channel
+
+	feeds, err := fs.Feeds(channelName)
+	if err != nil {
+		return fmt.Errorf("error getting feeds for %s: %w", channelName, err)
+	}
+
+	for _, f := range feeds {
+		now := time.Now()
+		f.PolledAt = now
+
+		doc, err := feed.Fetch(f.FeedURL)
+		if err != nil {
+			logger.Warningf(nil, "error fetching feed %s in %s: %s", f.ID, channelName, err)
+			f.LastError = err.Error()
+		} else {
+			f.LastError = ""
+			if len(doc.Title) > 0 {
+				f.Title = doc.Title
+			}
+
+			share, skip := feed.Select(f, doc.Items, now)
+			for _, item := range skip {
+				f.MarkSeen(item.GUID, item.URL)
+			}
+
+			// shares are paced by the connection's send queue
+			for _, item := range share {
+				commands.ShareFeedItem(cfg, ircs, channelName, f, item.URL)
+				f.MarkSeen(item.GUID, item.URL)
+				f.RecordPost(time.Now())
+				logger.Debugf(nil, "shared %s from feed %s in %s", item.URL, f.ID, channelName)
+			}
+		}
+
+		// the feed may have been edited or removed while it was polled, so only what polling changed is saved
+		current, err := fs.Feed(channelName, f.ID)
+		if err != nil {
+			logger.Errorf(nil, "error reloading feed %s in %s: %s", f.ID, channelName, err)
+			continue
+		}
+		if current == nil {
+			continue
+		}
+
+		current.Title = f.Title
+		current.Seen = f.Seen
+		current.PostedAt = f.PostedAt
+		current.PolledAt = f.PolledAt
+		current.LastError = f.LastError
+		if err := fs.SetFeed(channelName, current); err != nil {
+			logger.Errorf(nil, "error updating feed %s in %s: %s", f.ID, channelName, err)
+		}
+	}
+
+	return nil
+}
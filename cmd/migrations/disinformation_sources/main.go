@@ -3,8 +3,8 @@ package main
 import (
 	"assistant/pkg/api/context"
 	"assistant/pkg/config"
-	"assistant/pkg/firestore"
 	"assistant/pkg/log"
+	"assistant/pkg/storage"
 	"fmt"
 	"os"
 )
@@ -37,8 +37,8 @@ func main() {
 		panic(err)
 	}
 
-	initializeFirestore(ctx, cfg)
-	defer firestore.Get().Close()
+	initializeStorage(ctx, cfg)
+	defer storage.Get().Close()
 
 	initializeLogger(ctx, cfg)
 	defer log.Logger().Close()
@@ -48,10 +48,10 @@ func main() {
 	}
 }
 
-func initializeFirestore(ctx context.Context, cfg *config.Config) {
-	_, err := firestore.Initialize(ctx, cfg)
+func initializeStorage(ctx context.Context, cfg *config.Config) {
+	_, err := storage.Initialize(ctx, cfg)
 	if err != nil {
-		panic(fmt.Errorf("error initializing firestore, %s", err))
+		panic(fmt.Errorf("error initializing storage, %s", err))
 	}
 }
 
@@ -62,7 +62,7 @@ func initializeLogger(ctx context.Context, cfg *config.Config) {
 }
 
 func createDisinformationSources(channel string) error {
-	fs := firestore.Get()
+	fs := storage.Get()
 	logger := log.Logger()
 
 	ch, err := fs.Channel(channel)
@@ -3,8 +3,8 @@ package main
 import (
 	"assistant/pkg/api/context"
 	"assistant/pkg/config"
-	"assistant/pkg/firestore"
 	"assistant/pkg/models"
+	"assistant/pkg/storage"
 	"bufio"
 	"fmt"
 	"io"
@@ -56,16 +56,16 @@ func main() {
 		panic(err)
 	}
 
-	initializeFirestore(ctx, cfg)
-	defer firestore.Get().Close()
+	initializeStorage(ctx, cfg)
+	defer storage.Get().Close()
 
 	processFile(cfg, logFilename, channel, start)
 }
 
-func initializeFirestore(ctx context.Context, cfg *config.Config) {
-	_, err := firestore.Initialize(ctx, cfg)
+func initializeStorage(ctx context.Context, cfg *config.Config) {
+	_, err := storage.Initialize(ctx, cfg)
 	if err != nil {
-		panic(fmt.Errorf("error initializing firestore, %s", err))
+		panic(fmt.Errorf("error initializing storage, %s", err))
 	}
 }
 
@@ -119,7 +119,7 @@ func processFile(cfg *config.Config, logFilename, channel string, start int) err
 	i := 0
 	grabs := make([]grab, 0)
 	adds := make([]add, 0)
-	fs := firestore.Get()
+	fs := storage.Get()
 
 	for {
 		line, err := reader.ReadString('\n')
@@ -4,8 +4,8 @@ import (
 	"assistant/pkg/api/context"
 	"assistant/pkg/api/repository"
 	"assistant/pkg/config"
-	"assistant/pkg/firestore"
 	"assistant/pkg/log"
+	"assistant/pkg/storage"
 	"fmt"
 	"os"
 )
@@ -29,8 +29,8 @@ func main() {
 		panic(err)
 	}
 
-	initializeFirestore(ctx, cfg)
-	defer firestore.Get().Close()
+	initializeStorage(ctx, cfg)
+	defer storage.Get().Close()
 
 	initializeLogger(ctx, cfg)
 	defer log.Logger().Close()
@@ -38,9 +38,9 @@ func main() {
 	moveChannelBiasesToSources(cfg)
 }
 
-func initializeFirestore(ctx context.Context, cfg *config.Config) {
-	if _, err := firestore.Initialize(ctx, cfg); err != nil {
-		panic(fmt.Errorf("error initializing firestore, %s", err))
+func initializeStorage(ctx context.Context, cfg *config.Config) {
+	if _, err := storage.Initialize(ctx, cfg); err != nil {
+		panic(fmt.Errorf("error initializing storage, %s", err))
 	}
 }
 
@@ -51,7 +51,7 @@ func initializeLogger(ctx context.Context, cfg *config.Config) {
 }
 
 func moveChannelBiasesToSources(cfg *config.Config) {
-	//fs := firestore.Get()
+	//fs := storage.Get()
 
 	asst, err := repository.GetAssistant(nil, false)
 	if err != nil {
@@ -0,0 +1,53 @@
+package actions
+
+import (
+	"assistant/pkg/api/irc"
+	"assistant/pkg/log"
+	"assistant/pkg/models"
+	"assistant/pkg/storage"
+)
+
+// Actor is who took a moderation action and how, for the channel's audit log. Nick is whoever ran the command or used
+// the dashboard, or the bot itself for automatic rules and scheduled removals.
+type Actor struct {
+	Nick   string
+	Source string
+}
+
+// Audit records a moderation action in the channel's audit log.
+func Audit(ircs irc.IRC, channel string, by Actor, action, target, reason, duration string) {
+	logger := log.Logger()
+
+	entry := models.NewAuditEntry(action, by.Nick, by.Source, target, reason, duration)
+	if err := storage.Network(ircs.Network()).AddAuditEntry(channel, entry); err != nil {
+		logger.Errorf(nil, "audit: error adding %s of %s in %s: %s", action, target, channel, err)
+	}
+}
+
+// Kick kicks the user from the channel and records it in the audit log.
+func Kick(ircs irc.IRC, channel, nick, host, reason string, by Actor) {
+	ircs.Kick(channel, nick, reason)
+	log.Logger().Infof(nil, "kick: kicked %s from %s: %s", nick, channel, reason)
+	Audit(ircs, channel, by, models.AuditActionKick, models.AuditTarget(nick, host), reason, "")
+}
+
+// Unban lifts the ban on the mask and records it in the audit log.
+func Unban(ircs irc.IRC, channel, mask string, by Actor) {
+	ircs.Unban(channel, mask)
+	log.Logger().Infof(nil, "unban: unbanned %s in %s", mask, channel)
+	Audit(ircs, channel, by, models.AuditActionUnban, mask, "", "")
+}
+
+// Unquiet lifts the quiet on the mask and records it in the audit log.
+func Unquiet(ircs irc.IRC, channel, mask string, by Actor) {
+	ircs.Unquiet(channel, mask)
+	log.Logger().Infof(nil, "unquiet: unquieted %s in %s", mask, channel)
+	Audit(ircs, channel, by, models.AuditActionUnmute, mask, "", "")
+}
+
+// Unmute voices the user and records it in the audit log.
+func Unmute(ircs irc.IRC, channel, nick string, by Actor) {
+	ircs.Voice(channel, nick)
+	log.Logger().Infof(nil, "unmute: unmuted %s in %s", nick, channel)
+	Audit(ircs, channel, by, models.AuditActionUnmute, nick, "", "")
+}
@@ -3,16 +3,19 @@ package actions
 import (
 	"assistant/pkg/api/elapse"
 	"assistant/pkg/api/irc"
-	"assistant/pkg/firestore"
 	"assistant/pkg/log"
 	"assistant/pkg/models"
+	"assistant/pkg/storage"
 	"fmt"
 	"time"
 )
 
-func Ban(ircs irc.IRC, channel, mask, duration, reason string) {
+func Ban(ircs irc.IRC, channel, mask, duration, reason string, by Actor) {
 	logger := log.Logger()
 
+	// record the reason given, before the kick reason adds the duration to it
+	Audit(ircs, channel, by, models.AuditActionBan, mask, reason, duration)
+
 	// build kick reason with duration info, if duration specified
 	if duration != "" {
 		durationDesc := elapse.ParseDurationDescription(duration)
@@ -54,7 +57,7 @@ func Ban(ircs irc.IRC, channel, mask, duration, reason string) {
 			return
 		}
 		task := models.NewBanRemovalTask(time.Now().Add(dur), m.String(), channel)
-		if err := firestore.Get().AddTask(task); err != nil {
+		if err := storage.Network(ircs.Network()).AddTask(task); err != nil {
 			logger.Errorf(nil, "ban: error scheduling ban removal: %s", err)
 		}
 	}
@@ -4,17 +4,17 @@ import (
 	"assistant/pkg/api/elapse"
 	"assistant/pkg/api/irc"
 	"assistant/pkg/api/style"
-	"assistant/pkg/firestore"
 	"assistant/pkg/log"
 	"assistant/pkg/models"
+	"assistant/pkg/storage"
 	"fmt"
 	"slices"
 	"time"
 )
 
-func Mute(ircs irc.IRC, channel, nick, host, duration, reason string) {
+func Mute(ircs irc.IRC, channel, nick, host, duration, reason string, by Actor) {
 	logger := log.Logger()
-	fs := firestore.Get()
+	fs := storage.Network(ircs.Network())
 
 	// send channel notification
 	var msg string
@@ -39,6 +39,19 @@ func Mute(ircs irc.IRC, channel, nick, host, duration, reason string) {
 		}
 	}
 
+	// include users linked to the same services account
+	if account := ircs.Account(nick); len(account) > 0 {
+		accountUsers, err := fs.GetUsersByAccount(channel, account)
+		if err != nil {
+			logger.Errorf(nil, "mute: error getting users by account: %s", err)
+		}
+		for _, au := range accountUsers {
+			if !slices.ContainsFunc(users, func(u *models.User) bool { return u.Nick == au.Nick }) {
+				users = append(users, au)
+			}
+		}
+	}
+
 	// ensure the target nick is included
 	hasTarget := false
 	for _, u := range users {
@@ -48,7 +61,7 @@ func Mute(ircs irc.IRC, channel, nick, host, duration, reason string) {
 		}
 	}
 	if !hasTarget {
-		users = append([]*models.User{{Nick: nick}}, users...)
+		users = append([]*models.User{{Nick: nick, Host: host}}, users...)
 	}
 
 	// check auto-voice status before removing it
@@ -72,6 +85,7 @@ func Mute(ircs irc.IRC, channel, nick, host, duration, reason string) {
 	channelAutoVoiceChanged := false
 	for _, u := range users {
 		ircs.Mute(channel, u.Nick)
+		Audit(ircs, channel, by, models.AuditActionMute, models.AuditTarget(u.Nick, u.Host), reason, duration)
 		if duration != "" {
 			logger.Infof(nil, "mute: temporarily muted %s in %s for %s", u.Nick, channel, duration)
 		} else {
@@ -113,3 +127,42 @@ func Mute(ircs irc.IRC, channel, nick, host, duration, reason string) {
 		}
 	}
 }
+
+// Quiet silences only the user, without announcing it in the channel or touching anyone sharing their host. It sets a
+// quiet on their nick and host where the server supports quiets, and otherwise devoices them.
+func Quiet(ircs irc.IRC, channel, nick, host, duration, reason string, by Actor) {
+	logger := log.Logger()
+
+	mask := nick
+	if len(host) > 0 {
+		mask = fmt.Sprintf("%s!*@%s", nick, host)
+	}
+
+	quieted := ircs.Quiet(channel, mask)
+	if quieted {
+		logger.Infof(nil, "quiet: quieted %s in %s", mask, channel)
+	} else {
+		ircs.Mute(channel, nick)
+		logger.Infof(nil, "quiet: devoiced %s in %s, the server doesn't support quiets", nick, channel)
+	}
+	Audit(ircs, channel, by, models.AuditActionMute, models.AuditTarget(nick, host), reason, duration)
+
+	// schedule quiet removal if duration specified
+	if duration == "" {
+		return
+	}
+
+	dur, err := elapse.ParseDuration(duration)
+	if err != nil {
+		logger.Errorf(nil, "quiet: error parsing duration: %s", err)
+		return
+	}
+
+	task := models.NewMuteRemovalTask(time.Now().Add(dur), channel, nick, "", false)
+	if quieted {
+		task = models.NewQuietRemovalTask(time.Now().Add(dur), channel, mask)
+	}
+	if err := storage.Network(ircs.Network()).AddTask(task); err != nil {
+		logger.Errorf(nil, "quiet: error scheduling quiet removal: %s", err)
+	}
+}
@@ -0,0 +1,31 @@
+package actions
+
+import (
+	"assistant/pkg/api/irc"
+	"assistant/pkg/log"
+	"assistant/pkg/storage"
+)
+
+// NotifyOps sends the message to the channel's ops channel or, if it doesn't have one, privately to the users who get its
+// voice request notifications.
+func NotifyOps(ircs irc.IRC, channel, msg string) {
+	logger := log.Logger()
+
+	ch, err := storage.Network(ircs.Network()).Channel(channel)
+	if err != nil {
+		logger.Errorf(nil, "notify: error getting channel %s: %s", channel, err)
+		return
+	}
+	if ch != nil && len(ch.OpsChannel) > 0 {
+		ircs.SendMessage(ch.OpsChannel, msg)
+		return
+	}
+	if ch == nil || len(ch.VoiceRequestNotifications) == 0 {
+		logger.Infof(nil, "notify: no one to notify in %s: %s", channel, msg)
+		return
+	}
+
+	for _, n := range ch.VoiceRequestNotifications {
+		ircs.SendMessage(n.User, msg)
+	}
+}
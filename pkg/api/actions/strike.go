@@ -0,0 +1,122 @@
+package actions
+
+import (
+	"assistant/pkg/api/irc"
+	"assistant/pkg/log"
+	"assistant/pkg/models"
+	"assistant/pkg/storage"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Strike records a strike against the user and, if the channel has a strike ladder, takes the ladder's action for
+// their active strikes. It reports whether the ladder decided the outcome, in which case callers skip the action
+// they'd otherwise take.
+func Strike(ircs irc.IRC, channel, nick, host, account, source, reason string, weight int, by Actor) bool {
+	step, active, laddered := addStrike(ircs, channel, nick, host, account, source, reason, weight, by)
+	if !laddered {
+		return false
+	}
+
+	if step == nil {
+		log.Logger().Infof(nil, "strike: %s has %d active strikes in %s, below the ladder", nick, active, channel)
+		return true
+	}
+
+	takeAction(ircs, channel, nick, host, *step, withStrikes(reason, active), Actor{Nick: by.Nick, Source: models.AuditSourceStrikeLadder})
+	return true
+}
+
+// StrikeAtLeast records a strike like Strike, but takes the minimum action whenever the channel's ladder would take a
+// lesser one or none. The strike is audited with the reason, while the user is only given the public reason.
+func StrikeAtLeast(ircs irc.IRC, channel, nick, host, account, source, reason, public string, weight int, minimum models.StrikeStep, by Actor) {
+	step, active, laddered := addStrike(ircs, channel, nick, host, account, source, reason, weight, by)
+	if !laddered || step == nil || minimum.Outranks(*step) {
+		takeAction(ircs, channel, nick, host, minimum, public, by)
+		return
+	}
+
+	takeAction(ircs, channel, nick, host, *step, withStrikes(public, active), Actor{Nick: by.Nick, Source: models.AuditSourceStrikeLadder})
+}
+
+// addStrike records and audits a strike against the user. It returns the ladder step their active strikes reach, if
+// any, and whether the channel has a ladder at all.
+func addStrike(ircs irc.IRC, channel, nick, host, account, source, reason string, weight int, by Actor) (*models.StrikeStep, int, bool) {
+	logger := log.Logger()
+	fs := storage.Network(ircs.Network())
+
+	ch, err := fs.Channel(channel)
+	if err != nil {
+		logger.Errorf(nil, "strike: error getting channel: %s", err)
+	}
+	if ch == nil {
+		// record the strike with the default expiry, without a ladder to escalate through
+		ch = models.NewChannel(channel, "")
+	}
+
+	strike := models.NewStrike(nick, host, account, source, reason, weight, ch.StrikeExpiryDuration(), by.Nick)
+	if err := fs.AddStrike(channel, strike); err != nil {
+		logger.Errorf(nil, "strike: error adding strike: %s", err)
+	}
+	Audit(ircs, channel, by, models.AuditActionStrike, models.AuditTarget(nick, host), strings.TrimSpace(fmt.Sprintf("%s (×%d)", reason, weight)), "")
+	logger.Infof(nil, "strike: %s in %s for %s (%s), weight %d", nick, channel, reason, source, weight)
+
+	if !ch.HasStrikeLadder() {
+		return nil, 0, false
+	}
+
+	strikes, err := fs.UserStrikes(channel, nick, host, account)
+	if err != nil {
+		logger.Errorf(nil, "strike: error getting strikes: %s", err)
+		return nil, 0, false
+	}
+
+	active := models.ActiveStrikes(strikes, time.Now())
+	step := ch.StrikeLadderStep(active)
+	if step != nil {
+		logger.Infof(nil, "strike: %s has %d active strikes in %s, action: %s %s", nick, active, channel, step.Action, step.Duration)
+	}
+	return step, active, true
+}
+
+func withStrikes(reason string, active int) string {
+	label := "strikes"
+	if active == 1 {
+		label = "strike"
+	}
+	return fmt.Sprintf("%s (%d %s)", reason, active, label)
+}
+
+// takeAction warns, mutes, kicks or bans the user as the step says, kicking instead of banning users without a host.
+// Users of a shared host are banned by nick and host, so that the ban doesn't catch everyone else using it.
+func takeAction(ircs irc.IRC, channel, nick, host string, step models.StrikeStep, reason string, by Actor) {
+	switch step.Action {
+	case models.ModerationActionWarn:
+		Warn(ircs, channel, nick, host, reason, by)
+	case models.ModerationActionMute:
+		Mute(ircs, channel, nick, host, step.Duration, reason, by)
+	case models.ModerationActionKick:
+		Kick(ircs, channel, nick, host, reason, by)
+	case models.ModerationActionBan:
+		if len(host) == 0 {
+			Kick(ircs, channel, nick, host, reason, by)
+			break
+		}
+		mask := fmt.Sprintf("*!*@%s", host)
+		if irc.IsSharedHost(host) {
+			mask = fmt.Sprintf("%s!*@%s", nick, host)
+		}
+		Ban(ircs, channel, mask, step.Duration, reason, by)
+	}
+}
+
+func Warn(ircs irc.IRC, channel, nick, host, reason string, by Actor) {
+	msg := fmt.Sprintf("⚠️ %s has been warned", nick)
+	if reason != "" {
+		msg += ": " + reason
+	}
+	ircs.SendMessage(channel, msg)
+	log.Logger().Infof(nil, "warn: warned %s in %s: %s", nick, channel, reason)
+	Audit(ircs, channel, by, models.AuditActionWarn, models.AuditTarget(nick, host), reason, "")
+}
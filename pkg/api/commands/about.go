@@ -43,7 +43,7 @@ func (c *AboutCommand) CanExecute(e *irc.Event) bool {
 	return c.isCommandEventValid(c, e, 0)
 }
 
-func (c *AboutCommand) Execute(e *irc.Event) {
+func (c *AboutCommand) Execute(e *irc.Event, _ *Args) {
 	logger := log.Logger()
 	logger.Infof(e, "⚡ %s [%s/%s] ", c.Name(), e.From, e.ReplyTarget())
 	message := "Version 0.1. Source: https://github.com/argxentum/irc-assistant."
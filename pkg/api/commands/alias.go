@@ -0,0 +1,197 @@
+package commands
+
+import (
+	"assistant/pkg/api/context"
+	"assistant/pkg/api/irc"
+	"assistant/pkg/api/repository"
+	"assistant/pkg/api/style"
+	"assistant/pkg/config"
+	"assistant/pkg/log"
+	"assistant/pkg/models"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const AliasCommandName = "alias"
+
+const (
+	customCommandActionAdd    = "add"
+	customCommandActionRemove = "remove"
+)
+
+type AliasCommand struct {
+	*commandStub
+}
+
+func NewAliasCommand(ctx context.Context, cfg *config.Config, ircs irc.IRC) Command {
+	return &AliasCommand{
+		commandStub: newCommandStub(ctx, cfg, ircs, RoleAdmin, irc.ChannelStatusOperator),
+	}
+}
+
+func (c *AliasCommand) Name() string {
+	return AliasCommandName
+}
+
+func (c *AliasCommand) Description() string {
+	return "Shows and manages the channel's command aliases."
+}
+
+func (c *AliasCommand) Triggers() []string {
+	return []string{"alias", "aliases"}
+}
+
+var aliasArgs = ArgSpec{
+	Note: "shows the channel's aliases",
+	Subcommands: []Subcommand{
+		{Name: customCommandActionAdd, ArgSpec: ArgSpec{
+			Args: []Arg{
+				{Name: "alias", Type: ArgTypeString},
+				{Name: "command", Type: ArgTypeText},
+			},
+			Note: "the command's arguments can use $nick, $1 to $9 and $*, otherwise they're appended",
+		}},
+		{Name: customCommandActionRemove, ArgSpec: ArgSpec{
+			Args: []Arg{
+				{Name: "alias", Type: ArgTypeString},
+			},
+		}},
+	},
+}
+
+func (c *AliasCommand) Usages() []string {
+	return aliasArgs.Usages()
+}
+
+func (c *AliasCommand) Arguments() *ArgSpec {
+	return &aliasArgs
+}
+
+func (c *AliasCommand) AllowedInPrivateMessages() bool {
+	return false
+}
+
+func (c *AliasCommand) CanExecute(e *irc.Event) bool {
+	return c.isCommandEventValid(c, e, 0)
+}
+
+func (c *AliasCommand) Execute(e *irc.Event, args *Args) {
+	logger := log.Logger()
+	logger.Infof(e, "⚡ %s [%s/%s] %s", c.Name(), e.From, e.ReplyTarget(), strings.Join(Tokens(e.Message())[1:], " "))
+
+	switch args.Subcommand() {
+	case customCommandActionAdd:
+		value := args.String("command")
+		if !strings.HasPrefix(value, c.cfg.Commands.Prefix) {
+			value = c.cfg.Commands.Prefix + value
+		}
+		target := strings.TrimPrefix(Tokens(value)[0], c.cfg.Commands.Prefix)
+		if !c.isBuiltInTrigger(target) {
+			c.Replyf(e, "%s is not a command.", style.Bold(target))
+			return
+		}
+		c.addCustomCommand(e, models.NewCustomCommand(c.customTrigger(args.String("alias")), models.CustomCommandTypeAlias, value, e.From))
+	case customCommandActionRemove:
+		c.removeCustomCommand(e, c.customTrigger(args.String("alias")), models.CustomCommandTypeAlias)
+	default:
+		c.listCustomCommands(e, "Aliases", models.CustomCommandTypeAlias)
+	}
+}
+
+// customTrigger returns the trigger of a custom command as given, without the command prefix.
+func (cs *commandStub) customTrigger(input string) string {
+	return strings.ToLower(strings.TrimPrefix(input, cs.cfg.Commands.Prefix))
+}
+
+func (cs *commandStub) isBuiltInTrigger(trigger string) bool {
+	for _, cmd := range cs.registry().Commands() {
+		if slices.Contains(cmd.Triggers(), trigger) {
+			return true
+		}
+	}
+	return false
+}
+
+// addCustomCommand adds an alias or macro to the event's channel and reloads the channel's commands.
+func (cs *commandStub) addCustomCommand(e *irc.Event, cc models.CustomCommand) {
+	logger := log.Logger()
+
+	if err := cc.Validate(); err != nil {
+		cs.Replyf(e, "Unable to add %s: %s.", style.Bold(cc.Trigger), err)
+		return
+	}
+	if cs.isBuiltInTrigger(cc.Trigger) {
+		cs.Replyf(e, "%s is already a command.", style.Bold(cc.Trigger))
+		return
+	}
+
+	ch, err := repository.GetChannel(e, e.ReplyTarget())
+	if err != nil {
+		logger.Errorf(e, "error retrieving channel, %s", err)
+		return
+	}
+
+	if !ch.AddCustomCommand(cc) {
+		cs.Replyf(e, "%s already exists in %s.", style.Bold(cc.Trigger), style.Bold(ch.Name))
+		return
+	}
+
+	if err = repository.UpdateChannelCustomCommands(e, ch); err != nil {
+		return
+	}
+	cs.registry().InvalidateChannelCommands(ch.Name)
+
+	cs.SendMessage(e, e.ReplyTarget(), fmt.Sprintf("Added %s%s in %s", cs.cfg.Commands.Prefix, style.Bold(cc.Trigger), style.Bold(ch.Name)))
+}
+
+// removeCustomCommand removes an alias or macro of one of the given types from the event's channel.
+func (cs *commandStub) removeCustomCommand(e *irc.Event, trigger string, types ...string) {
+	logger := log.Logger()
+
+	ch, err := repository.GetChannel(e, e.ReplyTarget())
+	if err != nil {
+		logger.Errorf(e, "error retrieving channel, %s", err)
+		return
+	}
+
+	if !ch.RemoveCustomCommand(trigger, types...) {
+		cs.Replyf(e, "%s doesn't exist in %s.", style.Bold(trigger), style.Bold(ch.Name))
+		return
+	}
+
+	if err = repository.UpdateChannelCustomCommands(e, ch); err != nil {
+		return
+	}
+	cs.registry().InvalidateChannelCommands(ch.Name)
+
+	cs.SendMessage(e, e.ReplyTarget(), fmt.Sprintf("Removed %s%s from %s", cs.cfg.Commands.Prefix, style.Bold(trigger), style.Bold(ch.Name)))
+}
+
+// listCustomCommands shows the channel's aliases or macros of one of the given types.
+func (cs *commandStub) listCustomCommands(e *irc.Event, label string, types ...string) {
+	ch, err := repository.GetChannel(e, e.ReplyTarget())
+	if err != nil {
+		log.Logger().Errorf(e, "error retrieving channel, %s", err)
+		return
+	}
+
+	descriptions := make([]string, 0)
+	for _, cc := range ch.CustomCommands {
+		if !slices.Contains(types, cc.Type) {
+			continue
+		}
+		value := cc.Value
+		if cc.Type != models.CustomCommandTypeAlias {
+			value = fmt.Sprintf("%s %s", cc.Type, cc.Value)
+		}
+		descriptions = append(descriptions, fmt.Sprintf("%s%s → %s", cs.cfg.Commands.Prefix, style.Bold(cc.Trigger), value))
+	}
+
+	if len(descriptions) == 0 {
+		cs.Replyf(e, "There are no %s in %s.", strings.ToLower(label), style.Bold(ch.Name))
+		return
+	}
+
+	cs.SendMessage(e, e.ReplyTarget(), fmt.Sprintf("%s: %s", style.Underline(label), strings.Join(descriptions, ", ")))
+}
@@ -46,7 +46,7 @@ func (c *AnimatedTextCommand) CanExecute(e *irc.Event) bool {
 	return c.isCommandEventValid(c, e, 1)
 }
 
-func (c *AnimatedTextCommand) Execute(e *irc.Event) {
+func (c *AnimatedTextCommand) Execute(e *irc.Event, _ *Args) {
 	logger := log.Logger()
 	tokens := Tokens(e.Message())
 	message := strings.Join(tokens[1:], "_") + ".gif"
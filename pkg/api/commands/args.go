@@ -0,0 +1,445 @@
+package commands
+
+import (
+	"assistant/pkg/api/elapse"
+	"assistant/pkg/api/irc"
+	"assistant/pkg/api/style"
+	"assistant/pkg/models"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type ArgType string
+
+const (
+	// ArgTypeString is a single word.
+	ArgTypeString ArgType = "string"
+	// ArgTypeQuoted is a single word or several in double quotes.
+	ArgTypeQuoted ArgType = "quoted"
+	// ArgTypeText is the rest of the input. It can only be the last argument.
+	ArgTypeText     ArgType = "text"
+	ArgTypeNick     ArgType = "nick"
+	ArgTypeChannel  ArgType = "channel"
+	ArgTypeDuration ArgType = "duration"
+	ArgTypeInt      ArgType = "int"
+	ArgTypeURL      ArgType = "url"
+	// ArgTypeBool is a flag that takes no value, set by being present.
+	ArgTypeBool ArgType = "bool"
+	// ArgTypeChoice is one of the argument's Choices, ignoring case.
+	ArgTypeChoice ArgType = "choice"
+)
+
+const flagPrefix = "--"
+
+var nickRegexp = regexp.MustCompile(`^[A-Za-z\[\]\\` + "`" + `_^{|}][A-Za-z0-9\[\]\\` + "`" + `_^{|}-]*$`)
+
+// Arg is a positional argument. Optional arguments that are typed, such as a channel or duration, are only taken from
+// the input when the next word is of that type, so that they can come before required arguments.
+type Arg struct {
+	Name     string
+	Type     ArgType
+	Optional bool
+	Default  string
+	Choices  []string
+}
+
+// Flag is a --name argument, given anywhere in the input as --name value, --name=value or, for bool flags, --name.
+type Flag struct {
+	Name    string
+	Type    ArgType
+	Default string
+}
+
+// ArgSpec declares the arguments a command takes. Commands that return one from Arguments have their input parsed and
+// validated before they're executed, and their usage derived from it. Note is shown after the usage.
+type ArgSpec struct {
+	Args        []Arg
+	Flags       []Flag
+	Subcommands []Subcommand
+	Note        string
+}
+
+// Subcommand is one of a command's forms, chosen by its first word, such as add or remove, and taking its own
+// arguments. Input that doesn't start with a subcommand is parsed by the command's own arguments.
+type Subcommand struct {
+	Name    string
+	Aliases []string
+	ArgSpec
+}
+
+// Args holds parsed argument and flag values, keyed by name, and the subcommand they were given to, if any.
+type Args struct {
+	subcommand string
+	values     map[string]string
+}
+
+// ArgError is a parsing or validation error, worded to be shown to the user.
+type ArgError struct {
+	message string
+}
+
+func (e *ArgError) Error() string {
+	return e.message
+}
+
+func argErrorf(format string, args ...any) *ArgError {
+	return &ArgError{message: fmt.Sprintf(format, args...)}
+}
+
+// Parse parses the words after a command's trigger.
+func (s *ArgSpec) Parse(input []string) (*Args, error) {
+	if sub, rest := s.subcommand(input); sub != nil {
+		args, err := sub.Parse(rest)
+		if err != nil {
+			return nil, err
+		}
+		args.subcommand = sub.Name
+		return args, nil
+	}
+
+	args := &Args{values: make(map[string]string)}
+
+	positional, err := s.parseFlags(input, args)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, arg := range s.Args {
+		if len(positional) == 0 {
+			if !arg.Optional {
+				return nil, argErrorf("Missing %s.", arg.placeholder())
+			}
+			if len(arg.Default) > 0 {
+				args.values[arg.Name] = arg.Default
+			}
+			continue
+		}
+
+		// an optional argument is skipped when the next word isn't of its type, or when the words left are needed by
+		// the required arguments after it
+		if arg.Optional && (arg.validate(positional[0]) != nil || len(positional) <= s.requiredAfter(i)) {
+			if len(arg.Default) > 0 {
+				args.values[arg.Name] = arg.Default
+			}
+			continue
+		}
+
+		var value string
+		value, positional, err = takeArg(arg.Type, positional)
+		if err != nil {
+			return nil, err
+		}
+		if err = arg.validate(value); err != nil {
+			return nil, argErrorf("Invalid %s: %s", arg.placeholder(), err)
+		}
+		if arg.Type == ArgTypeChoice {
+			value = strings.ToLower(value)
+		}
+		args.values[arg.Name] = value
+	}
+
+	if len(positional) > 0 {
+		if len(s.Args) == 0 && len(s.Subcommands) > 0 {
+			return nil, argErrorf("Unknown subcommand %s.", style.Bold(positional[0]))
+		}
+		return nil, argErrorf("Unexpected %s.", strings.Join(positional, " "))
+	}
+
+	return args, nil
+}
+
+// parseFlags takes flags out of the input into args and returns the positional words left. When the spec has no
+// flags, words starting with -- are left as they are.
+func (s *ArgSpec) parseFlags(input []string, args *Args) ([]string, error) {
+	if len(s.Flags) == 0 {
+		return slices.DeleteFunc(slices.Clone(input), func(w string) bool { return len(w) == 0 }), nil
+	}
+
+	for _, flag := range s.Flags {
+		if len(flag.Default) > 0 {
+			args.values[flag.Name] = flag.Default
+		}
+	}
+
+	positional := make([]string, 0, len(input))
+	for i := 0; i < len(input); i++ {
+		word := input[i]
+		if len(word) == 0 {
+			continue
+		}
+		if !strings.HasPrefix(word, flagPrefix) || len(word) == len(flagPrefix) {
+			positional = append(positional, word)
+			continue
+		}
+
+		name, value, hasValue := strings.Cut(strings.TrimPrefix(word, flagPrefix), "=")
+		flag := s.flag(name)
+		if flag == nil {
+			return nil, argErrorf("Unknown flag %s.", style.Bold(flagPrefix+name))
+		}
+
+		if flag.Type == ArgTypeBool {
+			if hasValue {
+				if _, err := strconv.ParseBool(value); err != nil {
+					return nil, argErrorf("Invalid value for %s: %s", style.Bold(flagPrefix+name), value)
+				}
+			} else {
+				value = "true"
+			}
+			args.values[flag.Name] = value
+			continue
+		}
+
+		if !hasValue {
+			var rest []string
+			var err error
+			if value, rest, err = takeArg(flag.Type, input[i+1:]); err != nil || len(value) == 0 {
+				return nil, argErrorf("Missing value for %s.", style.Bold(flagPrefix+name))
+			}
+			i = len(input) - len(rest) - 1
+		}
+		if err := validateArg(flag.Type, value); err != nil {
+			return nil, argErrorf("Invalid value for %s: %s", style.Bold(flagPrefix+name), err)
+		}
+		args.values[flag.Name] = value
+	}
+
+	return positional, nil
+}
+
+// subcommand returns the subcommand the input starts with, if any, and the words after it.
+func (s *ArgSpec) subcommand(input []string) (*Subcommand, []string) {
+	for i, word := range input {
+		if len(word) == 0 {
+			continue
+		}
+		for j := range s.Subcommands {
+			sub := &s.Subcommands[j]
+			if strings.EqualFold(sub.Name, word) || slices.ContainsFunc(sub.Aliases, func(a string) bool { return strings.EqualFold(a, word) }) {
+				return sub, input[i+1:]
+			}
+		}
+		return nil, input
+	}
+	return nil, input
+}
+
+func (s *ArgSpec) flag(name string) *Flag {
+	for i := range s.Flags {
+		if strings.EqualFold(s.Flags[i].Name, name) {
+			return &s.Flags[i]
+		}
+	}
+	return nil
+}
+
+// requiredAfter returns the number of required arguments after the i-th.
+func (s *ArgSpec) requiredAfter(i int) int {
+	n := 0
+	for _, arg := range s.Args[i+1:] {
+		if !arg.Optional {
+			n++
+		}
+	}
+	return n
+}
+
+// takeArg takes the words of one argument off the input.
+func takeArg(t ArgType, input []string) (string, []string, error) {
+	if len(input) == 0 {
+		return "", input, nil
+	}
+
+	switch t {
+	case ArgTypeText:
+		return strings.Join(input, " "), nil, nil
+	case ArgTypeQuoted:
+		if !strings.HasPrefix(input[0], `"`) {
+			return input[0], input[1:], nil
+		}
+		for i, word := range input {
+			if (i > 0 || len(word) > 1) && strings.HasSuffix(word, `"`) {
+				quoted := strings.Join(input[:i+1], " ")
+				return quoted[1 : len(quoted)-1], input[i+1:], nil
+			}
+		}
+		return "", nil, argErrorf("Missing closing quote.")
+	default:
+		return input[0], input[1:], nil
+	}
+}
+
+func (a Arg) validate(value string) error {
+	if a.Type == ArgTypeChoice {
+		if !slices.ContainsFunc(a.Choices, func(c string) bool { return strings.EqualFold(c, value) }) {
+			return fmt.Errorf("%s is not one of %s", value, strings.Join(a.Choices, ", "))
+		}
+		return nil
+	}
+	return validateArg(a.Type, value)
+}
+
+func validateArg(t ArgType, value string) error {
+	switch t {
+	case ArgTypeNick:
+		if !nickRegexp.MatchString(value) {
+			return fmt.Errorf("%s is not a nick", value)
+		}
+	case ArgTypeChannel:
+		if !irc.IsChannel(value) {
+			return fmt.Errorf("%s is not a channel", value)
+		}
+	case ArgTypeDuration:
+		if !elapse.IsDuration(value) {
+			return fmt.Errorf("%s is not a duration, such as 30m or 2h", value)
+		}
+	case ArgTypeInt:
+		if _, err := strconv.Atoi(value); err != nil {
+			return fmt.Errorf("%s is not a number", value)
+		}
+	case ArgTypeURL:
+		u, err := url.Parse(value)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || len(u.Host) == 0 {
+			return fmt.Errorf("%s is not a URL", value)
+		}
+	}
+	return nil
+}
+
+func (a Arg) placeholder() string {
+	if a.Type == ArgTypeChoice {
+		return strings.Join(a.Choices, "|")
+	}
+	return fmt.Sprintf("<%s>", a.Name)
+}
+
+// Usage returns the command's syntax in the form of Usages, with %s in place of the trigger.
+func (s *ArgSpec) Usage() string {
+	return s.usage("%s")
+}
+
+// Usages returns the syntax of each of the command's forms, starting with the one without a subcommand.
+func (s *ArgSpec) Usages() []string {
+	usages := []string{s.Usage()}
+	for _, sub := range s.Subcommands {
+		usages = append(usages, sub.usage("%s "+sub.Name))
+	}
+	return usages
+}
+
+func (s *ArgSpec) usage(prefix string) string {
+	parts := make([]string, 0, len(s.Args)+len(s.Flags)+1)
+	for _, arg := range s.Args {
+		p := arg.placeholder()
+		if len(arg.Default) > 0 {
+			p = fmt.Sprintf("%s=%s", p, arg.Default)
+		}
+		if arg.Optional {
+			p = fmt.Sprintf("[%s]", p)
+		}
+		parts = append(parts, p)
+	}
+	for _, flag := range s.Flags {
+		p := flagPrefix + flag.Name
+		if flag.Type != ArgTypeBool {
+			p = fmt.Sprintf("%s <%s>", p, flag.Type)
+			if len(flag.Default) > 0 {
+				p = fmt.Sprintf("%s=%s", p, flag.Default)
+			}
+		}
+		parts = append(parts, fmt.Sprintf("[%s]", p))
+	}
+	if len(s.Note) > 0 {
+		parts = append(parts, fmt.Sprintf("(%s)", s.Note))
+	}
+
+	usage := prefix
+	if len(parts) > 0 {
+		usage += " " + strings.ReplaceAll(strings.Join(parts, " "), "%", "%%")
+	}
+	return usage
+}
+
+// argumentInfo describes a spec's arguments and flags, and those of its subcommands, for models.CommandInfo.
+func argumentInfo(s *ArgSpec) []models.CommandArgument {
+	if s == nil {
+		return nil
+	}
+
+	info := s.argumentInfo("")
+	for _, sub := range s.Subcommands {
+		info = append(info, sub.argumentInfo(sub.Name)...)
+	}
+	return info
+}
+
+func (s *ArgSpec) argumentInfo(subcommand string) []models.CommandArgument {
+	info := make([]models.CommandArgument, 0, len(s.Args)+len(s.Flags))
+	for _, arg := range s.Args {
+		info = append(info, models.CommandArgument{
+			Name:       arg.Name,
+			Type:       string(arg.Type),
+			Optional:   arg.Optional,
+			Default:    arg.Default,
+			Choices:    arg.Choices,
+			Subcommand: subcommand,
+		})
+	}
+	for _, flag := range s.Flags {
+		info = append(info, models.CommandArgument{
+			Name:       flagPrefix + flag.Name,
+			Type:       string(flag.Type),
+			Optional:   true,
+			Default:    flag.Default,
+			Flag:       true,
+			Subcommand: subcommand,
+		})
+	}
+	return info
+}
+
+// Subcommand returns the name of the subcommand the arguments were given to, or an empty string for the command's own.
+func (a *Args) Subcommand() string {
+	return a.subcommand
+}
+
+// Has returns whether an argument or flag was given or has a default.
+func (a *Args) Has(name string) bool {
+	_, ok := a.values[name]
+	return ok
+}
+
+func (a *Args) String(name string) string {
+	return a.values[name]
+}
+
+func (a *Args) Int(name string) int {
+	n, _ := strconv.Atoi(a.values[name])
+	return n
+}
+
+func (a *Args) Duration(name string) time.Duration {
+	if v, ok := a.values[name]; ok {
+		d, _ := elapse.ParseDuration(v)
+		return d
+	}
+	return 0
+}
+
+func (a *Args) Bool(name string) bool {
+	b, _ := strconv.ParseBool(a.values[name])
+	return b
+}
+
+// Words returns a text argument split into words.
+func (a *Args) Words(name string) []string {
+	if v, ok := a.values[name]; ok && len(v) > 0 {
+		return strings.Fields(v)
+	}
+	return []string{}
+}
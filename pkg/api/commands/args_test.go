@@ -0,0 +1,227 @@
+package commands
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestArgSpecParsesTypedArguments(t *testing.T) {
+	spec := ArgSpec{
+		Args: []Arg{
+			{Name: "channel", Type: ArgTypeChannel, Optional: true},
+			{Name: "nick", Type: ArgTypeNick},
+			{Name: "duration", Type: ArgTypeDuration, Optional: true, Default: "1h"},
+			{Name: "reason", Type: ArgTypeText, Optional: true},
+		},
+	}
+
+	args, err := spec.Parse(strings.Fields("#channel nick 30m being rude"))
+	if err != nil {
+		t.Fatalf("parse: %s", err)
+	}
+	if args.String("channel") != "#channel" || args.String("nick") != "nick" {
+		t.Fatalf("channel/nick = %s/%s, want #channel/nick", args.String("channel"), args.String("nick"))
+	}
+	if args.Duration("duration") != 30*time.Minute {
+		t.Fatalf("duration = %s, want 30m", args.Duration("duration"))
+	}
+	if args.String("reason") != "being rude" {
+		t.Fatalf("reason = %q, want %q", args.String("reason"), "being rude")
+	}
+
+	args, err = spec.Parse(strings.Fields("nick being rude"))
+	if err != nil {
+		t.Fatalf("parse: %s", err)
+	}
+	if args.Has("channel") {
+		t.Fatalf("channel = %s, want none", args.String("channel"))
+	}
+	if args.Duration("duration") != time.Hour {
+		t.Fatalf("duration = %s, want default of 1h", args.Duration("duration"))
+	}
+	if args.String("reason") != "being rude" {
+		t.Fatalf("reason = %q, want %q", args.String("reason"), "being rude")
+	}
+}
+
+func TestArgSpecOptionalArgumentLeavesWordsForRequired(t *testing.T) {
+	spec := ArgSpec{
+		Args: []Arg{
+			{Name: "word", Type: ArgTypeString, Optional: true},
+			{Name: "other", Type: ArgTypeString},
+		},
+	}
+
+	args, err := spec.Parse([]string{"one"})
+	if err != nil {
+		t.Fatalf("parse: %s", err)
+	}
+	if args.Has("word") || args.String("other") != "one" {
+		t.Fatalf("word/other = %q/%q, want none/one", args.String("word"), args.String("other"))
+	}
+}
+
+func TestArgSpecParseErrors(t *testing.T) {
+	spec := ArgSpec{
+		Args: []Arg{
+			{Name: "count", Type: ArgTypeInt},
+			{Name: "url", Type: ArgTypeURL, Optional: true},
+		},
+		Flags: []Flag{
+			{Name: "limit", Type: ArgTypeInt},
+		},
+	}
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"", "Missing <count>."},
+		{"ten", "Invalid <count>"},
+		{"10 ftp://example.com", "Unexpected ftp://example.com."},
+		{"10 --limit", "Missing value for"},
+		{"10 --limit=many", "Invalid value for"},
+		{"10 --other 1", "Unknown flag"},
+	}
+
+	for _, test := range tests {
+		_, err := spec.Parse(strings.Fields(test.input))
+		if err == nil {
+			t.Fatalf("parse %q: no error, want %q", test.input, test.want)
+		}
+		if !strings.Contains(err.Error(), test.want) {
+			t.Fatalf("parse %q: error = %q, want %q", test.input, err, test.want)
+		}
+	}
+}
+
+func TestArgSpecParsesFlagsAndQuotedArguments(t *testing.T) {
+	spec := ArgSpec{
+		Args: []Arg{
+			{Name: "title", Type: ArgTypeQuoted},
+			{Name: "rest", Type: ArgTypeText, Optional: true},
+		},
+		Flags: []Flag{
+			{Name: "limit", Type: ArgTypeInt, Default: "5"},
+			{Name: "quiet", Type: ArgTypeBool},
+		},
+	}
+
+	args, err := spec.Parse(strings.Fields(`--quiet "a quoted title" --limit 3 and more`))
+	if err != nil {
+		t.Fatalf("parse: %s", err)
+	}
+	if args.String("title") != "a quoted title" {
+		t.Fatalf("title = %q, want %q", args.String("title"), "a quoted title")
+	}
+	if args.String("rest") != "and more" {
+		t.Fatalf("rest = %q, want %q", args.String("rest"), "and more")
+	}
+	if args.Int("limit") != 3 || !args.Bool("quiet") {
+		t.Fatalf("limit/quiet = %d/%t, want 3/true", args.Int("limit"), args.Bool("quiet"))
+	}
+
+	args, err = spec.Parse([]string{"title"})
+	if err != nil {
+		t.Fatalf("parse: %s", err)
+	}
+	if args.Int("limit") != 5 || args.Bool("quiet") {
+		t.Fatalf("limit/quiet = %d/%t, want default of 5/false", args.Int("limit"), args.Bool("quiet"))
+	}
+}
+
+func TestArgSpecUsage(t *testing.T) {
+	spec := ArgSpec{
+		Args: []Arg{
+			{Name: "channel", Type: ArgTypeChannel, Optional: true},
+			{Name: "nick", Type: ArgTypeNick},
+			{Name: "duration", Type: ArgTypeDuration, Optional: true, Default: "1h"},
+		},
+		Flags: []Flag{
+			{Name: "quiet", Type: ArgTypeBool},
+			{Name: "limit", Type: ArgTypeInt, Default: "5"},
+		},
+	}
+
+	want := "%s [<channel>] <nick> [<duration>=1h] [--quiet] [--limit <int>=5]"
+	if got := spec.Usage(); got != want {
+		t.Fatalf("usage = %q, want %q", got, want)
+	}
+}
+
+func TestArgSpecParsesSubcommands(t *testing.T) {
+	spec := ArgSpec{
+		Args: []Arg{
+			{Name: "query", Type: ArgTypeText, Optional: true},
+		},
+		Subcommands: []Subcommand{
+			{Name: "add", ArgSpec: ArgSpec{
+				Args: []Arg{
+					{Name: "name", Type: ArgTypeString},
+					{Name: "kind", Type: ArgTypeChoice, Choices: []string{"reply", "reddit"}},
+				},
+			}},
+			{Name: "remove", Aliases: []string{"delete"}, ArgSpec: ArgSpec{
+				Args: []Arg{
+					{Name: "name", Type: ArgTypeString},
+				},
+			}},
+		},
+	}
+
+	args, err := spec.Parse(strings.Fields("ADD greeting Reply"))
+	if err != nil {
+		t.Fatalf("parse: %s", err)
+	}
+	if args.Subcommand() != "add" || args.String("name") != "greeting" || args.String("kind") != "reply" {
+		t.Fatalf("subcommand/name/kind = %s/%s/%s, want add/greeting/reply", args.Subcommand(), args.String("name"), args.String("kind"))
+	}
+
+	args, err = spec.Parse(strings.Fields("delete greeting"))
+	if err != nil {
+		t.Fatalf("parse: %s", err)
+	}
+	if args.Subcommand() != "remove" || args.String("name") != "greeting" {
+		t.Fatalf("subcommand/name = %s/%s, want remove/greeting", args.Subcommand(), args.String("name"))
+	}
+
+	args, err = spec.Parse(strings.Fields("something else"))
+	if err != nil {
+		t.Fatalf("parse: %s", err)
+	}
+	if args.Subcommand() != "" || args.String("query") != "something else" {
+		t.Fatalf("subcommand/query = %q/%q, want none/something else", args.Subcommand(), args.String("query"))
+	}
+
+	if _, err = spec.Parse(strings.Fields("add greeting shout")); err == nil || !strings.Contains(err.Error(), "not one of reply, reddit") {
+		t.Fatalf("parse error = %v, want the choices", err)
+	}
+
+	bare := ArgSpec{Subcommands: spec.Subcommands}
+	if _, err = bare.Parse(strings.Fields("rename greeting")); err == nil || !strings.Contains(err.Error(), "Unknown subcommand") {
+		t.Fatalf("parse error = %v, want an unknown subcommand", err)
+	}
+}
+
+func TestArgSpecUsages(t *testing.T) {
+	spec := ArgSpec{
+		Note: "lists them",
+		Subcommands: []Subcommand{
+			{Name: "add", ArgSpec: ArgSpec{
+				Args: []Arg{
+					{Name: "name", Type: ArgTypeString},
+					{Name: "kind", Type: ArgTypeChoice, Choices: []string{"reply", "reddit"}},
+				},
+				Note: "100% new",
+			}},
+			{Name: "list"},
+		},
+	}
+
+	want := []string{"%s (lists them)", "%s add <name> reply|reddit (100%% new)", "%s list"}
+	if got := spec.Usages(); !slices.Equal(got, want) {
+		t.Fatalf("usages = %q, want %q", got, want)
+	}
+}
@@ -5,9 +5,9 @@ import (
 	"assistant/pkg/api/elapse"
 	"assistant/pkg/api/irc"
 	"assistant/pkg/config"
-	"assistant/pkg/firestore"
 	"assistant/pkg/log"
 	"assistant/pkg/models"
+	"assistant/pkg/storage"
 	"fmt"
 	"time"
 )
@@ -59,7 +59,7 @@ func (c *AuthCommand) CanExecute(e *irc.Event) bool {
 	return c.isCommandEventValid(c, e, 1)
 }
 
-func (c *AuthCommand) Execute(e *irc.Event) {
+func (c *AuthCommand) Execute(e *irc.Event, _ *Args) {
 	logger := log.Logger()
 
 	tokens := Tokens(e.Message())
@@ -70,8 +70,7 @@ func (c *AuthCommand) Execute(e *irc.Event) {
 		return
 	}
 
-	fs := firestore.Get()
-	ch, err := fs.Channel(channel)
+	ch, err := c.store().Channel(channel)
 	if err != nil || ch == nil {
 		c.Replyf(e, "I'm not in %s.", channel)
 		return
@@ -80,14 +79,14 @@ func (c *AuthCommand) Execute(e *irc.Event) {
 	nick, _ := e.Sender()
 	logger.Infof(e, "⚡ %s [%s] channel: %s", c.Name(), nick, channel)
 
-	token, err := models.NewAuthToken(nick, channel)
+	token, err := models.NewAuthToken(c.cfg.IRC.Name, nick, channel)
 	if err != nil {
 		logger.Errorf(e, "error generating auth token: %s", err)
 		c.Replyf(e, "Error generating auth token.")
 		return
 	}
 
-	if err := fs.CreateAuthToken(token); err != nil {
+	if err := storage.Get().CreateAuthToken(token); err != nil {
 		logger.Errorf(e, "error storing auth token: %s", err)
 		c.Replyf(e, "Error generating auth token.")
 		return
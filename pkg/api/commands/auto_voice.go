@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"assistant/pkg/api/actions"
 	"assistant/pkg/api/context"
 	"assistant/pkg/api/irc"
 	"assistant/pkg/api/repository"
@@ -45,7 +46,7 @@ func (c *AutoVoiceCommand) CanExecute(e *irc.Event) bool {
 	return c.isCommandEventValid(c, e, 1)
 }
 
-func (c *AutoVoiceCommand) Execute(e *irc.Event) {
+func (c *AutoVoiceCommand) Execute(e *irc.Event, _ *Args) {
 	tokens := Tokens(e.Message())
 	nick := tokens[1]
 	channel := e.ReplyTarget()
@@ -79,7 +80,7 @@ func (c *AutoVoiceCommand) Execute(e *irc.Event) {
 
 		users := make([]*models.User, 0)
 
-		u, err := repository.GetUserByNick(e, channel, nick, true)
+		u, err := repository.GetUserByIdentity(e, channel, nick, c.irc.Account(nick), true)
 		if err != nil {
 			logger.Errorf(e, "error getting users by host: %v", err)
 			return
@@ -87,6 +88,10 @@ func (c *AutoVoiceCommand) Execute(e *irc.Event) {
 
 		users = append(users, u)
 
+		if u.Nick != nick {
+			c.irc.Voice(channel, nick)
+		}
+
 		if len(u.Host) > 0 {
 			hus, err := repository.GetUsersByHost(e, channel, u.Host)
 			if err != nil {
@@ -104,6 +109,7 @@ func (c *AutoVoiceCommand) Execute(e *irc.Event) {
 		for _, user := range users {
 			c.irc.Voice(channel, user.Nick)
 			logger.Infof(e, "voiced %s (%s) in %s", user.Nick, user.Host, channel)
+			actions.Audit(c.irc, channel, c.actor(e), models.AuditActionAutoVoice, models.AuditTarget(user.Nick, user.Host), "", "")
 
 			user.IsAutoVoiced = true
 			if err = repository.UpdateUserIsAutoVoiced(e, channel, user); err != nil {
@@ -48,7 +48,7 @@ func (c *BanCommand) CanExecute(e *irc.Event) bool {
 	return c.isCommandEventValid(c, e, 1)
 }
 
-func (c *BanCommand) Execute(e *irc.Event) {
+func (c *BanCommand) Execute(e *irc.Event, _ *Args) {
 	logger := log.Logger()
 	channel := e.ReplyTarget()
 	tokens := Tokens(e.Message())
@@ -120,5 +120,5 @@ func (c *BanCommand) ban(e *irc.Event, channel, mask, duration, reason string) {
 		return
 	}
 
-	actions.Ban(c.irc, channel, mask, duration, reason)
+	actions.Ban(c.irc, channel, mask, duration, reason, c.actor(e))
 }
@@ -1,12 +1,13 @@
 package commands
 
 import (
+	"assistant/pkg/api/actions"
 	"assistant/pkg/api/context"
 	"assistant/pkg/api/irc"
 	"assistant/pkg/api/style"
 	"assistant/pkg/config"
-	"assistant/pkg/firestore"
 	"assistant/pkg/log"
+	"assistant/pkg/models"
 	"fmt"
 	"strings"
 )
@@ -28,18 +29,32 @@ func (c *BannedWordAddCommand) Name() string {
 }
 
 func (c *BannedWordAddCommand) Description() string {
-	return "Adds a word to the channel's banned words list."
+	return "Adds words, or a phrase or pattern, to the channel's banned words list."
 }
 
 func (c *BannedWordAddCommand) Triggers() []string {
 	return []string{"bwadd"}
 }
 
+var bannedWordAddArgs = ArgSpec{
+	Args: []Arg{
+		{Name: "channel", Type: ArgTypeChannel, Optional: true},
+		{Name: "words", Type: ArgTypeText},
+	},
+	Flags: []Flag{
+		{Name: "kind", Type: ArgTypeString},
+		{Name: "action", Type: ArgTypeString, Default: models.ModerationActionKick},
+		{Name: "duration", Type: ArgTypeDuration},
+		{Name: "dry-run", Type: ArgTypeBool},
+	},
+}
+
 func (c *BannedWordAddCommand) Usages() []string {
-	return []string{
-		"%s <word> (in a channel)",
-		"%s <channel> <word1> [<word2> ...] (outside a channel)",
-	}
+	return []string{bannedWordAddArgs.Usage()}
+}
+
+func (c *BannedWordAddCommand) Arguments() *ArgSpec {
+	return &bannedWordAddArgs
 }
 
 func (c *BannedWordAddCommand) AllowedInPrivateMessages() bool {
@@ -47,51 +62,61 @@ func (c *BannedWordAddCommand) AllowedInPrivateMessages() bool {
 }
 
 func (c *BannedWordAddCommand) IsAuthorized(e *irc.Event, channel string, callback func(bool)) {
-	tokens := Tokens(e.Message())
-
-	if e.IsPrivateMessage() && len(tokens) > 2 {
-		c.commandStub.authorizer.IsAuthorized(e, tokens[1], callback)
-	} else {
-		c.commandStub.authorizer.IsAuthorized(e, channel, callback)
+	if args, err := bannedWordAddArgs.Parse(Tokens(e.Message())[1:]); err == nil && e.IsPrivateMessage() && args.Has("channel") {
+		channel = args.String("channel")
 	}
+	c.commandStub.authorizer.IsAuthorized(e, channel, callback)
 }
 
 func (c *BannedWordAddCommand) CanExecute(e *irc.Event) bool {
-	return c.isCommandEventValid(c, e, 1)
+	return c.isCommandEventValid(c, e, 0)
 }
 
-func (c *BannedWordAddCommand) Execute(e *irc.Event) {
-	tokens := Tokens(e.Message())
-
-	if e.IsPrivateMessage() && len(tokens) < 3 {
-		c.Replyf(e, "Invalid usage. See %s for more information.", style.Italics(fmt.Sprintf("%s%s %s", c.cfg.Commands.Prefix, registry.Command(HelpCommandName).Triggers()[0], strings.TrimPrefix(tokens[0], c.cfg.Commands.Prefix))))
+func (c *BannedWordAddCommand) Execute(e *irc.Event, args *Args) {
+	if e.IsPrivateMessage() && !args.Has("channel") {
+		c.Replyf(e, "Invalid usage. See %s for more information.", style.Italics(fmt.Sprintf("%s%s %s", c.cfg.Commands.Prefix, c.registry().Command(HelpCommandName).Triggers()[0], strings.TrimPrefix(Tokens(e.Message())[0], c.cfg.Commands.Prefix))))
 		return
 	}
 
 	channel := e.ReplyTarget()
-	words := make([]string, 0)
+	if args.Has("channel") {
+		channel = args.String("channel")
+	}
 
-	if len(tokens) > 2 {
-		channel = tokens[1]
-		words = tokens[2:]
-	} else {
-		words = tokens[1:]
+	// phrases and regexes are the rest of the input, and anything else a word each
+	kind := args.String("kind")
+	words := args.Words("words")
+	if kind == models.BannedWordKindPhrase || kind == models.BannedWordKindRegex {
+		words = []string{args.String("words")}
+	}
+
+	bannedWords := make([]*models.BannedWord, 0, len(words))
+	for _, word := range words {
+		bw := &models.BannedWord{Word: word, Kind: kind, Action: args.String("action"), Duration: args.String("duration"), DryRun: args.Bool("dry-run")}
+		bw.Kind = bw.MatchKind()
+		bw.Normalize()
+		if err := bw.Validate(); err != nil {
+			c.Replyf(e, "Invalid banned word %s: %s", style.Bold(word), err)
+			return
+		}
+		bannedWords = append(bannedWords, bw)
 	}
 
 	logger := log.Logger()
 	logger.Infof(e, "⚡ %s [%s/%s] %s %s", c.Name(), e.From, e.ReplyTarget(), channel, strings.Join(words, ", "))
 
-	store := firestore.Get()
-	for _, word := range words {
-		err := store.AddBannedWord(channel, word)
+	store := c.store()
+	for _, bw := range bannedWords {
+		err := store.AddBannedWord(channel, bw)
 		if err != nil {
 			logger.Errorf(e, "error adding banned word: %s", err)
 			return
 		}
 	}
 
-	for _, word := range words {
-		c.ctx.Session().AddBannedWord(channel, word)
+	for _, bw := range bannedWords {
+		c.ctx.Session().AddBannedWord(channel, bw.Word)
+		actions.Audit(c.irc, channel, c.actor(e), models.AuditActionBannedWordAdd, bw.Word, bw.Settings(), "")
 	}
 
 	c.Replyf(e, "Updated banned words in %s.", style.Bold(channel))
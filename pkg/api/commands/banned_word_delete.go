@@ -1,12 +1,13 @@
 package commands
 
 import (
+	"assistant/pkg/api/actions"
 	"assistant/pkg/api/context"
 	"assistant/pkg/api/irc"
 	"assistant/pkg/api/style"
 	"assistant/pkg/config"
-	"assistant/pkg/firestore"
 	"assistant/pkg/log"
+	"assistant/pkg/models"
 	"fmt"
 	"strings"
 )
@@ -59,11 +60,11 @@ func (c *BannedWordDeleteCommand) CanExecute(e *irc.Event) bool {
 	return c.isCommandEventValid(c, e, 1)
 }
 
-func (c *BannedWordDeleteCommand) Execute(e *irc.Event) {
+func (c *BannedWordDeleteCommand) Execute(e *irc.Event, _ *Args) {
 	tokens := Tokens(e.Message())
 
 	if e.IsPrivateMessage() && len(tokens) < 3 {
-		c.Replyf(e, "Invalid usage. See %s for more information.", style.Italics(fmt.Sprintf("%s%s %s", c.cfg.Commands.Prefix, registry.Command(HelpCommandName).Triggers()[0], strings.TrimPrefix(tokens[0], c.cfg.Commands.Prefix))))
+		c.Replyf(e, "Invalid usage. See %s for more information.", style.Italics(fmt.Sprintf("%s%s %s", c.cfg.Commands.Prefix, c.registry().Command(HelpCommandName).Triggers()[0], strings.TrimPrefix(tokens[0], c.cfg.Commands.Prefix))))
 		return
 	}
 
@@ -80,7 +81,7 @@ func (c *BannedWordDeleteCommand) Execute(e *irc.Event) {
 	logger := log.Logger()
 	logger.Infof(e, "⚡ %s [%s/%s] %s %s", c.Name(), e.From, e.ReplyTarget(), channel, strings.Join(words, ", "))
 
-	store := firestore.Get()
+	store := c.store()
 	for _, word := range words {
 		err := store.RemoveBannedWord(channel, word)
 		if err != nil {
@@ -91,6 +92,7 @@ func (c *BannedWordDeleteCommand) Execute(e *irc.Event) {
 
 	for _, word := range words {
 		c.ctx.Session().RemoveBannedWord(channel, word)
+		actions.Audit(c.irc, channel, c.actor(e), models.AuditActionBannedWordRemove, word, "", "")
 	}
 
 	c.Replyf(e, "Updated banned words in %s.", style.Bold(channel))
@@ -65,7 +65,7 @@ func (c *BingSimpleAnswerCommand) CanExecute(e *irc.Event) bool {
 	return c.isCommandEventValid(c, e, c.minTokens)
 }
 
-func (c *BingSimpleAnswerCommand) Execute(e *irc.Event) {
+func (c *BingSimpleAnswerCommand) Execute(e *irc.Event, _ *Args) {
 	logger := log.Logger()
 	logger.Infof(e, "⚡ %s [%s/%s] %s", c.Name(), e.From, e.ReplyTarget(), c.subject)
 
@@ -0,0 +1,89 @@
+package commands
+
+import (
+	"assistant/pkg/api/context"
+	"assistant/pkg/api/irc"
+	"assistant/pkg/api/text"
+	"assistant/pkg/config"
+	"assistant/pkg/log"
+	"assistant/pkg/models"
+	"fmt"
+	"strings"
+)
+
+const channelMacroDescriptionMaxLength = 80
+
+// ChannelMacroCommand is a macro defined for a channel, registered for that channel only.
+type ChannelMacroCommand struct {
+	*commandStub
+	macro models.CustomCommand
+}
+
+func NewChannelMacroCommand(ctx context.Context, cfg *config.Config, ircs irc.IRC, macro models.CustomCommand) Command {
+	c := &ChannelMacroCommand{
+		commandStub: defaultCommandStub(ctx, cfg, ircs),
+		macro:       macro,
+	}
+	if a, ok := c.authorizer.(*commandAuthorizer); ok {
+		a.command = c.Name()
+	}
+	return c
+}
+
+func (c *ChannelMacroCommand) Name() string {
+	return fmt.Sprintf("macro/%s", c.macro.Trigger)
+}
+
+func (c *ChannelMacroCommand) Description() string {
+	switch c.macro.Type {
+	case models.CustomCommandTypeReddit:
+		return fmt.Sprintf("Searches for a recent r/%s post on the given topic.", c.macro.Value)
+	default:
+		return fmt.Sprintf("Replies with: %s", text.SanitizeToMaxLength(c.macro.Value, channelMacroDescriptionMaxLength))
+	}
+}
+
+func (c *ChannelMacroCommand) Triggers() []string {
+	return []string{c.macro.Trigger}
+}
+
+func (c *ChannelMacroCommand) Usages() []string {
+	if c.macro.Type == models.CustomCommandTypeReddit {
+		return []string{"%s <topic>"}
+	}
+	if c.macro.IsParameterized() {
+		return []string{"%s [<arguments>]"}
+	}
+	return []string{"%s"}
+}
+
+func (c *ChannelMacroCommand) AllowedInPrivateMessages() bool {
+	return false
+}
+
+func (c *ChannelMacroCommand) CanExecute(e *irc.Event) bool {
+	if c.macro.Type == models.CustomCommandTypeReddit {
+		return c.isCommandEventValid(c, e, 1)
+	}
+	return c.isCommandEventValid(c, e, 0)
+}
+
+func (c *ChannelMacroCommand) Execute(e *irc.Event, _ *Args) {
+	tokens := Tokens(e.Message())
+	args := tokens[1:]
+
+	logger := log.Logger()
+	logger.Infof(e, "⚡ %s [%s/%s] %s", c.Name(), e.From, e.ReplyTarget(), strings.Join(args, " "))
+
+	switch c.macro.Type {
+	case models.CustomCommandTypeReddit:
+		task := models.NewProxyRedditSearchRequestTask(e.ReplyTarget(), e.From, c.macro.Value, strings.Join(args, " "), models.RedditSearchSortNew)
+		if err := c.publishProxy(task); err != nil {
+			logger.Errorf(e, "error publishing reddit search request, %s", err)
+		}
+	case models.CustomCommandTypeReply:
+		if message := c.macro.Expand(e.From, args); len(message) > 0 {
+			c.SendMessage(e, e.ReplyTarget(), message)
+		}
+	}
+}
@@ -1,12 +1,16 @@
 package commands
 
 import (
+	"assistant/pkg/api/actions"
 	"assistant/pkg/api/context"
 	"assistant/pkg/api/irc"
 	"assistant/pkg/api/style"
 	"assistant/pkg/api/text"
 	"assistant/pkg/config"
 	"assistant/pkg/log"
+	"assistant/pkg/models"
+	"assistant/pkg/queue"
+	"assistant/pkg/storage"
 	"fmt"
 	"slices"
 	"strings"
@@ -21,7 +25,9 @@ const (
 )
 
 const (
-	CommandMetadataShowURL = "show_url"
+	CommandMetadataShowURL     = "show_url"
+	CommandMetadataFeed        = "feed"
+	CommandMetadataAuditSource = "audit_source"
 )
 
 type Command interface {
@@ -29,11 +35,12 @@ type Command interface {
 	Description() string
 	Triggers() []string
 	Usages() []string
+	Arguments() *ArgSpec
 	AllowedInPrivateMessages() bool
 	Authorizer() CommandAuthorizer
 	IsAuthorized(e *irc.Event, channel string, callback func(bool))
 	CanExecute(e *irc.Event) bool
-	Execute(e *irc.Event)
+	Execute(e *irc.Event, args *Args)
 	Replyf(e *irc.Event, message string, args ...any)
 }
 
@@ -57,6 +64,27 @@ func defaultCommandStub(ctx context.Context, cfg *config.Config, ircs irc.IRC) *
 	return newCommandStub(ctx, cfg, ircs, RoleUnprivileged, irc.ChannelStatusNone)
 }
 
+// registry returns the command registry of the network the command is registered on.
+func (cs *commandStub) registry() CommandRegistry {
+	return registryForNetwork(cs.cfg.IRC.Name)
+}
+
+// store returns the storage for the network the command is registered on.
+func (cs *commandStub) store() storage.Store {
+	return storage.Network(cs.cfg.IRC.Name)
+}
+
+// publishProxy publishes a request to the proxy, tagged so that its response is routed back to this network.
+func (cs *commandStub) publishProxy(task *models.Task) error {
+	task.Network = cs.cfg.IRC.Name
+	return queue.GetProxy().Publish(task)
+}
+
+// Arguments returns nil for commands that parse their own input. Commands that declare an ArgSpec override it.
+func (cs *commandStub) Arguments() *ArgSpec {
+	return nil
+}
+
 func (cs *commandStub) Authorizer() CommandAuthorizer {
 	return cs.authorizer
 }
@@ -82,7 +110,7 @@ func (cs *commandStub) isCommandEventValid(c Command, e *irc.Event, minBodyToken
 
 	// if sleeping, ignore all triggers except wake
 	if !cs.ctx.Session().IsAwake() {
-		isWakeTrigger := c.Name() == WakeCommandName && slices.Contains(registry.Command(WakeCommandName).Triggers(), strings.TrimPrefix(tokens[0], cs.cfg.Commands.Prefix))
+		isWakeTrigger := c.Name() == WakeCommandName && slices.Contains(cs.registry().Command(WakeCommandName).Triggers(), strings.TrimPrefix(tokens[0], cs.cfg.Commands.Prefix))
 		if isWakeTrigger {
 			if !cs.authorizer.IsUserAuthorizedByRole(nick, cs.authorizer.RequiredRole()) {
 				cs.UnauthorizedReply(e)
@@ -96,23 +124,32 @@ func (cs *commandStub) isCommandEventValid(c Command, e *irc.Event, minBodyToken
 	// if the commandStub is not allowed in private messages and the message is a private message, ignore
 	if !c.AllowedInPrivateMessages() && e.IsPrivateMessage() {
 		if attempted {
-			cs.Replyf(e, "The %s command is not allowed in private messages. See %s for more information.", style.Bold(strings.TrimPrefix(tokens[0], cs.cfg.Commands.Prefix)), style.Italics(fmt.Sprintf("%s%s %s", cs.cfg.Commands.Prefix, registry.Command(HelpCommandName).Triggers()[0], strings.TrimPrefix(tokens[0], cs.cfg.Commands.Prefix))))
+			cs.Replyf(e, "The %s command is not allowed in private messages. See %s for more information.", style.Bold(strings.TrimPrefix(tokens[0], cs.cfg.Commands.Prefix)), style.Italics(fmt.Sprintf("%s%s %s", cs.cfg.Commands.Prefix, cs.registry().Command(HelpCommandName).Triggers()[0], strings.TrimPrefix(tokens[0], cs.cfg.Commands.Prefix))))
 		}
 		return false
 	}
 
-	// if the commandStub defines role-based authorization and not channel status-based authorization, check it
-	if len(cs.authorizer.RequiredRole()) > 0 && len(cs.authorizer.RequiredChannelStatus()) == 0 && !cs.authorizer.IsUserAuthorizedByRole(nick, cs.authorizer.RequiredRole()) {
+	// if the commandStub defines role-based authorization and not channel status-based authorization, check it, letting
+	// the channel's role matrix decide when the command was attempted
+	if len(cs.authorizer.RequiredRole()) > 0 && len(cs.authorizer.RequiredChannelStatus()) == 0 {
+		authorized := cs.authorizer.IsUserAuthorizedByRole(nick, cs.authorizer.RequiredRole())
 		if attempted {
-			cs.UnauthorizedReply(e)
+			if granted, decided := cs.authorizer.IsUserAuthorizedByChannelRole(e, eventChannel(e, tokens)); decided {
+				authorized = granted
+			}
+		}
+		if !authorized {
+			if attempted {
+				cs.UnauthorizedReply(e)
+			}
+			return false
 		}
-		return false
 	}
 
 	// if the commandStub requires a minimum number of body Tokens, check that
 	if minBodyTokens > 0 && len(tokens) < minBodyTokens+1 {
 		if attempted {
-			cs.Replyf(e, "Invalid number of arguments for %s. See %s for more information.", style.Bold(strings.TrimPrefix(tokens[0], cs.cfg.Commands.Prefix)), style.Italics(fmt.Sprintf("%s%s %s", cs.cfg.Commands.Prefix, registry.Command(HelpCommandName).Triggers()[0], strings.TrimPrefix(tokens[0], cs.cfg.Commands.Prefix))))
+			cs.Replyf(e, "Invalid number of arguments for %s. See %s for more information.", style.Bold(strings.TrimPrefix(tokens[0], cs.cfg.Commands.Prefix)), style.Italics(fmt.Sprintf("%s%s %s", cs.cfg.Commands.Prefix, cs.registry().Command(HelpCommandName).Triggers()[0], strings.TrimPrefix(tokens[0], cs.cfg.Commands.Prefix))))
 		}
 		return false
 	}
@@ -133,6 +170,11 @@ func (cs *commandStub) isCommandEventValid(c Command, e *irc.Event, minBodyToken
 
 // isBotAuthorizedByChannelStatus checks if the bot is authorized based on channel status
 func (cs *commandStub) isBotAuthorizedByChannelStatus(channel string, status irc.ChannelStatus, callback func(bool)) {
+	if user := cs.irc.ChannelMember(channel, cs.cfg.IRC.Nick); user != nil {
+		callback(irc.IsStatusAtLeast(user.Status, status))
+		return
+	}
+
 	cs.authorizer.ListUsers(channel, func(users []*irc.User) {
 		for _, user := range users {
 			if user.Mask.Nick == cs.cfg.IRC.Nick {
@@ -144,6 +186,16 @@ func (cs *commandStub) isBotAuthorizedByChannelStatus(channel string, status irc
 	})
 }
 
+// isAdminOrOperator calls back with whether the sender is an admin or an operator in the channel.
+func (cs *commandStub) isAdminOrOperator(e *irc.Event, channel string, callback func(bool)) {
+	nick, _ := e.Sender()
+	if cs.authorizer.IsUserAuthorizedByRole(nick, RoleAdmin) {
+		callback(true)
+		return
+	}
+	cs.authorizer.IsUserAuthorizedByChannelStatus(e, channel, irc.ChannelStatusOperator, callback)
+}
+
 func (cs *commandStub) Join(channel string) {
 	log.Logger().Infof(nil, "joining %s", channel)
 	cs.irc.Join(channel)
@@ -185,7 +237,7 @@ func (cs *commandStub) UnauthorizedReply(e *irc.Event) {
 }
 
 func (cs *commandStub) ExecuteSynthesizedEvent(orig *irc.Event, command, payload string, metadata map[string]any) {
-	cmd := registry.Command(command)
+	cmd := cs.registry().Command(command)
 	args := orig.Arguments
 
 	if len(cmd.Triggers()) > 0 {
@@ -200,11 +252,52 @@ func (cs *commandStub) ExecuteSynthesizedEvent(orig *irc.Event, command, payload
 		Code:      orig.Code,
 		From:      orig.From,
 		Source:    orig.Source,
+		Account:   orig.Account,
+		Network:   orig.Network,
 		Arguments: args,
 		Metadata:  metadata,
 	}
 
-	cmd.Execute(modified)
+	if parsed, ok := ParseArgs(cs.cfg, cmd, modified); ok {
+		cmd.Execute(modified, parsed)
+	}
+}
+
+// ParseArgs parses the input of a command that declares its arguments, replying with the error if it's invalid.
+// Commands that parse their own input are given no arguments. It's called once the sender is authorized, so that
+// only they are told what's wrong with their input.
+func ParseArgs(cfg *config.Config, c Command, e *irc.Event) (*Args, bool) {
+	spec := c.Arguments()
+	if spec == nil {
+		return nil, true
+	}
+
+	tokens := Tokens(e.Message())
+	args, err := spec.Parse(tokens[1:])
+	if err != nil {
+		help := registryForNetwork(cfg.IRC.Name).Command(HelpCommandName).Triggers()[0]
+		c.Replyf(e, "%s See %s for more information.", err, style.Italics(fmt.Sprintf("%s%s %s", cfg.Commands.Prefix, help, strings.TrimPrefix(tokens[0], cfg.Commands.Prefix))))
+		return nil, false
+	}
+	return args, true
+}
+
+// actor returns who ran the command for the audit log. Commands synthesized by an automatic rule name the rule as
+// their audit source and are attributed to the bot rather than the user whose message triggered them.
+func (cs *commandStub) actor(e *irc.Event) actions.Actor {
+	if source, ok := e.Metadata[CommandMetadataAuditSource].(string); ok {
+		return actions.Actor{Nick: cs.cfg.IRC.Nick, Source: source}
+	}
+	return actions.Actor{Nick: e.From, Source: models.AuditSourceCommand}
+}
+
+// eventChannel returns the channel a command applies to: the channel it was sent in, or the channel named by its first
+// argument when sent in a private message.
+func eventChannel(e *irc.Event, tokens []string) string {
+	if e.IsPrivateMessage() && len(tokens) > 1 && irc.IsChannel(tokens[1]) {
+		return tokens[1]
+	}
+	return e.ReplyTarget()
 }
 
 // Tokens splits the input string into sanitized Tokens
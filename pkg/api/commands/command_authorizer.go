@@ -3,6 +3,7 @@ package commands
 import (
 	"assistant/pkg/api/context"
 	"assistant/pkg/api/irc"
+	"assistant/pkg/api/repository"
 	"assistant/pkg/config"
 )
 
@@ -11,6 +12,7 @@ type CommandAuthorizer interface {
 	RequiredChannelStatus() irc.ChannelStatus
 	IsAuthorized(e *irc.Event, channel string, callback func(bool))
 	IsUserAuthorizedByRole(nick string, role Role) bool
+	IsUserAuthorizedByChannelRole(e *irc.Event, channel string) (authorized, decided bool)
 	IsUserAuthorizedByChannelStatus(e *irc.Event, channel string, status irc.ChannelStatus, callback func(bool))
 	GetUser(channel, nick string, callback func(user *irc.User))
 	ListUsers(channel string, callback func([]*irc.User))
@@ -23,6 +25,7 @@ type commandAuthorizer struct {
 	irc                   irc.IRC
 	requiredRole          Role
 	requiredChannelStatus irc.ChannelStatus
+	command               string
 }
 
 func newCommandAuthorizer(ctx context.Context, cfg *config.Config, irc irc.IRC, role Role, channelStatus irc.ChannelStatus) *commandAuthorizer {
@@ -62,6 +65,39 @@ func (c *commandAuthorizer) IsUserAuthorizedByRole(nick string, role Role) bool
 	return true
 }
 
+// IsUserAuthorizedByChannelRole checks the channel's role matrix for the command the authorizer is bound to. When
+// none of the sender's roles grants or revokes the command, or the command is reserved for the bot's owner and admins,
+// decided is false and other checks apply.
+func (c *commandAuthorizer) IsUserAuthorizedByChannelRole(e *irc.Event, channel string) (authorized, decided bool) {
+	if len(c.command) == 0 || !irc.IsChannel(channel) || isReservedForBotAdmins(c) {
+		return false, false
+	}
+
+	nick, _ := e.Sender()
+	if nick == c.cfg.IRC.Owner {
+		return true, true
+	}
+
+	ch, err := repository.GetChannel(e, channel)
+	if err != nil {
+		return false, false
+	}
+
+	account := e.Account
+	if len(account) == 0 {
+		account = c.irc.Account(nick)
+	}
+
+	return ch.CommandPermission(e.Source, account, c.command)
+}
+
+// isReservedForBotAdmins returns whether only the bot's owner and admins may use a command, with no channel status that
+// also allows it. Channel roles can't grant such commands, so channel operators can't use roles to reach them.
+func isReservedForBotAdmins(a CommandAuthorizer) bool {
+	role := a.RequiredRole()
+	return len(a.RequiredChannelStatus()) == 0 && (role == RoleOwner || role == RoleAdmin)
+}
+
 // GetUser retrieves a user in the channel by nick
 func (c *commandAuthorizer) GetUser(channel, nick string, callback func(user *irc.User)) {
 	c.irc.GetUser(channel, nick, callback)
@@ -79,6 +115,11 @@ func (c *commandAuthorizer) ListUsersByMask(channel, mask string, callback func(
 func (c *commandAuthorizer) IsUserAuthorizedByChannelStatus(e *irc.Event, channel string, status irc.ChannelStatus, callback func(bool)) {
 	nick, _ := e.Sender()
 
+	if user := c.irc.ChannelMember(channel, nick); user != nil {
+		callback(irc.IsStatusAtLeast(user.Status, status))
+		return
+	}
+
 	c.ListUsers(channel, func(users []*irc.User) {
 		for _, user := range users {
 			if user.Mask.Nick == nick && irc.IsStatusAtLeast(user.Status, status) {
@@ -90,8 +131,14 @@ func (c *commandAuthorizer) IsUserAuthorizedByChannelStatus(e *irc.Event, channe
 	})
 }
 
-// IsAuthorized checks authorization using both channel status-based and role-based authorization
+// IsAuthorized checks authorization using the channel's role matrix, then channel status-based and role-based
+// authorization. The matrix only applies to commands that aren't reserved for the bot's owner and admins.
 func (c *commandAuthorizer) IsAuthorized(e *irc.Event, channel string, callback func(bool)) {
+	if authorized, decided := c.IsUserAuthorizedByChannelRole(e, channel); decided {
+		callback(authorized)
+		return
+	}
+
 	if len(c.requiredChannelStatus) > 0 {
 		c.IsUserAuthorizedByChannelStatus(e, channel, c.requiredChannelStatus, func(authorized bool) {
 			if authorized {
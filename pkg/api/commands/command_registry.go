@@ -3,13 +3,19 @@ package commands
 import (
 	"assistant/pkg/api/context"
 	"assistant/pkg/api/irc"
+	"assistant/pkg/api/repository"
 	"assistant/pkg/config"
+	"assistant/pkg/log"
 	"assistant/pkg/models"
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 )
 
-var registry CommandRegistry
+// registries holds the command registry of each network, keyed by network name.
+var registries = make(map[string]CommandRegistry)
+var registriesMu sync.Mutex
 
 type CommandRegistry interface {
 	Command(name string) Command
@@ -17,31 +23,62 @@ type CommandRegistry interface {
 	CommandsSortedForProcessing() []Command
 	CommandInfoList() []*models.CommandInfo
 	RegisterCommands()
+	ChannelCommands(e *irc.Event) []Command
+	ChannelAliases(e *irc.Event) []models.CustomCommand
+	ExpandChannelAlias(e *irc.Event) bool
+	InvalidateChannelCommands(channel string)
+}
+
+// channelCommandsTTL is how long a channel's aliases and macros are cached, so that changes made from the dashboard
+// are picked up.
+const channelCommandsTTL = 1 * time.Minute
+
+// channelCommandSet is the aliases and macros of a channel.
+type channelCommandSet struct {
+	aliases  []models.CustomCommand
+	commands []Command
+	loadedAt time.Time
 }
 
 type commandRegistry struct {
-	ctx      context.Context
-	cfg      *config.Config
-	irc      irc.IRC
-	commands map[string]Command
+	ctx             context.Context
+	cfg             *config.Config
+	irc             irc.IRC
+	commands        map[string]Command
+	orderedCommands []Command
+	channelCommands map[string]*channelCommandSet
+	channelMu       sync.Mutex
 }
 
+// LoadCommandRegistry returns the command registry for the network in cfg, creating it on first use.
 func LoadCommandRegistry(ctx context.Context, cfg *config.Config, irc irc.IRC) CommandRegistry {
-	if registry != nil {
+	registriesMu.Lock()
+	defer registriesMu.Unlock()
+
+	if registry, ok := registries[cfg.IRC.Name]; ok {
 		return registry
 	}
 
-	registry = &commandRegistry{
-		ctx:      ctx,
-		cfg:      cfg,
-		irc:      irc,
-		commands: make(map[string]Command),
+	registry := &commandRegistry{
+		ctx:             ctx,
+		cfg:             cfg,
+		irc:             irc,
+		commands:        make(map[string]Command),
+		channelCommands: make(map[string]*channelCommandSet),
 	}
 
+	registries[cfg.IRC.Name] = registry
 	registry.RegisterCommands()
+	registry.bindAuthorizers()
 	return registry
 }
 
+func registryForNetwork(network string) CommandRegistry {
+	registriesMu.Lock()
+	defer registriesMu.Unlock()
+	return registries[network]
+}
+
 func (cr *commandRegistry) Command(name string) Command {
 	if f, ok := cr.commands[name]; ok {
 		return f
@@ -54,11 +91,9 @@ func (cr *commandRegistry) Commands() map[string]Command {
 	return cr.commands
 }
 
-var orderedCommands = make([]Command, 0)
-
 func (cr *commandRegistry) CommandsSortedForProcessing() []Command {
-	if len(orderedCommands) > 0 {
-		return orderedCommands
+	if len(cr.orderedCommands) > 0 {
+		return cr.orderedCommands
 	}
 
 	nonTriggered := make([]Command, 0)
@@ -66,11 +101,11 @@ func (cr *commandRegistry) CommandsSortedForProcessing() []Command {
 		if len(f.Triggers()) == 0 {
 			nonTriggered = append(nonTriggered, f)
 		} else {
-			orderedCommands = append(orderedCommands, f)
+			cr.orderedCommands = append(cr.orderedCommands, f)
 		}
 	}
-	orderedCommands = append(orderedCommands, nonTriggered...)
-	return orderedCommands
+	cr.orderedCommands = append(cr.orderedCommands, nonTriggered...)
+	return cr.orderedCommands
 }
 
 func (cr *commandRegistry) CommandInfoList() []*models.CommandInfo {
@@ -94,6 +129,7 @@ func (cr *commandRegistry) CommandInfoList() []*models.CommandInfo {
 			Description:  cmd.Description(),
 			Triggers:     cmd.Triggers(),
 			Usages:       usages,
+			Arguments:    argumentInfo(cmd.Arguments()),
 			RequiresAuth: requiresAuth,
 			AllowDM:      cmd.AllowedInPrivateMessages(),
 		})
@@ -101,11 +137,112 @@ func (cr *commandRegistry) CommandInfoList() []*models.CommandInfo {
 	return result
 }
 
+// ChannelCommands returns the macros defined for the event's channel.
+func (cr *commandRegistry) ChannelCommands(e *irc.Event) []Command {
+	if set := cr.channelCommandSet(e); set != nil {
+		return set.commands
+	}
+	return nil
+}
+
+// ChannelAliases returns the aliases defined for the event's channel.
+func (cr *commandRegistry) ChannelAliases(e *irc.Event) []models.CustomCommand {
+	if set := cr.channelCommandSet(e); set != nil {
+		return set.aliases
+	}
+	return nil
+}
+
+// ExpandChannelAlias replaces a message starting with one of the channel's aliases with the command line it stands
+// for, and returns whether it did. Expansions aren't expanded again, so aliases can't refer to other aliases.
+func (cr *commandRegistry) ExpandChannelAlias(e *irc.Event) bool {
+	tokens := Tokens(e.Message())
+	if len(tokens) == 0 || !strings.HasPrefix(tokens[0], cr.cfg.Commands.Prefix) {
+		return false
+	}
+
+	trigger := strings.ToLower(strings.TrimPrefix(tokens[0], cr.cfg.Commands.Prefix))
+	for _, alias := range cr.ChannelAliases(e) {
+		if alias.Trigger != trigger {
+			continue
+		}
+
+		expanded := alias.Value
+		if alias.IsParameterized() {
+			expanded = alias.Expand(e.From, tokens[1:])
+		} else if len(tokens) > 1 {
+			expanded = fmt.Sprintf("%s %s", expanded, strings.Join(tokens[1:], " "))
+		}
+
+		log.Logger().Debugf(e, "expanding alias %s to %s", trigger, expanded)
+		e.Arguments[len(e.Arguments)-1] = expanded
+		return true
+	}
+
+	return false
+}
+
+// InvalidateChannelCommands drops the channel's cached aliases and macros, so that they're reloaded on next use.
+func (cr *commandRegistry) InvalidateChannelCommands(channel string) {
+	cr.channelMu.Lock()
+	defer cr.channelMu.Unlock()
+	delete(cr.channelCommands, channel)
+}
+
+func (cr *commandRegistry) channelCommandSet(e *irc.Event) *channelCommandSet {
+	if e.IsPrivateMessage() {
+		return nil
+	}
+
+	channel := e.ReplyTarget()
+	cr.channelMu.Lock()
+	defer cr.channelMu.Unlock()
+
+	if set, ok := cr.channelCommands[channel]; ok && time.Since(set.loadedAt) < channelCommandsTTL {
+		return set
+	}
+
+	set := &channelCommandSet{
+		aliases:  make([]models.CustomCommand, 0),
+		commands: make([]Command, 0),
+		loadedAt: time.Now(),
+	}
+	cr.channelCommands[channel] = set
+
+	ch, err := repository.GetChannel(e, channel)
+	if err != nil {
+		log.Logger().Debugf(e, "unable to load channel commands, %s", err)
+		return set
+	}
+
+	for _, cc := range ch.CustomCommands {
+		if cc.Type == models.CustomCommandTypeAlias {
+			set.aliases = append(set.aliases, cc)
+		} else {
+			set.commands = append(set.commands, NewChannelMacroCommand(cr.ctx, cr.cfg, cr.irc, cc))
+		}
+	}
+
+	return set
+}
+
+// bindAuthorizers tells each command's authorizer the name of its command, so it can look it up in channel role
+// matrices.
+func (cr *commandRegistry) bindAuthorizers() {
+	for name, cmd := range cr.commands {
+		if a, ok := cmd.Authorizer().(*commandAuthorizer); ok {
+			a.command = name
+		}
+	}
+}
+
 func (cr *commandRegistry) RegisterCommands() {
 	cr.commands[HelpCommandName] = NewHelpCommand(cr.ctx, cr.cfg, cr.irc)
 	cr.commands[UptimeCommandName] = NewUptimeCommand(cr.ctx, cr.cfg, cr.irc)
 	cr.commands[AboutCommandName] = NewAboutCommand(cr.ctx, cr.cfg, cr.irc)
 	cr.commands[PollsCommandName] = NewPollsCommand(cr.ctx, cr.cfg, cr.irc)
+	cr.commands[PollCommandName] = NewPollCommand(cr.ctx, cr.cfg, cr.irc)
+	cr.commands[VoteCommandName] = NewVoteCommand(cr.ctx, cr.cfg, cr.irc)
 	cr.commands[PredictItCommandName] = NewPredictItCommand(cr.ctx, cr.cfg, cr.irc)
 	cr.commands[PolymarketCommandName] = NewPolymarketCommand(cr.ctx, cr.cfg, cr.irc)
 	cr.commands[KalshiCommandName] = NewKalshiCommand(cr.ctx, cr.cfg, cr.irc)
@@ -120,6 +257,10 @@ func (cr *commandRegistry) RegisterCommands() {
 	cr.commands[CredibilityCommandName] = NewCredibilityCommand(cr.ctx, cr.cfg, cr.irc)
 	cr.commands[ReminderCommandName] = NewReminderCommand(cr.ctx, cr.cfg, cr.irc)
 	cr.commands[RemindersCommandName] = NewRemindersCommand(cr.ctx, cr.cfg, cr.irc)
+	cr.commands[SnoozeCommandName] = NewSnoozeCommand(cr.ctx, cr.cfg, cr.irc)
+	cr.commands[TimeZoneCommandName] = NewTimeZoneCommand(cr.ctx, cr.cfg, cr.irc)
+	cr.commands[TellCommandName] = NewTellCommand(cr.ctx, cr.cfg, cr.irc)
+	cr.commands[TellsCommandName] = NewTellsCommand(cr.ctx, cr.cfg, cr.irc)
 	cr.commands[AnimatedTextCommandName] = NewAnimatedTextCommand(cr.ctx, cr.cfg, cr.irc)
 	cr.commands[GIFSearchCommandName] = NewGifSearchCommand(cr.ctx, cr.cfg, cr.irc)
 	cr.commands[WikipediaCommandName] = NewWikipediaCommand(cr.ctx, cr.cfg, cr.irc)
@@ -128,6 +269,11 @@ func (cr *commandRegistry) RegisterCommands() {
 	cr.commands[QuoteAddCommandName] = NewQuoteAddCommand(cr.ctx, cr.cfg, cr.irc)
 	cr.commands[QuotesSearchCommandName] = NewQuotesSearchCommand(cr.ctx, cr.cfg, cr.irc)
 	cr.commands[QuoteRandomCommandName] = NewQuoteRandomCommand(cr.ctx, cr.cfg, cr.irc)
+	cr.commands[FactCommandName] = NewFactCommand(cr.ctx, cr.cfg, cr.irc)
+	cr.commands[FactoidLookupCommandName] = NewFactoidLookupCommand(cr.ctx, cr.cfg, cr.irc)
+	cr.commands[LearnCommandName] = NewLearnCommand(cr.ctx, cr.cfg, cr.irc)
+	cr.commands[ForgetCommandName] = NewForgetCommand(cr.ctx, cr.cfg, cr.irc)
+	cr.commands[FactLockCommandName] = NewFactLockCommand(cr.ctx, cr.cfg, cr.irc)
 	cr.commands[PersonalNoteAddCommandName] = NewPersonalNoteAddCommand(cr.ctx, cr.cfg, cr.irc)
 	cr.commands[PersonalNoteDeleteCommandName] = NewPersonalNoteDeleteCommand(cr.ctx, cr.cfg, cr.irc)
 	cr.commands[PersonalNotesSearchCommandName] = NewPersonalNotesSearchCommand(cr.ctx, cr.cfg, cr.irc)
@@ -143,6 +289,7 @@ func (cr *commandRegistry) RegisterCommands() {
 	cr.commands[CommunityNoteAddCommandName] = NewCommunityNoteAddCommand(cr.ctx, cr.cfg, cr.irc)
 	cr.commands[CommunityNoteEditCommandName] = NewCommunityNoteEditCommand(cr.ctx, cr.cfg, cr.irc)
 	cr.commands[DrudgeHeadlinesCommandName] = NewDrudgeHeadlinesCommand(cr.ctx, cr.cfg, cr.irc)
+	cr.commands[FeedCommandName] = NewFeedCommand(cr.ctx, cr.cfg, cr.irc)
 	cr.commands[LLMCommandName] = NewLLMCommand(cr.ctx, cr.cfg, cr.irc)
 	cr.commands[RoastCommandName] = NewRoastCommand(cr.ctx, cr.cfg, cr.irc)
 	cr.commands[TriviaCommandName] = NewTriviaCommand(cr.ctx, cr.cfg, cr.irc)
@@ -207,12 +354,18 @@ func (cr *commandRegistry) RegisterCommands() {
 	// commands requiring authorization
 	cr.commands[EnableCommandName] = NewEnableCommand(cr.ctx, cr.cfg, cr.irc)
 	cr.commands[DisableCommandName] = NewDisableCommand(cr.ctx, cr.cfg, cr.irc)
+	cr.commands[GrantCommandName] = NewGrantCommand(cr.ctx, cr.cfg, cr.irc)
+	cr.commands[RevokeCommandName] = NewRevokeCommand(cr.ctx, cr.cfg, cr.irc)
+	cr.commands[RolesCommandName] = NewRolesCommand(cr.ctx, cr.cfg, cr.irc)
+	cr.commands[AliasCommandName] = NewAliasCommand(cr.ctx, cr.cfg, cr.irc)
+	cr.commands[MacroCommandName] = NewMacroCommand(cr.ctx, cr.cfg, cr.irc)
 	cr.commands[DataManagementCommandName] = NewDataManagementCommand(cr.ctx, cr.cfg, cr.irc)
 	cr.commands[EchoCommandName] = NewEchoCommand(cr.ctx, cr.cfg, cr.irc)
 	cr.commands[SayCommandName] = NewSayCommand(cr.ctx, cr.cfg, cr.irc)
 	cr.commands[JoinCommandName] = NewJoinCommand(cr.ctx, cr.cfg, cr.irc)
 	cr.commands[LeaveCommandName] = NewLeaveCommand(cr.ctx, cr.cfg, cr.irc)
 	cr.commands[MuteCommandName] = NewMuteCommand(cr.ctx, cr.cfg, cr.irc)
+	cr.commands[WarnCommandName] = NewWarnCommand(cr.ctx, cr.cfg, cr.irc)
 	cr.commands[UnmuteCommandName] = NewUnmuteCommand(cr.ctx, cr.cfg, cr.irc)
 	cr.commands[AutoVoiceCommandName] = NewAutoVoiceCommand(cr.ctx, cr.cfg, cr.irc)
 	cr.commands[VoiceRequestCommandName] = NewVoiceRequestCommand(cr.ctx, cr.cfg, cr.irc)
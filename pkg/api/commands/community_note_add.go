@@ -48,7 +48,7 @@ func (c *CommunityNoteAddCommand) CanExecute(e *irc.Event) bool {
 	return c.isCommandEventValid(c, e, 3)
 }
 
-func (c *CommunityNoteAddCommand) Execute(e *irc.Event) {
+func (c *CommunityNoteAddCommand) Execute(e *irc.Event, _ *Args) {
 	logger := log.Logger()
 	logger.Infof(e, "⚡ %s [%s/%s]", c.Name(), e.From, e.ReplyTarget())
 	tokens := Tokens(e.Message())
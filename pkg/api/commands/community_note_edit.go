@@ -51,7 +51,7 @@ const actionEditSource = "s"
 const actionEditCounterSource = "c"
 const actionEditContent = "n"
 
-func (c *CommunityNoteEditCommand) Execute(e *irc.Event) {
+func (c *CommunityNoteEditCommand) Execute(e *irc.Event, _ *Args) {
 	logger := log.Logger()
 	logger.Infof(e, "⚡ %s [%s/%s] ", c.Name(), e.From, e.ReplyTarget())
 	tokens := Tokens(e.Message())
@@ -46,7 +46,7 @@ func (c *CommunityNoteGetCommand) CanExecute(e *irc.Event) bool {
 	return c.isCommandEventValid(c, e, 1)
 }
 
-func (c *CommunityNoteGetCommand) Execute(e *irc.Event) {
+func (c *CommunityNoteGetCommand) Execute(e *irc.Event, _ *Args) {
 	logger := log.Logger()
 	logger.Infof(e, "⚡ %s [%s/%s] ", c.Name(), e.From, e.ReplyTarget())
 	tokens := Tokens(e.Message())
@@ -46,7 +46,7 @@ func (c *CredibilityCommand) CanExecute(e *irc.Event) bool {
 	return c.isCommandEventValid(c, e, 1)
 }
 
-func (c *CredibilityCommand) Execute(e *irc.Event) {
+func (c *CredibilityCommand) Execute(e *irc.Event, _ *Args) {
 	logger := log.Logger()
 	tokens := Tokens(e.Message())
 	channel := e.ReplyTarget()
@@ -56,7 +56,7 @@ func (c *CurrencyCommand) CanExecute(e *irc.Event) bool {
 	return c.isCommandEventValid(c, e, 1)
 }
 
-func (c *CurrencyCommand) Execute(e *irc.Event) {
+func (c *CurrencyCommand) Execute(e *irc.Event, _ *Args) {
 	logger := log.Logger()
 	msg := e.Message()
 	msg = strings.ReplaceAll(msg, " to ", " ")
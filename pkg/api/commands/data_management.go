@@ -5,9 +5,9 @@ import (
 	"assistant/pkg/api/irc"
 	"assistant/pkg/api/style"
 	"assistant/pkg/config"
-	"assistant/pkg/firestore"
 	"assistant/pkg/log"
 	"assistant/pkg/models"
+	"assistant/pkg/storage"
 	"fmt"
 	"strconv"
 	"strings"
@@ -78,7 +78,7 @@ func (c *DataManagementCommand) CanExecute(e *irc.Event) bool {
 	return c.isCommandEventValid(c, e, 3)
 }
 
-func (c *DataManagementCommand) Execute(e *irc.Event) {
+func (c *DataManagementCommand) Execute(e *irc.Event, _ *Args) {
 	logger := log.Logger()
 	tokens := Tokens(e.Message())
 
@@ -170,7 +170,7 @@ func (c *DataManagementCommand) manageSourceData(e *irc.Event, action commandAct
 
 func (c *DataManagementCommand) copySourceData(e *irc.Event, id string, field commandField, value any) (*models.Source, error) {
 	logger := log.Logger()
-	fs := firestore.Get()
+	fs := storage.Get()
 	orig, err := fs.GetSource(id)
 	if err != nil {
 		return nil, err
@@ -229,7 +229,7 @@ func (c *DataManagementCommand) copySourceData(e *irc.Event, id string, field co
 
 func (c *DataManagementCommand) editSourceData(e *irc.Event, id string, field commandField, value any) error {
 	logger := log.Logger()
-	fs := firestore.Get()
+	fs := storage.Get()
 	source, err := fs.GetSource(id)
 	if err != nil {
 		return err
@@ -288,7 +288,7 @@ func (c *DataManagementCommand) editSourceData(e *irc.Event, id string, field co
 }
 
 func (c *DataManagementCommand) deleteSourceData(e *irc.Event, id string, field commandField) error {
-	fs := firestore.Get()
+	fs := storage.Get()
 
 	if field == commandFieldNone {
 		return fs.DeleteSource(id)
@@ -54,7 +54,7 @@ func (c *DefineCommand) CanExecute(e *irc.Event) bool {
 	return c.isCommandEventValid(c, e, 1)
 }
 
-func (c *DefineCommand) Execute(e *irc.Event) {
+func (c *DefineCommand) Execute(e *irc.Event, _ *Args) {
 	logger := log.Logger()
 	tokens := Tokens(e.Message())
 	word := strings.Join(tokens[1:], " ")
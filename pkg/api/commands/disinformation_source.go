@@ -1,12 +1,13 @@
 package commands
 
 import (
+	"assistant/pkg/api/actions"
 	"assistant/pkg/api/context"
 	"assistant/pkg/api/irc"
 	"assistant/pkg/api/style"
 	"assistant/pkg/config"
-	"assistant/pkg/firestore"
 	"assistant/pkg/log"
+	"assistant/pkg/models"
 	"fmt"
 	"strings"
 )
@@ -66,13 +67,13 @@ func (c *DisinformationSourceCommand) CanExecute(e *irc.Event) bool {
 	return c.isCommandEventValid(c, e, 2)
 }
 
-func (c *DisinformationSourceCommand) Execute(e *irc.Event) {
-	fs := firestore.Get()
+func (c *DisinformationSourceCommand) Execute(e *irc.Event, _ *Args) {
+	fs := c.store()
 	logger := log.Logger()
 	tokens := Tokens(e.Message())
 
 	if e.IsPrivateMessage() && len(tokens) < 4 {
-		c.Replyf(e, "Invalid usage. See %s for more information.", style.Italics(fmt.Sprintf("%s%s %s", c.cfg.Commands.Prefix, registry.Command(HelpCommandName).Triggers()[0], strings.TrimPrefix(tokens[0], c.cfg.Commands.Prefix))))
+		c.Replyf(e, "Invalid usage. See %s for more information.", style.Italics(fmt.Sprintf("%s%s %s", c.cfg.Commands.Prefix, c.registry().Command(HelpCommandName).Triggers()[0], strings.TrimPrefix(tokens[0], c.cfg.Commands.Prefix))))
 		return
 	}
 
@@ -104,11 +105,15 @@ func (c *DisinformationSourceCommand) Execute(e *irc.Event) {
 		if action == disinfoActionAdd {
 			if err := fs.AddDisinformationSource(channelName, source); err != nil {
 				logger.Errorf(e, "error adding disinformation source: %v", err)
+			} else {
+				actions.Audit(c.irc, channelName, c.actor(e), models.AuditActionDisinfoSourceAdd, source, "", "")
 			}
 			c.Replyf(e, "Updated disinformation sources in %s.", style.Bold(channelName))
 		} else if action == disinfoActionRemove {
 			if err := fs.DeleteDisinformationSource(channelName, source); err != nil {
 				logger.Errorf(e, "error removing disinformation source: %v", err)
+			} else {
+				actions.Audit(c.irc, channelName, c.actor(e), models.AuditActionDisinfoSourceRemove, source, "", "")
 			}
 			c.Replyf(e, "Updated disinformation sources in %s.", style.Bold(channelName))
 		} else if action == disinfoActionVerify {
@@ -48,7 +48,7 @@ func (c *DrudgeHeadlinesCommand) CanExecute(e *irc.Event) bool {
 	return c.isCommandEventValid(c, e, 0)
 }
 
-func (c *DrudgeHeadlinesCommand) Execute(e *irc.Event) {
+func (c *DrudgeHeadlinesCommand) Execute(e *irc.Event, _ *Args) {
 	logger := log.Logger()
 	logger.Infof(e, "⚡ %s [%s/%s]", c.Name(), e.From, e.ReplyTarget())
 	tokens := Tokens(e.Message())
@@ -5,7 +5,6 @@ import (
 	"assistant/pkg/api/irc"
 	"assistant/pkg/config"
 	"assistant/pkg/log"
-	"strings"
 )
 
 const EchoCommandName = "echo"
@@ -32,8 +31,18 @@ func (c *EchoCommand) Triggers() []string {
 	return []string{"echo"}
 }
 
+var echoArgs = ArgSpec{
+	Args: []Arg{
+		{Name: "message", Type: ArgTypeText},
+	},
+}
+
 func (c *EchoCommand) Usages() []string {
-	return []string{"%s <message>"}
+	return []string{echoArgs.Usage()}
+}
+
+func (c *EchoCommand) Arguments() *ArgSpec {
+	return &echoArgs
 }
 
 func (c *EchoCommand) AllowedInPrivateMessages() bool {
@@ -41,12 +50,11 @@ func (c *EchoCommand) AllowedInPrivateMessages() bool {
 }
 
 func (c *EchoCommand) CanExecute(e *irc.Event) bool {
-	return c.isCommandEventValid(c, e, 1)
+	return c.isCommandEventValid(c, e, 0)
 }
 
-func (c *EchoCommand) Execute(e *irc.Event) {
-	tokens := Tokens(e.Message())
-	message := strings.Join(tokens[1:], " ")
+func (c *EchoCommand) Execute(e *irc.Event, args *Args) {
+	message := args.String("message")
 	log.Logger().Infof(e, "⚡ %s [%s/%s] %s", c.Name(), e.From, e.ReplyTarget(), message)
 	c.SendMessage(e, e.ReplyTarget(), message)
 }
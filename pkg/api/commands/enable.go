@@ -48,7 +48,7 @@ func (c *EnableCommand) CanExecute(e *irc.Event) bool {
 	return c.isCommandEventValid(c, e, 1)
 }
 
-func (c *EnableCommand) Execute(e *irc.Event) {
+func (c *EnableCommand) Execute(e *irc.Event, _ *Args) {
 	tokens := Tokens(e.Message())
 	channel := ""
 	input := tokens[1:]
@@ -73,7 +73,7 @@ func (c *EnableCommand) Execute(e *irc.Event) {
 
 	for _, t := range input {
 		t = strings.TrimPrefix(strings.ToLower(t), c.cfg.Commands.Prefix)
-		for k, v := range registry.Commands() {
+		for k, v := range c.registry().Commands() {
 			if k == t || slices.Contains(v.Triggers(), t) {
 				commands = append(commands, k)
 			}
@@ -0,0 +1,210 @@
+package commands
+
+import (
+	"assistant/pkg/api/context"
+	"assistant/pkg/api/elapse"
+	"assistant/pkg/api/irc"
+	"assistant/pkg/api/repository"
+	"assistant/pkg/api/style"
+	"assistant/pkg/api/text"
+	"assistant/pkg/config"
+	"assistant/pkg/log"
+	"assistant/pkg/models"
+	"fmt"
+	"strings"
+)
+
+const FactCommandName = "fact"
+
+const (
+	factActionList   = "list"
+	factActionSearch = "search"
+	factActionInfo   = "info"
+)
+
+const maxFactoidSearchKeywords = 10
+const maxFactoidsListed = 30
+
+type FactCommand struct {
+	*commandStub
+}
+
+func NewFactCommand(ctx context.Context, cfg *config.Config, ircs irc.IRC) Command {
+	return &FactCommand{
+		commandStub: defaultCommandStub(ctx, cfg, ircs),
+	}
+}
+
+func (c *FactCommand) Name() string {
+	return FactCommandName
+}
+
+func (c *FactCommand) Description() string {
+	return "Shows, lists and searches the channel's factoids. Factoids can also be shown with ?<key>."
+}
+
+func (c *FactCommand) Triggers() []string {
+	return []string{"fact", "factoid"}
+}
+
+func (c *FactCommand) Usages() []string {
+	return []string{
+		"%s <key> [<nick>] (shows the factoid, addressed to nick if given)",
+		"%s list",
+		"%s search <keywords>",
+		"%s info <key> (shows the factoid's authors, versions and aliases)",
+	}
+}
+
+func (c *FactCommand) AllowedInPrivateMessages() bool {
+	return false
+}
+
+func (c *FactCommand) CanExecute(e *irc.Event) bool {
+	return c.isCommandEventValid(c, e, 1)
+}
+
+func (c *FactCommand) Execute(e *irc.Event, _ *Args) {
+	tokens := Tokens(e.Message())
+	logger := log.Logger()
+	logger.Infof(e, "⚡ %s [%s/%s] %s", c.Name(), e.From, e.ReplyTarget(), strings.Join(tokens[1:], " "))
+
+	switch strings.ToLower(tokens[1]) {
+	case factActionList:
+		c.listFactoids(e)
+	case factActionSearch:
+		c.searchFactoids(e, tokens[2:])
+	case factActionInfo:
+		if len(tokens) < 3 {
+			c.Replyf(e, "Please specify a factoid: %s", style.Italics(fmt.Sprintf("%s %s <key>", tokens[0], factActionInfo)))
+			return
+		}
+		c.showFactoidInfo(e, tokens[2])
+	default:
+		addressee := ""
+		if len(tokens) > 2 {
+			addressee = tokens[2]
+		}
+		if !c.showFactoid(e, tokens[1], addressee) {
+			c.Replyf(e, "There's no %s factoid in %s.", style.Bold(models.NormalizeFactoidKey(tokens[1])), e.ReplyTarget())
+		}
+	}
+}
+
+// showFactoid sends the factoid with the key to the event's channel, addressed to addressee if given, and returns
+// whether there was one.
+func (cs *commandStub) showFactoid(e *irc.Event, key, addressee string) bool {
+	f, err := repository.ResolveFactoid(e, e.ReplyTarget(), key)
+	if err != nil {
+		log.Logger().Errorf(e, "error resolving factoid, %s", err)
+		return true
+	}
+	if f == nil {
+		return false
+	}
+
+	message := f.Expand(e.From, e.ReplyTarget())
+	if len(addressee) > 0 {
+		message = fmt.Sprintf("%s: %s", addressee, message)
+	}
+	cs.SendMessage(e, e.ReplyTarget(), message)
+	return true
+}
+
+func (c *FactCommand) listFactoids(e *irc.Event) {
+	factoids, err := repository.GetFactoids(e, e.ReplyTarget())
+	if err != nil {
+		log.Logger().Errorf(e, "error listing factoids, %s", err)
+		return
+	}
+
+	if len(factoids) == 0 {
+		c.Replyf(e, "There are no factoids in %s.", e.ReplyTarget())
+		return
+	}
+
+	keys := make([]string, 0, len(factoids))
+	for _, f := range factoids {
+		if len(keys) == maxFactoidsListed {
+			keys = append(keys, fmt.Sprintf("and %d more", len(factoids)-maxFactoidsListed))
+			break
+		}
+		keys = append(keys, f.Key)
+	}
+
+	c.SendMessage(e, e.ReplyTarget(), fmt.Sprintf("%s: %s", style.Underline("Factoids"), strings.Join(keys, ", ")))
+}
+
+func (c *FactCommand) searchFactoids(e *irc.Event, input []string) {
+	keywords := text.ParseKeywords(strings.Join(input, " "))
+	keywords = keywords[:min(len(keywords), maxFactoidSearchKeywords)]
+	if len(keywords) == 0 {
+		c.Replyf(e, "Please specify keywords to search for.")
+		return
+	}
+
+	factoids, err := repository.FindFactoids(e, e.ReplyTarget(), keywords)
+	if err != nil {
+		log.Logger().Errorf(e, "error searching factoids, %s", err)
+		return
+	}
+
+	if len(factoids) == 0 {
+		c.Replyf(e, "No factoids found for %s.", style.Bold(strings.Join(keywords, " ")))
+		return
+	}
+
+	keys := make([]string, 0, len(factoids))
+	for _, f := range factoids[:min(len(factoids), maxFactoidsListed)] {
+		keys = append(keys, f.Key)
+	}
+
+	c.SendMessage(e, e.ReplyTarget(), fmt.Sprintf("%s: %s", style.Underline("Matching factoids"), strings.Join(keys, ", ")))
+}
+
+func (c *FactCommand) showFactoidInfo(e *irc.Event, key string) {
+	logger := log.Logger()
+
+	f, err := repository.GetFactoid(e, e.ReplyTarget(), key)
+	if err != nil {
+		logger.Errorf(e, "error retrieving factoid, %s", err)
+		return
+	}
+	if f == nil {
+		c.Replyf(e, "There's no %s factoid in %s.", style.Bold(models.NormalizeFactoidKey(key)), e.ReplyTarget())
+		return
+	}
+
+	info := fmt.Sprintf("%s: version %d, by %s %s", style.Bold(f.Key), len(f.Versions), f.UpdatedBy, elapse.PastTimeDescription(f.UpdatedAt))
+	if f.IsAlias() {
+		info = fmt.Sprintf("%s: alias of %s, by %s %s", style.Bold(f.Key), style.Bold(f.AliasOf), f.UpdatedBy, elapse.PastTimeDescription(f.UpdatedAt))
+	}
+	info += fmt.Sprintf(" • created by %s %s", f.CreatedBy, elapse.PastTimeDescription(f.CreatedAt))
+	if f.Locked {
+		info += fmt.Sprintf(" • locked by %s", f.LockedBy)
+	}
+
+	factoids, err := repository.GetFactoids(e, e.ReplyTarget())
+	if err != nil {
+		logger.Errorf(e, "error listing factoids, %s", err)
+		return
+	}
+
+	aliases := make([]string, 0)
+	for _, other := range factoids {
+		if other.AliasOf == f.Key {
+			aliases = append(aliases, other.Key)
+		}
+	}
+	if len(aliases) > 0 {
+		info += fmt.Sprintf(" • aliases: %s", strings.Join(aliases, ", "))
+	}
+
+	c.SendMessage(e, e.ReplyTarget(), info)
+}
+
+// canChangeLockedFactoid calls back with whether the sender can change or forget locked factoids, which admins and
+// channel operators can.
+func (cs *commandStub) canChangeLockedFactoid(e *irc.Event, callback func(bool)) {
+	cs.isAdminOrOperator(e, e.ReplyTarget(), callback)
+}
@@ -0,0 +1,99 @@
+package commands
+
+import (
+	"assistant/pkg/api/context"
+	"assistant/pkg/api/irc"
+	"assistant/pkg/api/repository"
+	"assistant/pkg/api/style"
+	"assistant/pkg/config"
+	"assistant/pkg/log"
+	"assistant/pkg/models"
+	"strings"
+)
+
+const FactLockCommandName = "fact_lock"
+
+const (
+	factLockTrigger   = "lockfact"
+	factUnlockTrigger = "unlockfact"
+)
+
+type FactLockCommand struct {
+	*commandStub
+}
+
+func NewFactLockCommand(ctx context.Context, cfg *config.Config, ircs irc.IRC) Command {
+	return &FactLockCommand{
+		commandStub: newCommandStub(ctx, cfg, ircs, RoleAdmin, irc.ChannelStatusOperator),
+	}
+}
+
+func (c *FactLockCommand) Name() string {
+	return FactLockCommandName
+}
+
+func (c *FactLockCommand) Description() string {
+	return "Locks or unlocks a channel factoid. Only channel operators can change or forget locked factoids."
+}
+
+func (c *FactLockCommand) Triggers() []string {
+	return []string{factLockTrigger, factUnlockTrigger}
+}
+
+func (c *FactLockCommand) Usages() []string {
+	return []string{"%s <key>"}
+}
+
+func (c *FactLockCommand) AllowedInPrivateMessages() bool {
+	return false
+}
+
+func (c *FactLockCommand) CanExecute(e *irc.Event) bool {
+	return c.isCommandEventValid(c, e, 1)
+}
+
+func (c *FactLockCommand) Execute(e *irc.Event, _ *Args) {
+	tokens := Tokens(e.Message())
+	key := models.NormalizeFactoidKey(tokens[1])
+	channel := e.ReplyTarget()
+	lock := strings.EqualFold(strings.TrimPrefix(tokens[0], c.cfg.Commands.Prefix), factLockTrigger)
+
+	logger := log.Logger()
+	logger.Infof(e, "⚡ %s [%s/%s] %s %s", c.Name(), e.From, channel, tokens[0], key)
+
+	f, err := repository.GetFactoid(e, channel, key)
+	if err != nil {
+		logger.Errorf(e, "error retrieving factoid, %s", err)
+		return
+	}
+	if f == nil {
+		c.Replyf(e, "There's no %s factoid in %s.", style.Bold(key), channel)
+		return
+	}
+
+	if f.Locked == lock {
+		if lock {
+			c.Replyf(e, "The %s factoid is already locked.", style.Bold(key))
+		} else {
+			c.Replyf(e, "The %s factoid isn't locked.", style.Bold(key))
+		}
+		return
+	}
+
+	f.Locked = lock
+	f.LockedBy = ""
+	if lock {
+		f.LockedBy = e.From
+	}
+
+	if err := repository.SetFactoid(e, channel, f); err != nil {
+		logger.Errorf(e, "error saving factoid, %s", err)
+		return
+	}
+
+	if lock {
+		c.Replyf(e, "Locked %s.", style.Bold(key))
+	} else {
+		c.Replyf(e, "Unlocked %s.", style.Bold(key))
+	}
+}
@@ -0,0 +1,59 @@
+package commands
+
+import (
+	"assistant/pkg/api/context"
+	"assistant/pkg/api/irc"
+	"assistant/pkg/config"
+	"assistant/pkg/log"
+	"regexp"
+)
+
+const FactoidLookupCommandName = "factoid_lookup"
+
+var factoidLookupRegexp = regexp.MustCompile(`^\?([A-Za-z0-9][A-Za-z0-9_.-]{0,31})(?:\s+(\S+))?\s*$`)
+
+type FactoidLookupCommand struct {
+	*commandStub
+}
+
+func NewFactoidLookupCommand(ctx context.Context, cfg *config.Config, ircs irc.IRC) Command {
+	return &FactoidLookupCommand{
+		commandStub: defaultCommandStub(ctx, cfg, ircs),
+	}
+}
+
+func (c *FactoidLookupCommand) Name() string {
+	return FactoidLookupCommandName
+}
+
+func (c *FactoidLookupCommand) Description() string {
+	return "Shows the factoid with the given key."
+}
+
+func (c *FactoidLookupCommand) Triggers() []string {
+	return []string{}
+}
+
+func (c *FactoidLookupCommand) Usages() []string {
+	return []string{"?<key> [<nick>]"}
+}
+
+func (c *FactoidLookupCommand) AllowedInPrivateMessages() bool {
+	return false
+}
+
+func (c *FactoidLookupCommand) CanExecute(e *irc.Event) bool {
+	return c.isCommandEventValid(c, e, 0) && factoidLookupRegexp.MatchString(e.Message())
+}
+
+func (c *FactoidLookupCommand) Execute(e *irc.Event, _ *Args) {
+	matches := factoidLookupRegexp.FindStringSubmatch(e.Message())
+	if len(matches) < 3 {
+		return
+	}
+
+	// unknown keys are ignored, since ? also starts ordinary messages
+	if c.showFactoid(e, matches[1], matches[2]) {
+		log.Logger().Infof(e, "⚡ %s [%s/%s] %s", c.Name(), e.From, e.ReplyTarget(), matches[1])
+	}
+}
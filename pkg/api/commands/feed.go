@@ -0,0 +1,345 @@
+package commands
+
+import (
+	"assistant/pkg/api/context"
+	"assistant/pkg/api/elapse"
+	"assistant/pkg/api/feed"
+	"assistant/pkg/api/irc"
+	"assistant/pkg/api/repository"
+	"assistant/pkg/api/style"
+	"assistant/pkg/config"
+	"assistant/pkg/log"
+	"assistant/pkg/models"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+const FeedCommandName = "feed"
+
+const (
+	feedActionAdd    = "add"
+	feedActionList   = "list"
+	feedActionRemove = "remove"
+	feedActionDelete = "delete"
+	feedActionFilter = "filter"
+	feedActionCap    = "cap"
+	feedActionQuiet  = "quiet"
+)
+
+const feedQuietHoursOff = "off"
+
+type FeedCommand struct {
+	*commandStub
+}
+
+func NewFeedCommand(ctx context.Context, cfg *config.Config, ircs irc.IRC) Command {
+	return &FeedCommand{
+		commandStub: newCommandStub(ctx, cfg, ircs, RoleAdmin, irc.ChannelStatusOperator),
+	}
+}
+
+func (c *FeedCommand) Name() string {
+	return FeedCommandName
+}
+
+func (c *FeedCommand) Description() string {
+	return "Subscribes the channel to an RSS or Atom feed, subreddit, Mastodon account or Bluesky account, sharing new items that match the optional filter. Alternatives in a filter are separated by |. Each feed shares at most 4 items an hour unless capped otherwise, and nothing during its quiet hours."
+}
+
+func (c *FeedCommand) Triggers() []string {
+	return []string{"feed", "feeds"}
+}
+
+var feedArgs = ArgSpec{
+	Note: "lists the channel's feeds",
+	Subcommands: []Subcommand{
+		{Name: feedActionAdd, ArgSpec: ArgSpec{
+			Args: []Arg{
+				{Name: "url", Type: ArgTypeString},
+				{Name: "filter", Type: ArgTypeText, Optional: true},
+			},
+		}},
+		{Name: feedActionList},
+		{Name: feedActionRemove, Aliases: []string{feedActionDelete}, ArgSpec: ArgSpec{
+			Args: []Arg{
+				{Name: "id", Type: ArgTypeString},
+			},
+		}},
+		{Name: feedActionFilter, ArgSpec: ArgSpec{
+			Args: []Arg{
+				{Name: "id", Type: ArgTypeString},
+				{Name: "filter", Type: ArgTypeText, Optional: true},
+			},
+			Note: "without a filter, shares every item",
+		}},
+		{Name: feedActionCap, ArgSpec: ArgSpec{
+			Args: []Arg{
+				{Name: "id", Type: ArgTypeString},
+				{Name: "items per hour", Type: ArgTypeInt},
+			},
+		}},
+		{Name: feedActionQuiet, ArgSpec: ArgSpec{
+			Args: []Arg{
+				{Name: "id", Type: ArgTypeString},
+				{Name: "hours", Type: ArgTypeText},
+			},
+			Note: "<from>-<until>, or off",
+		}},
+	},
+}
+
+func (c *FeedCommand) Usages() []string {
+	return feedArgs.Usages()
+}
+
+func (c *FeedCommand) Arguments() *ArgSpec {
+	return &feedArgs
+}
+
+func (c *FeedCommand) AllowedInPrivateMessages() bool {
+	return false
+}
+
+func (c *FeedCommand) CanExecute(e *irc.Event) bool {
+	return c.isCommandEventValid(c, e, 0)
+}
+
+func (c *FeedCommand) Execute(e *irc.Event, args *Args) {
+	channel := e.ReplyTarget()
+
+	log.Logger().Infof(e, "⚡ %s [%s/%s] %s", c.Name(), e.From, channel, strings.Join(Tokens(e.Message())[1:], " "))
+
+	switch args.Subcommand() {
+	case feedActionAdd:
+		c.addFeed(e, channel, args.String("url"), args.String("filter"))
+	case feedActionRemove:
+		c.removeFeed(e, channel, args.String("id"))
+	case feedActionFilter:
+		c.setFilter(e, channel, args.String("id"), args.String("filter"))
+	case feedActionCap:
+		c.setCap(e, channel, args.String("id"), args.Int("items per hour"))
+	case feedActionQuiet:
+		c.setQuietHours(e, channel, args.String("id"), args.String("hours"))
+	default:
+		c.listFeeds(e, channel)
+	}
+}
+
+func (c *FeedCommand) addFeed(e *irc.Event, channel, input, filter string) {
+	logger := log.Logger()
+
+	source, err := feed.Resolve(input)
+	if err != nil {
+		c.Replyf(e, "That isn't a feed, subreddit, Mastodon account or Bluesky account URL: %s", style.Bold(input))
+		return
+	}
+
+	feeds, err := repository.GetFeeds(e, channel)
+	if err != nil {
+		logger.Errorf(e, "error retrieving feeds, %s", err)
+		return
+	}
+
+	if existing := slices.IndexFunc(feeds, func(f *models.Feed) bool { return f.FeedURL == source.URL }); existing >= 0 {
+		c.Replyf(e, "%s is already subscribed to %s as feed %s.", channel, style.Bold(feeds[existing].Title), style.Bold(feeds[existing].ID))
+		return
+	}
+
+	if len(feeds) >= models.MaxFeedsPerChannel {
+		c.Replyf(e, "%s already has %d feeds, remove one first.", channel, len(feeds))
+		return
+	}
+
+	f, err := feed.Subscribe(source, input, filter, e.From)
+	if err != nil {
+		logger.Warningf(e, "error fetching feed %s, %s", source.URL, err)
+		c.Replyf(e, "Couldn't read a feed from %s.", style.Bold(input))
+		return
+	}
+
+	if err := repository.SetFeed(e, channel, f); err != nil {
+		logger.Errorf(e, "error adding feed, %s", err)
+		return
+	}
+
+	if len(feeds) == 0 {
+		if err := repository.ScheduleFeedsTask(e, channel); err != nil {
+			logger.Errorf(e, "error scheduling feed polling, %s", err)
+		}
+	}
+
+	message := fmt.Sprintf("Subscribed %s to %s as feed %s. New items", channel, style.Bold(f.Title), style.Bold(f.ID))
+	if len(f.Filter) > 0 {
+		message += fmt.Sprintf(" matching %s", style.Bold(f.Filter))
+	}
+	c.Replyf(e, "%s will be shared here, up to %d an hour.", message, f.MaxPostsPerHour)
+}
+
+func (c *FeedCommand) listFeeds(e *irc.Event, channel string) {
+	feeds, err := repository.GetFeeds(e, channel)
+	if err != nil {
+		log.Logger().Errorf(e, "error retrieving feeds, %s", err)
+		return
+	}
+
+	if len(feeds) == 0 {
+		c.Replyf(e, "%s isn't subscribed to any feeds.", channel)
+		return
+	}
+
+	messages := make([]string, 0, len(feeds))
+	for _, f := range feeds {
+		details := []string{f.Kind, fmt.Sprintf("%d/hour", f.MaxPostsPerHour)}
+		if len(f.Filter) > 0 {
+			details = append(details, fmt.Sprintf("filter: %s", f.Filter))
+		}
+		if f.HasQuietHours() {
+			details = append(details, fmt.Sprintf("quiet %s", f.QuietHours()))
+		}
+		if len(f.LastError) > 0 {
+			details = append(details, fmt.Sprintf("failing since %s", elapse.PastTimeDescription(f.PolledAt)))
+		}
+		messages = append(messages, fmt.Sprintf("%s: %s %s (%s)", style.Bold(f.ID), f.Title, f.URL, strings.Join(details, ", ")))
+	}
+
+	c.SendMessages(e, channel, messages)
+}
+
+func (c *FeedCommand) removeFeed(e *irc.Event, channel, id string) {
+	f, ok := c.findFeed(e, channel, id)
+	if !ok {
+		return
+	}
+
+	if err := repository.RemoveFeed(e, channel, f.ID); err != nil {
+		log.Logger().Errorf(e, "error removing feed, %s", err)
+		return
+	}
+
+	c.Replyf(e, "Unsubscribed %s from %s.", channel, style.Bold(f.Title))
+}
+
+func (c *FeedCommand) setFilter(e *irc.Event, channel, id, filter string) {
+	f, ok := c.findFeed(e, channel, id)
+	if !ok {
+		return
+	}
+
+	f.Filter = strings.TrimSpace(filter)
+	if err := repository.SetFeed(e, channel, f); err != nil {
+		log.Logger().Errorf(e, "error updating feed, %s", err)
+		return
+	}
+
+	if len(f.Filter) == 0 {
+		c.Replyf(e, "All new items from %s will be shared.", style.Bold(f.Title))
+		return
+	}
+	c.Replyf(e, "Only new items from %s matching %s will be shared.", style.Bold(f.Title), style.Bold(f.Filter))
+}
+
+func (c *FeedCommand) setCap(e *irc.Event, channel, id string, n int) {
+	if n < 1 || n > models.MaxFeedMaxPostsPerHour {
+		c.Replyf(e, "A feed can share between 1 and %d items an hour.", models.MaxFeedMaxPostsPerHour)
+		return
+	}
+
+	f, ok := c.findFeed(e, channel, id)
+	if !ok {
+		return
+	}
+
+	f.MaxPostsPerHour = n
+	if err := repository.SetFeed(e, channel, f); err != nil {
+		log.Logger().Errorf(e, "error updating feed, %s", err)
+		return
+	}
+
+	c.Replyf(e, "%s will share up to %d items an hour.", style.Bold(f.Title), n)
+}
+
+func (c *FeedCommand) setQuietHours(e *irc.Event, channel, id, input string) {
+	f, ok := c.findFeed(e, channel, id)
+	if !ok {
+		return
+	}
+
+	if strings.EqualFold(input, feedQuietHoursOff) {
+		f.ClearQuietHours()
+		if err := repository.SetFeed(e, channel, f); err != nil {
+			log.Logger().Errorf(e, "error updating feed, %s", err)
+			return
+		}
+		c.Replyf(e, "%s no longer has quiet hours.", style.Bold(f.Title))
+		return
+	}
+
+	from, until, found := strings.Cut(input, "-")
+	if !found {
+		c.Replyf(e, "Please give quiet hours as %s, e.g. %s.", style.Italics("<from>-<until>"), style.Italics("22:00-07:00"))
+		return
+	}
+
+	fromHour, fromMinute, err := elapse.ParseTimeOfDay(from)
+	if err != nil {
+		c.Replyf(e, "invalid time, %s", style.Italics(from))
+		return
+	}
+	untilHour, untilMinute, err := elapse.ParseTimeOfDay(until)
+	if err != nil {
+		c.Replyf(e, "invalid time, %s", style.Italics(until))
+		return
+	}
+
+	if err := f.SetQuietHours(fromHour, fromMinute, untilHour, untilMinute, c.userTimeZone(e)); err != nil {
+		c.Replyf(e, "Quiet hours must start and end at different times.")
+		return
+	}
+
+	if err := repository.SetFeed(e, channel, f); err != nil {
+		log.Logger().Errorf(e, "error updating feed, %s", err)
+		return
+	}
+
+	c.Replyf(e, "%s won't share anything %s.", style.Bold(f.Title), f.QuietHours())
+}
+
+func (c *FeedCommand) findFeed(e *irc.Event, channel, id string) (*models.Feed, bool) {
+	f, err := repository.GetFeed(e, channel, strings.ToLower(id))
+	if err != nil {
+		log.Logger().Errorf(e, "error retrieving feed, %s", err)
+		return nil, false
+	}
+
+	if f == nil {
+		c.Replyf(e, "There's no feed %s in %s.", style.Bold(id), channel)
+		return nil, false
+	}
+
+	return f, true
+}
+
+// ShareFeedItem shares an item from one of a channel's feeds, naming the feed and then summarizing the item's link as
+// though it had been posted in the channel.
+func ShareFeedItem(cfg *config.Config, ircs irc.IRC, channel string, f *models.Feed, url string) {
+	ircs.SendMessage(channel, fmt.Sprintf("📰 %s: %s", style.Bold(f.Title), url))
+
+	registry := registryForNetwork(cfg.IRC.Name)
+	if registry == nil {
+		return
+	}
+
+	e := &irc.Event{
+		ID:        uuid.NewString(),
+		Code:      irc.CodePrivateMessage,
+		From:      cfg.IRC.Nick,
+		Network:   cfg.IRC.Name,
+		Arguments: []string{channel, url},
+		Metadata:  map[string]any{CommandMetadataFeed: true},
+	}
+
+	registry.Command(SummaryCommandName).Execute(e, nil)
+}
@@ -0,0 +1,96 @@
+package commands
+
+import (
+	"assistant/pkg/api/context"
+	"assistant/pkg/api/irc"
+	"assistant/pkg/api/repository"
+	"assistant/pkg/api/style"
+	"assistant/pkg/config"
+	"assistant/pkg/log"
+	"assistant/pkg/models"
+)
+
+const ForgetCommandName = "forget"
+
+type ForgetCommand struct {
+	*commandStub
+}
+
+func NewForgetCommand(ctx context.Context, cfg *config.Config, ircs irc.IRC) Command {
+	return &ForgetCommand{
+		commandStub: newCommandStub(ctx, cfg, ircs, RoleAdmin, irc.ChannelStatusHalfOperator),
+	}
+}
+
+func (c *ForgetCommand) Name() string {
+	return ForgetCommandName
+}
+
+func (c *ForgetCommand) Description() string {
+	return "Removes a channel factoid and its versions."
+}
+
+func (c *ForgetCommand) Triggers() []string {
+	return []string{"forget"}
+}
+
+var forgetArgs = ArgSpec{
+	Args: []Arg{
+		{Name: "key", Type: ArgTypeString},
+	},
+}
+
+func (c *ForgetCommand) Usages() []string {
+	return []string{forgetArgs.Usage()}
+}
+
+func (c *ForgetCommand) Arguments() *ArgSpec {
+	return &forgetArgs
+}
+
+func (c *ForgetCommand) AllowedInPrivateMessages() bool {
+	return false
+}
+
+func (c *ForgetCommand) CanExecute(e *irc.Event) bool {
+	return c.isCommandEventValid(c, e, 0)
+}
+
+func (c *ForgetCommand) Execute(e *irc.Event, args *Args) {
+	key := models.NormalizeFactoidKey(args.String("key"))
+	channel := e.ReplyTarget()
+
+	logger := log.Logger()
+	logger.Infof(e, "⚡ %s [%s/%s] %s", c.Name(), e.From, channel, key)
+
+	f, err := repository.GetFactoid(e, channel, key)
+	if err != nil {
+		logger.Errorf(e, "error retrieving factoid, %s", err)
+		return
+	}
+	if f == nil {
+		c.Replyf(e, "There's no %s factoid in %s.", style.Bold(key), channel)
+		return
+	}
+
+	forget := func() {
+		if err := repository.DeleteFactoid(e, channel, key); err != nil {
+			logger.Errorf(e, "error deleting factoid, %s", err)
+			return
+		}
+		c.Replyf(e, "Forgot %s.", style.Bold(key))
+	}
+
+	if !f.Locked {
+		forget()
+		return
+	}
+
+	c.canChangeLockedFactoid(e, func(allowed bool) {
+		if !allowed {
+			c.Replyf(e, "The %s factoid is locked.", style.Bold(key))
+			return
+		}
+		forget()
+	})
+}
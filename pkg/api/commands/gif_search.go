@@ -45,7 +45,7 @@ func (c *GIFSearchCommand) CanExecute(e *irc.Event) bool {
 	return c.isCommandEventValid(c, e, 1)
 }
 
-func (c *GIFSearchCommand) Execute(e *irc.Event) {
+func (c *GIFSearchCommand) Execute(e *irc.Event, _ *Args) {
 	tokens := Tokens(e.Message())
 	message := strings.Join(tokens[1:], "_") + ".gif"
 	log.Logger().Infof(e, "⚡ %s [%s/%s] %s", c.Name(), e.From, e.ReplyTarget(), message)
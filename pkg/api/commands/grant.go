@@ -0,0 +1,197 @@
+package commands
+
+import (
+	"assistant/pkg/api/context"
+	"assistant/pkg/api/irc"
+	"assistant/pkg/api/repository"
+	"assistant/pkg/api/style"
+	"assistant/pkg/config"
+	"assistant/pkg/log"
+	"assistant/pkg/models"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const GrantCommandName = "grant"
+
+type GrantCommand struct {
+	*commandStub
+}
+
+func NewGrantCommand(ctx context.Context, cfg *config.Config, ircs irc.IRC) Command {
+	return &GrantCommand{
+		commandStub: newCommandStub(ctx, cfg, ircs, RoleAdmin, irc.ChannelStatusOperator),
+	}
+}
+
+func (c *GrantCommand) Name() string {
+	return GrantCommandName
+}
+
+func (c *GrantCommand) Description() string {
+	return "Grants commands to a channel role, so its members can use them whatever their channel status."
+}
+
+func (c *GrantCommand) Triggers() []string {
+	return []string{"grant"}
+}
+
+// roleCommandsArgs are the arguments of grant and revoke.
+var roleCommandsArgs = ArgSpec{
+	Args: []Arg{
+		{Name: "channel", Type: ArgTypeChannel, Optional: true},
+		{Name: "role", Type: ArgTypeString},
+		{Name: "commands", Type: ArgTypeText},
+	},
+}
+
+func (c *GrantCommand) Usages() []string {
+	return []string{roleCommandsArgs.Usage()}
+}
+
+func (c *GrantCommand) Arguments() *ArgSpec {
+	return &roleCommandsArgs
+}
+
+func (c *GrantCommand) AllowedInPrivateMessages() bool {
+	return true
+}
+
+func (c *GrantCommand) IsAuthorized(e *irc.Event, channel string, callback func(bool)) {
+	c.isAuthorizedInRoleChannel(e, channel, callback)
+}
+
+func (c *GrantCommand) CanExecute(e *irc.Event) bool {
+	return c.isCommandEventValid(c, e, 0)
+}
+
+func (c *GrantCommand) Execute(e *irc.Event, args *Args) {
+	c.changeRoleCommands(e, args, models.RoleChangeGrant)
+}
+
+// changeRoleCommands grants or revokes the commands in args to the role in args.
+func (cs *commandStub) changeRoleCommands(e *irc.Event, args *Args, action string) {
+	channel := args.String("channel")
+	if len(channel) == 0 {
+		if e.IsPrivateMessage() {
+			cs.Replyf(e, "Please specify a channel: %s", style.Italics(fmt.Sprintf("%s <channel> <role> <command>", Tokens(e.Message())[0])))
+			return
+		}
+		channel = e.ReplyTarget()
+	}
+
+	logger := log.Logger()
+	logger.Infof(e, "⚡ %s [%s/%s] %s %s %s", action, e.From, e.ReplyTarget(), channel, args.String("role"), args.String("commands"))
+
+	ch, err := repository.GetChannel(e, channel)
+	if err != nil {
+		logger.Errorf(e, "error retrieving channel, %s", err)
+		return
+	}
+
+	role := strings.ToLower(args.String("role"))
+	if !ch.HasRole(role) {
+		cs.Replyf(e, "There is no %s role in %s.", style.Bold(role), style.Bold(channel))
+		return
+	}
+
+	commands := cs.commandNames(args.Words("commands"))
+	if len(commands) == 0 {
+		cs.Replyf(e, "No commands found for: %s", style.Bold(args.String("commands")))
+		return
+	}
+
+	processed := make([]string, 0)
+	reserved := make([]string, 0)
+	for _, command := range commands {
+		if cmd := cs.registry().Command(command); cmd != nil && isReservedForBotAdmins(cmd.Authorizer()) {
+			reserved = append(reserved, command)
+			continue
+		}
+
+		changed := false
+		if action == models.RoleChangeGrant {
+			changed = ch.GrantCommand(role, command)
+		} else {
+			changed = ch.RevokeCommand(role, command)
+		}
+		if changed {
+			processed = append(processed, command)
+		}
+	}
+
+	if len(processed) == 0 {
+		if len(reserved) > 0 {
+			cs.Replyf(e, "%s can only be used by the bot's owner and admins, which roles can't change.", style.Bold(strings.Join(reserved, ", ")))
+			return
+		}
+		cs.Replyf(e, "No changes to the %s role in %s.", style.Bold(role), style.Bold(channel))
+		return
+	}
+
+	if err = repository.UpdateChannelRoles(e, ch); err != nil {
+		return
+	}
+
+	recorded := true
+	for _, command := range processed {
+		if err = repository.AddRoleChange(e, channel, models.NewRoleChange(e.From, action, role, command)); err != nil {
+			recorded = false
+		}
+	}
+
+	verb := "Granted"
+	preposition := "to"
+	if action == models.RoleChangeRevoke {
+		verb = "Revoked"
+		preposition = "from"
+	}
+	reply := fmt.Sprintf("%s %s %s the %s role in %s", verb, style.Bold(strings.Join(processed, ", ")), preposition, style.Bold(role), style.Bold(channel))
+	if len(reserved) > 0 {
+		reply += fmt.Sprintf(" (skipped %s, which only the bot's owner and admins can use)", strings.Join(reserved, ", "))
+	}
+	if !recorded {
+		reply += ", but the change couldn't be recorded in the role history"
+	}
+	cs.SendMessage(e, e.ReplyTarget(), reply)
+}
+
+// roleCommandChannel returns the channel a role management command applies to and the tokens after it, replying with
+// usage help when it's sent in a private message without one.
+func (cs *commandStub) roleCommandChannel(e *irc.Event, tokens []string) (string, []string, bool) {
+	if len(tokens) > 1 && irc.IsChannel(tokens[1]) {
+		return tokens[1], tokens[2:], true
+	}
+
+	if e.IsPrivateMessage() {
+		cs.Replyf(e, "Please specify a channel: %s", style.Italics(fmt.Sprintf("%s <channel> ...", tokens[0])))
+		return "", nil, false
+	}
+
+	return e.ReplyTarget(), tokens[1:], true
+}
+
+// isAuthorizedInRoleChannel checks authorization in the channel named by the first argument, if any, rather than the
+// one the command was sent in.
+func (cs *commandStub) isAuthorizedInRoleChannel(e *irc.Event, channel string, callback func(bool)) {
+	tokens := Tokens(e.Message())
+	if len(tokens) > 1 && irc.IsChannel(tokens[1]) {
+		channel = tokens[1]
+	}
+	cs.authorizer.IsAuthorized(e, channel, callback)
+}
+
+// commandNames returns the names of the commands matching each input by name or trigger.
+func (cs *commandStub) commandNames(input []string) []string {
+	names := make([]string, 0)
+	for _, t := range input {
+		t = strings.TrimPrefix(strings.ToLower(t), cs.cfg.Commands.Prefix)
+		for k, v := range cs.registry().Commands() {
+			if (k == t || slices.Contains(v.Triggers(), t)) && !slices.Contains(names, k) {
+				names = append(names, k)
+			}
+		}
+	}
+	return names
+}
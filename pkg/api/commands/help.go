@@ -47,7 +47,7 @@ func (c *HelpCommand) CanExecute(e *irc.Event) bool {
 	return c.isCommandEventValid(c, e, 0)
 }
 
-func (c *HelpCommand) Execute(e *irc.Event) {
+func (c *HelpCommand) Execute(e *irc.Event, _ *Args) {
 	tokens := Tokens(e.Message())
 	logger := log.Logger()
 	logger.Infof(e, "⚡ %s [%s/%s] %s", c.Name(), e.From, e.ReplyTarget(), e.Message())
@@ -59,7 +59,7 @@ func (c *HelpCommand) Execute(e *irc.Event) {
 
 		// create map of command name to slice of current user authorization and allowed user status
 		commands := make([]string, 0)
-		for _, cmd := range registry.Commands() {
+		for _, cmd := range c.availableCommands(e) {
 			cmdt := ""
 			for i, t := range cmd.Triggers() {
 				if len(cmdt) > 0 {
@@ -84,6 +84,16 @@ func (c *HelpCommand) Execute(e *irc.Event) {
 			cmds += cmd
 		}
 		reply = append(reply, fmt.Sprintf("%s: %s (* requires authorization)", style.Underline("Commands"), cmds))
+
+		if aliases := c.registry().ChannelAliases(e); len(aliases) > 0 {
+			descriptions := make([]string, 0, len(aliases))
+			for _, alias := range aliases {
+				descriptions = append(descriptions, fmt.Sprintf("%s → %s", alias.Trigger, alias.Value))
+			}
+			slices.Sort(descriptions)
+			reply = append(reply, fmt.Sprintf("%s: %s", style.Underline("Aliases"), strings.Join(descriptions, ", ")))
+		}
+
 		usages := ""
 		for _, u := range c.Usages() {
 			if len(usages) > 0 {
@@ -99,8 +109,15 @@ func (c *HelpCommand) Execute(e *irc.Event) {
 
 	trigger := strings.TrimPrefix(tokens[1], c.cfg.Commands.Prefix)
 
+	for _, alias := range c.registry().ChannelAliases(e) {
+		if alias.Trigger == strings.ToLower(trigger) {
+			c.SendMessage(e, e.ReplyTarget(), fmt.Sprintf("%s: Alias for %s in %s.", style.Bold(style.Underline(trigger)), style.Italics(alias.Value), e.ReplyTarget()))
+			return
+		}
+	}
+
 	var cmd Command
-	for _, s := range registry.Commands() {
+	for _, s := range c.availableCommands(e) {
 		for _, t := range s.Triggers() {
 			if trigger == t {
 				cmd = s
@@ -110,7 +127,7 @@ func (c *HelpCommand) Execute(e *irc.Event) {
 
 	if cmd == nil {
 		logger.Warningf(e, "command %s not found", trigger)
-		c.Replyf(e, "Command %s not found. See %s for a list of available commands.", style.Bold(trigger), style.Italics(fmt.Sprintf("%s%s", c.cfg.Commands.Prefix, registry.Command(HelpCommandName).Triggers()[0])))
+		c.Replyf(e, "Command %s not found. See %s for a list of available commands.", style.Bold(trigger), style.Italics(fmt.Sprintf("%s%s", c.cfg.Commands.Prefix, c.registry().Command(HelpCommandName).Triggers()[0])))
 		return
 	}
 
@@ -182,3 +199,12 @@ func (c *HelpCommand) Execute(e *irc.Event) {
 
 	c.SendMessages(e, e.ReplyTarget(), reply)
 }
+
+// availableCommands returns the registered commands along with the macros of the event's channel.
+func (c *HelpCommand) availableCommands(e *irc.Event) []Command {
+	commands := make([]Command, 0, len(c.registry().Commands()))
+	for _, cmd := range c.registry().Commands() {
+		commands = append(commands, cmd)
+	}
+	return append(commands, c.registry().ChannelCommands(e)...)
+}
@@ -47,7 +47,7 @@ func (c *HostSearchCommand) CanExecute(e *irc.Event) bool {
 	return c.isCommandEventValid(c, e, 1)
 }
 
-func (c *HostSearchCommand) Execute(e *irc.Event) {
+func (c *HostSearchCommand) Execute(e *irc.Event, _ *Args) {
 	logger := log.Logger()
 	tokens := Tokens(e.Message())
 
@@ -49,7 +49,7 @@ func (c *JoinCommand) CanExecute(e *irc.Event) bool {
 	return irc.IsChannel(tokens[1])
 }
 
-func (c *JoinCommand) Execute(e *irc.Event) {
+func (c *JoinCommand) Execute(e *irc.Event, _ *Args) {
 	tokens := Tokens(e.Message())
 	channels := tokens[1:]
 
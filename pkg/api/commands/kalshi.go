@@ -46,7 +46,7 @@ func (c *KalshiCommand) CanExecute(e *irc.Event) bool {
 	return c.isCommandEventValid(c, e, 1)
 }
 
-func (c *KalshiCommand) Execute(e *irc.Event) {
+func (c *KalshiCommand) Execute(e *irc.Event, _ *Args) {
 	logger := log.Logger()
 
 	tokens := Tokens(e.Message())
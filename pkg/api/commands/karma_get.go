@@ -7,7 +7,6 @@ import (
 	"assistant/pkg/api/repository"
 	"assistant/pkg/api/style"
 	"assistant/pkg/config"
-	"assistant/pkg/firestore"
 	"assistant/pkg/log"
 	"fmt"
 	"math/rand/v2"
@@ -49,15 +48,15 @@ func (c *KarmaGetCommand) CanExecute(e *irc.Event) bool {
 	return c.isCommandEventValid(c, e, 1)
 }
 
-func (c *KarmaGetCommand) Execute(e *irc.Event) {
+func (c *KarmaGetCommand) Execute(e *irc.Event, _ *Args) {
 	tokens := Tokens(e.Message())
 	channel := e.ReplyTarget()
 	nick := tokens[1]
 
 	log.Logger().Infof(e, "⚡ %s [%s/%s] %s", c.Name(), e.From, e.ReplyTarget(), nick)
-	fs := firestore.Get()
+	fs := c.store()
 
-	u, err := repository.GetUserByNick(e, channel, nick, false)
+	u, err := repository.GetUserByIdentity(e, channel, nick, c.irc.Account(nick), false)
 	if err != nil {
 		log.Logger().Errorf(e, "error getting user, %s", err)
 		c.Replyf(e, "unable to get karma for %s.", style.Bold(nick))
@@ -55,7 +55,7 @@ func (c *KarmaSetCommand) CanExecute(e *irc.Event) bool {
 
 var karmaRegex = regexp.MustCompile(`(?i)(.*?)\s*(\+\+|--)(?:\s*,?\s+(.*))?`)
 
-func (c *KarmaSetCommand) Execute(e *irc.Event) {
+func (c *KarmaSetCommand) Execute(e *irc.Event, _ *Args) {
 	logger := log.Logger()
 
 	matches := karmaRegex.FindStringSubmatch(e.Message())
@@ -70,13 +70,22 @@ func (c *KarmaSetCommand) Execute(e *irc.Event) {
 		return
 	}
 
-	if strings.ToLower(e.From) == strings.ToLower(to) {
+	account := c.irc.Account(to)
+	if strings.ToLower(e.From) == strings.ToLower(to) || (len(account) > 0 && account == e.Account) {
 		logger.Debugf(e, "cannot update own karma: %s", e.Message())
 		c.Replyf(e, "You cannot update your own karma.")
 		return
 	}
 
-	pkh, err := repository.GetMostRecentUserKarmaHistoryFromSender(e, e.ReplyTarget(), to, e.From)
+	recipient := to
+	if u, err := repository.GetUserByAccount(e, e.ReplyTarget(), account); err != nil {
+		logger.Errorf(e, "error getting user by account: %s", err)
+		return
+	} else if u != nil {
+		recipient = u.Nick
+	}
+
+	pkh, err := repository.GetMostRecentUserKarmaHistoryFromSender(e, e.ReplyTarget(), recipient, e.From)
 	if err != nil {
 		logger.Errorf(e, "error getting karma: %s", err)
 		return
@@ -110,7 +119,7 @@ func (c *KarmaSetCommand) Execute(e *irc.Event) {
 
 		log.Logger().Infof(e, "⚡ %s [%s/%s] %s %s %s", c.Name(), e.From, e.ReplyTarget(), to, op, reason)
 
-		karma, err := repository.AddUserKarmaHistory(e, e.ReplyTarget(), e.From, to, op, reason)
+		karma, err := repository.AddUserKarmaHistory(e, e.ReplyTarget(), e.From, to, account, op, reason)
 		if err != nil {
 			logger.Errorf(e, "error updating karma: %s", err)
 			return
@@ -1,11 +1,11 @@
 package commands
 
 import (
+	"assistant/pkg/api/actions"
 	"assistant/pkg/api/context"
 	"assistant/pkg/api/irc"
 	"assistant/pkg/config"
 	"assistant/pkg/log"
-	"strings"
 )
 
 const KickCommandName = "kick"
@@ -32,8 +32,19 @@ func (c *KickCommand) Triggers() []string {
 	return []string{"kick", "k"}
 }
 
+var kickArgs = ArgSpec{
+	Args: []Arg{
+		{Name: "nick", Type: ArgTypeNick},
+		{Name: "reason", Type: ArgTypeText, Optional: true},
+	},
+}
+
 func (c *KickCommand) Usages() []string {
-	return []string{"%s <nick> [<reason>]"}
+	return []string{kickArgs.Usage()}
+}
+
+func (c *KickCommand) Arguments() *ArgSpec {
+	return &kickArgs
 }
 
 func (c *KickCommand) AllowedInPrivateMessages() bool {
@@ -41,12 +52,11 @@ func (c *KickCommand) AllowedInPrivateMessages() bool {
 }
 
 func (c *KickCommand) CanExecute(e *irc.Event) bool {
-	return c.isCommandEventValid(c, e, 1)
+	return c.isCommandEventValid(c, e, 0)
 }
 
-func (c *KickCommand) Execute(e *irc.Event) {
-	tokens := Tokens(e.Message())
-	nick := tokens[1]
+func (c *KickCommand) Execute(e *irc.Event, args *Args) {
+	nick := args.String("nick")
 	channel := e.ReplyTarget()
 
 	logger := log.Logger()
@@ -59,11 +69,6 @@ func (c *KickCommand) Execute(e *irc.Event) {
 			return
 		}
 
-		reason := ""
-		if len(tokens) > 2 {
-			reason = strings.Join(tokens[2:], " ")
-		}
-		c.irc.Kick(channel, nick, reason)
-		logger.Infof(e, "kicked %s in %s", nick, channel)
+		actions.Kick(c.irc, channel, nick, "", args.String("reason"), c.actor(e))
 	})
 }
@@ -0,0 +1,132 @@
+package commands
+
+import (
+	"assistant/pkg/api/context"
+	"assistant/pkg/api/irc"
+	"assistant/pkg/api/repository"
+	"assistant/pkg/api/style"
+	"assistant/pkg/config"
+	"assistant/pkg/log"
+	"assistant/pkg/models"
+	"strings"
+)
+
+const LearnCommandName = "learn"
+
+// factoidAliasPrefix marks a learned value as the key of another factoid to alias.
+const factoidAliasPrefix = "@"
+
+type LearnCommand struct {
+	*commandStub
+}
+
+func NewLearnCommand(ctx context.Context, cfg *config.Config, ircs irc.IRC) Command {
+	return &LearnCommand{
+		commandStub: newCommandStub(ctx, cfg, ircs, RoleAdmin, irc.ChannelStatusVoice),
+	}
+}
+
+func (c *LearnCommand) Name() string {
+	return LearnCommandName
+}
+
+func (c *LearnCommand) Description() string {
+	return "Adds or updates a channel factoid. Values can use $nick and $channel. Previous values are kept as versions."
+}
+
+func (c *LearnCommand) Triggers() []string {
+	return []string{"learn", "remember"}
+}
+
+var learnArgs = ArgSpec{
+	Args: []Arg{
+		{Name: "key", Type: ArgTypeString},
+		{Name: "value", Type: ArgTypeText},
+	},
+	Note: "a value of @<other key> makes key an alias of the other factoid",
+}
+
+func (c *LearnCommand) Usages() []string {
+	return []string{learnArgs.Usage()}
+}
+
+func (c *LearnCommand) Arguments() *ArgSpec {
+	return &learnArgs
+}
+
+func (c *LearnCommand) AllowedInPrivateMessages() bool {
+	return false
+}
+
+func (c *LearnCommand) CanExecute(e *irc.Event) bool {
+	return c.isCommandEventValid(c, e, 0)
+}
+
+func (c *LearnCommand) Execute(e *irc.Event, args *Args) {
+	key := models.NormalizeFactoidKey(args.String("key"))
+	value := args.String("value")
+	channel := e.ReplyTarget()
+
+	logger := log.Logger()
+	logger.Infof(e, "⚡ %s [%s/%s] %s %s", c.Name(), e.From, channel, key, value)
+
+	if !models.IsValidFactoidKey(key) {
+		c.Replyf(e, "Factoid keys are up to 32 letters, numbers, dots, dashes and underscores.")
+		return
+	}
+
+	f, err := repository.GetFactoid(e, channel, key)
+	if err != nil {
+		logger.Errorf(e, "error retrieving factoid, %s", err)
+		return
+	}
+
+	aliasOf := ""
+	if len(args.Words("value")) == 1 && strings.HasPrefix(value, factoidAliasPrefix) {
+		aliasOf = models.NormalizeFactoidKey(strings.TrimPrefix(value, factoidAliasPrefix))
+		target, err := repository.ResolveFactoid(e, channel, aliasOf)
+		if err != nil {
+			logger.Errorf(e, "error resolving factoid, %s", err)
+			return
+		}
+		if target == nil || target.Key == key {
+			c.Replyf(e, "There's no %s factoid to alias in %s.", style.Bold(aliasOf), channel)
+			return
+		}
+	}
+
+	learn := func() {
+		if f == nil {
+			f = models.NewFactoid(key, e.From)
+		}
+		if len(aliasOf) > 0 {
+			f.SetAlias(aliasOf, e.From)
+		} else {
+			f.SetValue(value, e.From)
+		}
+
+		if err := repository.SetFactoid(e, channel, f); err != nil {
+			logger.Errorf(e, "error saving factoid, %s", err)
+			return
+		}
+
+		if len(f.Versions) > 1 {
+			c.Replyf(e, "Updated %s (version %d).", style.Bold(key), len(f.Versions))
+		} else {
+			c.Replyf(e, "Learned %s.", style.Bold(key))
+		}
+	}
+
+	if f == nil || !f.Locked {
+		learn()
+		return
+	}
+
+	c.canChangeLockedFactoid(e, func(allowed bool) {
+		if !allowed {
+			c.Replyf(e, "The %s factoid is locked.", style.Bold(key))
+			return
+		}
+		learn()
+	})
+}
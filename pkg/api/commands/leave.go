@@ -49,7 +49,7 @@ func (c *LeaveCommand) CanExecute(e *irc.Event) bool {
 	return c.isCommandEventValid(c, e, 0)
 }
 
-func (c *LeaveCommand) Execute(e *irc.Event) {
+func (c *LeaveCommand) Execute(e *irc.Event, _ *Args) {
 	tokens := Tokens(e.Message())
 	channels := tokens[1:]
 
@@ -6,7 +6,6 @@ import (
 	"assistant/pkg/config"
 	"assistant/pkg/log"
 	"assistant/pkg/models"
-	"assistant/pkg/queue"
 	"strings"
 )
 
@@ -46,7 +45,7 @@ func (c *LLMCommand) CanExecute(e *irc.Event) bool {
 	return c.isCommandEventValid(c, e, 1)
 }
 
-func (c *LLMCommand) Execute(e *irc.Event) {
+func (c *LLMCommand) Execute(e *irc.Event, _ *Args) {
 	logger := log.Logger()
 
 	tokens := Tokens(e.Message())
@@ -57,7 +56,7 @@ func (c *LLMCommand) Execute(e *irc.Event) {
 	logger.Infof(e, "⚡ %s [%s/%s] %s", c.Name(), e.From, e.ReplyTarget(), prompt)
 
 	task := models.NewProxyLLMRequestTask(e.ReplyTarget(), e.From, "llm", prompt)
-	if err := queue.GetProxy().Publish(task); err != nil {
+	if err := c.publishProxy(task); err != nil {
 		logger.Errorf(e, "error publishing LLM request, %s", err)
 		return
 	}
@@ -0,0 +1,88 @@
+package commands
+
+import (
+	"assistant/pkg/api/context"
+	"assistant/pkg/api/irc"
+	"assistant/pkg/config"
+	"assistant/pkg/log"
+	"assistant/pkg/models"
+	"strings"
+)
+
+const MacroCommandName = "macro"
+
+type MacroCommand struct {
+	*commandStub
+}
+
+func NewMacroCommand(ctx context.Context, cfg *config.Config, ircs irc.IRC) Command {
+	return &MacroCommand{
+		commandStub: newCommandStub(ctx, cfg, ircs, RoleAdmin, irc.ChannelStatusOperator),
+	}
+}
+
+func (c *MacroCommand) Name() string {
+	return MacroCommandName
+}
+
+func (c *MacroCommand) Description() string {
+	return "Shows and manages the channel's macros: subreddit searches and fixed replies."
+}
+
+func (c *MacroCommand) Triggers() []string {
+	return []string{"macro", "macros"}
+}
+
+var macroArgs = ArgSpec{
+	Note: "shows the channel's macros",
+	Subcommands: []Subcommand{
+		{Name: customCommandActionAdd, ArgSpec: ArgSpec{
+			Args: []Arg{
+				{Name: "trigger", Type: ArgTypeString},
+				{Name: "type", Type: ArgTypeChoice, Choices: []string{models.CustomCommandTypeReddit, models.CustomCommandTypeReply}},
+				{Name: "value", Type: ArgTypeText},
+			},
+			Note: "reddit searches the subreddit for a recent post on a topic, reply replies with the message, which can use $nick, $1 to $9 and $*",
+		}},
+		{Name: customCommandActionRemove, ArgSpec: ArgSpec{
+			Args: []Arg{
+				{Name: "trigger", Type: ArgTypeString},
+			},
+		}},
+	},
+}
+
+func (c *MacroCommand) Usages() []string {
+	return macroArgs.Usages()
+}
+
+func (c *MacroCommand) Arguments() *ArgSpec {
+	return &macroArgs
+}
+
+func (c *MacroCommand) AllowedInPrivateMessages() bool {
+	return false
+}
+
+func (c *MacroCommand) CanExecute(e *irc.Event) bool {
+	return c.isCommandEventValid(c, e, 0)
+}
+
+func (c *MacroCommand) Execute(e *irc.Event, args *Args) {
+	logger := log.Logger()
+	logger.Infof(e, "⚡ %s [%s/%s] %s", c.Name(), e.From, e.ReplyTarget(), strings.Join(Tokens(e.Message())[1:], " "))
+
+	switch args.Subcommand() {
+	case customCommandActionAdd:
+		macroType := args.String("type")
+		value := args.String("value")
+		if macroType == models.CustomCommandTypeReddit {
+			value = strings.TrimPrefix(value, "r/")
+		}
+		c.addCustomCommand(e, models.NewCustomCommand(c.customTrigger(args.String("trigger")), macroType, value, e.From))
+	case customCommandActionRemove:
+		c.removeCustomCommand(e, c.customTrigger(args.String("trigger")), models.CustomCommandTypeReddit, models.CustomCommandTypeReply)
+	default:
+		c.listCustomCommands(e, "Macros", models.CustomCommandTypeReddit, models.CustomCommandTypeReply)
+	}
+}
@@ -52,7 +52,7 @@ func (c *BingMarketsCommand) CanExecute(e *irc.Event) bool {
 	return c.isCommandEventValid(c, e, 0)
 }
 
-func (c *BingMarketsCommand) Execute(e *irc.Event) {
+func (c *BingMarketsCommand) Execute(e *irc.Event, _ *Args) {
 	tokens := Tokens(e.Message())
 	region := "US"
 	if len(tokens) > 1 {
@@ -54,7 +54,7 @@ func (c *GoogleFinanceMarketsCommand) CanExecute(e *irc.Event) bool {
 	return c.isCommandEventValid(c, e, 0)
 }
 
-func (c *GoogleFinanceMarketsCommand) Execute(e *irc.Event) {
+func (c *GoogleFinanceMarketsCommand) Execute(e *irc.Event, _ *Args) {
 	logger := log.Logger()
 	logger.Infof(e, "⚡ %s [%s/%s]", c.Name(), e.From, e.ReplyTarget())
 
@@ -54,7 +54,7 @@ func (c *MarketDataMarketsCommand) CanExecute(e *irc.Event) bool {
 	return c.isCommandEventValid(c, e, 0)
 }
 
-func (c *MarketDataMarketsCommand) Execute(e *irc.Event) {
+func (c *MarketDataMarketsCommand) Execute(e *irc.Event, _ *Args) {
 	tokens := Tokens(e.Message())
 	region := "US"
 	if len(tokens) > 1 {
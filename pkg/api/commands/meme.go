@@ -80,7 +80,7 @@ var textLabels = []string{"text", "t", "label", "l"}
 var textLabelsPattern = strings.Join(textLabels, "|")
 var textParamRegex = regexp.MustCompile(fmt.Sprintf(`((?:%s):.*?)$`, textLabelsPattern))
 
-func (c *MemeCommand) Execute(e *irc.Event) {
+func (c *MemeCommand) Execute(e *irc.Event, _ *Args) {
 	logger := log.Logger()
 	logger.Infof(e, "⚡ %s [%s/%s] ", c.Name(), e.From, e.ReplyTarget())
 	tokens := Tokens(e.Message())
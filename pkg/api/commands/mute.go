@@ -8,6 +8,7 @@ import (
 	"assistant/pkg/api/style"
 	"assistant/pkg/config"
 	"assistant/pkg/log"
+	"assistant/pkg/models"
 	"strings"
 )
 
@@ -47,7 +48,7 @@ func (c *MuteCommand) CanExecute(e *irc.Event) bool {
 	return c.isCommandEventValid(c, e, 1)
 }
 
-func (c *MuteCommand) Execute(e *irc.Event) {
+func (c *MuteCommand) Execute(e *irc.Event, _ *Args) {
 	logger := log.Logger()
 	tokens := Tokens(e.Message())
 
@@ -105,6 +106,7 @@ func (c *MuteCommand) mute(e *irc.Event, channel, nick, duration, reason string,
 	if isGhostMute {
 		logger.Infof(e, "handling ghost mute of %s command in channel %s", nick, channel)
 		c.irc.Mute(channel, nick)
+		actions.Audit(c.irc, channel, c.actor(e), models.AuditActionMute, nick, "", "")
 		return
 	}
 
@@ -115,7 +117,7 @@ func (c *MuteCommand) mute(e *irc.Event, channel, nick, duration, reason string,
 		}
 
 		go func() {
-			actions.Mute(c.irc, channel, nick, iu.Mask.Host, duration, reason)
+			actions.Mute(c.irc, channel, nick, iu.Mask.Host, duration, reason, c.actor(e))
 		}()
 	})
 }
@@ -47,15 +47,15 @@ func (c *PersonalNoteAddCommand) CanExecute(e *irc.Event) bool {
 	return c.isCommandEventValid(c, e, 1)
 }
 
-func (c *PersonalNoteAddCommand) Execute(e *irc.Event) {
+func (c *PersonalNoteAddCommand) Execute(e *irc.Event, _ *Args) {
 	logger := log.Logger()
 	logger.Infof(e, "⚡ %s [%s/%s] ", c.Name(), e.From, e.ReplyTarget())
-	nick := e.From
+	owner := repository.PersonalNoteOwner(e)
 	tokens := Tokens(e.Message())
 
 	// attempt to perform a lookup if the user accidentally provided an ID
 	if len(tokens) == 2 && personalNoteIDRegex.MatchString(tokens[1]) {
-		n, err := repository.GetPersonalNote(e, nick, tokens[1])
+		n, err := repository.GetPersonalNote(e, owner, tokens[1])
 		if err != nil {
 			logger.Errorf(e, "Error searching for personal note: %v", err)
 			c.Replyf(e, "Sorry, I ran into an error.")
@@ -63,7 +63,7 @@ func (c *PersonalNoteAddCommand) Execute(e *irc.Event) {
 		}
 
 		if n != nil {
-			c.SendMessages(e, e.ReplyTarget(), createPersonalNoteOutputMessages(e, nick, n))
+			c.SendMessages(e, e.ReplyTarget(), createPersonalNoteOutputMessages(e, e.From, n))
 			return
 		}
 	}
@@ -78,7 +78,7 @@ func (c *PersonalNoteAddCommand) Execute(e *irc.Event) {
 
 	n := models.NewPersonalNote(input, url)
 
-	if err := repository.AddPersonalNote(e, e.From, n); err != nil {
+	if err := repository.AddPersonalNote(e, owner, n); err != nil {
 		logger.Errorf(e, "Error adding personal note: %v", err)
 		c.Replyf(e, "Sorry, I couldn't save the personal note.")
 		return
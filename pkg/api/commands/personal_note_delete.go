@@ -45,14 +45,14 @@ func (c *PersonalNoteDeleteCommand) CanExecute(e *irc.Event) bool {
 	return c.isCommandEventValid(c, e, 1)
 }
 
-func (c *PersonalNoteDeleteCommand) Execute(e *irc.Event) {
+func (c *PersonalNoteDeleteCommand) Execute(e *irc.Event, _ *Args) {
 	logger := log.Logger()
 	logger.Infof(e, "⚡ %s [%s/%s] ", c.Name(), e.From, e.ReplyTarget())
-	nick := e.From
+	owner := repository.PersonalNoteOwner(e)
 	tokens := Tokens(e.Message())
 	id := tokens[1]
 
-	if err := repository.DeletePersonalNote(e, nick, id); err != nil {
+	if err := repository.DeletePersonalNote(e, owner, id); err != nil {
 		c.Replyf(e, "Error deleting personal note %s", style.Bold(id))
 		return
 	}
@@ -56,14 +56,14 @@ func (c *PersonalNotesSearchCommand) CanExecute(e *irc.Event) bool {
 
 var personalNoteIDRegex = regexp.MustCompile(`^([a-zA-Z0-9]+)$`)
 
-func (c *PersonalNotesSearchCommand) Execute(e *irc.Event) {
+func (c *PersonalNotesSearchCommand) Execute(e *irc.Event, _ *Args) {
 	logger := log.Logger()
 	logger.Infof(e, "⚡ %s [%s/%s] ", c.Name(), e.From, e.ReplyTarget())
-	nick := e.From
+	owner := repository.PersonalNoteOwner(e)
 	tokens := Tokens(e.Message())
 
 	if len(tokens) == 2 && personalNoteIDRegex.MatchString(tokens[1]) {
-		n, err := repository.GetPersonalNote(e, nick, tokens[1])
+		n, err := repository.GetPersonalNote(e, owner, tokens[1])
 		if err != nil {
 			logger.Errorf(e, "Error searching for personal note: %v", err)
 			c.Replyf(e, "Sorry, I ran into an error searching for personal note %s.", style.Bold(tokens[1]))
@@ -71,7 +71,7 @@ func (c *PersonalNotesSearchCommand) Execute(e *irc.Event) {
 		}
 
 		if n != nil {
-			c.SendMessages(e, e.ReplyTarget(), createPersonalNoteOutputMessages(e, nick, n))
+			c.SendMessages(e, e.ReplyTarget(), createPersonalNoteOutputMessages(e, e.From, n))
 			return
 		}
 	}
@@ -89,11 +89,11 @@ func (c *PersonalNotesSearchCommand) Execute(e *irc.Event) {
 	var notes []*models.PersonalNote
 	var err error
 	if len(keywords) > 0 {
-		notes, err = repository.GetPersonalNotesMatchingKeywords(e, e.From, keywords)
+		notes, err = repository.GetPersonalNotesMatchingKeywords(e, owner, keywords)
 	} else if len(url) > 0 {
-		notes, err = repository.GetPersonalNotesMatchingSource(e, e.From, url)
+		notes, err = repository.GetPersonalNotesMatchingSource(e, owner, url)
 	} else {
-		notes, err = repository.GetPersonalNotes(e, e.From)
+		notes, err = repository.GetPersonalNotes(e, owner)
 	}
 
 	if err != nil {
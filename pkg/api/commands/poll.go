@@ -0,0 +1,361 @@
+package commands
+
+import (
+	"assistant/pkg/api/context"
+	"assistant/pkg/api/elapse"
+	"assistant/pkg/api/irc"
+	"assistant/pkg/api/repository"
+	"assistant/pkg/api/style"
+	"assistant/pkg/config"
+	"assistant/pkg/log"
+	"assistant/pkg/models"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+const PollCommandName = "poll"
+
+const (
+	pollActionClose   = "close"
+	pollActionEnd     = "end"
+	pollActionResults = "results"
+	pollActionList    = "list"
+)
+
+const (
+	minPollDuration = time.Minute
+	maxPollsListed  = 5
+)
+
+var (
+	quotedPollQuestionRegexp = regexp.MustCompile(`^"([^"]+)"\s*(.*)$`)
+	pollQuestionRegexp       = regexp.MustCompile(`^(.+?\?)\s+(.*)$`)
+)
+
+type PollCommand struct {
+	*commandStub
+}
+
+func NewPollCommand(ctx context.Context, cfg *config.Config, ircs irc.IRC) Command {
+	return &PollCommand{
+		commandStub: defaultCommandStub(ctx, cfg, ircs),
+	}
+}
+
+func (c *PollCommand) Name() string {
+	return PollCommandName
+}
+
+func (c *PollCommand) Description() string {
+	return "Runs a channel poll, voted on with vote. A poll can close after a duration and allow several choices. Without arguments, shows the open poll and its votes so far."
+}
+
+func (c *PollCommand) Triggers() []string {
+	return []string{"poll"}
+}
+
+const pollExample = `%s 10m "Where should we eat?" pizza | sushi | tacos`
+
+var pollArgs = ArgSpec{
+	Args: []Arg{
+		{Name: "multi", Type: ArgTypeChoice, Choices: []string{"multi", "multiple"}, Optional: true},
+		{Name: "duration", Type: ArgTypeDuration, Optional: true},
+		{Name: "poll", Type: ArgTypeText, Optional: true},
+	},
+	Note: "a quoted question or one ending in ?, then options separated by |",
+	Subcommands: []Subcommand{
+		{Name: pollActionClose, Aliases: []string{pollActionEnd}},
+		{Name: pollActionResults, ArgSpec: ArgSpec{
+			Args: []Arg{
+				{Name: "id", Type: ArgTypeString, Optional: true},
+			},
+		}},
+		{Name: pollActionList},
+	},
+}
+
+func (c *PollCommand) Usages() []string {
+	return slices.Insert(pollArgs.Usages(), 1, pollExample)
+}
+
+func (c *PollCommand) Arguments() *ArgSpec {
+	return &pollArgs
+}
+
+func (c *PollCommand) AllowedInPrivateMessages() bool {
+	return false
+}
+
+func (c *PollCommand) CanExecute(e *irc.Event) bool {
+	return c.isCommandEventValid(c, e, 0)
+}
+
+func (c *PollCommand) Execute(e *irc.Event, args *Args) {
+	channel := e.ReplyTarget()
+
+	logger := log.Logger()
+	logger.Infof(e, "⚡ %s [%s/%s] %s", c.Name(), e.From, channel, strings.Join(Tokens(e.Message())[1:], " "))
+
+	switch args.Subcommand() {
+	case pollActionClose:
+		c.closePoll(e, channel)
+	case pollActionResults:
+		c.showResults(e, channel, args.String("id"))
+	case pollActionList:
+		c.listPolls(e, channel)
+	default:
+		if !args.Has("multi") && !args.Has("duration") && !args.Has("poll") {
+			c.showOpenPoll(e, channel)
+			return
+		}
+		c.createPoll(e, channel, args)
+	}
+}
+
+func (c *PollCommand) createPoll(e *irc.Event, channel string, args *Args) {
+	logger := log.Logger()
+
+	trigger := Tokens(e.Message())[0]
+	multiple := args.Has("multi")
+	duration := args.Duration("duration")
+
+	question, options, ok := parsePoll(args.String("poll"))
+	if !ok {
+		c.Replyf(e, "Please give a question and options, such as %s", style.Italics(fmt.Sprintf(pollExample, trigger)))
+		return
+	}
+
+	if len(options) < models.MinPollOptions || len(options) > models.MaxPollOptions {
+		c.Replyf(e, "A poll needs between %d and %d options, separated by %s.", models.MinPollOptions, models.MaxPollOptions, style.Bold("|"))
+		return
+	}
+
+	if duration != 0 && (duration < minPollDuration || duration > models.MaxPollDuration) {
+		c.Replyf(e, "A poll can stay open for between a minute and a week.")
+		return
+	}
+
+	open, err := repository.GetOpenPoll(e, channel)
+	if err != nil {
+		logger.Errorf(e, "error retrieving open poll, %s", err)
+		return
+	}
+	if open != nil {
+		c.Replyf(e, "There's already an open poll in %s: %s. Close it with %s first.", channel, style.Bold(open.Question), style.Italics(fmt.Sprintf("%s %s", trigger, pollActionClose)))
+		return
+	}
+
+	poll := models.NewPoll(question, options, multiple, e.From)
+
+	var task *models.Task
+	if duration != 0 {
+		poll.ClosesAt = time.Now().Add(duration)
+		task = models.NewPollCloseTask(poll.ClosesAt, channel, poll.ID)
+		poll.CloseTaskID = task.ID
+	}
+
+	if err := repository.AddPoll(e, channel, poll); err != nil {
+		logger.Errorf(e, "error adding poll, %s", err)
+		return
+	}
+
+	if task != nil {
+		if err := c.store().AddTask(task); err != nil {
+			logger.Errorf(e, "error scheduling close of poll %s, %s", poll.ID, err)
+		}
+	}
+
+	message := fmt.Sprintf("%s asks: %s %s", e.From, style.Bold(poll.Question), pollOptions(poll))
+
+	how := fmt.Sprintf("vote with %s%s <number>", c.cfg.Commands.Prefix, c.registry().Command(VoteCommandName).Triggers()[0])
+	if multiple {
+		how += ", choosing as many as you like"
+	}
+	if !poll.ClosesAt.IsZero() {
+		how += fmt.Sprintf(", closes %s", elapse.TimeDescription(poll.ClosesAt))
+	}
+
+	c.SendMessages(e, channel, []string{message, fmt.Sprintf("Poll %s: %s", style.Bold(poll.ID), how)})
+}
+
+func (c *PollCommand) showOpenPoll(e *irc.Event, channel string) {
+	poll, err := repository.GetOpenPoll(e, channel)
+	if err != nil {
+		log.Logger().Errorf(e, "error retrieving open poll, %s", err)
+		return
+	}
+	if poll == nil {
+		c.Replyf(e, "There's no open poll in %s.", channel)
+		return
+	}
+
+	c.SendMessage(e, channel, FormatPollTally(poll))
+}
+
+func (c *PollCommand) closePoll(e *irc.Event, channel string) {
+	logger := log.Logger()
+
+	poll, err := repository.GetOpenPoll(e, channel)
+	if err != nil {
+		logger.Errorf(e, "error retrieving open poll, %s", err)
+		return
+	}
+	if poll == nil {
+		c.Replyf(e, "There's no open poll in %s.", channel)
+		return
+	}
+
+	closePoll := func() {
+		poll.Close(e.From)
+		if err := repository.ClosePoll(e, channel, poll); err != nil {
+			logger.Errorf(e, "error closing poll, %s", err)
+			return
+		}
+
+		if err := repository.CancelPollCloseTask(e, channel, poll); err != nil {
+			logger.Warningf(e, "error cancelling close of poll %s, %s", poll.ID, err)
+		}
+
+		c.SendMessage(e, channel, FormatPollResults(poll))
+	}
+
+	if poll.CreatedBy == e.From {
+		closePoll()
+		return
+	}
+
+	c.isAdminOrOperator(e, channel, func(allowed bool) {
+		if !allowed {
+			c.Replyf(e, "Only %s or a channel operator can close this poll.", poll.CreatedBy)
+			return
+		}
+		closePoll()
+	})
+}
+
+func (c *PollCommand) showResults(e *irc.Event, channel, id string) {
+	var poll *models.Poll
+	var err error
+	if len(id) > 0 {
+		poll, err = repository.GetPoll(e, channel, strings.ToLower(id))
+	} else {
+		poll, err = repository.GetLastClosedPoll(e, channel)
+	}
+	if err != nil {
+		log.Logger().Errorf(e, "error retrieving poll, %s", err)
+		return
+	}
+
+	if poll == nil {
+		if len(id) > 0 {
+			c.Replyf(e, "There's no poll %s in %s.", style.Bold(id), channel)
+		} else {
+			c.Replyf(e, "There are no closed polls in %s.", channel)
+		}
+		return
+	}
+
+	if poll.IsOpen() {
+		c.SendMessage(e, channel, FormatPollTally(poll))
+		return
+	}
+
+	c.SendMessage(e, channel, FormatPollResults(poll))
+}
+
+func (c *PollCommand) listPolls(e *irc.Event, channel string) {
+	polls, err := repository.GetPolls(e, channel)
+	if err != nil {
+		log.Logger().Errorf(e, "error retrieving polls, %s", err)
+		return
+	}
+
+	if len(polls) == 0 {
+		c.Replyf(e, "There are no polls in %s.", channel)
+		return
+	}
+
+	messages := make([]string, 0, maxPollsListed)
+	for _, poll := range polls[:min(len(polls), maxPollsListed)] {
+		messages = append(messages, fmt.Sprintf("%s: %s (%s, %s, %s)", style.Bold(poll.ID), poll.Question, poll.Status, voters(len(poll.Votes)), elapse.TimeDescription(poll.CreatedAt)))
+	}
+
+	c.SendMessages(e, channel, messages)
+}
+
+// parsePoll splits input into a question, either quoted or ending with a question mark, and the options after it,
+// which are separated by |.
+func parsePoll(input string) (string, []string, bool) {
+	m := quotedPollQuestionRegexp.FindStringSubmatch(input)
+	if m == nil {
+		m = pollQuestionRegexp.FindStringSubmatch(input)
+	}
+	if m == nil || len(strings.TrimSpace(m[1])) == 0 {
+		return "", nil, false
+	}
+
+	options := make([]string, 0)
+	for _, option := range strings.Split(m[2], "|") {
+		option = strings.TrimSpace(option)
+		if len(option) > 0 && !slices.ContainsFunc(options, func(o string) bool { return strings.EqualFold(o, option) }) {
+			options = append(options, option)
+		}
+	}
+
+	return strings.TrimSpace(m[1]), options, true
+}
+
+func pollOptions(poll *models.Poll) string {
+	options := make([]string, len(poll.Options))
+	for i, option := range poll.Options {
+		options[i] = fmt.Sprintf("%d. %s", i+1, option)
+	}
+	return strings.Join(options, " • ")
+}
+
+func pollCounts(poll *models.Poll) string {
+	counts := poll.Tally()
+	options := make([]string, len(poll.Options))
+	for i, option := range poll.Options {
+		options[i] = fmt.Sprintf("%d. %s: %s", i+1, option, style.Bold(fmt.Sprintf("%d", counts[i])))
+	}
+	return strings.Join(options, " • ")
+}
+
+// FormatPollTally describes an open poll and its votes so far.
+func FormatPollTally(poll *models.Poll) string {
+	message := fmt.Sprintf("Poll %s: %s %s (%s so far", style.Bold(poll.ID), style.Bold(poll.Question), pollCounts(poll), voters(len(poll.Votes)))
+	if !poll.ClosesAt.IsZero() {
+		message += fmt.Sprintf(", closes %s", elapse.TimeDescription(poll.ClosesAt))
+	}
+	return message + ")"
+}
+
+// FormatPollResults announces the results of a closed poll.
+func FormatPollResults(poll *models.Poll) string {
+	message := fmt.Sprintf("Poll %s closed: %s %s (%s)", style.Bold(poll.ID), style.Bold(poll.Question), pollCounts(poll), voters(len(poll.Votes)))
+
+	winners := poll.Winners()
+	switch len(winners) {
+	case 0:
+		return message + ". Nobody voted."
+	case 1:
+		return message + fmt.Sprintf(". Winner: %s", style.Bold(poll.Options[winners[0]-1]))
+	default:
+		tied := make([]string, len(winners))
+		for i, w := range winners {
+			tied[i] = poll.Options[w-1]
+		}
+		return message + fmt.Sprintf(". Tie between %s", style.Bold(strings.Join(tied, ", ")))
+	}
+}
+
+func voters(n int) string {
+	if n == 1 {
+		return "1 voter"
+	}
+	return fmt.Sprintf("%d voters", n)
+}
@@ -77,7 +77,7 @@ type polymarketEventResult struct {
 	Markets []polymarketMarketResult `json:"markets"`
 }
 
-func (c *PolymarketCommand) Execute(e *irc.Event) {
+func (c *PolymarketCommand) Execute(e *irc.Event, _ *Args) {
 	logger := log.Logger()
 
 	tokens := Tokens(e.Message())
@@ -81,7 +81,7 @@ type predictItMarketResult struct {
 	} `json:"contracts"`
 }
 
-func (c *PredictItCommand) Execute(e *irc.Event) {
+func (c *PredictItCommand) Execute(e *irc.Event, _ *Args) {
 	logger := log.Logger()
 
 	tokens := Tokens(e.Message())
@@ -6,7 +6,6 @@ import (
 	"assistant/pkg/api/repository"
 	"assistant/pkg/api/style"
 	"assistant/pkg/config"
-	"assistant/pkg/firestore"
 	"assistant/pkg/log"
 	"assistant/pkg/models"
 	"fmt"
@@ -49,7 +48,7 @@ func (c *QuoteAddCommand) CanExecute(e *irc.Event) bool {
 	return c.isCommandEventValid(c, e, 1)
 }
 
-func (c *QuoteAddCommand) Execute(e *irc.Event) {
+func (c *QuoteAddCommand) Execute(e *irc.Event, _ *Args) {
 	logger := log.Logger()
 	logger.Infof(e, "⚡ %s [%s/%s] ", c.Name(), e.From, e.ReplyTarget())
 
@@ -114,7 +113,7 @@ func (c *QuoteAddCommand) Execute(e *irc.Event) {
 		return
 	}
 
-	fs := firestore.Get()
+	fs := c.store()
 	if err = fs.CreateQuote(e.ReplyTarget(), q); err != nil {
 		logger.Errorf(e, "error saving quote: %v", err)
 		if !silent {
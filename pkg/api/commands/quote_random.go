@@ -49,7 +49,7 @@ func (c *QuoteRandomCommand) CanExecute(e *irc.Event) bool {
 	return c.isCommandEventValid(c, e, 0)
 }
 
-func (c *QuoteRandomCommand) Execute(e *irc.Event) {
+func (c *QuoteRandomCommand) Execute(e *irc.Event, _ *Args) {
 	logger := log.Logger()
 	logger.Infof(e, "⚡ %s [%s/%s] ", c.Name(), e.From, e.ReplyTarget())
 	tokens := Tokens(e.Message())
@@ -63,10 +63,10 @@ func (c *QuoteRandomCommand) Execute(e *irc.Event) {
 	var err error
 	if nick == "" {
 		logger.Debugf(e, "Searching for random quote in channel %s", e.ReplyTarget())
-		quotes, err = repository.FindChannelQuotes(e.ReplyTarget())
+		quotes, err = repository.FindChannelQuotes(e, e.ReplyTarget())
 	} else {
 		logger.Debugf(e, "Searching for random quote from user %s in channel %s", nick, e.ReplyTarget())
-		quotes, err = repository.FindUserQuotes(e.ReplyTarget(), nick)
+		quotes, err = repository.FindUserQuotes(e, e.ReplyTarget(), nick)
 		if err != nil {
 			c.Replyf(e, "Unable to find quotes from %s", style.Bold(nick))
 			return
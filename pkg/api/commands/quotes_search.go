@@ -54,7 +54,7 @@ func (c *QuotesSearchCommand) CanExecute(e *irc.Event) bool {
 
 var fromRegex = regexp.MustCompile(`(?:from|by|user|nick|of|author):\s*(.*?)(?:\s|$)`)
 
-func (c *QuotesSearchCommand) Execute(e *irc.Event) {
+func (c *QuotesSearchCommand) Execute(e *irc.Event, _ *Args) {
 	logger := log.Logger()
 	logger.Infof(e, "⚡ %s [%s/%s] ", c.Name(), e.From, e.ReplyTarget())
 
@@ -89,11 +89,11 @@ func (c *QuotesSearchCommand) Execute(e *irc.Event) {
 	var err error
 
 	if len(author) > 0 && len(keywords) > 0 {
-		quotes, err = repository.FindUserQuotesWithContent(e.ReplyTarget(), author, keywords)
+		quotes, err = repository.FindUserQuotesWithContent(e, e.ReplyTarget(), author, keywords)
 	} else if len(author) > 0 {
-		quotes, err = repository.FindUserQuotes(e.ReplyTarget(), author)
+		quotes, err = repository.FindUserQuotes(e, e.ReplyTarget(), author)
 	} else if len(keywords) > 0 {
-		quotes, err = repository.FindQuotes(e.ReplyTarget(), keywords)
+		quotes, err = repository.FindQuotes(e, e.ReplyTarget(), keywords)
 	}
 
 	if err != nil {
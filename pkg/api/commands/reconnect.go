@@ -6,9 +6,7 @@ import (
 	"assistant/pkg/api/irc"
 	"assistant/pkg/api/style"
 	"assistant/pkg/config"
-	"assistant/pkg/firestore"
 	"assistant/pkg/log"
-	"assistant/pkg/models"
 	"fmt"
 	"time"
 )
@@ -49,7 +47,7 @@ func (c *ReconnectCommand) CanExecute(e *irc.Event) bool {
 	return c.isCommandEventValid(c, e, 0)
 }
 
-func (c *ReconnectCommand) Execute(e *irc.Event) {
+func (c *ReconnectCommand) Execute(e *irc.Event, _ *Args) {
 	tokens := Tokens(e.Message())
 	interval := "0s"
 	if len(tokens) >= 2 {
@@ -66,14 +64,7 @@ func (c *ReconnectCommand) Execute(e *irc.Event) {
 		return
 	}
 
-	task := models.NewReconnectTask(time.Now().Add(seconds))
-	err = firestore.Get().AddTask(task)
-	if err != nil {
-		logger.Errorf(e, "error adding task, %s", err)
-		return
-	}
-
-	c.Replyf(e, "disconnecting now, reconnecting %s", style.Bold(elapse.TimeDescription(task.DueAt)))
+	c.Replyf(e, "disconnecting now, reconnecting %s", style.Bold(elapse.TimeDescription(time.Now().Add(seconds))))
 
-	c.irc.Disconnect()
+	c.irc.Reconnect(seconds)
 }
@@ -6,7 +6,6 @@ import (
 	"assistant/pkg/config"
 	"assistant/pkg/log"
 	"assistant/pkg/models"
-	"assistant/pkg/queue"
 	"strings"
 )
 
@@ -46,7 +45,7 @@ func (c *RedditCommand) CanExecute(e *irc.Event) bool {
 	return c.isCommandEventValid(c, e, 2)
 }
 
-func (c *RedditCommand) Execute(e *irc.Event) {
+func (c *RedditCommand) Execute(e *irc.Event, _ *Args) {
 	logger := log.Logger()
 	tokens := Tokens(e.Message())
 
@@ -58,7 +57,7 @@ func (c *RedditCommand) Execute(e *irc.Event) {
 	logger.Infof(e, "⚡ %s [%s/%s] r/%s %s", c.Name(), e.From, e.ReplyTarget(), subreddit, query)
 
 	task := models.NewProxyRedditSearchRequestTask(e.ReplyTarget(), e.From, subreddit, query, models.RedditSearchSortRelevance)
-	if err := queue.GetProxy().Publish(task); err != nil {
+	if err := c.publishProxy(task); err != nil {
 		logger.Errorf(e, "error publishing reddit search request, %s", err)
 	}
 }
@@ -6,7 +6,6 @@ import (
 	"assistant/pkg/config"
 	"assistant/pkg/log"
 	"assistant/pkg/models"
-	"assistant/pkg/queue"
 	"fmt"
 	"strings"
 )
@@ -53,7 +52,7 @@ func (c *RedditTemplateCommand) CanExecute(e *irc.Event) bool {
 	return c.isCommandEventValid(c, e, 1)
 }
 
-func (c *RedditTemplateCommand) Execute(e *irc.Event) {
+func (c *RedditTemplateCommand) Execute(e *irc.Event, _ *Args) {
 	tokens := Tokens(e.Message())
 	query := strings.Join(tokens[1:], " ")
 
@@ -61,7 +60,7 @@ func (c *RedditTemplateCommand) Execute(e *irc.Event) {
 	logger.Infof(e, "⚡ %s [%s/%s] %s", c.Name(), e.From, e.ReplyTarget(), query)
 
 	task := models.NewProxyRedditSearchRequestTask(e.ReplyTarget(), e.From, c.subreddit, query, models.RedditSearchSortNew)
-	if err := queue.GetProxy().Publish(task); err != nil {
+	if err := c.publishProxy(task); err != nil {
 		logger.Errorf(e, "error publishing reddit search request, %s", err)
 	}
 }
@@ -6,9 +6,9 @@ import (
 	"assistant/pkg/api/irc"
 	"assistant/pkg/api/style"
 	"assistant/pkg/config"
-	"assistant/pkg/firestore"
 	"assistant/pkg/log"
 	"assistant/pkg/models"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -16,6 +16,8 @@ import (
 
 const ReminderCommandName = "reminder"
 
+const reminderTimeFormat = "Monday, January 2 at 3:04 PM MST"
+
 type ReminderCommand struct {
 	*commandStub
 }
@@ -31,7 +33,7 @@ func (c *ReminderCommand) Name() string {
 }
 
 func (c *ReminderCommand) Description() string {
-	return "Creates a reminder message to be delivered after the given duration."
+	return "Creates a reminder message to be delivered after the given duration, at the given time or on a recurring schedule, in your time zone (see tz). Channel operators can set reminders for the whole channel."
 }
 
 func (c *ReminderCommand) Triggers() []string {
@@ -39,7 +41,18 @@ func (c *ReminderCommand) Triggers() []string {
 }
 
 func (c *ReminderCommand) Usages() []string {
-	return []string{"%s <duration> <message>"}
+	return []string{
+		"%s [<channel>] <when> <message>",
+		"%s 2h <message>",
+		"%s tomorrow at 9am <message>",
+		"%s next friday 17:00 <message>",
+		"%s on 2026-12-01 noon <message>",
+		"%s in 3 days at 8 <message>",
+		"%s every weekday at 9:00 <message>",
+		"%s every mon, wed and fri at 5pm <message>",
+		"%s cron 0 9 * * 1-5 <message>",
+		"%s #channel every monday at 10am <message>",
+	}
 }
 
 func (c *ReminderCommand) AllowedInPrivateMessages() bool {
@@ -50,27 +63,108 @@ func (c *ReminderCommand) CanExecute(e *irc.Event) bool {
 	return c.isCommandEventValid(c, e, 2)
 }
 
-func (c *ReminderCommand) Execute(e *irc.Event) {
+func (c *ReminderCommand) Execute(e *irc.Event, _ *Args) {
 	logger := log.Logger()
 
 	tokens := Tokens(e.Message())
-	duration := tokens[1]
-	message := strings.Join(tokens[2:], " ")
-	logger.Infof(e, "⚡ %s [%s/%s] @ %s, %s", c.Name(), e.From, e.ReplyTarget(), duration, message)
+	logger.Infof(e, "⚡ %s [%s/%s] %s", c.Name(), e.From, e.ReplyTarget(), strings.Join(tokens[1:], " "))
 
-	seconds, err := elapse.ParseDuration(duration)
-	if err != nil {
-		logger.Errorf(e, "error parsing duration, %s", err)
-		c.Replyf(e, "invalid duration, see %s for help", style.Italics(fmt.Sprintf("%s%s", c.cfg.Commands.Prefix, c.Triggers()[0])))
+	if !irc.IsChannel(tokens[1]) {
+		c.createReminder(e, tokens[0], e.ReplyTarget(), false, tokens[1:])
 		return
 	}
 
-	task := models.NewReminderTask(time.Now().Add(seconds), e.From, e.ReplyTarget(), message)
-	err = firestore.Get().AddTask(task)
-	if err != nil {
+	channel := tokens[1]
+	if len(tokens) < 4 {
+		c.Replyf(e, "Please include a time and a message: %s", style.Italics(fmt.Sprintf(c.Usages()[0], tokens[0])))
+		return
+	}
+
+	c.isAdminOrOperator(e, channel, func(allowed bool) {
+		if !allowed {
+			c.Replyf(e, "Only channel operators can set reminders for %s.", style.Bold(channel))
+			return
+		}
+		c.createReminder(e, tokens[0], channel, true, tokens[2:])
+	})
+}
+
+// createReminder creates a reminder from words that start with its time or schedule and end with its message.
+func (c *ReminderCommand) createReminder(e *irc.Event, trigger, destination string, forChannel bool, words []string) {
+	logger := log.Logger()
+
+	loc := c.userTimeZone(e)
+	dueAt, schedule, n, ok := c.parseReminderTime(e, words, loc)
+	if !ok {
+		return
+	}
+
+	message := strings.Join(words[n:], " ")
+	if len(strings.TrimSpace(message)) == 0 {
+		c.Replyf(e, "Please include a message: %s", style.Italics(fmt.Sprintf(c.Usages()[0], trigger)))
+		return
+	}
+
+	var task *models.Task
+	if schedule != nil {
+		task = models.NewRecurringReminderTask(dueAt, e.From, destination, message, schedule.String(), loc.String())
+	} else {
+		task = models.NewReminderTask(dueAt, e.From, destination, message)
+	}
+
+	if forChannel {
+		data := task.Data.(models.ReminderTaskData)
+		data.ForChannel = true
+		task.Data = data
+	}
+
+	if err := c.store().AddTask(task); err != nil {
 		logger.Errorf(e, "error adding task, %s", err)
 		return
 	}
 
-	c.Replyf(e, "reminder set for %s", style.Bold(elapse.TimeDescription(task.DueAt)))
+	target := ""
+	if forChannel {
+		target = fmt.Sprintf(" for %s", destination)
+	}
+
+	if schedule != nil {
+		c.Replyf(e, "recurring reminder %s%s set for %s, next on %s (%s)", style.Bold(models.ReminderID(task)), target, style.Bold(schedule.String()), style.Bold(dueAt.Format(reminderTimeFormat)), elapse.TimeDescription(task.DueAt))
+		return
+	}
+
+	c.Replyf(e, "reminder %s%s set for %s (%s)", style.Bold(models.ReminderID(task)), target, style.Bold(dueAt.Format(reminderTimeFormat)), elapse.TimeDescription(task.DueAt))
+}
+
+// parseReminderTime parses the recurring schedule or time at the start of words, in the given time zone, returning when
+// the reminder is next due, its schedule if it recurs and how many words were used. Problems are replied to.
+func (cs *commandStub) parseReminderTime(e *irc.Event, words []string, loc *time.Location) (time.Time, elapse.Schedule, int, bool) {
+	now := time.Now().In(loc)
+
+	schedule, n, err := elapse.ParseSchedulePrefix(words)
+	if errors.Is(err, elapse.ErrScheduleTooFrequent) {
+		cs.Replyf(e, "Reminders can't repeat more often than every %d minutes.", int(elapse.MinScheduleInterval.Minutes()))
+		return time.Time{}, nil, 0, false
+	}
+	if err == nil {
+		dueAt := schedule.Next(now)
+		if dueAt.IsZero() {
+			cs.Replyf(e, "That schedule never comes around.")
+			return time.Time{}, nil, 0, false
+		}
+		return dueAt, schedule, n, true
+	}
+
+	dueAt, n, err := elapse.ParseTimePrefix(words, now)
+	if errors.Is(err, elapse.ErrTimeInPast) {
+		cs.Replyf(e, "That time has already passed.")
+		return time.Time{}, nil, 0, false
+	}
+	if err != nil {
+		log.Logger().Errorf(e, "error parsing time, %s", err)
+		cs.Replyf(e, "invalid time, see %s for help", style.Italics(fmt.Sprintf("%s%s %s", cs.cfg.Commands.Prefix, cs.registry().Command(HelpCommandName).Triggers()[0], cs.registry().Command(ReminderCommandName).Triggers()[0])))
+		return time.Time{}, nil, 0, false
+	}
+
+	return dueAt, nil, n, true
 }
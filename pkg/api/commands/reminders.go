@@ -6,11 +6,9 @@ import (
 	"assistant/pkg/api/irc"
 	"assistant/pkg/api/style"
 	"assistant/pkg/config"
-	"assistant/pkg/firestore"
 	"assistant/pkg/log"
 	"assistant/pkg/models"
 	"fmt"
-	"slices"
 	"strconv"
 	"strings"
 )
@@ -21,6 +19,12 @@ const (
 	remindersActionCancel = "cancel"
 	remindersActionRemove = "remove"
 	remindersActionDelete = "delete"
+	remindersActionEdit   = "edit"
+)
+
+const (
+	reminderFieldText = "text"
+	reminderFieldTime = "time"
 )
 
 type RemindersCommand struct {
@@ -38,15 +42,37 @@ func (c *RemindersCommand) Name() string {
 }
 
 func (c *RemindersCommand) Description() string {
-	return "Show or cancel reminders."
+	return "Shows, cancels or edits your reminders. Reminders are referred to by their ID or list number."
 }
 
 func (c *RemindersCommand) Triggers() []string {
 	return []string{"reminders"}
 }
 
+var remindersArgs = ArgSpec{
+	Subcommands: []Subcommand{
+		{Name: remindersActionCancel, Aliases: []string{remindersActionRemove, remindersActionDelete}, ArgSpec: ArgSpec{
+			Args: []Arg{
+				{Name: "id", Type: ArgTypeString},
+			},
+		}},
+		{Name: remindersActionEdit, ArgSpec: ArgSpec{
+			Args: []Arg{
+				{Name: "id", Type: ArgTypeString},
+				{Name: "field", Type: ArgTypeChoice, Choices: []string{reminderFieldText, reminderFieldTime}},
+				{Name: "value", Type: ArgTypeText},
+			},
+			Note: "the reminder's new message or when it's due",
+		}},
+	},
+}
+
 func (c *RemindersCommand) Usages() []string {
-	return []string{"reminders", "reminders cancel <number>"}
+	return remindersArgs.Usages()
+}
+
+func (c *RemindersCommand) Arguments() *ArgSpec {
+	return &remindersArgs
 }
 
 func (c *RemindersCommand) AllowedInPrivateMessages() bool {
@@ -57,81 +83,132 @@ func (c *RemindersCommand) CanExecute(e *irc.Event) bool {
 	return c.isCommandEventValid(c, e, 0)
 }
 
-func (c *RemindersCommand) Execute(e *irc.Event) {
-	tokens := Tokens(e.Message())
-	log.Logger().Infof(e, "⚡ %s [%s/%s] %s", c.Name(), e.From, e.ReplyTarget(), strings.Join(tokens, " "))
+func (c *RemindersCommand) Execute(e *irc.Event, args *Args) {
+	log.Logger().Infof(e, "⚡ %s [%s/%s] %s", c.Name(), e.From, e.ReplyTarget(), e.Message())
 
-	if len(tokens) == 1 {
+	switch args.Subcommand() {
+	case remindersActionCancel:
+		c.cancelReminder(e, args.String("id"))
+	case remindersActionEdit:
+		c.editReminder(e, args.String("id"), args.String("field"), args.Words("value"))
+	default:
 		c.showReminders(e)
+	}
+}
+
+func (c *RemindersCommand) showReminders(e *irc.Event) {
+	reminders, ok := c.pendingReminders(e)
+	if !ok {
 		return
 	}
 
-	action := strings.ToLower(strings.TrimSpace(tokens[1]))
-	if len(tokens) > 2 && len(action) > 0 && slices.Contains([]string{remindersActionCancel, remindersActionRemove, remindersActionDelete}, action) {
-		number, err := strconv.Atoi(strings.ToLower(strings.TrimSpace(tokens[2])))
-		if err != nil {
-			c.Replyf(e, "Invalid reminder number.")
-			return
+	for i, reminder := range reminders {
+		data := reminder.Data.(models.ReminderTaskData)
+
+		details := fmt.Sprintf("due %s", elapse.TimeDescription(reminder.DueAt))
+		if data.IsRecurring() {
+			details += fmt.Sprintf(", %s", data.Recurrence)
+		}
+		if data.ForChannel {
+			details += fmt.Sprintf(", for %s", data.Destination)
 		}
 
-		c.cancelReminder(e, number)
-	} else {
-		c.showReminders(e)
+		c.Replyf(e, "%s: %s (%s)", style.Bold(fmt.Sprintf("%d. Reminder %s", i+1, models.ReminderID(reminder))), data.Content, details)
 	}
 }
 
-func (c *RemindersCommand) showReminders(e *irc.Event) {
-	fs := firestore.Get()
+func (c *RemindersCommand) cancelReminder(e *irc.Event, ref string) {
+	reminder, ok := c.findReminder(e, ref)
+	if !ok {
+		return
+	}
 
-	reminders, err := fs.GetPendingTasks(e.From, e.ReplyTarget(), models.TaskTypeReminder)
-	if err != nil {
-		log.Logger().Errorf(e, "error getting reminders, %s", err)
+	reminder.Status = models.TaskStatusCancelled
+	if err := c.store().CompleteTask(reminder); err != nil {
+		log.Logger().Errorf(e, "error cancelling reminder, %s", err)
 		return
 	}
 
-	if len(reminders) == 0 {
-		if e.IsPrivateMessage() {
-			c.Replyf(e, "No reminders found.")
-		} else {
-			c.Replyf(e, "You have no active reminders in %s.", e.From)
+	c.Replyf(e, "Reminder %s cancelled.", style.Bold(models.ReminderID(reminder)))
+}
+
+func (c *RemindersCommand) editReminder(e *irc.Event, ref, field string, words []string) {
+	reminder, ok := c.findReminder(e, ref)
+	if !ok {
+		return
+	}
+
+	data := reminder.Data.(models.ReminderTaskData)
+
+	switch field {
+	case reminderFieldText:
+		data.Content = strings.Join(words, " ")
+	case reminderFieldTime:
+		loc := c.userTimeZone(e)
+		dueAt, schedule, n, ok := c.parseReminderTime(e, words, loc)
+		if !ok {
+			return
+		}
+		if n < len(words) {
+			c.Replyf(e, "invalid time, %s", style.Italics(strings.Join(words, " ")))
+			return
+		}
+
+		reminder.DueAt = dueAt
+		data.Recurrence, data.TimeZone = "", ""
+		if schedule != nil {
+			data.Recurrence, data.TimeZone = schedule.String(), loc.String()
 		}
+	}
+
+	// the scheduled delivery carries the reminder, so replace it even when only the text changed
+	reminder.Data = data
+	if err := c.store().RescheduleTask(reminder); err != nil {
+		log.Logger().Errorf(e, "error rescheduling reminder, %s", err)
 		return
 	}
 
-	for i, reminder := range reminders {
-		data := reminder.Data.(models.ReminderTaskData)
-		c.Replyf(e, "%s: %s (due %s)", style.Bold(fmt.Sprintf("Reminder %d", i+1)), data.Content, elapse.TimeDescription(reminder.DueAt))
+	c.Replyf(e, "Reminder %s updated, due %s: %s", style.Bold(models.ReminderID(reminder)), reminder.DueAt.In(c.userTimeZone(e)).Format(reminderTimeFormat), data.Content)
+}
+
+// findReminder finds the sender's pending reminder with the given ID or list number, replying if there isn't one.
+func (c *RemindersCommand) findReminder(e *irc.Event, ref string) (*models.Task, bool) {
+	reminders, ok := c.pendingReminders(e)
+	if !ok {
+		return nil, false
+	}
+
+	for _, reminder := range reminders {
+		if strings.EqualFold(models.ReminderID(reminder), ref) {
+			return reminder, true
+		}
+	}
+
+	if number, err := strconv.Atoi(ref); err == nil && number >= 1 && number <= len(reminders) {
+		return reminders[number-1], true
 	}
+
+	c.Replyf(e, "No reminder %s found.", style.Bold(ref))
+	return nil, false
 }
 
-func (c *RemindersCommand) cancelReminder(e *irc.Event, number int) {
-	fs := firestore.Get()
-	reminders, err := fs.GetPendingTasks(e.From, e.ReplyTarget(), models.TaskTypeReminder)
+// pendingReminders returns the sender's pending reminders for the event's channel or private messages, replying if
+// there aren't any.
+func (c *RemindersCommand) pendingReminders(e *irc.Event) ([]*models.Task, bool) {
+	reminders, err := c.store().GetPendingTasks(e.From, e.ReplyTarget(), models.TaskTypeReminder)
 	if err != nil {
 		log.Logger().Errorf(e, "error getting reminders, %s", err)
-		return
+		return nil, false
 	}
 
 	if len(reminders) == 0 {
 		if e.IsPrivateMessage() {
 			c.Replyf(e, "No reminders found.")
 		} else {
-			c.Replyf(e, "You have no active reminders in %s.", e.From)
+			c.Replyf(e, "You have no active reminders in %s.", e.ReplyTarget())
 		}
-		return
-	}
-
-	if number < 1 || number > len(reminders) {
-		c.Replyf(e, "Invalid reminder number.")
-		return
-	}
-
-	reminder := reminders[number-1]
-	reminder.Status = models.TaskStatusCancelled
-	if err := fs.CompleteTask(reminder); err != nil {
-		log.Logger().Errorf(e, "error cancelling reminder, %s", err)
-		return
+		return nil, false
 	}
 
-	c.Replyf(e, "Reminder %d cancelled.", number)
+	return reminders, true
 }
@@ -0,0 +1,56 @@
+package commands
+
+import (
+	"assistant/pkg/api/context"
+	"assistant/pkg/api/irc"
+	"assistant/pkg/config"
+	"assistant/pkg/models"
+)
+
+const RevokeCommandName = "revoke"
+
+type RevokeCommand struct {
+	*commandStub
+}
+
+func NewRevokeCommand(ctx context.Context, cfg *config.Config, ircs irc.IRC) Command {
+	return &RevokeCommand{
+		commandStub: newCommandStub(ctx, cfg, ircs, RoleAdmin, irc.ChannelStatusOperator),
+	}
+}
+
+func (c *RevokeCommand) Name() string {
+	return RevokeCommandName
+}
+
+func (c *RevokeCommand) Description() string {
+	return "Revokes commands from a channel role, so its members can't use them whatever their channel status."
+}
+
+func (c *RevokeCommand) Triggers() []string {
+	return []string{"revoke"}
+}
+
+func (c *RevokeCommand) Usages() []string {
+	return []string{roleCommandsArgs.Usage()}
+}
+
+func (c *RevokeCommand) Arguments() *ArgSpec {
+	return &roleCommandsArgs
+}
+
+func (c *RevokeCommand) AllowedInPrivateMessages() bool {
+	return true
+}
+
+func (c *RevokeCommand) IsAuthorized(e *irc.Event, channel string, callback func(bool)) {
+	c.isAuthorizedInRoleChannel(e, channel, callback)
+}
+
+func (c *RevokeCommand) CanExecute(e *irc.Event) bool {
+	return c.isCommandEventValid(c, e, 0)
+}
+
+func (c *RevokeCommand) Execute(e *irc.Event, args *Args) {
+	c.changeRoleCommands(e, args, models.RoleChangeRevoke)
+}
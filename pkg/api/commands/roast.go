@@ -4,10 +4,8 @@ import (
 	"assistant/pkg/api/context"
 	"assistant/pkg/api/irc"
 	"assistant/pkg/config"
-	"assistant/pkg/firestore"
 	"assistant/pkg/log"
 	"assistant/pkg/models"
-	"assistant/pkg/queue"
 	"fmt"
 	"strings"
 )
@@ -48,7 +46,7 @@ func (c *RoastCommand) CanExecute(e *irc.Event) bool {
 	return c.isCommandEventValid(c, e, 1)
 }
 
-func (c *RoastCommand) Execute(e *irc.Event) {
+func (c *RoastCommand) Execute(e *irc.Event, _ *Args) {
 	logger := log.Logger()
 
 	tokens := Tokens(e.Message())
@@ -57,7 +55,7 @@ func (c *RoastCommand) Execute(e *irc.Event) {
 
 	logger.Infof(e, "⚡ %s [%s/%s] target: %s", c.Name(), e.From, channel, nick)
 
-	fs := firestore.Get()
+	fs := c.store()
 	user, err := fs.GetUserByNick(channel, nick)
 	if err != nil {
 		logger.Errorf(e, "error getting user %s: %s", nick, err)
@@ -75,7 +73,7 @@ func (c *RoastCommand) Execute(e *irc.Event) {
 	prompt := fmt.Sprintf("Target: %s\nRequested by: %s\n\nRecent messages:\n%s", nick, e.From, strings.Join(msgs, "\n"))
 
 	task := models.NewProxyLLMRequestTask(channel, e.From, "roast", prompt)
-	if err := queue.GetProxy().Publish(task); err != nil {
+	if err := c.publishProxy(task); err != nil {
 		logger.Errorf(e, "error publishing roast request: %s", err)
 		return
 	}
@@ -0,0 +1,206 @@
+package commands
+
+import (
+	"assistant/pkg/api/context"
+	"assistant/pkg/api/irc"
+	"assistant/pkg/api/repository"
+	"assistant/pkg/api/style"
+	"assistant/pkg/config"
+	"assistant/pkg/log"
+	"assistant/pkg/models"
+	"fmt"
+	"strings"
+)
+
+const RolesCommandName = "roles"
+
+const (
+	rolesActionCreate   = "create"
+	rolesActionDelete   = "delete"
+	rolesActionAssign   = "assign"
+	rolesActionUnassign = "unassign"
+	rolesActionReset    = "reset"
+)
+
+type RolesCommand struct {
+	*commandStub
+}
+
+func NewRolesCommand(ctx context.Context, cfg *config.Config, ircs irc.IRC) Command {
+	return &RolesCommand{
+		commandStub: newCommandStub(ctx, cfg, ircs, RoleAdmin, irc.ChannelStatusOperator),
+	}
+}
+
+func (c *RolesCommand) Name() string {
+	return RolesCommandName
+}
+
+func (c *RolesCommand) Description() string {
+	return "Shows and manages channel roles: owner, moderator, trusted, regular and custom roles."
+}
+
+func (c *RolesCommand) Triggers() []string {
+	return []string{"roles"}
+}
+
+func (c *RolesCommand) Usages() []string {
+	return []string{
+		"%s [<channel>] (shows roles with their members and commands)",
+		"%s [<channel>] create <role> (creates a custom role)",
+		"%s [<channel>] delete <role> (deletes a custom role)",
+		"%s [<channel>] assign <role> <nick|$a:account|mask> (adds a user to a role, by services account when logged in, otherwise by host)",
+		"%s [<channel>] unassign <role> <nick|$a:account|mask> (removes a user from a role)",
+		"%s [<channel>] reset <role> <command> (clears a grant or revoke)",
+	}
+}
+
+func (c *RolesCommand) AllowedInPrivateMessages() bool {
+	return true
+}
+
+func (c *RolesCommand) IsAuthorized(e *irc.Event, channel string, callback func(bool)) {
+	c.isAuthorizedInRoleChannel(e, channel, callback)
+}
+
+func (c *RolesCommand) CanExecute(e *irc.Event) bool {
+	return c.isCommandEventValid(c, e, 0)
+}
+
+func (c *RolesCommand) Execute(e *irc.Event, _ *Args) {
+	tokens := Tokens(e.Message())
+	channel, input, ok := c.roleCommandChannel(e, tokens)
+	if !ok {
+		return
+	}
+
+	logger := log.Logger()
+	logger.Infof(e, "⚡ %s [%s/%s] %s %s", c.Name(), e.From, e.ReplyTarget(), channel, strings.Join(input, " "))
+
+	ch, err := repository.GetChannel(e, channel)
+	if err != nil {
+		logger.Errorf(e, "error retrieving channel, %s", err)
+		return
+	}
+
+	if len(input) == 0 {
+		c.listRoles(e, ch)
+		return
+	}
+
+	action := strings.ToLower(input[0])
+	if len(input) < 2 || (action != rolesActionCreate && action != rolesActionDelete && len(input) < 3) {
+		c.Replyf(e, "Invalid number of arguments for %s. See %s for more information.", style.Bold(action), style.Italics(fmt.Sprintf("%s%s %s", c.cfg.Commands.Prefix, c.registry().Command(HelpCommandName).Triggers()[0], c.Triggers()[0])))
+		return
+	}
+
+	role := strings.ToLower(input[1])
+	if role == models.ChannelRoleOwner && (action == rolesActionAssign || action == rolesActionUnassign) {
+		if nick, _ := e.Sender(); !c.authorizer.IsUserAuthorizedByRole(nick, RoleAdmin) {
+			c.Replyf(e, "Only the bot's owner and admins can change the members of the %s role.", style.Bold(role))
+			return
+		}
+	}
+
+	var change *models.RoleChange
+	var reply string
+
+	switch action {
+	case rolesActionCreate:
+		if !ch.CreateRole(role) {
+			c.Replyf(e, "The %s role already exists in %s.", style.Bold(role), style.Bold(channel))
+			return
+		}
+		change = models.NewRoleChange(e.From, models.RoleChangeCreate, role, "")
+		reply = fmt.Sprintf("Created the %s role in %s", style.Bold(role), style.Bold(channel))
+	case rolesActionDelete:
+		if !ch.DeleteRole(role) {
+			c.Replyf(e, "There is no custom %s role in %s.", style.Bold(role), style.Bold(channel))
+			return
+		}
+		change = models.NewRoleChange(e.From, models.RoleChangeDelete, role, "")
+		reply = fmt.Sprintf("Deleted the %s role in %s", style.Bold(role), style.Bold(channel))
+	case rolesActionAssign:
+		member := c.roleMember(channel, input[2])
+		if len(member) == 0 {
+			c.Replyf(e, "Unable to find %s in %s. Give a services account as %s or a host mask instead.", style.Bold(input[2]), style.Bold(channel), style.Italics(models.AccountMemberPrefix+"account"))
+			return
+		}
+		if !ch.HasRole(role) || !ch.AssignRole(role, member) {
+			c.Replyf(e, "Unable to add %s to the %s role in %s.", style.Bold(input[2]), style.Bold(role), style.Bold(channel))
+			return
+		}
+		change = models.NewRoleChange(e.From, models.RoleChangeAssign, role, member)
+		reply = fmt.Sprintf("Added %s to the %s role in %s", style.Bold(member), style.Bold(role), style.Bold(channel))
+	case rolesActionUnassign:
+		member := input[2]
+		removed := ch.UnassignRole(role, member)
+		if resolved := c.roleMember(channel, input[2]); !removed && len(resolved) > 0 && resolved != member {
+			member = resolved
+			removed = ch.UnassignRole(role, member)
+		}
+		if !removed {
+			c.Replyf(e, "%s doesn't have the %s role in %s.", style.Bold(input[2]), style.Bold(role), style.Bold(channel))
+			return
+		}
+		change = models.NewRoleChange(e.From, models.RoleChangeUnassign, role, member)
+		reply = fmt.Sprintf("Removed %s from the %s role in %s", style.Bold(member), style.Bold(role), style.Bold(channel))
+	case rolesActionReset:
+		commands := c.commandNames(input[2:3])
+		if len(commands) == 0 || !ch.ResetCommand(role, commands[0]) {
+			c.Replyf(e, "The %s role in %s has no grant or revoke of %s.", style.Bold(role), style.Bold(channel), style.Bold(input[2]))
+			return
+		}
+		change = models.NewRoleChange(e.From, models.RoleChangeReset, role, commands[0])
+		reply = fmt.Sprintf("Reset %s for the %s role in %s", style.Bold(commands[0]), style.Bold(role), style.Bold(channel))
+	default:
+		c.Replyf(e, "Unknown action %s. See %s for more information.", style.Bold(action), style.Italics(fmt.Sprintf("%s%s %s", c.cfg.Commands.Prefix, c.registry().Command(HelpCommandName).Triggers()[0], c.Triggers()[0])))
+		return
+	}
+
+	if err = repository.UpdateChannelRoles(e, ch); err != nil {
+		return
+	}
+
+	if err = repository.AddRoleChange(e, channel, change); err != nil {
+		reply += ", but the change couldn't be recorded in the role history"
+	}
+	c.SendMessage(e, e.ReplyTarget(), reply)
+}
+
+// roleMember returns how a user is stored as a role member: accounts and host masks as given, and nicks as their
+// services account when logged in, otherwise the host they're using in the channel. Nicks alone aren't stored because
+// anyone can take them. It returns an empty string for a nick that can't be found.
+func (c *RolesCommand) roleMember(channel, input string) string {
+	if strings.HasPrefix(input, models.AccountMemberPrefix) || models.IsRoleMemberMask(input) {
+		return input
+	}
+	if account := c.irc.Account(input); len(account) > 0 {
+		return models.AccountMemberPrefix + account
+	}
+	if user := c.irc.ChannelMember(channel, input); user != nil && len(user.Mask.Host) > 0 {
+		return fmt.Sprintf("*!*@%s", user.Mask.Host)
+	}
+	return ""
+}
+
+func (c *RolesCommand) listRoles(e *irc.Event, ch *models.Channel) {
+	messages := make([]string, 0)
+	for _, r := range ch.AllRoles() {
+		members := "none"
+		if len(r.Members) > 0 {
+			members = strings.Join(r.Members, ", ")
+		}
+
+		message := fmt.Sprintf("%s: %s", style.Bold(r.Name), members)
+		if len(r.Granted) > 0 {
+			message += fmt.Sprintf(" • granted %s", strings.Join(r.Granted, ", "))
+		}
+		if len(r.Revoked) > 0 {
+			message += fmt.Sprintf(" • revoked %s", strings.Join(r.Revoked, ", "))
+		}
+		messages = append(messages, message)
+	}
+
+	c.SendMessages(e, e.ReplyTarget(), messages)
+}
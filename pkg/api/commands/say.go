@@ -5,7 +5,6 @@ import (
 	"assistant/pkg/api/irc"
 	"assistant/pkg/config"
 	"assistant/pkg/log"
-	"strings"
 )
 
 const SayCommandName = "say"
@@ -32,8 +31,19 @@ func (c *SayCommand) Triggers() []string {
 	return []string{"say"}
 }
 
+var sayArgs = ArgSpec{
+	Args: []Arg{
+		{Name: "channel", Type: ArgTypeChannel},
+		{Name: "message", Type: ArgTypeText},
+	},
+}
+
 func (c *SayCommand) Usages() []string {
-	return []string{"%s <channel> <message>"}
+	return []string{sayArgs.Usage()}
+}
+
+func (c *SayCommand) Arguments() *ArgSpec {
+	return &sayArgs
 }
 
 func (c *SayCommand) AllowedInPrivateMessages() bool {
@@ -41,18 +51,12 @@ func (c *SayCommand) AllowedInPrivateMessages() bool {
 }
 
 func (c *SayCommand) CanExecute(e *irc.Event) bool {
-	if !c.isCommandEventValid(c, e, 3) {
-		return false
-	}
-
-	tokens := Tokens(e.Message())
-	return irc.IsChannel(tokens[1])
+	return c.isCommandEventValid(c, e, 0)
 }
 
-func (c *SayCommand) Execute(e *irc.Event) {
-	tokens := Tokens(e.Message())
-	channel := tokens[1]
-	message := strings.Join(tokens[2:], " ")
+func (c *SayCommand) Execute(e *irc.Event, args *Args) {
+	channel := args.String("channel")
+	message := args.String("message")
 
 	logger := log.Logger()
 	logger.Infof(e, "⚡ %s [%s/%s] %s %s", c.Name(), e.From, e.ReplyTarget(), channel, message)
@@ -69,7 +69,7 @@ func (c *SearchCommand) CanExecute(e *irc.Event) bool {
 
 var httpRegex = regexp.MustCompile(`^https?://`)
 
-func (c *SearchCommand) Execute(e *irc.Event) {
+func (c *SearchCommand) Execute(e *irc.Event, _ *Args) {
 	tokens := Tokens(e.Message())
 	input := strings.Join(tokens[1:], " ")
 
@@ -239,7 +239,7 @@ func (c *SearchCommand) searchStartPage(e *irc.Event, input string) (*summaryRes
 func createSearchResultSummary(e *irc.Event, title, url string) *summaryResult {
 	s := createSummaryResult()
 
-	if sc := registry.Command(SummaryCommandName); sc != nil {
+	if sc := registryForNetwork(e.Network).Command(SummaryCommandName); sc != nil {
 		dsc := sc.(*SummaryCommand)
 		if ds, _, err := dsc.domainSummary(e, url); ds != nil && err == nil {
 			s.addMessages(ds.messages...)
@@ -45,7 +45,7 @@ func (c *ShortcutAddCommand) CanExecute(e *irc.Event) bool {
 	return c.isCommandEventValid(c, e, 1)
 }
 
-func (c *ShortcutAddCommand) Execute(e *irc.Event) {
+func (c *ShortcutAddCommand) Execute(e *irc.Event, _ *Args) {
 	tokens := Tokens(e.Message())
 	url := tokens[1]
 
@@ -44,7 +44,7 @@ func (c *ShortcutDeleteCommand) CanExecute(e *irc.Event) bool {
 	return c.isCommandEventValid(c, e, 1)
 }
 
-func (c *ShortcutDeleteCommand) Execute(e *irc.Event) {
+func (c *ShortcutDeleteCommand) Execute(e *irc.Event, _ *Args) {
 	tokens := Tokens(e.Message())
 	id := tokens[1]
 
@@ -45,12 +45,12 @@ func (c *SleepCommand) CanExecute(e *irc.Event) bool {
 	return c.isCommandEventValid(c, e, 0)
 }
 
-func (c *SleepCommand) Execute(e *irc.Event) {
+func (c *SleepCommand) Execute(e *irc.Event, _ *Args) {
 	logger := log.Logger()
 	logger.Infof(e, "⚡ %s [%s/%s]", c.Name(), e.From, e.ReplyTarget())
 
 	wakeTrigger := ""
-	for k, v := range registry.Commands() {
+	for k, v := range c.registry().Commands() {
 		if k == WakeCommandName {
 			if len(v.Triggers()) > 0 {
 				wakeTrigger = fmt.Sprintf("%s%s", c.cfg.Commands.Prefix, v.Triggers()[0])
@@ -0,0 +1,117 @@
+package commands
+
+import (
+	"assistant/pkg/api/context"
+	"assistant/pkg/api/elapse"
+	"assistant/pkg/api/irc"
+	"assistant/pkg/api/style"
+	"assistant/pkg/config"
+	"assistant/pkg/log"
+	"assistant/pkg/models"
+	"errors"
+	"strings"
+	"time"
+)
+
+const SnoozeCommandName = "snooze"
+
+const (
+	// snoozeWindow is how long after a reminder is delivered it can be snoozed.
+	snoozeWindow = time.Hour
+
+	defaultSnoozeDuration = "10m"
+)
+
+type SnoozeCommand struct {
+	*commandStub
+}
+
+func NewSnoozeCommand(ctx context.Context, cfg *config.Config, ircs irc.IRC) Command {
+	return &SnoozeCommand{
+		commandStub: defaultCommandStub(ctx, cfg, ircs),
+	}
+}
+
+func (c *SnoozeCommand) Name() string {
+	return SnoozeCommandName
+}
+
+func (c *SnoozeCommand) Description() string {
+	return "Delivers the reminder you were last given again after the given duration or at the given time, 10 minutes by default."
+}
+
+func (c *SnoozeCommand) Triggers() []string {
+	return []string{"snooze"}
+}
+
+var snoozeArgs = ArgSpec{
+	Args: []Arg{
+		{Name: "when", Type: ArgTypeText, Optional: true, Default: defaultSnoozeDuration},
+	},
+}
+
+func (c *SnoozeCommand) Usages() []string {
+	return []string{snoozeArgs.Usage(), "%s 15m", "%s tomorrow at 9am"}
+}
+
+func (c *SnoozeCommand) Arguments() *ArgSpec {
+	return &snoozeArgs
+}
+
+func (c *SnoozeCommand) AllowedInPrivateMessages() bool {
+	return true
+}
+
+func (c *SnoozeCommand) CanExecute(e *irc.Event) bool {
+	return c.isCommandEventValid(c, e, 0)
+}
+
+func (c *SnoozeCommand) Execute(e *irc.Event, args *Args) {
+	logger := log.Logger()
+
+	words := args.Words("when")
+	logger.Infof(e, "⚡ %s [%s/%s] %s", c.Name(), e.From, e.ReplyTarget(), strings.Join(words, " "))
+
+	dueAt, n, err := elapse.ParseTimePrefix(words, time.Now().In(c.userTimeZone(e)))
+	if errors.Is(err, elapse.ErrTimeInPast) {
+		c.Replyf(e, "That time has already passed.")
+		return
+	}
+	if err != nil || n < len(words) {
+		c.Replyf(e, "invalid time, %s", style.Italics(strings.Join(words, " ")))
+		return
+	}
+
+	fs := c.store()
+	reminder, err := fs.LastDeliveredReminder(e.From, e.ReplyTarget(), time.Now().Add(-snoozeWindow))
+	if err != nil {
+		logger.Errorf(e, "error getting last delivered reminder, %s", err)
+		return
+	}
+	if reminder == nil {
+		c.Replyf(e, "You haven't been given a reminder here in the last hour.")
+		return
+	}
+
+	data := reminder.Data.(models.ReminderTaskData)
+	snoozed := models.NewReminderTask(dueAt, data.User, data.Destination, data.Content)
+	if data.ForChannel {
+		snoozedData := snoozed.Data.(models.ReminderTaskData)
+		snoozedData.ForChannel = true
+		snoozed.Data = snoozedData
+	}
+
+	if err := fs.AddTask(snoozed); err != nil {
+		logger.Errorf(e, "error adding task, %s", err)
+		return
+	}
+
+	// a reminder can only be snoozed once for each time it is delivered
+	data.DeliveredAt = time.Time{}
+	reminder.Data = data
+	if err := fs.SetTask(reminder); err != nil {
+		logger.Warningf(e, "error clearing delivery of reminder %s, %s", reminder.ID, err)
+	}
+
+	c.Replyf(e, "Snoozed %s until %s (%s).", style.Bold(data.Content), style.Bold(dueAt.Format(reminderTimeFormat)), elapse.TimeDescription(dueAt))
+}
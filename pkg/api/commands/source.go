@@ -50,7 +50,7 @@ func (c *SourceCommand) CanExecute(e *irc.Event) bool {
 	return c.isCommandEventValid(c, e, 1)
 }
 
-func (c *SourceCommand) Execute(e *irc.Event) {
+func (c *SourceCommand) Execute(e *irc.Event, _ *Args) {
 	tokens := Tokens(e.Message())
 	input := strings.Join(tokens[1:], " ")
 
@@ -54,7 +54,7 @@ var biasRatingRegexp = regexp.MustCompile(`(?m)(?i)bias rating:([^\n]+)`)
 var factualityReportingRegexp = regexp.MustCompile(`(?m)(?i)factual reporting:([^\n]+)`)
 var credibilityRegexp = regexp.MustCompile(`(?m)(?i).*?credibility rating:([^\n]+)`)
 
-func (c *SourceAddMBFCCommand) Execute(e *irc.Event) {
+func (c *SourceAddMBFCCommand) Execute(e *irc.Event, _ *Args) {
 	logger := log.Logger()
 	tokens := Tokens(e.Message())
 
@@ -54,7 +54,7 @@ func (c *StockCommand) CanExecute(e *irc.Event) bool {
 	return c.isCommandEventValid(c, e, 1)
 }
 
-func (c *StockCommand) Execute(e *irc.Event) {
+func (c *StockCommand) Execute(e *irc.Event, _ *Args) {
 	tokens := Tokens(e.Message())
 	symbol := strings.Join(tokens[1:], " ")
 
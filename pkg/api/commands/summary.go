@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"assistant/pkg/api/actions"
 	"assistant/pkg/api/context"
 	"assistant/pkg/api/elapse"
 	"assistant/pkg/api/irc"
@@ -9,10 +10,9 @@ import (
 	"assistant/pkg/api/style"
 	"assistant/pkg/api/summary"
 	"assistant/pkg/config"
-	"assistant/pkg/firestore"
 	"assistant/pkg/log"
 	"assistant/pkg/models"
-	"assistant/pkg/queue"
+	"assistant/pkg/storage"
 	"bytes"
 	"errors"
 	"fmt"
@@ -89,6 +89,10 @@ type SummaryCommand struct {
 	docRetriever  retriever.DocumentRetriever
 	userPausesMu  sync.RWMutex
 	userPauses    map[string]UserPause
+	dsfOnce       sync.Once
+	dsf           map[string]func(e *irc.Event, url string) (*summaryResult, *models.Source, error)
+	csfOnce       sync.Once
+	csf           map[string]func(e *irc.Event, doc *retriever.Document) (*summaryResult, error)
 }
 
 func NewSummaryCommand(ctx context.Context, cfg *config.Config, irc irc.IRC) Command {
@@ -184,9 +188,9 @@ type urlBundle struct {
 	actual   string
 }
 
-func (c *SummaryCommand) Execute(e *irc.Event) {
+func (c *SummaryCommand) Execute(e *irc.Event, _ *Args) {
 	logger := log.Logger()
-	fs := firestore.Get()
+	fs := c.store()
 	pauseKey := e.From + "@" + e.ReplyTarget()
 
 	channel, err := fs.Channel(e.ReplyTarget())
@@ -258,7 +262,7 @@ func (c *SummaryCommand) Execute(e *irc.Event) {
 		if c.shouldProxyDomainBeforeLocalSummary(ub.url) {
 			logger.Debugf(e, "proxying domain summarization for %s", ub.url)
 			task := models.NewProxySummaryRequestTask(e.ReplyTarget(), e.From, ub.url)
-			if err := queue.GetProxy().Publish(task); err != nil {
+			if err := c.publishProxy(task); err != nil {
 				logger.Errorf(e, "error publishing proxy summary request, %s", err)
 			}
 			return
@@ -271,7 +275,7 @@ func (c *SummaryCommand) Execute(e *irc.Event) {
 		if err != nil {
 			logger.Debugf(e, "domain specific summarization failed for %s, falling back to proxy: %s", ub.url, err)
 			task := models.NewProxySummaryRequestTask(e.ReplyTarget(), e.From, ub.actual)
-			if err := queue.GetProxy().Publish(task); err != nil {
+			if err := c.publishProxy(task); err != nil {
 				logger.Errorf(e, "error publishing proxy summary request for %s: %s", ub.actual, err)
 			}
 		} else if ds != nil {
@@ -280,7 +284,7 @@ func (c *SummaryCommand) Execute(e *irc.Event) {
 		} else {
 			logger.Debugf(e, "domain specific summarization returned nil for %s, falling back to proxy", ub.url)
 			task := models.NewProxySummaryRequestTask(e.ReplyTarget(), e.From, ub.actual)
-			if err := queue.GetProxy().Publish(task); err != nil {
+			if err := c.publishProxy(task); err != nil {
 				logger.Errorf(e, "error publishing proxy summary request for %s: %s", ub.actual, err)
 			}
 		}
@@ -291,7 +295,7 @@ func (c *SummaryCommand) Execute(e *irc.Event) {
 	if err != nil {
 		logger.Debugf(e, "error retrieving document for %s, falling back to proxy: %v", ub.url, err)
 		task := models.NewProxySummaryRequestTask(e.ReplyTarget(), e.From, ub.actual)
-		if err := queue.GetProxy().Publish(task); err != nil {
+		if err := c.publishProxy(task); err != nil {
 			logger.Errorf(e, "error publishing proxy summary request for %s: %s", ub.url, err)
 		}
 		return
@@ -315,7 +319,7 @@ func (c *SummaryCommand) Execute(e *irc.Event) {
 		}
 	}
 
-	if !e.IsPrivateMessage() {
+	if !e.IsPrivateMessage() && !isFeedEvent(e) {
 		pause, paused, existed := c.recordIgnoredSummaryIfPaused(pauseKey, time.Now())
 		if paused {
 			logger.Debugf(e, "ignoring paused summary request from %s in %s", e.From, e.ReplyTarget())
@@ -376,6 +380,13 @@ func (c *SummaryCommand) Execute(e *irc.Event) {
 	}
 }
 
+// isFeedEvent reports whether the event shares an item from a channel's feed rather than a link someone posted, so
+// nobody is paused, credited or penalized for it.
+func isFeedEvent(e *irc.Event) bool {
+	feed, ok := e.Metadata[CommandMetadataFeed].(bool)
+	return ok && feed
+}
+
 func isValidCanonicalLink(original, canonical string) bool {
 	return len(canonical) > 0 && canonical != original && strings.HasPrefix(strings.ToLower(canonical), "https://")
 }
@@ -385,7 +396,7 @@ var escapedHtmlEntityRegex = regexp.MustCompile(`&[a-zA-Z0-9]+;`)
 func (c *SummaryCommand) completeSummary(e *irc.Event, source *models.Source, ub urlBundle, target string, messages []string, dis bool) {
 	logger := log.Logger()
 
-	if !e.IsPrivateMessage() {
+	if !e.IsPrivateMessage() && !isFeedEvent(e) {
 		pause := c.recordCompletedSummary(e.From+"@"+target, target, e.From, time.Now())
 		logPause(e, pause)
 	}
@@ -420,7 +431,7 @@ func (c *SummaryCommand) completeSummary(e *irc.Event, source *models.Source, ub
 		unescapedMessages = append(unescapedMessages, cn...)
 	}
 
-	if !e.IsPrivateMessage() {
+	if !e.IsPrivateMessage() && !isFeedEvent(e) {
 		c.updateUserCredibility(e, target, source, dis)
 		c.updateSourceCitations(e, ub.url, source)
 	}
@@ -447,7 +458,7 @@ func (c *SummaryCommand) updateUserCredibility(e *irc.Event, channel string, sou
 		return
 	}
 
-	u, err := repository.GetUserByNick(e, channel, e.From, true)
+	u, err := repository.GetUserByIdentity(e, channel, e.From, e.Account, true)
 	if err != nil {
 		logger.Errorf(e, "error getting user for credibility update: %v", err)
 		return
@@ -469,7 +480,7 @@ func (c *SummaryCommand) updateUserCredibility(e *irc.Event, channel string, sou
 		logger.Debugf(e, "updating %s credibility for %s in %s", tier, e.From, channel)
 	}
 
-	fs := firestore.Get()
+	fs := c.store()
 	if err := fs.UpdateUser(channel, u, fields); err != nil {
 		logger.Errorf(e, "error updating user credibility: %v", err)
 	}
@@ -477,7 +488,7 @@ func (c *SummaryCommand) updateUserCredibility(e *irc.Event, channel string, sou
 
 func (c *SummaryCommand) updateSourceCitations(e *irc.Event, url string, source *models.Source) {
 	logger := log.Logger()
-	fs := firestore.Get()
+	fs := storage.Get()
 
 	if source != nil {
 		if err := fs.IncrementSourceCitations(source.ID); err != nil {
@@ -502,7 +513,7 @@ func (c *SummaryCommand) combinedSourceSummary(e *irc.Event, ub urlBundle, sourc
 	logger := log.Logger()
 	sourceSummary := ""
 
-	if dis {
+	if dis && !isFeedEvent(e) {
 		logger.Debugf(e, "content is possible disinformation, applying penalty and adding warning")
 		c.addDisinformationPenalty(e, 1)
 	}
@@ -607,9 +618,9 @@ func (c *SummaryCommand) addDisinformationPenalty(e *irc.Event, penalty int) {
 	logger := log.Logger()
 	logger.Debugf(e, "incrementing disinformation penalty for %s in %s by %d", e.From, e.ReplyTarget(), penalty)
 
-	u, err := repository.GetUserByNick(e, e.ReplyTarget(), e.From, false)
+	u, err := repository.GetUserByIdentity(e, e.ReplyTarget(), e.From, e.Account, false)
 	if err != nil {
-		logger.Errorf(e, "error getting user by nick: %v", err)
+		logger.Errorf(e, "error getting user: %v", err)
 		return
 	}
 
@@ -630,28 +641,32 @@ func (c *SummaryCommand) addDisinformationPenalty(e *irc.Event, penalty int) {
 
 	logger.Debug(e, "adding disinformation penalty removal task")
 
-	task := models.NewDisinformationMutePenaltyRemovalTask(time.Now().Add(time.Duration(c.cfg.DisinfoPenalty.TempMuteIntervalMinutes)*time.Minute), e.ReplyTarget(), e.From, penalty)
-	err = firestore.Get().AddTask(task)
+	task := models.NewDisinformationMutePenaltyRemovalTask(time.Now().Add(time.Duration(c.cfg.DisinfoPenalty.TempMuteIntervalMinutes)*time.Minute), e.ReplyTarget(), u.Nick, penalty)
+	err = c.store().AddTask(task)
 	if err != nil {
 		logger.Errorf(e, "error adding mute disinformation penalty removal task, %s", err)
 		return
 	}
 
-	task = models.NewDisinformationBanPenaltyRemovalTask(time.Now().Add(time.Duration(c.cfg.DisinfoPenalty.TempBanIntervalHours)*time.Hour), e.ReplyTarget(), e.From, penalty)
-	err = firestore.Get().AddTask(task)
+	task = models.NewDisinformationBanPenaltyRemovalTask(time.Now().Add(time.Duration(c.cfg.DisinfoPenalty.TempBanIntervalHours)*time.Hour), e.ReplyTarget(), u.Nick, penalty)
+	err = c.store().AddTask(task)
 	if err != nil {
 		logger.Errorf(e, "error adding ban disinformation penalty removal task, %s", err)
 		return
 	}
 
-	if u.ExtendedPenalty >= c.cfg.DisinfoPenalty.TempBanThreshold {
-		c.ExecuteSynthesizedEvent(e, BanCommandName, fmt.Sprintf("%dh %s excessive disinformation threshold reached", c.cfg.DisinfoPenalty.TempBanTimeoutHours, e.From), nil)
+	// with a strike ladder in the channel, the ladder decides what happens rather than the disinformation thresholds
+	by := actions.Actor{Nick: c.cfg.IRC.Nick, Source: models.AuditSourceDisinformation}
+	if penalty > 0 && actions.Strike(c.irc, e.ReplyTarget(), e.From, e.Mask().Host, e.Account, models.StrikeSourceDisinformation, "disinformation", penalty*models.StrikeWeight(models.StrikeSourceDisinformation), by) {
+		logger.Debugf(e, "disinformation strike for %s in %s handled by the strike ladder", e.From, e.ReplyTarget())
+	} else if u.ExtendedPenalty >= c.cfg.DisinfoPenalty.TempBanThreshold {
+		c.ExecuteSynthesizedEvent(e, BanCommandName, fmt.Sprintf("%dh %s excessive disinformation threshold reached", c.cfg.DisinfoPenalty.TempBanTimeoutHours, e.From), map[string]any{CommandMetadataAuditSource: by.Source})
 		u.ExtendedPenalty = 0
 	} else if u.Penalty >= c.cfg.DisinfoPenalty.TempMuteThreshold {
-		c.ExecuteSynthesizedEvent(e, MuteCommandName, fmt.Sprintf("%dm %s disinformation threshold reached", c.cfg.DisinfoPenalty.TempMuteTimeoutMinutes, e.From), nil)
+		c.ExecuteSynthesizedEvent(e, MuteCommandName, fmt.Sprintf("%dm %s disinformation threshold reached", c.cfg.DisinfoPenalty.TempMuteTimeoutMinutes, e.From), map[string]any{CommandMetadataAuditSource: by.Source})
 	}
 
-	fs := firestore.Get()
+	fs := c.store()
 	err = fs.UpdateUser(e.ReplyTarget(), u, map[string]any{"extended_penalty": u.ExtendedPenalty, "penalty": u.Penalty, "updated_at": time.Now()})
 	if err != nil {
 		logger.Errorf(e, "error updating penalties for %s: %v", e.ReplyTarget(), err)
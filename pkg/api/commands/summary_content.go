@@ -6,16 +6,14 @@ import (
 	"strings"
 )
 
-var csf map[string]func(e *irc.Event, doc *retriever.Document) (*summaryResult, error)
-
 func (c *SummaryCommand) contentSummarization() map[string]func(e *irc.Event, doc *retriever.Document) (*summaryResult, error) {
-	if csf == nil {
-		csf = map[string]func(e *irc.Event, doc *retriever.Document) (*summaryResult, error){
+	c.csfOnce.Do(func() {
+		c.csf = map[string]func(e *irc.Event, doc *retriever.Document) (*summaryResult, error){
 			"https://joinmastodon.org/apps": c.parseMastodon,
 		}
-	}
+	})
 
-	return csf
+	return c.csf
 }
 
 func (c *SummaryCommand) contentSummary(e *irc.Event, doc *retriever.Document) (func(e *irc.Event, doc *retriever.Document) (*summaryResult, error), error) {
@@ -4,17 +4,13 @@ import (
 	"assistant/pkg/api/irc"
 	"assistant/pkg/models"
 	"slices"
-	"sync"
 
 	"github.com/bobesa/go-domain-util/domainutil"
 )
 
-var dsfOnce sync.Once
-var dsf map[string]func(e *irc.Event, url string) (*summaryResult, *models.Source, error)
-
 func (c *SummaryCommand) domainSummarization() map[string]func(e *irc.Event, url string) (*summaryResult, *models.Source, error) {
-	dsfOnce.Do(func() {
-		dsf = map[string]func(e *irc.Event, url string) (*summaryResult, *models.Source, error){
+	c.dsfOnce.Do(func() {
+		c.dsf = map[string]func(e *irc.Event, url string) (*summaryResult, *models.Source, error){
 			c.cfg.Web.Domain: c.parseShortcut,
 			"youtube.com":    c.parseYouTube,
 			"youtu.be":       c.parseYouTube,
@@ -33,7 +29,7 @@ func (c *SummaryCommand) domainSummarization() map[string]func(e *irc.Event, url
 		}
 	})
 
-	return dsf
+	return c.dsf
 }
 
 func (c *SummaryCommand) requiresDomainSummary(url string) bool {
@@ -38,6 +38,6 @@ func (c *SummaryCommand) parseShortcut(e *irc.Event, url string) (*summaryResult
 
 	e.Arguments[1] = strings.ReplaceAll(e.Arguments[1], url, source)
 
-	registry.Command(SummaryCommandName).Execute(e)
+	c.registry().Command(SummaryCommandName).Execute(e, nil)
 	return nil, nil, nil
 }
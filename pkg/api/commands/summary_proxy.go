@@ -5,7 +5,6 @@ import (
 	"assistant/pkg/api/retriever"
 	"assistant/pkg/log"
 	"assistant/pkg/models"
-	"assistant/pkg/queue"
 	"sync"
 	"time"
 
@@ -70,7 +69,7 @@ func (c *SummaryCommand) proxySummaryRequest(e *irc.Event, doc *retriever.Docume
 	defer removeProxySummaryWaiter(requestID)
 
 	task := models.NewProxySummaryRequestTaskWithWaiter(requestID, e.ReplyTarget(), e.From, doc.URL)
-	if err := queue.GetProxy().Publish(task); err != nil {
+	if err := c.publishProxy(task); err != nil {
 		logger.Errorf(e, "error publishing proxy summary request: %s", err)
 		return nil, err
 	}
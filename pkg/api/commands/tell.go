@@ -0,0 +1,119 @@
+package commands
+
+import (
+	"assistant/pkg/api/context"
+	"assistant/pkg/api/irc"
+	"assistant/pkg/api/repository"
+	"assistant/pkg/api/style"
+	"assistant/pkg/config"
+	"assistant/pkg/log"
+	"assistant/pkg/models"
+	"strings"
+)
+
+const TellCommandName = "tell"
+
+type TellCommand struct {
+	*commandStub
+}
+
+func NewTellCommand(ctx context.Context, cfg *config.Config, ircs irc.IRC) Command {
+	return &TellCommand{
+		commandStub: defaultCommandStub(ctx, cfg, ircs),
+	}
+}
+
+func (c *TellCommand) Name() string {
+	return TellCommandName
+}
+
+func (c *TellCommand) Description() string {
+	return "Leaves a message for a user, delivered the next time they speak or join the channel."
+}
+
+func (c *TellCommand) Triggers() []string {
+	return []string{"tell"}
+}
+
+var tellArgs = ArgSpec{
+	Args: []Arg{
+		{Name: "nick", Type: ArgTypeNick},
+		{Name: "message", Type: ArgTypeText},
+	},
+}
+
+func (c *TellCommand) Usages() []string {
+	return []string{tellArgs.Usage()}
+}
+
+func (c *TellCommand) Arguments() *ArgSpec {
+	return &tellArgs
+}
+
+func (c *TellCommand) AllowedInPrivateMessages() bool {
+	return false
+}
+
+func (c *TellCommand) CanExecute(e *irc.Event) bool {
+	return c.isCommandEventValid(c, e, 0)
+}
+
+func (c *TellCommand) Execute(e *irc.Event, args *Args) {
+	nick := args.String("nick")
+	message := args.String("message")
+	channel := e.ReplyTarget()
+
+	logger := log.Logger()
+	logger.Infof(e, "⚡ %s [%s/%s] %s %s", c.Name(), e.From, channel, nick, message)
+
+	if strings.EqualFold(nick, e.From) {
+		c.Replyf(e, "You can tell yourself that.")
+		return
+	}
+
+	if strings.EqualFold(nick, c.cfg.IRC.Nick) {
+		c.Replyf(e, "I'm right here.")
+		return
+	}
+
+	recipient, err := repository.GetUserByNick(e, channel, nick, false)
+	if err != nil {
+		logger.Errorf(e, "error retrieving user, %s", err)
+		return
+	}
+	if recipient == nil {
+		c.Replyf(e, "I haven't seen %s in %s.", style.Bold(nick), channel)
+		return
+	}
+
+	tell := models.NewTell(e.From, recipient, message)
+
+	pending, err := repository.GetPendingTells(e, channel, &irc.Mask{Nick: recipient.Nick, UserID: recipient.UserID, Host: recipient.Host, Account: recipient.Account})
+	if err != nil {
+		logger.Errorf(e, "error retrieving pending tells, %s", err)
+		return
+	}
+
+	if len(pending) >= models.MaxPendingTellsPerRecipient {
+		c.Replyf(e, "%s already has too many messages waiting.", style.Bold(recipient.Nick))
+		return
+	}
+
+	fromSender := 0
+	for _, t := range pending {
+		if strings.EqualFold(t.From, e.From) {
+			fromSender++
+		}
+	}
+	if fromSender >= models.MaxPendingTellsPerSender {
+		c.Replyf(e, "You've already left %s %d messages.", style.Bold(recipient.Nick), fromSender)
+		return
+	}
+
+	if err = repository.AddTell(e, channel, tell); err != nil {
+		logger.Errorf(e, "error adding tell, %s", err)
+		return
+	}
+
+	c.Replyf(e, "I'll tell %s when they're next here.", style.Bold(recipient.Nick))
+}
@@ -0,0 +1,161 @@
+package commands
+
+import (
+	"assistant/pkg/api/context"
+	"assistant/pkg/api/elapse"
+	"assistant/pkg/api/irc"
+	"assistant/pkg/api/repository"
+	"assistant/pkg/api/style"
+	"assistant/pkg/config"
+	"assistant/pkg/log"
+	"assistant/pkg/models"
+	"fmt"
+	"strings"
+)
+
+const TellsCommandName = "tells"
+
+const tellsActionClear = "clear"
+
+type TellsCommand struct {
+	*commandStub
+}
+
+func NewTellsCommand(ctx context.Context, cfg *config.Config, ircs irc.IRC) Command {
+	return &TellsCommand{
+		commandStub: defaultCommandStub(ctx, cfg, ircs),
+	}
+}
+
+func (c *TellsCommand) Name() string {
+	return TellsCommandName
+}
+
+func (c *TellsCommand) Description() string {
+	return fmt.Sprintf("Lists or clears the messages waiting for you, or sets whether they're delivered in the channel or privately (%s or %s).", models.TellDeliveryChannel, models.TellDeliveryPrivate)
+}
+
+func (c *TellsCommand) Triggers() []string {
+	return []string{"tells"}
+}
+
+var tellsArgs = ArgSpec{
+	Args: []Arg{
+		{Name: "channel", Type: ArgTypeChannel, Optional: true},
+		{Name: "action", Type: ArgTypeString, Optional: true},
+	},
+}
+
+func (c *TellsCommand) Usages() []string {
+	return []string{
+		"%s [<channel>] (lists the messages waiting for you)",
+		"%s [<channel>] clear",
+		fmt.Sprintf("%%s [<channel>] %s|%s (sets where your messages are delivered)", models.TellDeliveryChannel, models.TellDeliveryPrivate),
+	}
+}
+
+func (c *TellsCommand) Arguments() *ArgSpec {
+	return &tellsArgs
+}
+
+func (c *TellsCommand) AllowedInPrivateMessages() bool {
+	return true
+}
+
+func (c *TellsCommand) CanExecute(e *irc.Event) bool {
+	return c.isCommandEventValid(c, e, 0)
+}
+
+func (c *TellsCommand) Execute(e *irc.Event, args *Args) {
+	channel := args.String("channel")
+	if len(channel) == 0 {
+		if e.IsPrivateMessage() {
+			c.Replyf(e, "Please specify a channel: %s", style.Italics(fmt.Sprintf("%s <channel>", Tokens(e.Message())[0])))
+			return
+		}
+		channel = e.ReplyTarget()
+	}
+
+	action := strings.ToLower(args.String("action"))
+
+	logger := log.Logger()
+	logger.Infof(e, "⚡ %s [%s/%s] %s %s", c.Name(), e.From, e.ReplyTarget(), channel, action)
+
+	switch {
+	case len(action) == 0:
+		c.listTells(e, channel)
+	case action == tellsActionClear:
+		c.clearTells(e, channel)
+	case models.IsValidTellDelivery(action):
+		c.setTellDelivery(e, channel, action)
+	default:
+		c.Replyf(e, "Unknown action %s. See %s for more information.", style.Bold(action), style.Italics(fmt.Sprintf("%s%s %s", c.cfg.Commands.Prefix, c.registry().Command(HelpCommandName).Triggers()[0], c.Triggers()[0])))
+	}
+}
+
+func (c *TellsCommand) listTells(e *irc.Event, channel string) {
+	tells, err := repository.GetPendingTells(e, channel, e.Mask())
+	if err != nil {
+		log.Logger().Errorf(e, "error retrieving pending tells, %s", err)
+		return
+	}
+
+	if len(tells) == 0 {
+		c.Replyf(e, "There are no messages waiting for you in %s.", channel)
+		return
+	}
+
+	messages := make([]string, 0, len(tells))
+	for _, t := range tells {
+		messages = append(messages, fmt.Sprintf("%s, %s: %s", style.Bold(t.From), elapse.PastTimeDescription(t.CreatedAt), t.Message))
+	}
+
+	// pending messages are sent privately, since they're only for the sender
+	c.SendMessages(e, e.From, messages)
+}
+
+func (c *TellsCommand) clearTells(e *irc.Event, channel string) {
+	logger := log.Logger()
+
+	tells, err := repository.GetPendingTells(e, channel, e.Mask())
+	if err != nil {
+		logger.Errorf(e, "error retrieving pending tells, %s", err)
+		return
+	}
+
+	for _, t := range tells {
+		if err = repository.DeleteTell(e, channel, t.ID); err != nil {
+			logger.Errorf(e, "error deleting tell, %s", err)
+			return
+		}
+	}
+
+	label := "messages"
+	if len(tells) == 1 {
+		label = "message"
+	}
+
+	c.Replyf(e, "Cleared %d %s waiting for you in %s.", len(tells), label, channel)
+}
+
+func (c *TellsCommand) setTellDelivery(e *irc.Event, channel, delivery string) {
+	logger := log.Logger()
+
+	u, err := repository.GetUserByIdentity(e, channel, e.From, e.Account, true)
+	if err != nil {
+		logger.Errorf(e, "error retrieving user, %s", err)
+		return
+	}
+
+	u.TellDelivery = delivery
+	if err = repository.UpdateUserTellDelivery(e, channel, u); err != nil {
+		logger.Errorf(e, "error updating tell delivery, %s", err)
+		return
+	}
+
+	if delivery == models.TellDeliveryPrivate {
+		c.Replyf(e, "Messages left for you in %s will be sent privately.", channel)
+	} else {
+		c.Replyf(e, "Messages left for you in %s will be delivered in the channel.", channel)
+	}
+}
@@ -53,7 +53,7 @@ func (c *TimeCommand) CanExecute(e *irc.Event) bool {
 	return c.isCommandEventValid(c, e, 1)
 }
 
-func (c *TimeCommand) Execute(e *irc.Event) {
+func (c *TimeCommand) Execute(e *irc.Event, _ *Args) {
 	logger := log.Logger()
 	tokens := Tokens(e.Message())
 	location := strings.Join(tokens[1:], " ")
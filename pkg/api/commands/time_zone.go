@@ -0,0 +1,134 @@
+package commands
+
+import (
+	"assistant/pkg/api/context"
+	"assistant/pkg/api/irc"
+	"assistant/pkg/api/repository"
+	"assistant/pkg/api/style"
+	"assistant/pkg/config"
+	"assistant/pkg/log"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const TimeZoneCommandName = "time_zone"
+
+type TimeZoneCommand struct {
+	*commandStub
+}
+
+func NewTimeZoneCommand(ctx context.Context, cfg *config.Config, ircs irc.IRC) Command {
+	return &TimeZoneCommand{
+		commandStub: defaultCommandStub(ctx, cfg, ircs),
+	}
+}
+
+func (c *TimeZoneCommand) Name() string {
+	return TimeZoneCommandName
+}
+
+func (c *TimeZoneCommand) Description() string {
+	return "Shows or sets your time zone, used to resolve times given to reminders."
+}
+
+func (c *TimeZoneCommand) Triggers() []string {
+	return []string{"tz", "timezone"}
+}
+
+func (c *TimeZoneCommand) Usages() []string {
+	return []string{"%s [<zone>] (e.g. America/New_York, Europe/Berlin or UTC)"}
+}
+
+func (c *TimeZoneCommand) AllowedInPrivateMessages() bool {
+	return false
+}
+
+func (c *TimeZoneCommand) CanExecute(e *irc.Event) bool {
+	return c.isCommandEventValid(c, e, 0)
+}
+
+func (c *TimeZoneCommand) Execute(e *irc.Event, _ *Args) {
+	tokens := Tokens(e.Message())
+	logger := log.Logger()
+	logger.Infof(e, "⚡ %s [%s/%s] %s", c.Name(), e.From, e.ReplyTarget(), strings.Join(tokens[1:], " "))
+
+	if len(tokens) == 1 {
+		loc := c.userTimeZone(e)
+		c.Replyf(e, "Your time zone is %s, where it's %s.", style.Bold(loc.String()), style.Bold(time.Now().In(loc).Format("3:04 PM MST")))
+		return
+	}
+
+	loc, err := loadTimeZone(tokens[1])
+	if err != nil {
+		c.Replyf(e, "Unknown time zone %s. Please use a name such as %s.", style.Bold(tokens[1]), style.Italics("America/New_York"))
+		return
+	}
+
+	u, err := repository.GetUserByIdentity(e, e.ReplyTarget(), e.From, e.Account, true)
+	if err != nil {
+		logger.Errorf(e, "error retrieving user, %s", err)
+		return
+	}
+
+	u.TimeZone = loc.String()
+	if err = repository.UpdateUserTimeZone(e, e.ReplyTarget(), u); err != nil {
+		logger.Errorf(e, "error updating time zone, %s", err)
+		return
+	}
+
+	c.Replyf(e, "Your time zone is now %s, where it's %s.", style.Bold(loc.String()), style.Bold(time.Now().In(loc).Format("3:04 PM MST")))
+}
+
+// userTimeZone returns the time zone the sender set in the event's channel, or UTC if they haven't set one or the
+// event is a private message.
+func (cs *commandStub) userTimeZone(e *irc.Event) *time.Location {
+	if e.IsPrivateMessage() {
+		return time.UTC
+	}
+
+	u, err := repository.GetUserByIdentity(e, e.ReplyTarget(), e.From, e.Account, false)
+	if err != nil {
+		log.Logger().Errorf(e, "error retrieving user, %s", err)
+		return time.UTC
+	}
+
+	if u == nil || len(u.TimeZone) == 0 {
+		return time.UTC
+	}
+
+	loc, err := time.LoadLocation(u.TimeZone)
+	if err != nil {
+		log.Logger().Warningf(e, "invalid time zone %s for %s, %s", u.TimeZone, u.Nick, err)
+		return time.UTC
+	}
+
+	return loc
+}
+
+// loadTimeZone loads the named IANA time zone, forgiving differences in case such as america/new_york.
+func loadTimeZone(name string) (*time.Location, error) {
+	if strings.EqualFold(name, "utc") {
+		return time.UTC, nil
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err == nil {
+		return loc, nil
+	}
+
+	parts := strings.Split(strings.ToLower(name), "/")
+	for i, part := range parts {
+		words := strings.Split(part, "_")
+		for j, w := range words {
+			if len(w) > 0 {
+				r := []rune(w)
+				r[0] = unicode.ToUpper(r[0])
+				words[j] = string(r)
+			}
+		}
+		parts[i] = strings.Join(words, "_")
+	}
+
+	return time.LoadLocation(strings.Join(parts, "/"))
+}
@@ -50,11 +50,11 @@ func (c *TriviaCommand) CanExecute(e *irc.Event) bool {
 	return c.isCommandEventValid(c, e, 0)
 }
 
-func (c *TriviaCommand) Execute(e *irc.Event) {
+func (c *TriviaCommand) Execute(e *irc.Event, _ *Args) {
 	logger := log.Logger()
 	logger.Infof(e, "⚡ %s [%s/%s]", c.Name(), e.From, e.ReplyTarget())
 
-	mgr := modes.GetManager()
+	mgr := modes.GetManager(c.cfg.IRC.Name)
 
 	if mgr.IsActive(e.ReplyTarget()) {
 		c.Replyf(e, "A game is already in progress.")
@@ -94,6 +94,9 @@ func (c *TriviaCommand) Execute(e *irc.Event) {
 	}
 
 	setupURL := c.cfg.Web.ExternalRootURL + "/trivia/" + url.PathEscape(e.ReplyTarget())
+	if len(c.cfg.IRC.Name) > 0 {
+		setupURL += "?network=" + url.QueryEscape(c.cfg.IRC.Name)
+	}
 	c.SendMessage(e, e.From, fmt.Sprintf("Set up your trivia game: %s", setupURL))
 	c.Replyf(e, "Check your DMs for the trivia setup link!")
 }
@@ -125,7 +128,7 @@ func (c *TriviaCommand) startWithCategory(e *irc.Event, categoryID, categoryName
 		return
 	}
 	cooldown := c.triviaCooldown()
-	if err := modes.GetManager().Activate(mode, cooldown); err != nil {
+	if err := modes.GetManager(c.cfg.IRC.Name).Activate(mode, cooldown); err != nil {
 		c.Replyf(e, "%s", err)
 		return
 	}
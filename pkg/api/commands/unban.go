@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"assistant/pkg/api/actions"
 	"assistant/pkg/api/context"
 	"assistant/pkg/api/irc"
 	"assistant/pkg/config"
@@ -43,7 +44,7 @@ func (c *UnbanCommand) CanExecute(e *irc.Event) bool {
 	return c.isCommandEventValid(c, e, 1)
 }
 
-func (c *UnbanCommand) Execute(e *irc.Event) {
+func (c *UnbanCommand) Execute(e *irc.Event, _ *Args) {
 	tokens := Tokens(e.Message())
 	mask := tokens[1]
 	channel := e.ReplyTarget()
@@ -58,7 +59,6 @@ func (c *UnbanCommand) Execute(e *irc.Event) {
 			return
 		}
 
-		c.irc.Unban(channel, mask)
-		logger.Infof(e, "unbanned %s in %s", mask, channel)
+		actions.Unban(c.irc, channel, mask, c.actor(e))
 	})
 }
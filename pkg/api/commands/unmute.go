@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"assistant/pkg/api/actions"
 	"assistant/pkg/api/context"
 	"assistant/pkg/api/irc"
 	"assistant/pkg/api/repository"
@@ -45,7 +46,7 @@ func (c *UnmuteCommand) CanExecute(e *irc.Event) bool {
 	return c.isCommandEventValid(c, e, 1)
 }
 
-func (c *UnmuteCommand) Execute(e *irc.Event) {
+func (c *UnmuteCommand) Execute(e *irc.Event, _ *Args) {
 	tokens := Tokens(e.Message())
 
 	channel := ""
@@ -77,7 +78,7 @@ func (c *UnmuteCommand) Execute(e *irc.Event) {
 
 		for _, nick := range nicks {
 			repository.RemoveChannelVoiceRequest(e, ch, nick, "")
-			c.irc.Voice(channel, nick)
+			actions.Unmute(c.irc, channel, nick, c.actor(e))
 		}
 
 		if err = repository.UpdateChannelVoiceRequests(e, ch); err != nil {
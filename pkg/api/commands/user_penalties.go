@@ -2,17 +2,23 @@ package commands
 
 import (
 	"assistant/pkg/api/context"
+	"assistant/pkg/api/elapse"
 	"assistant/pkg/api/irc"
 	"assistant/pkg/api/repository"
 	"assistant/pkg/api/style"
 	"assistant/pkg/config"
 	"assistant/pkg/log"
+	"assistant/pkg/models"
 	"assistant/pkg/penalty"
 	"fmt"
+	"strings"
+	"time"
 )
 
 const UserPenaltiesName = "user_penalties"
 
+const maxListedStrikes = 5
+
 type UserPenalties struct {
 	*commandStub
 }
@@ -28,7 +34,7 @@ func (c *UserPenalties) Name() string {
 }
 
 func (c *UserPenalties) Description() string {
-	return "Shows current penalties for a channel user."
+	return "Shows current penalties and recent strikes for a channel user."
 }
 
 func (c *UserPenalties) Triggers() []string {
@@ -47,7 +53,7 @@ func (c *UserPenalties) CanExecute(e *irc.Event) bool {
 	return c.isCommandEventValid(c, e, 1)
 }
 
-func (c *UserPenalties) Execute(e *irc.Event) {
+func (c *UserPenalties) Execute(e *irc.Event, _ *Args) {
 	logger := log.Logger()
 	tokens := Tokens(e.Message())
 
@@ -61,7 +67,7 @@ func (c *UserPenalties) Execute(e *irc.Event) {
 
 	logger.Infof(e, "⚡ %s [%s/%s] %s", c.Name(), e.From, e.ReplyTarget(), channel)
 
-	user, err := repository.GetUserByNick(e, channel, nick, false)
+	user, err := repository.GetUserByIdentity(e, channel, nick, c.irc.Account(nick), false)
 	if err != nil {
 		logger.Errorf(e, "failed to get user %s in channel %s: %v", nick, channel, err)
 		c.SendMessage(e, e.ReplyTarget(), fmt.Sprintf("An error occurred while retrieving penalties for %s in %s.", style.Bold(nick), channel))
@@ -89,5 +95,54 @@ func (c *UserPenalties) Execute(e *irc.Event) {
 		banPctStr = style.ColorForeground(banPctStr, style.ColorYellow)
 	}
 
-	c.SendMessage(e, e.ReplyTarget(), fmt.Sprintf("Penalty status for %s in %s • temporary mute: %s • temporary ban: %s", style.Bold(nick), channel, mutePctStr, banPctStr))
+	strikes, err := repository.GetUserStrikes(e, channel, user.Nick, user.Host, user.Account)
+	if err != nil {
+		logger.Errorf(e, "failed to get strikes for %s in channel %s: %v", nick, channel, err)
+	}
+
+	active := models.ActiveStrikes(strikes, time.Now())
+	strikesStr := describeStrikes(active)
+	if ch, _ := repository.GetChannel(e, channel); ch != nil {
+		if next := nextStrikeStep(ch, active); next != nil {
+			strikesStr += fmt.Sprintf(" (%s at %d)", describeStrikeStep(*next), next.Strikes)
+		}
+	}
+
+	messages := []string{fmt.Sprintf("Penalty status for %s in %s • temporary mute: %s • temporary ban: %s • strikes: %s", style.Bold(nick), channel, mutePctStr, banPctStr, strikesStr)}
+
+	if len(strikes) > 0 {
+		history := make([]string, 0, maxListedStrikes)
+		for _, s := range strikes[:min(len(strikes), maxListedStrikes)] {
+			entry := strings.ReplaceAll(s.Source, "_", " ")
+			if s.Weight != 1 {
+				entry += fmt.Sprintf(" ×%d", s.Weight)
+			}
+			if len(s.Reason) > 0 {
+				entry += ": " + s.Reason
+			}
+			entry += fmt.Sprintf(", %s by %s", elapse.PastTimeDescription(s.CreatedAt), s.CreatedBy)
+			history = append(history, entry)
+		}
+		messages = append(messages, fmt.Sprintf("Active strikes: %s", strings.Join(history, " • ")))
+	}
+
+	c.SendMessages(e, e.ReplyTarget(), messages)
+}
+
+// nextStrikeStep returns the channel's next step on its strike ladder above the active strikes, if any.
+func nextStrikeStep(ch *models.Channel, active int) *models.StrikeStep {
+	var next *models.StrikeStep
+	for i, s := range ch.StrikeLadder {
+		if s.Strikes > active && (next == nil || s.Strikes < next.Strikes) {
+			next = &ch.StrikeLadder[i]
+		}
+	}
+	return next
+}
+
+func describeStrikeStep(s models.StrikeStep) string {
+	if len(s.Duration) > 0 && (s.Action == models.ModerationActionMute || s.Action == models.ModerationActionBan) {
+		return fmt.Sprintf("%s %s", s.Duration, s.Action)
+	}
+	return s.Action
 }
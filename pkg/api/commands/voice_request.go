@@ -6,7 +6,6 @@ import (
 	"assistant/pkg/api/repository"
 	"assistant/pkg/api/style"
 	"assistant/pkg/config"
-	"assistant/pkg/firestore"
 	"assistant/pkg/log"
 	"assistant/pkg/models"
 	"fmt"
@@ -54,7 +53,7 @@ func (c *VoiceRequestCommand) CanExecute(e *irc.Event) bool {
 	return c.isCommandEventValid(c, e, 1)
 }
 
-func (c *VoiceRequestCommand) Execute(e *irc.Event) {
+func (c *VoiceRequestCommand) Execute(e *irc.Event, _ *Args) {
 	if !e.IsPrivateMessage() {
 		return
 	}
@@ -99,7 +98,7 @@ func (c *VoiceRequestCommand) Execute(e *irc.Event) {
 		}
 
 		task := models.NewNotifyVoiceRequestsTask(nextNoonUTC(), channel)
-		err = firestore.Get().AddTask(task)
+		err = c.store().AddTask(task)
 		if err != nil {
 			logger.Errorf(e, "error adding task, %s", err)
 			return
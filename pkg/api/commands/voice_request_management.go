@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"assistant/pkg/api/actions"
 	"assistant/pkg/api/context"
 	"assistant/pkg/api/elapse"
 	"assistant/pkg/api/irc"
@@ -8,6 +9,7 @@ import (
 	"assistant/pkg/api/style"
 	"assistant/pkg/config"
 	"assistant/pkg/log"
+	"assistant/pkg/models"
 	"fmt"
 	"strconv"
 	"strings"
@@ -54,7 +56,7 @@ func (c *VoiceRequestManagementCommand) CanExecute(e *irc.Event) bool {
 	return c.isCommandEventValid(c, e, 0)
 }
 
-func (c *VoiceRequestManagementCommand) Execute(e *irc.Event) {
+func (c *VoiceRequestManagementCommand) Execute(e *irc.Event, _ *Args) {
 	tokens := Tokens(e.Message())
 
 	channel := e.ReplyTarget()
@@ -128,6 +130,7 @@ func (c *VoiceRequestManagementCommand) Execute(e *irc.Event) {
 					if err = repository.UpdateUserIsAutoVoiced(e, channel, u); err != nil {
 						logger.Errorf(e, "error updating user isAutoVoiced, %s", err)
 					}
+					actions.Audit(c.irc, channel, c.actor(e), models.AuditActionAutoVoice, vr.Mask(), "voice request approved", "")
 				}
 			}
 			if err = repository.UpdateChannelVoiceRequests(e, ch); err != nil {
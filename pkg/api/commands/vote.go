@@ -0,0 +1,130 @@
+package commands
+
+import (
+	"assistant/pkg/api/context"
+	"assistant/pkg/api/irc"
+	"assistant/pkg/api/repository"
+	"assistant/pkg/api/style"
+	"assistant/pkg/config"
+	"assistant/pkg/log"
+	"assistant/pkg/models"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const VoteCommandName = "vote"
+
+// votesMu serializes votes, which each read and rewrite a poll's votes.
+var votesMu sync.Mutex
+
+type VoteCommand struct {
+	*commandStub
+}
+
+func NewVoteCommand(ctx context.Context, cfg *config.Config, ircs irc.IRC) Command {
+	return &VoteCommand{
+		commandStub: defaultCommandStub(ctx, cfg, ircs),
+	}
+}
+
+func (c *VoteCommand) Name() string {
+	return VoteCommandName
+}
+
+func (c *VoteCommand) Description() string {
+	return "Votes in the channel's open poll. Voting again changes your vote."
+}
+
+func (c *VoteCommand) Triggers() []string {
+	return []string{"vote"}
+}
+
+var voteArgs = ArgSpec{
+	Args: []Arg{
+		{Name: "numbers", Type: ArgTypeText},
+	},
+	Note: "the numbers of the options, more than one if the poll allows",
+}
+
+func (c *VoteCommand) Usages() []string {
+	return []string{voteArgs.Usage()}
+}
+
+func (c *VoteCommand) Arguments() *ArgSpec {
+	return &voteArgs
+}
+
+func (c *VoteCommand) AllowedInPrivateMessages() bool {
+	return false
+}
+
+func (c *VoteCommand) CanExecute(e *irc.Event) bool {
+	return c.isCommandEventValid(c, e, 0)
+}
+
+func (c *VoteCommand) Execute(e *irc.Event, args *Args) {
+	channel := e.ReplyTarget()
+
+	logger := log.Logger()
+	logger.Infof(e, "⚡ %s [%s/%s] %s", c.Name(), e.From, channel, args.String("numbers"))
+
+	choices := make([]int, 0)
+	for _, field := range strings.FieldsFunc(args.String("numbers"), func(r rune) bool { return r == ' ' || r == ',' }) {
+		choice, err := strconv.Atoi(field)
+		if err != nil {
+			c.Replyf(e, "Please vote with the number of an option: %s", style.Italics(fmt.Sprintf(c.Usages()[0], Tokens(e.Message())[0])))
+			return
+		}
+		choices = append(choices, choice)
+	}
+
+	votesMu.Lock()
+	defer votesMu.Unlock()
+
+	poll, err := repository.GetOpenPoll(e, channel)
+	if err != nil {
+		logger.Errorf(e, "error retrieving open poll, %s", err)
+		return
+	}
+	if poll == nil {
+		c.Replyf(e, "There's no open poll in %s.", channel)
+		return
+	}
+
+	if len(choices) > 1 && !poll.Multiple {
+		c.Replyf(e, "This poll allows only one choice.")
+		return
+	}
+
+	for _, choice := range choices {
+		if choice < 1 || choice > len(poll.Options) {
+			c.Replyf(e, "Please choose an option from 1 to %d.", len(poll.Options))
+			return
+		}
+	}
+
+	changed, err := poll.Vote(models.PollVoter(e.Mask()), e.From, choices)
+	if err != nil {
+		logger.Errorf(e, "error voting in poll %s, %s", poll.ID, err)
+		return
+	}
+
+	if err := repository.UpdatePollVotes(e, channel, poll); err != nil {
+		logger.Errorf(e, "error saving vote, %s", err)
+		return
+	}
+
+	chosen := make([]string, len(choices))
+	for i, choice := range choices {
+		chosen[i] = poll.Options[choice-1]
+	}
+
+	verb := "Voted"
+	if changed {
+		verb = "Changed your vote to"
+	}
+
+	c.Replyf(e, "%s %s. %s", verb, style.Bold(strings.Join(chosen, ", ")), pollCounts(poll))
+}
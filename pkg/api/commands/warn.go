@@ -0,0 +1,124 @@
+package commands
+
+import (
+	"assistant/pkg/api/actions"
+	"assistant/pkg/api/context"
+	"assistant/pkg/api/irc"
+	"assistant/pkg/api/repository"
+	"assistant/pkg/api/style"
+	"assistant/pkg/config"
+	"assistant/pkg/log"
+	"assistant/pkg/models"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const WarnCommandName = "warn"
+
+const maxWarnWeight = 10
+
+type WarnCommand struct {
+	*commandStub
+}
+
+func NewWarnCommand(ctx context.Context, cfg *config.Config, ircs irc.IRC) Command {
+	return &WarnCommand{
+		commandStub: newCommandStub(ctx, cfg, ircs, RoleAdmin, irc.ChannelStatusHalfOperator),
+	}
+}
+
+func (c *WarnCommand) Name() string {
+	return WarnCommandName
+}
+
+func (c *WarnCommand) Description() string {
+	return "Warns the specified user, adding strikes that count towards the channel's strike ladder. If the channel has a ladder, the user's active strikes decide whether they're warned, muted, kicked or banned."
+}
+
+func (c *WarnCommand) Triggers() []string {
+	return []string{"warn"}
+}
+
+func (c *WarnCommand) Usages() []string {
+	return []string{"%s [<channel>] <nick> [<strikes>] [<reason>]"}
+}
+
+func (c *WarnCommand) AllowedInPrivateMessages() bool {
+	return true
+}
+
+func (c *WarnCommand) CanExecute(e *irc.Event) bool {
+	return c.isCommandEventValid(c, e, 1)
+}
+
+func (c *WarnCommand) Execute(e *irc.Event, _ *Args) {
+	logger := log.Logger()
+	tokens := Tokens(e.Message())
+
+	channel := e.ReplyTarget()
+	if len(tokens) > 2 && irc.IsChannel(tokens[1]) {
+		channel = tokens[1]
+		tokens = append(tokens[:1], tokens[2:]...)
+	}
+
+	if !irc.IsChannel(channel) {
+		c.Replyf(e, "Please specify the channel to warn the user in.")
+		return
+	}
+
+	nick := tokens[1]
+	weight := models.StrikeWeight(models.StrikeSourceManual)
+	reasonIdx := 2
+	if len(tokens) > 2 {
+		if n, err := strconv.Atoi(tokens[2]); err == nil {
+			if n < 1 || n > maxWarnWeight {
+				c.Replyf(e, "A warning can add between 1 and %d strikes.", maxWarnWeight)
+				return
+			}
+			weight = n
+			reasonIdx++
+		}
+	}
+
+	reason := strings.Join(tokens[min(reasonIdx, len(tokens)):], " ")
+
+	logger.Infof(e, "⚡ %s [%s/%s] %s %s %d", c.Name(), e.From, e.ReplyTarget(), channel, nick, weight)
+
+	c.authorizer.GetUser(channel, nick, func(iu *irc.User) {
+		if iu == nil {
+			c.Replyf(e, "User %s not found", style.Bold(nick))
+			return
+		}
+
+		go c.warn(e, channel, iu.Mask.Nick, iu.Mask.Host, iu.Mask.Account, reason, weight)
+	})
+}
+
+func (c *WarnCommand) warn(e *irc.Event, channel, nick, host, account, reason string, weight int) {
+	logger := log.Logger()
+
+	// below the ladder's first step, the user is still warned
+	minimum := models.StrikeStep{Action: models.ModerationActionWarn}
+	actions.StrikeAtLeast(c.irc, channel, nick, host, account, models.StrikeSourceManual, reason, reason, weight, minimum, c.actor(e))
+
+	if !e.IsPrivateMessage() {
+		return
+	}
+
+	strikes, err := repository.GetUserStrikes(e, channel, nick, host, account)
+	if err != nil {
+		logger.Errorf(e, "error retrieving strikes for %s, %s", nick, err)
+		return
+	}
+
+	c.Replyf(e, "Warned %s in %s, who now has %s.", style.Bold(nick), channel, describeStrikes(models.ActiveStrikes(strikes, time.Now())))
+}
+
+func describeStrikes(n int) string {
+	if n == 1 {
+		return "1 active strike"
+	}
+	return fmt.Sprintf("%d active strikes", n)
+}
@@ -7,7 +7,6 @@ import (
 	"assistant/pkg/api/style"
 	"assistant/pkg/api/text"
 	"assistant/pkg/config"
-	"assistant/pkg/firestore"
 	"assistant/pkg/log"
 	"assistant/pkg/models"
 	"encoding/json"
@@ -57,7 +56,7 @@ func (c *WeatherCommand) CanExecute(e *irc.Event) bool {
 	return c.isCommandEventValid(c, e, 0)
 }
 
-func (c *WeatherCommand) Execute(e *irc.Event) {
+func (c *WeatherCommand) Execute(e *irc.Event, _ *Args) {
 	logger := log.Logger()
 	tokens := Tokens(e.Message())
 
@@ -115,7 +114,7 @@ func (c *WeatherCommand) Execute(e *irc.Event) {
 	// update user location
 	if user != nil && len(formattedLocation) > 0 {
 		user.Location = formattedLocation
-		if err := firestore.Get().UpdateUser(e.ReplyTarget(), user, map[string]any{"location": formattedLocation}); err != nil {
+		if err := c.store().UpdateUser(e.ReplyTarget(), user, map[string]any{"location": formattedLocation}); err != nil {
 			logger.Errorf(e, "failed to update user location, %v", err)
 		} else {
 			logger.Debugf(e, "updated user location to %s", formattedLocation)
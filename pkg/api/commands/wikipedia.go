@@ -48,7 +48,7 @@ func (c *WikipediaCommand) CanExecute(e *irc.Event) bool {
 	return c.isCommandEventValid(c, e, 1)
 }
 
-func (c *WikipediaCommand) Execute(e *irc.Event) {
+func (c *WikipediaCommand) Execute(e *irc.Event, _ *Args) {
 	tokens := Tokens(e.Message())
 	query := strings.Join(tokens[1:], " ")
 	log.Logger().Infof(e, "⚡ %s [%s/%s] %s", c.Name(), e.From, e.ReplyTarget(), query)
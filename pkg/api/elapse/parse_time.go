@@ -0,0 +1,284 @@
+package elapse
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxTimeExpressionWords is the most words ParseTimePrefix considers part of a time expression.
+const MaxTimeExpressionWords = 6
+
+// defaultHour is the time of day used when an expression names a day but not a time.
+const defaultHour = 9
+
+// ErrTimeInPast is returned for expressions that parse but resolve to a time that has already passed.
+var ErrTimeInPast = errors.New("that time has already passed")
+
+var weekdays = map[string]time.Weekday{
+	"sunday": time.Sunday, "sun": time.Sunday,
+	"monday": time.Monday, "mon": time.Monday,
+	"tuesday": time.Tuesday, "tue": time.Tuesday, "tues": time.Tuesday,
+	"wednesday": time.Wednesday, "wed": time.Wednesday,
+	"thursday": time.Thursday, "thu": time.Thursday, "thur": time.Thursday, "thurs": time.Thursday,
+	"friday": time.Friday, "fri": time.Friday,
+	"saturday": time.Saturday, "sat": time.Saturday,
+}
+
+var months = map[string]time.Month{
+	"january": time.January, "jan": time.January,
+	"february": time.February, "feb": time.February,
+	"march": time.March, "mar": time.March,
+	"april": time.April, "apr": time.April,
+	"may":  time.May,
+	"june": time.June, "jun": time.June,
+	"july": time.July, "jul": time.July,
+	"august": time.August, "aug": time.August,
+	"september": time.September, "sep": time.September, "sept": time.September,
+	"october": time.October, "oct": time.October,
+	"november": time.November, "nov": time.November,
+	"december": time.December, "dec": time.December,
+}
+
+var (
+	relativeTimeRegexp = regexp.MustCompile(`^in (\d+(?:\.\d+)?) ?([a-z]+)(?: (.+))?$`)
+	relativeDayRegexp  = regexp.MustCompile(`^(today|tonight|tomorrow)\b`)
+	weekdayRegexp      = regexp.MustCompile(`^(?:(next|this|on) )?([a-z]+)\b`)
+	isoDateRegexp      = regexp.MustCompile(`^(?:on )?(\d{4})-(\d{2})-(\d{2})\b`)
+	monthDayRegexp     = regexp.MustCompile(`^(?:on )?([a-z]+) (\d{1,2})(?:st|nd|rd|th)?(?:,? (\d{4}))?\b`)
+	dayMonthRegexp     = regexp.MustCompile(`^(?:on )?(\d{1,2})(?:st|nd|rd|th)? ([a-z]+)(?: (\d{4}))?\b`)
+	timeOfDayRegexp    = regexp.MustCompile(`^(?:at )?(?:(noon|midnight)|(\d{1,2})(?::(\d{2}))? ?(am|pm)?)$`)
+)
+
+// ParseTimePrefix finds the longest run of leading words that is a time expression, as understood by ParseTime, and
+// returns the time and how many words it took. Expressions that resolve to the past are reported as ErrTimeInPast
+// rather than skipped.
+func ParseTimePrefix(words []string, now time.Time) (time.Time, int, error) {
+	for n := min(len(words), MaxTimeExpressionWords); n > 0; n-- {
+		t, err := ParseTime(strings.Join(words[:n], " "), now)
+		if err == nil {
+			return t, n, nil
+		}
+		if errors.Is(err, ErrTimeInPast) {
+			return time.Time{}, n, err
+		}
+	}
+
+	return time.Time{}, 0, fmt.Errorf("no time found in %q", strings.Join(words, " "))
+}
+
+// ParseTime resolves a time expression relative to now, in now's location. It understands durations ("2h"), relative
+// times ("in 3 days", "in 3 days at 8"), days ("tomorrow", "next friday", "on 2026-12-01", "dec 1") with an optional
+// time of day ("at 9am", "17:00", "noon"), and a time of day alone, which is the next time the clock shows it. Days
+// without a time of day resolve to 9am.
+func ParseTime(input string, now time.Time) (time.Time, error) {
+	s := strings.Join(strings.Fields(strings.ToLower(input)), " ")
+	if len(s) == 0 {
+		return time.Time{}, errors.New("empty time expression")
+	}
+
+	if IsDuration(s) {
+		d, err := ParseDuration(s)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return now.Add(d), nil
+	}
+
+	if m := relativeTimeRegexp.FindStringSubmatch(s); m != nil {
+		d, err := ParseDuration(m[1] + m[2])
+		if err != nil {
+			return time.Time{}, err
+		}
+		t := now.Add(d)
+		if len(m[3]) == 0 {
+			return t, nil
+		}
+		hour, minute, err := parseTimeOfDay(m[3], true)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return future(atTimeOfDay(t, hour, minute), now)
+	}
+
+	day, rest, matched, err := parseDay(s, now)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if !matched {
+		// a time of day alone needs "at", am/pm or minutes, so that a leading number isn't taken for one
+		hour, minute, err := parseTimeOfDay(s, false)
+		if err != nil {
+			return time.Time{}, err
+		}
+		t := atTimeOfDay(now, hour, minute)
+		if !t.After(now) {
+			t = t.AddDate(0, 0, 1)
+		}
+		return t, nil
+	}
+
+	hour, minute := defaultHour, 0
+	if day.tonight {
+		hour = 20
+	}
+	if len(rest) > 0 {
+		if hour, minute, err = parseTimeOfDay(rest, true); err != nil {
+			return time.Time{}, err
+		}
+	}
+
+	t := atTimeOfDay(day.date, hour, minute)
+	if day.weekday && !day.next && !t.After(now) {
+		t = t.AddDate(0, 0, 7)
+	}
+	if day.yearless && !t.After(now) {
+		t = t.AddDate(1, 0, 0)
+	}
+
+	return future(t, now)
+}
+
+type parsedDay struct {
+	date     time.Time
+	weekday  bool
+	next     bool
+	yearless bool
+	tonight  bool
+}
+
+// parseDay parses the day at the start of s, returning the rest of s and whether there was a day.
+func parseDay(s string, now time.Time) (parsedDay, string, bool, error) {
+	if m := relativeDayRegexp.FindStringSubmatch(s); m != nil {
+		day := parsedDay{date: now, tonight: m[1] == "tonight"}
+		if m[1] == "tomorrow" {
+			day.date = now.AddDate(0, 0, 1)
+		}
+		return day, strings.TrimSpace(s[len(m[0]):]), true, nil
+	}
+
+	if m := isoDateRegexp.FindStringSubmatch(s); m != nil {
+		year, _ := strconv.Atoi(m[1])
+		month, _ := strconv.Atoi(m[2])
+		dom, _ := strconv.Atoi(m[3])
+		date, err := makeDate(year, time.Month(month), dom, now.Location())
+		if err != nil {
+			return parsedDay{}, "", false, err
+		}
+		return parsedDay{date: date}, strings.TrimSpace(s[len(m[0]):]), true, nil
+	}
+
+	if m := monthDayRegexp.FindStringSubmatch(s); m != nil {
+		if month, ok := months[m[1]]; ok {
+			dom, _ := strconv.Atoi(m[2])
+			return yearlessDay(m[0], m[3], month, dom, s, now)
+		}
+	}
+
+	if m := dayMonthRegexp.FindStringSubmatch(s); m != nil {
+		if month, ok := months[m[2]]; ok {
+			dom, _ := strconv.Atoi(m[1])
+			return yearlessDay(m[0], m[3], month, dom, s, now)
+		}
+	}
+
+	if m := weekdayRegexp.FindStringSubmatch(s); m != nil {
+		if weekday, ok := weekdays[m[2]]; ok {
+			ahead := (int(weekday) - int(now.Weekday()) + 7) % 7
+			next := m[1] == "next"
+			if next && ahead == 0 {
+				ahead = 7
+			}
+			day := parsedDay{date: now.AddDate(0, 0, ahead), weekday: true, next: next}
+			return day, strings.TrimSpace(s[len(m[0]):]), true, nil
+		}
+	}
+
+	return parsedDay{}, s, false, nil
+}
+
+func yearlessDay(match, yearInput string, month time.Month, dom int, s string, now time.Time) (parsedDay, string, bool, error) {
+	year := now.Year()
+	if len(yearInput) > 0 {
+		year, _ = strconv.Atoi(yearInput)
+	}
+
+	date, err := makeDate(year, month, dom, now.Location())
+	if err != nil {
+		return parsedDay{}, "", false, err
+	}
+
+	return parsedDay{date: date, yearless: len(yearInput) == 0}, strings.TrimSpace(s[len(match):]), true, nil
+}
+
+func makeDate(year int, month time.Month, dom int, loc *time.Location) (time.Time, error) {
+	date := time.Date(year, month, dom, 0, 0, 0, 0, loc)
+	if date.Month() != month || date.Day() != dom {
+		return time.Time{}, fmt.Errorf("invalid date, %d-%02d-%02d", year, month, dom)
+	}
+	return date, nil
+}
+
+// ParseTimeOfDay parses a time of day such as "9am", "21:30" or "noon", returning its hour and minute. A bare hour is
+// read as on a 24-hour clock.
+func ParseTimeOfDay(input string) (int, int, error) {
+	return parseTimeOfDay(strings.ToLower(strings.TrimSpace(input)), true)
+}
+
+// parseTimeOfDay parses times such as "at 9am", "9:30 pm", "17:00" and "noon". A bare hour is only accepted when
+// allowBareHour is set, and is read as on a 24-hour clock.
+func parseTimeOfDay(s string, allowBareHour bool) (int, int, error) {
+	m := timeOfDayRegexp.FindStringSubmatch(s)
+	if m == nil {
+		return 0, 0, fmt.Errorf("invalid time of day, %s", s)
+	}
+
+	switch m[1] {
+	case "noon":
+		return 12, 0, nil
+	case "midnight":
+		return 0, 0, nil
+	}
+
+	hour, _ := strconv.Atoi(m[2])
+	minute := 0
+	if len(m[3]) > 0 {
+		minute, _ = strconv.Atoi(m[3])
+	}
+
+	if len(m[3]) == 0 && len(m[4]) == 0 && !allowBareHour && !strings.HasPrefix(s, "at ") {
+		return 0, 0, fmt.Errorf("invalid time of day, %s", s)
+	}
+
+	switch m[4] {
+	case "am", "pm":
+		if hour < 1 || hour > 12 {
+			return 0, 0, fmt.Errorf("invalid hour, %d%s", hour, m[4])
+		}
+		hour %= 12
+		if m[4] == "pm" {
+			hour += 12
+		}
+	}
+
+	if hour > 23 || minute > 59 {
+		return 0, 0, fmt.Errorf("invalid time of day, %s", s)
+	}
+
+	return hour, minute, nil
+}
+
+func atTimeOfDay(t time.Time, hour, minute int) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), hour, minute, 0, 0, t.Location())
+}
+
+func future(t, now time.Time) (time.Time, error) {
+	if !t.After(now) {
+		return time.Time{}, ErrTimeInPast
+	}
+	return t, nil
+}
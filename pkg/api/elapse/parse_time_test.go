@@ -0,0 +1,90 @@
+package elapse
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseTime(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data unavailable, %s", err)
+	}
+
+	// a wednesday
+	now := time.Date(2026, time.October, 14, 10, 30, 0, 0, loc)
+
+	tests := []struct {
+		input string
+		want  time.Time
+	}{
+		{"2h", now.Add(2 * time.Hour)},
+		{"in 3 days", now.AddDate(0, 0, 3)},
+		{"in 3 days at 8", time.Date(2026, time.October, 17, 8, 0, 0, 0, loc)},
+		{"tomorrow at 9am", time.Date(2026, time.October, 15, 9, 0, 0, 0, loc)},
+		{"tomorrow", time.Date(2026, time.October, 15, 9, 0, 0, 0, loc)},
+		{"tonight", time.Date(2026, time.October, 14, 20, 0, 0, 0, loc)},
+		{"next friday 17:00", time.Date(2026, time.October, 16, 17, 0, 0, 0, loc)},
+		{"next wednesday", time.Date(2026, time.October, 21, 9, 0, 0, 0, loc)},
+		{"wednesday at 5pm", time.Date(2026, time.October, 14, 17, 0, 0, 0, loc)},
+		{"wednesday at 9am", time.Date(2026, time.October, 21, 9, 0, 0, 0, loc)},
+		{"on 2026-12-01 noon", time.Date(2026, time.December, 1, 12, 0, 0, 0, loc)},
+		{"dec 1 at 6:30 pm", time.Date(2026, time.December, 1, 18, 30, 0, 0, loc)},
+		{"1st march", time.Date(2027, time.March, 1, 9, 0, 0, 0, loc)},
+		{"at 8", time.Date(2026, time.October, 15, 8, 0, 0, 0, loc)},
+		{"9pm", time.Date(2026, time.October, 14, 21, 0, 0, 0, loc)},
+		{"midnight", time.Date(2026, time.October, 15, 0, 0, 0, 0, loc)},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseTime(tt.input, now)
+		if err != nil {
+			t.Errorf("ParseTime(%q) error = %v", tt.input, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("ParseTime(%q) = %s, want %s", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestParseTimeInvalid(t *testing.T) {
+	now := time.Date(2026, time.October, 14, 10, 30, 0, 0, time.UTC)
+
+	for _, input := range []string{"", "8", "soon", "tomorrow at 25:00", "13pm", "feb 30", "next blursday"} {
+		if got, err := ParseTime(input, now); err == nil {
+			t.Errorf("ParseTime(%q) = %s, want error", input, got)
+		}
+	}
+
+	if _, err := ParseTime("on 2020-01-01", now); !errors.Is(err, ErrTimeInPast) {
+		t.Errorf("ParseTime() error = %v, want ErrTimeInPast", err)
+	}
+}
+
+func TestParseTimePrefix(t *testing.T) {
+	now := time.Date(2026, time.October, 14, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		input string
+		words int
+	}{
+		{"tomorrow at 9am call the bank", 3},
+		{"2h check the oven", 1},
+		{"in 3 days at 8 renew the domain", 5},
+		{"friday standup notes", 1},
+	}
+
+	for _, tt := range tests {
+		_, n, err := ParseTimePrefix(strings.Fields(tt.input), now)
+		if err != nil || n != tt.words {
+			t.Errorf("ParseTimePrefix(%q) = %d, %v, want %d words", tt.input, n, err, tt.words)
+		}
+	}
+
+	if _, _, err := ParseTimePrefix(strings.Fields("call the bank"), now); err == nil {
+		t.Errorf("ParseTimePrefix() error = nil, want error")
+	}
+}
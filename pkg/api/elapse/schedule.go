@@ -0,0 +1,462 @@
+package elapse
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxScheduleExpressionWords is the most words ParseSchedulePrefix considers part of a schedule expression.
+const MaxScheduleExpressionWords = 10
+
+// MinScheduleInterval is the shortest time allowed between two occurrences of a schedule.
+const MinScheduleInterval = 15 * time.Minute
+
+// maxScheduleSearch bounds how far ahead Next looks for a matching time, so impossible cron expressions such as
+// "0 0 31 2 *" end rather than loop forever.
+const maxScheduleSearch = 5 * 366 * 24 * time.Hour
+
+const cronTrigger = "cron"
+
+// ErrScheduleTooFrequent is returned for schedules that would repeat more often than MinScheduleInterval.
+var ErrScheduleTooFrequent = fmt.Errorf("schedules can't repeat more often than every %s", MinScheduleInterval)
+
+// Schedule is a recurring schedule, such as "every weekday at 9:00" or a cron expression.
+type Schedule interface {
+	// Next returns the first occurrence after t, in t's location, or the zero time if there is none.
+	Next(t time.Time) time.Time
+	// String returns the expression the schedule was parsed from, which ParseSchedule accepts.
+	String() string
+}
+
+var (
+	scheduleRegexp  = regexp.MustCompile(`^(?:(?:every|each) (.+)|(hourly|daily|weekly)(?: (.+))?)$`)
+	intervalRegexp  = regexp.MustCompile(`^(\d+(?:\.\d+)?)? ?([a-z]+)$`)
+	cronMacroRegexp = regexp.MustCompile(`^@(hourly|daily|weekly|monthly|yearly|annually)$`)
+)
+
+var cronMacros = map[string]string{
+	"hourly":   "0 * * * *",
+	"daily":    "0 0 * * *",
+	"weekly":   "0 0 * * 0",
+	"monthly":  "0 0 1 * *",
+	"yearly":   "0 0 1 1 *",
+	"annually": "0 0 1 1 *",
+}
+
+// ParseSchedulePrefix finds the longest run of leading words that is a schedule expression, as understood by
+// ParseSchedule, and returns the schedule and how many words it took. A cron expression is written as "cron" followed by
+// its five fields. Schedules that repeat too often are reported as ErrScheduleTooFrequent rather than skipped.
+func ParseSchedulePrefix(words []string) (Schedule, int, error) {
+	if len(words) > 0 && strings.EqualFold(words[0], cronTrigger) {
+		if len(words) < 6 {
+			return nil, 0, errors.New("cron expressions need five fields")
+		}
+		s, err := ParseSchedule(strings.Join(words[:6], " "))
+		if err != nil {
+			return nil, 0, err
+		}
+		return s, 6, nil
+	}
+
+	for n := min(len(words), MaxScheduleExpressionWords); n > 0; n-- {
+		s, err := ParseSchedule(strings.Join(words[:n], " "))
+		if err == nil {
+			return s, n, nil
+		}
+		if errors.Is(err, ErrScheduleTooFrequent) {
+			return nil, n, err
+		}
+	}
+
+	return nil, 0, fmt.Errorf("no schedule found in %q", strings.Join(words, " "))
+}
+
+// ParseSchedule parses a recurring schedule. It understands intervals ("every 2h", "every 30 minutes", "hourly"), days
+// of the week with an optional time of day ("every weekday at 9:00", "every mon, wed and fri at 5pm", "daily at 8am"),
+// cron macros ("@daily") and cron expressions ("cron 0 9 * * 1-5"). Days without a time of day occur at 9am.
+func ParseSchedule(input string) (Schedule, error) {
+	s := strings.Join(strings.Fields(strings.ToLower(input)), " ")
+	if len(s) == 0 {
+		return nil, errors.New("empty schedule expression")
+	}
+
+	if m := cronMacroRegexp.FindStringSubmatch(s); m != nil {
+		return parseCron(s, cronMacros[m[1]])
+	}
+
+	if spec, ok := strings.CutPrefix(s, cronTrigger+" "); ok {
+		return parseCron(s, spec)
+	}
+
+	m := scheduleRegexp.FindStringSubmatch(s)
+	if m == nil {
+		return nil, fmt.Errorf("invalid schedule, %s", s)
+	}
+
+	switch m[2] {
+	case "hourly":
+		if len(m[3]) > 0 {
+			return nil, fmt.Errorf("invalid schedule, %s", s)
+		}
+		return newIntervalSchedule(s, time.Hour)
+	case "daily":
+		return parseWeekdaySchedule(s, "day", m[3])
+	case "weekly":
+		if len(m[3]) > 0 {
+			return nil, fmt.Errorf("invalid schedule, %s", s)
+		}
+		return newIntervalSchedule(s, 7*24*time.Hour)
+	}
+
+	days, rest := splitScheduleDays(m[1])
+	if len(days) > 0 {
+		return parseWeekdaySchedule(s, days, rest)
+	}
+
+	im := intervalRegexp.FindStringSubmatch(m[1])
+	if im == nil {
+		return nil, fmt.Errorf("invalid schedule, %s", s)
+	}
+
+	quantity := im[1]
+	if len(quantity) == 0 {
+		quantity = "1"
+	}
+	if !IsDuration(quantity + im[2]) {
+		return nil, fmt.Errorf("invalid schedule, %s", s)
+	}
+
+	d, err := ParseDuration(quantity + im[2])
+	if err != nil {
+		return nil, err
+	}
+
+	return newIntervalSchedule(s, d)
+}
+
+// splitScheduleDays splits the days at the start of s, such as "weekday" or "mon, wed and fri", from the rest of s.
+func splitScheduleDays(s string) (string, string) {
+	words := strings.Fields(s)
+
+	n := 0
+	for n < len(words) {
+		word := strings.TrimSuffix(words[n], ",")
+		if word != "and" && !isScheduleDay(word) {
+			break
+		}
+		n++
+	}
+
+	// a trailing "and" belongs to whatever follows the days
+	for n > 0 && words[n-1] == "and" {
+		n--
+	}
+
+	return strings.Join(words[:n], " "), strings.Join(words[n:], " ")
+}
+
+func isScheduleDay(word string) bool {
+	switch word {
+	case "day", "weekday", "weekdays", "weekend", "weekends":
+		return true
+	}
+	_, ok := weekdays[strings.TrimSuffix(word, "s")]
+	if !ok {
+		_, ok = weekdays[word]
+	}
+	return ok
+}
+
+// parseWeekdaySchedule builds a schedule that occurs on the given days at the time of day in rest, or 9am.
+func parseWeekdaySchedule(s, days, rest string) (Schedule, error) {
+	var dow uint64
+	for _, word := range strings.FieldsFunc(days, func(r rune) bool { return r == ' ' || r == ',' }) {
+		switch word {
+		case "and":
+		case "day":
+			dow |= fieldBits(0, 6, 1)
+		case "weekday", "weekdays":
+			dow |= fieldBits(1, 5, 1)
+		case "weekend", "weekends":
+			dow |= 1<<uint(time.Saturday) | 1<<uint(time.Sunday)
+		default:
+			weekday, ok := weekdays[word]
+			if !ok {
+				weekday, ok = weekdays[strings.TrimSuffix(word, "s")]
+			}
+			if !ok {
+				return nil, fmt.Errorf("invalid day, %s", word)
+			}
+			dow |= 1 << uint(weekday)
+		}
+	}
+
+	hour, minute := defaultHour, 0
+	if len(rest) > 0 {
+		var err error
+		if hour, minute, err = parseTimeOfDay(rest, true); err != nil {
+			return nil, err
+		}
+	}
+
+	return &cronSchedule{
+		spec:    s,
+		minute:  1 << uint(minute),
+		hour:    1 << uint(hour),
+		dom:     fieldBits(1, 31, 1),
+		month:   fieldBits(1, 12, 1),
+		dow:     dow,
+		domStar: true,
+		dowStar: dow == fieldBits(0, 6, 1),
+	}, nil
+}
+
+type intervalSchedule struct {
+	spec  string
+	every time.Duration
+}
+
+func newIntervalSchedule(spec string, every time.Duration) (Schedule, error) {
+	if every < MinScheduleInterval {
+		return nil, ErrScheduleTooFrequent
+	}
+	return &intervalSchedule{spec: spec, every: every}, nil
+}
+
+func (s *intervalSchedule) Next(t time.Time) time.Time {
+	return t.Add(s.every)
+}
+
+func (s *intervalSchedule) String() string {
+	return s.spec
+}
+
+// cronSchedule is a standard five field cron schedule, with each field held as a bit set of the values it matches.
+type cronSchedule struct {
+	spec    string
+	minute  uint64
+	hour    uint64
+	dom     uint64
+	month   uint64
+	dow     uint64
+	domStar bool
+	dowStar bool
+}
+
+type cronField struct {
+	name  string
+	min   int
+	max   int
+	names map[string]int
+}
+
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: monthNumbers()},
+	{name: "day of week", min: 0, max: 7, names: weekdayNumbers()},
+}
+
+// parseCron parses the five fields of a cron expression: minute, hour, day of month, month and day of week. Fields
+// accept *, numbers, ranges, lists and steps, and months and days of the week can be named.
+func parseCron(s, spec string) (Schedule, error) {
+	fields := strings.Fields(spec)
+	if len(fields) != len(cronFields) {
+		return nil, fmt.Errorf("cron expressions need five fields, %s", spec)
+	}
+
+	values := make([]uint64, len(fields))
+	for i, field := range fields {
+		v, err := parseCronField(field, cronFields[i])
+		if err != nil {
+			return nil, err
+		}
+		values[i] = v
+	}
+
+	// both 0 and 7 are Sunday
+	if values[4]&(1<<7) != 0 {
+		values[4] = values[4]&^(1<<7) | 1
+	}
+
+	cs := &cronSchedule{
+		spec:    s,
+		minute:  values[0],
+		hour:    values[1],
+		dom:     values[2],
+		month:   values[3],
+		dow:     values[4],
+		domStar: strings.HasPrefix(fields[2], "*"),
+		dowStar: strings.HasPrefix(fields[4], "*"),
+	}
+
+	if cs.minInterval() < MinScheduleInterval {
+		return nil, ErrScheduleTooFrequent
+	}
+
+	return cs, nil
+}
+
+func parseCronField(field string, f cronField) (uint64, error) {
+	var result uint64
+
+	for _, part := range strings.Split(field, ",") {
+		rng, stepInput, hasStep := strings.Cut(part, "/")
+
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepInput); err != nil || step < 1 {
+				return 0, fmt.Errorf("invalid %s step, %s", f.name, part)
+			}
+		}
+
+		lo, hi := f.min, f.max
+		if rng != "*" {
+			loInput, hiInput, isRange := strings.Cut(rng, "-")
+
+			var err error
+			if lo, err = cronValue(loInput, f); err != nil {
+				return 0, err
+			}
+			hi = lo
+			if isRange {
+				if hi, err = cronValue(hiInput, f); err != nil {
+					return 0, err
+				}
+			} else if hasStep {
+				hi = f.max
+			}
+			if hi < lo {
+				return 0, fmt.Errorf("invalid %s range, %s", f.name, part)
+			}
+		}
+
+		result |= fieldBits(lo, hi, step)
+	}
+
+	return result, nil
+}
+
+func cronValue(s string, f cronField) (int, error) {
+	if v, ok := f.names[s]; ok {
+		return v, nil
+	}
+
+	v, err := strconv.Atoi(s)
+	if err != nil || v < f.min || v > f.max {
+		return 0, fmt.Errorf("invalid %s, %s", f.name, s)
+	}
+
+	return v, nil
+}
+
+func fieldBits(lo, hi, step int) uint64 {
+	var b uint64
+	for v := lo; v <= hi; v += step {
+		b |= 1 << uint(v)
+	}
+	return b
+}
+
+func monthNumbers() map[string]int {
+	names := make(map[string]int, len(months))
+	for name, month := range months {
+		names[name] = int(month)
+	}
+	return names
+}
+
+func weekdayNumbers() map[string]int {
+	names := make(map[string]int, len(weekdays))
+	for name, weekday := range weekdays {
+		names[name] = int(weekday)
+	}
+	return names
+}
+
+func (s *cronSchedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	limit := t.Add(maxScheduleSearch)
+
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = s.advance(t, time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc))
+			continue
+		}
+
+		if !s.dayMatches(t) {
+			t = s.advance(t, time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc))
+			continue
+		}
+
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = s.advance(t, time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc))
+			continue
+		}
+
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
+
+// advance moves to next, or a minute on from t if daylight saving time changes would keep next from moving forward.
+func (s *cronSchedule) advance(t, next time.Time) time.Time {
+	if !next.After(t) {
+		return t.Add(time.Minute)
+	}
+	return next
+}
+
+// dayMatches follows cron in matching either the day of the month or the day of the week when both are restricted.
+func (s *cronSchedule) dayMatches(t time.Time) bool {
+	dom := s.dom&(1<<uint(t.Day())) != 0
+	dow := s.dow&(1<<uint(t.Weekday())) != 0
+
+	if s.domStar || s.dowStar {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+// minInterval returns the shortest gap between occurrences on the same or consecutive hours.
+func (s *cronSchedule) minInterval() time.Duration {
+	if s.minute == 0 || s.hour == 0 {
+		return 0
+	}
+
+	first := bits.TrailingZeros64(s.minute)
+	last := 63 - bits.LeadingZeros64(s.minute)
+
+	gap := 60
+	if s.hour&(s.hour<<1) != 0 || s.hour&(1|1<<23) == 1|1<<23 {
+		gap = 60 - last + first
+	}
+
+	prev := first
+	for m := first + 1; m <= last; m++ {
+		if s.minute&(1<<uint(m)) != 0 {
+			gap = min(gap, m-prev)
+			prev = m
+		}
+	}
+
+	return time.Duration(gap) * time.Minute
+}
+
+func (s *cronSchedule) String() string {
+	return s.spec
+}
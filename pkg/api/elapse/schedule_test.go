@@ -0,0 +1,96 @@
+package elapse
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseSchedule(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data unavailable, %s", err)
+	}
+
+	// a wednesday
+	now := time.Date(2026, time.October, 14, 10, 30, 0, 0, loc)
+
+	tests := []struct {
+		input string
+		want  time.Time
+	}{
+		{"every 2h", now.Add(2 * time.Hour)},
+		{"every 30 minutes", now.Add(30 * time.Minute)},
+		{"hourly", now.Add(time.Hour)},
+		{"every day at 9:00", time.Date(2026, time.October, 15, 9, 0, 0, 0, loc)},
+		{"daily at 5pm", time.Date(2026, time.October, 14, 17, 0, 0, 0, loc)},
+		{"every weekday at 9:00", time.Date(2026, time.October, 15, 9, 0, 0, 0, loc)},
+		{"every weekend", time.Date(2026, time.October, 17, 9, 0, 0, 0, loc)},
+		{"every monday at noon", time.Date(2026, time.October, 19, 12, 0, 0, 0, loc)},
+		{"every mon, wed and fri at 10:45", time.Date(2026, time.October, 14, 10, 45, 0, 0, loc)},
+		{"every tuesdays", time.Date(2026, time.October, 20, 9, 0, 0, 0, loc)},
+		{"cron 0 9 * * 1-5", time.Date(2026, time.October, 15, 9, 0, 0, 0, loc)},
+		{"cron 30 8 1 * *", time.Date(2026, time.November, 1, 8, 30, 0, 0, loc)},
+		{"cron 0 12 * jan-mar sun", time.Date(2027, time.January, 3, 12, 0, 0, 0, loc)},
+		{"cron 0 0 13 * fri", time.Date(2026, time.October, 16, 0, 0, 0, 0, loc)},
+		{"cron 0,30 * * * *", time.Date(2026, time.October, 14, 11, 0, 0, 0, loc)},
+		{"@monthly", time.Date(2026, time.November, 1, 0, 0, 0, 0, loc)},
+	}
+
+	for _, tt := range tests {
+		s, err := ParseSchedule(tt.input)
+		if err != nil {
+			t.Errorf("ParseSchedule(%q) error = %v", tt.input, err)
+			continue
+		}
+		if got := s.Next(now); !got.Equal(tt.want) {
+			t.Errorf("ParseSchedule(%q).Next() = %s, want %s", tt.input, got, tt.want)
+		}
+		if s.String() != strings.ToLower(tt.input) {
+			t.Errorf("ParseSchedule(%q).String() = %q", tt.input, s.String())
+		}
+	}
+}
+
+func TestParseScheduleInvalid(t *testing.T) {
+	for _, input := range []string{"", "every", "tomorrow", "every blursday", "every day at 25:00", "cron 0 9 * *", "cron 60 * * * *", "cron 0 9 * * 1-8"} {
+		if s, err := ParseSchedule(input); err == nil {
+			t.Errorf("ParseSchedule(%q) = %s, want error", input, s)
+		}
+	}
+
+	for _, input := range []string{"every 5m", "every minute", "cron * * * * *", "cron */10 9 * * *", "cron 55,5 23,0 * * *"} {
+		if _, err := ParseSchedule(input); !errors.Is(err, ErrScheduleTooFrequent) {
+			t.Errorf("ParseSchedule(%q) error = %v, want ErrScheduleTooFrequent", input, err)
+		}
+	}
+}
+
+func TestParseSchedulePrefix(t *testing.T) {
+	tests := []struct {
+		input    string
+		schedule string
+		words    int
+	}{
+		{"every weekday at 9:00 standup in 5", "every weekday at 9:00", 4},
+		{"every 2 hours drink some water", "every 2 hours", 3},
+		{"cron 0 9 * * 1-5 standup", "cron 0 9 * * 1-5", 6},
+		{"daily water the plants", "daily", 1},
+	}
+
+	for _, tt := range tests {
+		s, n, err := ParseSchedulePrefix(strings.Fields(tt.input))
+		if err != nil {
+			t.Errorf("ParseSchedulePrefix(%q) error = %v", tt.input, err)
+			continue
+		}
+		if s.String() != tt.schedule || n != tt.words {
+			t.Errorf("ParseSchedulePrefix(%q) = %q, %d, want %q, %d", tt.input, s.String(), n, tt.schedule, tt.words)
+		}
+	}
+
+	if _, _, err := ParseSchedulePrefix(strings.Fields("tomorrow at 9am standup")); err == nil {
+		t.Errorf("ParseSchedulePrefix() of a one-off time should fail")
+	}
+}
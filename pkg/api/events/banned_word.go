@@ -0,0 +1,238 @@
+package events
+
+import (
+	"assistant/pkg/api/text"
+	"assistant/pkg/log"
+	"assistant/pkg/models"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// minSpacedLetters is how many single characters in a row are read as one spaced out word, so that "b a n n e d" is
+// caught without joining ordinary words like "a" and "I".
+const minSpacedLetters = 3
+
+// minFuzzyLength is the shortest fuzzy word matched by misspelling. Shorter ones only match as written, with leetspeak
+// and repeated letters read through, since a single edit turns them into too many innocent words ("shit" and "shot").
+const minFuzzyLength = 6
+
+// longFuzzyLength is the length from which fuzzy words allow two edits rather than one.
+const longFuzzyLength = 9
+
+// bannedPatterns caches compiled banned regexes by pattern, with nil for patterns that don't compile.
+var bannedPatterns sync.Map
+
+// bannedMessage is a message normalized for matching against banned words. Each token has the forms it could be read
+// as, with confusable letters folded, punctuation and separators dropped, and leetspeak read as letters.
+type bannedMessage struct {
+	folded string
+	tokens [][]string
+}
+
+func newBannedMessage(message string) *bannedMessage {
+	m := &bannedMessage{folded: text.FoldConfusables(message)}
+
+	spaced := make([]string, 0)
+	flushSpaced := func() {
+		if len(spaced) >= minSpacedLetters {
+			m.tokens = append(m.tokens, tokenForms(strings.Join(spaced, "")))
+		}
+		spaced = spaced[:0]
+	}
+
+	for _, field := range strings.Fields(m.folded) {
+		forms := tokenForms(field)
+		if len(forms) == 0 {
+			continue
+		}
+
+		if len([]rune(field)) <= 2 && len([]rune(forms[0])) <= 1 {
+			spaced = append(spaced, field)
+		} else {
+			flushSpaced()
+		}
+		m.tokens = append(m.tokens, forms)
+	}
+	flushSpaced()
+
+	return m
+}
+
+// tokenForms returns the token with everything but letters and digits removed, followed by the same with leetspeak
+// read as letters if that differs.
+func tokenForms(token string) []string {
+	plain := keepRunes(token, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsNumber(r) })
+	deleeted := keepRunes(text.Deleet(token), unicode.IsLetter)
+
+	forms := make([]string, 0, 2)
+	if len(plain) > 0 {
+		forms = append(forms, plain)
+	}
+	if len(deleeted) > 0 && deleeted != plain {
+		forms = append(forms, deleeted)
+	}
+	return forms
+}
+
+func keepRunes(s string, keep func(rune) bool) string {
+	var b strings.Builder
+	for _, r := range s {
+		if keep(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// matchingBannedWords returns the banned words the message contains.
+func matchingBannedWords(message string, bannedWords []*models.BannedWord) []*models.BannedWord {
+	if len(bannedWords) == 0 {
+		return nil
+	}
+
+	m := newBannedMessage(message)
+
+	matches := make([]*models.BannedWord, 0)
+	for _, bw := range bannedWords {
+		if m.contains(bw) {
+			matches = append(matches, bw)
+		}
+	}
+	return matches
+}
+
+func (m *bannedMessage) contains(bw *models.BannedWord) bool {
+	switch bw.MatchKind() {
+	case models.BannedWordKindRegex:
+		return m.matchesRegex(bw.Word)
+	case models.BannedWordKindFuzzy:
+		return m.containsFuzzy(text.FoldConfusables(bw.Word))
+	default:
+		return m.containsPhrase(strings.Fields(text.FoldConfusables(bw.Word)))
+	}
+}
+
+// containsPhrase reports whether consecutive tokens read as the words of the phrase, which can be a single word.
+func (m *bannedMessage) containsPhrase(words []string) bool {
+	if len(words) == 0 {
+		return false
+	}
+
+	for i := 0; i <= len(m.tokens)-len(words); i++ {
+		match := true
+		for j, word := range words {
+			if !hasForm(m.tokens[i+j], word) {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
+
+func hasForm(forms []string, word string) bool {
+	for _, form := range forms {
+		if form == word {
+			return true
+		}
+	}
+	return false
+}
+
+// containsFuzzy reports whether a token reads as the word, ignoring repeated letters, or for words of at least
+// minFuzzyLength letters as a close misspelling of it.
+func (m *bannedMessage) containsFuzzy(word string) bool {
+	target := collapseRepeats(word)
+	length := len([]rune(word))
+
+	maxEdits := 0
+	if length >= longFuzzyLength {
+		maxEdits = 2
+	} else if length >= minFuzzyLength {
+		maxEdits = 1
+	}
+
+	for _, forms := range m.tokens {
+		for _, form := range forms {
+			if form == word {
+				return true
+			}
+			// tokens shorter than the word need edits to spare, so that "ass" doesn't catch "as"
+			formLength := len([]rune(form))
+			collapsed := collapseRepeats(form)
+			if formLength >= length && collapsed == target {
+				return true
+			}
+			if maxEdits > 0 && formLength >= length-maxEdits && editDistance(collapsed, target) <= maxEdits {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchesRegex reports whether the pattern matches the message, ignoring case, as written or with leetspeak read as
+// letters.
+func (m *bannedMessage) matchesRegex(pattern string) bool {
+	re := compileBannedPattern(pattern)
+	if re == nil {
+		return false
+	}
+	return re.MatchString(m.folded) || re.MatchString(text.Deleet(m.folded))
+}
+
+func compileBannedPattern(pattern string) *regexp.Regexp {
+	if cached, ok := bannedPatterns.Load(pattern); ok {
+		return cached.(*regexp.Regexp)
+	}
+
+	re, err := regexp.Compile("(?i)" + pattern)
+	if err != nil {
+		log.Logger().Warningf(nil, "invalid banned pattern %q: %s", pattern, err)
+		re = nil
+	}
+	bannedPatterns.Store(pattern, re)
+	return re
+}
+
+// collapseRepeats replaces runs of the same letter with one, so that "baaaad" and "bad" compare equal.
+func collapseRepeats(s string) string {
+	var b strings.Builder
+	var last rune
+	for i, r := range s {
+		if i > 0 && r == last {
+			continue
+		}
+		b.WriteRune(r)
+		last = r
+	}
+	return b.String()
+}
+
+// editDistance returns the Levenshtein distance between the strings.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,71 @@
+package events
+
+import (
+	"assistant/pkg/models"
+	"testing"
+)
+
+func TestMatchingBannedWords(t *testing.T) {
+	tests := []struct {
+		name    string
+		word    models.BannedWord
+		message string
+		want    bool
+	}{
+		{"word", models.BannedWord{Word: "banned"}, "this is banned, ok", true},
+		{"word within another", models.BannedWord{Word: "ban"}, "a banner", false},
+		{"punctuation", models.BannedWord{Word: "banned"}, "b.a.n.n.e.d", true},
+		{"spaced out", models.BannedWord{Word: "banned"}, "you are b a n n e d now", true},
+		{"leetspeak", models.BannedWord{Word: "banned"}, "b4nn3d", true},
+		{"leet symbols", models.BannedWord{Word: "ass"}, "@$$", true},
+		{"cyrillic homoglyphs", models.BannedWord{Word: "banned"}, "bаnnеd", true},
+		{"accents", models.BannedWord{Word: "banned"}, "bánnéd", true},
+		{"fullwidth", models.BannedWord{Word: "banned"}, "ｂａｎｎｅｄ", true},
+		{"phrase", models.BannedWord{Word: "go away"}, "please go away now", true},
+		{"phrase out of order", models.BannedWord{Word: "go away"}, "away we go", false},
+		{"fuzzy misspelling", models.BannedWord{Word: "grapefruit", Kind: models.BannedWordKindFuzzy}, "grapfruiit", true},
+		{"fuzzy repeats", models.BannedWord{Word: "spam", Kind: models.BannedWordKindFuzzy}, "spaaaaam", true},
+		{"fuzzy too far", models.BannedWord{Word: "spam", Kind: models.BannedWordKindFuzzy}, "swim", false},
+		{"fuzzy short token", models.BannedWord{Word: "shit", Kind: models.BannedWordKindFuzzy}, "hit it", false},
+		{"fuzzy short word leetspeak", models.BannedWord{Word: "shit", Kind: models.BannedWordKindFuzzy}, "sh1iiit", true},
+		{"fuzzy short word substitution", models.BannedWord{Word: "shit", Kind: models.BannedWordKindFuzzy}, "took a shot", false},
+		{"fuzzy short word suit", models.BannedWord{Word: "shit", Kind: models.BannedWordKindFuzzy}, "nice suit", false},
+		{"fuzzy short word insertion", models.BannedWord{Word: "shit", Kind: models.BannedWordKindFuzzy}, "clean shirt", false},
+		{"fuzzy short word repeats", models.BannedWord{Word: "ass", Kind: models.BannedWordKindFuzzy}, "as if", false},
+		{"fuzzy five letters", models.BannedWord{Word: "bitch", Kind: models.BannedWordKindFuzzy}, "pitch the batch", false},
+		{"fuzzy deletion", models.BannedWord{Word: "grapefruit", Kind: models.BannedWordKindFuzzy}, "grapfrut", true},
+		{"fuzzy two edits on a medium word", models.BannedWord{Word: "bastard", Kind: models.BannedWordKindFuzzy}, "pass the mustard", false},
+		{"fuzzy one edit on a medium word", models.BannedWord{Word: "bastard", Kind: models.BannedWordKindFuzzy}, "bastrd", true},
+		{"regex", models.BannedWord{Word: `free\s+crypto`, Kind: models.BannedWordKindRegex}, "FREE   crypto here", true},
+		{"regex leetspeak", models.BannedWord{Word: `casino`, Kind: models.BannedWordKindRegex}, "best c4s1n0 online", true},
+		{"invalid regex", models.BannedWord{Word: `(`, Kind: models.BannedWordKindRegex}, "(", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bw := tt.word
+			matches := matchingBannedWords(tt.message, []*models.BannedWord{&bw})
+			if got := len(matches) > 0; got != tt.want {
+				t.Errorf("matchingBannedWords(%q) for %q = %v, want %v", tt.message, tt.word.Word, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"kitten", "sitting", 3},
+		{"banned", "baned", 1},
+	}
+
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package events
+
+import (
+	"assistant/pkg/models"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// commandLimiter tracks command uses in memory to enforce channels' command limits.
+type commandLimiter struct {
+	mu   sync.Mutex
+	uses map[string][]time.Time
+	now  func() time.Time
+}
+
+func newCommandLimiter() *commandLimiter {
+	return &commandLimiter{
+		uses: make(map[string][]time.Time),
+		now:  time.Now,
+	}
+}
+
+// Allow returns whether a use of the command by the user is within the limits, and records it if so. Otherwise, it
+// returns how long until it would be.
+func (l *commandLimiter) Allow(channel, user string, limits []models.CommandLimit) (bool, time.Duration) {
+	if len(limits) == 0 {
+		return true, 0
+	}
+
+	now := l.now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var wait time.Duration
+	for _, limit := range limits {
+		uses := l.recentUses(commandLimitKey(channel, user, limit), limit, now)
+		if w := limitWait(limit, uses, now); w > wait {
+			wait = w
+		}
+	}
+	if wait > 0 {
+		return false, wait
+	}
+
+	for _, limit := range limits {
+		key := commandLimitKey(channel, user, limit)
+		l.uses[key] = append(l.uses[key], now)
+	}
+	return true, 0
+}
+
+// recentUses drops uses that no longer count towards the limit and returns the rest, oldest first.
+func (l *commandLimiter) recentUses(key string, limit models.CommandLimit, now time.Time) []time.Time {
+	keep := max(limit.CooldownDuration(), limit.WindowDuration())
+	uses := l.uses[key]
+	i := 0
+	for i < len(uses) && now.Sub(uses[i]) >= keep {
+		i++
+	}
+	uses = uses[i:]
+	if len(uses) == 0 {
+		delete(l.uses, key)
+	} else {
+		l.uses[key] = uses
+	}
+	return uses
+}
+
+func limitWait(limit models.CommandLimit, uses []time.Time, now time.Time) time.Duration {
+	if len(uses) == 0 {
+		return 0
+	}
+
+	var wait time.Duration
+	if cooldown := limit.CooldownDuration(); cooldown > 0 {
+		wait = cooldown - now.Sub(uses[len(uses)-1])
+	}
+	if limit.Quota > 0 && len(uses) >= limit.Quota {
+		// uses older than the window have been dropped, so the quota frees up when the oldest of the last quota uses
+		// leaves it
+		if w := limit.WindowDuration() - now.Sub(uses[len(uses)-limit.Quota]); w > wait {
+			wait = w
+		}
+	}
+	return max(wait, 0)
+}
+
+func commandLimitKey(channel, user string, limit models.CommandLimit) string {
+	if limit.Scope == models.CommandLimitScopeChannel {
+		return fmt.Sprintf("%s/%s", channel, limit.Command)
+	}
+	return fmt.Sprintf("%s/%s/%s", channel, limit.Command, user)
+}
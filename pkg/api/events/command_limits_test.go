@@ -0,0 +1,83 @@
+package events
+
+import (
+	"assistant/pkg/models"
+	"testing"
+	"time"
+)
+
+func TestCommandLimiterQuota(t *testing.T) {
+	now := time.Date(2026, time.July, 31, 12, 0, 0, 0, time.UTC)
+	limiter := newCommandLimiter()
+	limiter.now = func() time.Time { return now }
+	limits := []models.CommandLimit{{Command: "roast", Scope: models.CommandLimitScopeUser, Quota: 3, Window: "1h"}}
+
+	for i := 0; i < 3; i++ {
+		if allowed, _ := limiter.Allow("#channel", "host", limits); !allowed {
+			t.Fatalf("use %d was limited", i+1)
+		}
+		now = now.Add(10 * time.Minute)
+	}
+
+	allowed, wait := limiter.Allow("#channel", "host", limits)
+	if allowed || wait != 30*time.Minute {
+		t.Fatalf("fourth use = %t, %s, want limited for 30m", allowed, wait)
+	}
+	if allowed, _ := limiter.Allow("#channel", "other", limits); !allowed {
+		t.Fatal("another user was limited")
+	}
+
+	now = now.Add(30 * time.Minute)
+	if allowed, _ := limiter.Allow("#channel", "host", limits); !allowed {
+		t.Fatal("use after the oldest left the window was limited")
+	}
+}
+
+func TestCommandLimiterCooldownPerChannel(t *testing.T) {
+	now := time.Date(2026, time.July, 31, 12, 0, 0, 0, time.UTC)
+	limiter := newCommandLimiter()
+	limiter.now = func() time.Time { return now }
+	limits := []models.CommandLimit{{Command: "meme", Scope: models.CommandLimitScopeChannel, Cooldown: "5m"}}
+
+	if allowed, _ := limiter.Allow("#channel", "host", limits); !allowed {
+		t.Fatal("first use was limited")
+	}
+
+	now = now.Add(2 * time.Minute)
+	allowed, wait := limiter.Allow("#channel", "other", limits)
+	if allowed || wait != 3*time.Minute {
+		t.Fatalf("use by another user = %t, %s, want limited for 3m", allowed, wait)
+	}
+	if allowed, _ := limiter.Allow("#other", "other", limits); !allowed {
+		t.Fatal("use in another channel was limited")
+	}
+
+	now = now.Add(3 * time.Minute)
+	if allowed, _ := limiter.Allow("#channel", "other", limits); !allowed {
+		t.Fatal("use after the cooldown was limited")
+	}
+}
+
+func TestCommandLimiterRecordsOnlyAllowedUses(t *testing.T) {
+	now := time.Date(2026, time.July, 31, 12, 0, 0, 0, time.UTC)
+	limiter := newCommandLimiter()
+	limiter.now = func() time.Time { return now }
+	limits := []models.CommandLimit{
+		{Command: "gif", Scope: models.CommandLimitScopeUser, Cooldown: "1m"},
+		{Command: "gif", Scope: models.CommandLimitScopeChannel, Quota: 2, Window: "1h"},
+	}
+
+	limiter.Allow("#channel", "host", limits)
+	now = now.Add(30 * time.Second)
+	if allowed, _ := limiter.Allow("#channel", "host", limits); allowed {
+		t.Fatal("use within the cooldown was allowed")
+	}
+
+	// the limited use above doesn't count towards the channel's quota
+	if allowed, _ := limiter.Allow("#channel", "other", limits); !allowed {
+		t.Fatal("second use in the channel was limited")
+	}
+	if allowed, _ := limiter.Allow("#channel", "third", limits); allowed {
+		t.Fatal("third use in the channel was allowed")
+	}
+}
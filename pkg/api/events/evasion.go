@@ -0,0 +1,222 @@
+package events
+
+import (
+	"assistant/pkg/api/irc"
+	"assistant/pkg/api/text"
+	"assistant/pkg/models"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Evasion signals and what each adds to a joiner's score against a banned or muted user. A joiner's score is the
+// highest against any one of them, capped at models.MaxEvasionScore.
+const (
+	evasionScoreAlias       = 60
+	evasionScoreHost        = 50
+	evasionScoreNetwork     = 35
+	evasionScoreIdent       = 30
+	evasionScoreCloak       = 25
+	evasionScoreSameNick    = 40
+	evasionScoreNickVariant = 35
+	evasionScoreSimilarNick = 25
+)
+
+// evasionLookback is how far back the audit log is searched for bans and mutes that joiners might be evading.
+const evasionLookback = 30 * 24 * time.Hour
+
+// evasionReportInterval is how long a joiner isn't reported again for the same channel, so that rejoining doesn't
+// repeat the report.
+const evasionReportInterval = 10 * time.Minute
+
+// minSimilarNickLength is the shortest nick compared by edit distance. Shorter nicks resemble too many others.
+const minSimilarNickLength = 4
+
+// genericIdents are idents that many unrelated users share, such as those set by web clients, which say nothing about
+// who's behind them.
+var genericIdents = []string{"", "*", "u", "user", "irc", "webchat", "kiwi", "kiwiirc", "quassel", "znc", "bnc"}
+
+// evasionSuspect is a banned or muted user that joiners are compared against. Any part of the mask can be a wildcard
+// pattern, as bans often are. Why describes the penalty, such as "banned as *!*@host".
+type evasionSuspect struct {
+	Nick  string
+	Ident string
+	Host  string
+	Why   string
+}
+
+// evasionMatch is a joiner's score against the suspect they most resemble, with the signals that made it up.
+type evasionMatch struct {
+	suspect evasionSuspect
+	score   int
+	signals []string
+}
+
+// bestEvasionMatch scores the joiner against each suspect and returns the best match, or nil if they resemble none.
+// Aliases are the other nicks seen using the joiner's host or ident. Resemblance by nick or cloak alone isn't enough, so
+// only suspects sharing the joiner's host, network or ident are matched.
+func bestEvasionMatch(joiner *irc.Mask, aliases []string, suspects []evasionSuspect) *evasionMatch {
+	var best *evasionMatch
+	for _, suspect := range suspects {
+		score, signals, identified := scoreEvasion(joiner, aliases, suspect)
+		if identified && score > 0 && (best == nil || score > best.score) {
+			best = &evasionMatch{suspect: suspect, score: score, signals: signals}
+		}
+	}
+	return best
+}
+
+// scoreEvasion scores the joiner against the suspect and reports whether any of the signals identify them, which only
+// host, network and ident signals do.
+func scoreEvasion(joiner *irc.Mask, aliases []string, suspect evasionSuspect) (int, []string, bool) {
+	score := 0
+	signals := make([]string, 0)
+	identified := false
+	add := func(points int, signal string) {
+		score += points
+		signals = append(signals, signal)
+	}
+
+	if isSpecificPattern(suspect.Nick) && !strings.EqualFold(suspect.Nick, joiner.Nick) && slices.ContainsFunc(aliases, func(a string) bool { return strings.EqualFold(a, suspect.Nick) }) {
+		add(evasionScoreAlias, fmt.Sprintf("previously seen as %s", suspect.Nick))
+	}
+
+	if isSpecificPattern(suspect.Host) {
+		if irc.MatchWildcard(suspect.Host, joiner.Host) && !isSharedHostPattern(suspect.Host) {
+			add(evasionScoreHost, "same host")
+			identified = true
+		} else if network := hostNetwork(joiner.Host); len(network) > 0 && network == hostNetwork(suspect.Host) {
+			add(evasionScoreNetwork, fmt.Sprintf("same network %s", network))
+			identified = true
+		} else if cloak := cloakSuffix(joiner.Host); len(cloak) > 0 && cloak == cloakSuffix(suspect.Host) && !irc.IsSharedHost(joiner.Host) {
+			add(evasionScoreCloak, fmt.Sprintf("same cloak %s", cloak))
+		}
+	}
+
+	if ident := normalizeIdent(joiner.UserID); isSpecificPattern(suspect.Ident) && !slices.Contains(genericIdents, ident) && irc.MatchWildcard(normalizeIdent(suspect.Ident), ident) {
+		add(evasionScoreIdent, "same ident")
+		identified = true
+	}
+
+	if isSpecificPattern(suspect.Nick) && !strings.ContainsAny(suspect.Nick, "*?") {
+		if strings.EqualFold(suspect.Nick, joiner.Nick) {
+			add(evasionScoreSameNick, "same nick")
+		} else {
+			a, b := nickSkeleton(joiner.Nick), nickSkeleton(suspect.Nick)
+			if len(a) > 0 && a == b {
+				add(evasionScoreNickVariant, fmt.Sprintf("nick variant of %s", suspect.Nick))
+			} else if len([]rune(a)) >= minSimilarNickLength && len([]rune(b)) >= minSimilarNickLength && editDistance(a, b) <= 2 {
+				add(evasionScoreSimilarNick, fmt.Sprintf("nick similar to %s", suspect.Nick))
+			}
+		}
+	}
+
+	return min(score, models.MaxEvasionScore), signals, identified
+}
+
+// isSharedHostPattern reports whether a host pattern with wildcards covers a shared host's users wholesale, as a ban on
+// gateway/web/kiwiirc/* does. Bans on them say nothing about who's behind them, although an exact host can, when it
+// carries an address or a user's name.
+func isSharedHostPattern(pattern string) bool {
+	return strings.ContainsAny(pattern, "*?") && irc.IsSharedHost(pattern)
+}
+
+// isSpecificPattern reports whether a mask part says anything about who it applies to, unlike an empty one or a lone
+// wildcard.
+func isSpecificPattern(s string) bool {
+	return len(strings.Trim(s, "*?~")) > 0
+}
+
+// hostNetwork returns the /24 network of an IPv4 host or the /64 of an IPv6 one, or an empty string for hosts that
+// aren't addresses.
+func hostNetwork(host string) string {
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return ""
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		return (&net.IPNet{IP: ip4.Mask(net.CIDRMask(24, 32)), Mask: net.CIDRMask(24, 32)}).String()
+	}
+	return (&net.IPNet{IP: ip.Mask(net.CIDRMask(64, 128)), Mask: net.CIDRMask(64, 128)}).String()
+}
+
+// cloakSuffix returns the part of a host that users of the same provider or cloak share: everything before the last
+// slash of a services cloak such as gateway/web/example/ip.1.2.3.4, or everything after the first label of a host name
+// with at least three. Addresses and wildcard patterns have none.
+func cloakSuffix(host string) string {
+	if len(host) == 0 || strings.ContainsAny(host, "*?") || net.ParseIP(host) != nil {
+		return ""
+	}
+
+	host = strings.ToLower(host)
+	if i := strings.LastIndex(host, "/"); i > 0 {
+		return host[:i]
+	}
+
+	labels := strings.Split(host, ".")
+	if len(labels) < 3 {
+		return ""
+	}
+	return strings.Join(labels[1:], ".")
+}
+
+func normalizeIdent(ident string) string {
+	return strings.ToLower(strings.TrimPrefix(ident, "~"))
+}
+
+// nickSkeleton reduces a nick to the letters it reads as, so that variants such as Tr0ll_, troll|away and troll2 compare
+// equal.
+func nickSkeleton(nick string) string {
+	if i := strings.Index(nick, "|"); i > 0 {
+		nick = nick[:i]
+	}
+	return keepRunes(text.Deleet(text.FoldConfusables(strings.TrimRight(nick, "0123456789_-^`"))), unicode.IsLetter)
+}
+
+// evasionSuspects returns the users joiners are compared against: the channel's active bans, and the users banned or
+// muted within the lookback who haven't since been unbanned or unmuted.
+func evasionSuspects(bans []*irc.BanEntry, entries []*models.AuditEntry, now time.Time) []evasionSuspect {
+	suspects := make([]evasionSuspect, 0, len(bans))
+	for _, ban := range bans {
+		// extended bans such as $a:account don't describe a mask
+		if !strings.ContainsAny(ban.Mask, "!@") {
+			continue
+		}
+		if mask := irc.ParseMask(ban.Mask); mask != nil {
+			suspects = append(suspects, evasionSuspect{Nick: mask.Nick, Ident: mask.UserID, Host: mask.Host, Why: fmt.Sprintf("banned as %s", ban.Mask)})
+		}
+	}
+
+	// entries are newest first, so lifted penalties are seen before the penalties they lifted
+	lifted := make(map[string]bool)
+	for _, entry := range entries {
+		if now.Sub(entry.CreatedAt) > evasionLookback {
+			break
+		}
+
+		mask := irc.ParseMask(entry.Target)
+		if mask == nil {
+			continue
+		}
+
+		switch entry.Action {
+		case models.AuditActionUnban:
+			lifted[models.AuditActionBan+" "+strings.ToLower(entry.Target)] = true
+		case models.AuditActionUnmute:
+			lifted[models.AuditActionMute+" "+strings.ToLower(mask.Nick)] = true
+		case models.AuditActionBan:
+			if !lifted[models.AuditActionBan+" "+strings.ToLower(entry.Target)] {
+				suspects = append(suspects, evasionSuspect{Nick: mask.Nick, Ident: mask.UserID, Host: mask.Host, Why: fmt.Sprintf("banned as %s", entry.Target)})
+			}
+		case models.AuditActionMute:
+			if !lifted[models.AuditActionMute+" "+strings.ToLower(mask.Nick)] {
+				suspects = append(suspects, evasionSuspect{Nick: mask.Nick, Ident: mask.UserID, Host: mask.Host, Why: fmt.Sprintf("muted as %s", entry.Target)})
+			}
+		}
+	}
+
+	return suspects
+}
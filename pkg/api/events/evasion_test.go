@@ -0,0 +1,92 @@
+package events
+
+import (
+	"assistant/pkg/api/irc"
+	"assistant/pkg/models"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestScoreEvasion(t *testing.T) {
+	tests := []struct {
+		name    string
+		joiner  string
+		aliases []string
+		suspect evasionSuspect
+		want    int
+	}{
+		{"unrelated", "alice!alice@alice.example.org", nil, evasionSuspect{Nick: "troll", Ident: "troll", Host: "203.0.113.7"}, 0},
+		{"same host", "fresh!fresh@203.0.113.7", nil, evasionSuspect{Nick: "*", Ident: "*", Host: "203.0.113.7"}, evasionScoreHost},
+		{"host pattern", "fresh!fresh@a1.dyn.example.net", nil, evasionSuspect{Nick: "*", Ident: "*", Host: "*.dyn.example.net"}, evasionScoreHost},
+		{"same /24", "fresh!fresh@203.0.113.99", nil, evasionSuspect{Nick: "*", Ident: "*", Host: "203.0.113.7"}, evasionScoreNetwork},
+		{"same /64", "fresh!fresh@2001:db8:1:2::beef", nil, evasionSuspect{Nick: "*", Ident: "*", Host: "2001:db8:1:2::1"}, evasionScoreNetwork},
+		{"different /64", "fresh!fresh@2001:db8:1:3::beef", nil, evasionSuspect{Nick: "*", Ident: "*", Host: "2001:db8:1:2::1"}, 0},
+		{"same cloak", "fresh!fresh@b7.dyn.example.net", nil, evasionSuspect{Nick: "*", Ident: "*", Host: "a1.dyn.example.net"}, evasionScoreCloak},
+		{"shared gateway cloak", "fresh!fresh@gateway/web/kiwiirc/ip.198.51.100.2", nil, evasionSuspect{Nick: "*", Ident: "*", Host: "gateway/web/kiwiirc/ip.203.0.113.7"}, 0},
+		{"shared gateway ban", "fresh!fresh@gateway/web/kiwiirc/ip.198.51.100.2", nil, evasionSuspect{Nick: "*", Ident: "*", Host: "gateway/web/kiwiirc/*"}, 0},
+		{"same gateway address", "fresh!fresh@gateway/web/kiwiirc/ip.203.0.113.7", nil, evasionSuspect{Nick: "*", Ident: "*", Host: "gateway/web/kiwiirc/ip.203.0.113.7"}, evasionScoreHost},
+		{"services cloak prefix", "fresh!fresh@user/fresh", nil, evasionSuspect{Nick: "*", Ident: "*", Host: "user/troll"}, 0},
+		{"same ident", "fresh!~Troll@other.example.org", nil, evasionSuspect{Nick: "*", Ident: "troll", Host: "*"}, evasionScoreIdent},
+		{"generic ident", "fresh!~webchat@other.example.org", nil, evasionSuspect{Nick: "*", Ident: "webchat", Host: "*"}, 0},
+		{"same nick", "Troll!x@other.example.org", nil, evasionSuspect{Nick: "troll", Ident: "*", Host: "*"}, evasionScoreSameNick},
+		{"nick variant", "Tr0ll_!x@other.example.org", nil, evasionSuspect{Nick: "troll", Ident: "*", Host: "*"}, evasionScoreNickVariant},
+		{"similar nick", "trolll0rd!x@other.example.org", nil, evasionSuspect{Nick: "trollord", Ident: "*", Host: "*"}, evasionScoreSimilarNick},
+		{"nick two edits away", "gruntle!x@other.example.org", nil, evasionSuspect{Nick: "grumble", Ident: "*", Host: "*"}, evasionScoreSimilarNick},
+		{"nick three edits away", "grumpy!x@other.example.org", nil, evasionSuspect{Nick: "grumble", Ident: "*", Host: "*"}, 0},
+		{"alias", "fresh!x@other.example.org", []string{"troll"}, evasionSuspect{Nick: "troll", Ident: "*", Host: "*"}, evasionScoreAlias},
+		{"capped", "tr0ll!troll@203.0.113.7", []string{"troll"}, evasionSuspect{Nick: "troll", Ident: "troll", Host: "203.0.113.7"}, models.MaxEvasionScore},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score, signals, _ := scoreEvasion(irc.ParseMask(tt.joiner), tt.aliases, tt.suspect)
+			if score != tt.want {
+				t.Errorf("scoreEvasion(%s) = %d (%s), want %d", tt.joiner, score, strings.Join(signals, ", "), tt.want)
+			}
+		})
+	}
+}
+
+func TestEvasionSuspects(t *testing.T) {
+	now := time.Now()
+	entry := func(action, target string, age time.Duration) *models.AuditEntry {
+		e := models.NewAuditEntry(action, "owner", models.AuditSourceCommand, target, "", "")
+		e.CreatedAt = now.Add(-age)
+		return e
+	}
+
+	bans := []*irc.BanEntry{{Mask: "*!*@203.0.113.7"}, {Mask: "$a:troll"}}
+	// newest first, as the audit log returns them
+	entries := []*models.AuditEntry{
+		entry(models.AuditActionUnmute, "pardoned", time.Hour),
+		entry(models.AuditActionKick, "kicked!*@kicked.example.org", 2*time.Hour),
+		entry(models.AuditActionMute, "pardoned!*@pardoned.example.org", 3*time.Hour),
+		entry(models.AuditActionMute, "muted!*@muted.example.org", 4*time.Hour),
+		entry(models.AuditActionBan, "*!*@old.example.org", 60*24*time.Hour),
+	}
+
+	suspects := evasionSuspects(bans, entries, now)
+	got := make([]string, 0, len(suspects))
+	for _, s := range suspects {
+		got = append(got, s.Why)
+	}
+
+	want := []string{"banned as *!*@203.0.113.7", "muted as muted!*@muted.example.org"}
+	if strings.Join(got, "; ") != strings.Join(want, "; ") {
+		t.Errorf("evasionSuspects() = %v, want %v", got, want)
+	}
+}
+
+func TestBestEvasionMatchNeedsIdentifyingSignal(t *testing.T) {
+	suspects := []evasionSuspect{{Nick: "troll", Ident: "*", Host: "troll.example.org", Why: "banned as troll!*@troll.example.org"}}
+
+	if match := bestEvasionMatch(irc.ParseMask("Tr0ll_!x@other.example.org"), nil, suspects); match != nil {
+		t.Errorf("bestEvasionMatch() on nick alone = %+v, want nil", match)
+	}
+
+	match := bestEvasionMatch(irc.ParseMask("Tr0ll_!x@troll.example.org"), nil, suspects)
+	if match == nil || match.score != evasionScoreHost+evasionScoreNickVariant {
+		t.Errorf("bestEvasionMatch() on host and nick = %+v, want a host and nick variant match", match)
+	}
+}
@@ -1,6 +1,7 @@
 package events
 
 import (
+	"assistant/pkg/api/actions"
 	"assistant/pkg/api/commands"
 	"assistant/pkg/api/context"
 	"assistant/pkg/api/elapse"
@@ -10,16 +11,15 @@ import (
 	"assistant/pkg/api/stats"
 	"assistant/pkg/api/style"
 	"assistant/pkg/config"
-	"assistant/pkg/firestore"
 	"assistant/pkg/log"
 	"assistant/pkg/models"
+	"assistant/pkg/storage"
 	"fmt"
 	"regexp"
 	"slices"
 	"strings"
 	"sync"
 	"time"
-	"unicode"
 )
 
 const userCommandRateLimitDuration = 1250 * time.Millisecond
@@ -40,6 +40,12 @@ type handler struct {
 	temporarilyIgnoredUserMasks map[string]int64
 	inactivityDurations         map[string]cachedInactivityDuration
 	inactivity                  *inactivityTracker
+	commandLimits               *commandLimiter
+	spam                        *spamDetector
+	spamRules                   map[string]cachedSpamRules
+	bannedWords                 map[string]cachedBannedWords
+	evasionReports              map[string]time.Time
+	tellsMu                     sync.Mutex
 }
 
 func NewHandler(ctx context.Context, cfg *config.Config, irc irc.IRC) Handler {
@@ -52,10 +58,15 @@ func NewHandler(ctx context.Context, cfg *config.Config, irc irc.IRC) Handler {
 		rateLimitCounter:            make(map[string]int),
 		temporarilyIgnoredUserMasks: make(map[string]int64),
 		inactivityDurations:         make(map[string]cachedInactivityDuration),
+		commandLimits:               newCommandLimiter(),
+		spam:                        newSpamDetector(),
+		spamRules:                   make(map[string]cachedSpamRules),
+		bannedWords:                 make(map[string]cachedBannedWords),
+		evasionReports:              make(map[string]time.Time),
 	}
 	eh.inactivity = newInactivityTracker(
 		func(channel string, dueAt time.Time) error {
-			return firestore.Get().UpdatePersistentChannelTaskDue(channel, models.ChannelInactivityTaskID, dueAt)
+			return storage.Network(eh.cfg.IRC.Name).UpdatePersistentChannelTaskDue(channel, models.ChannelInactivityTaskID, dueAt)
 		},
 		func(channel string, err error) {
 			log.Logger().Errorf(nil, "error updating persistent channel task for %s: %s", channel, err)
@@ -106,6 +117,13 @@ func (eh *handler) FindMatchingCommand(e *irc.Event) commands.Command {
 		}
 	}
 
+	for _, f := range eh.registry.ChannelCommands(e) {
+		if f.CanExecute(e) && !isCommandDisabled(f.Name()) {
+			eh.updateUserCommandHistory(e)
+			return f
+		}
+	}
+
 	llm := eh.registry.Command(commands.LLMCommandName)
 
 	if e.IsPrivateMessage() {
@@ -173,8 +191,7 @@ func (eh *handler) Handle(e *irc.Event) {
 			return
 		}
 		if strings.HasPrefix(strings.ToLower(e.Arguments[0]), irc.MessageClosingLink) && strings.Contains(strings.ToLower(e.Arguments[0]), irc.MessageServerShuttingDown) {
-			logger.Alertf(e, "server shutting down, attempting reconnect in %d seconds", eh.cfg.IRC.ReconnectDelay)
-			// todo
+			logger.Alertf(e, "server shutting down, the connection will be re-established once it's back")
 		}
 	case irc.CodeInvite:
 		if len(e.Arguments) < 2 {
@@ -187,6 +204,13 @@ func (eh *handler) Handle(e *irc.Event) {
 			channel := e.Arguments[1]
 			eh.irc.Join(channel)
 		}
+	case irc.CodeJoin:
+		if channel, _ := e.Recipient(); irc.IsChannel(channel) {
+			if eh.hasPendingTells(e, channel) {
+				go eh.deliverTells(e, channel)
+			}
+			go eh.checkEvasion(e, channel)
+		}
 	case irc.CodeNickChange:
 		if e.IsPrivateMessage() {
 			logger.Debugf(e, "ignoring nick change event in private message")
@@ -202,7 +226,7 @@ func (eh *handler) Handle(e *irc.Event) {
 		tokens := commands.Tokens(e.Message())
 
 		if !isPrivate {
-			if mode := modes.GetManager().ActiveMode(e.ReplyTarget()); mode != nil {
+			if mode := modes.GetManager(eh.cfg.IRC.Name).ActiveMode(e.ReplyTarget()); mode != nil {
 				// bypass checking auth on messages that don't start with "!"
 				if strings.HasPrefix(e.Message(), eh.cfg.Commands.Prefix) {
 					if f := eh.findModeBypassCommand(e, mode); f != nil {
@@ -212,7 +236,9 @@ func (eh *handler) Handle(e *irc.Event) {
 								f.Replyf(e, "You are not authorized to perform that command.")
 								return
 							}
-							go f.Execute(e)
+							if args, ok := commands.ParseArgs(eh.cfg, f, e); ok {
+								go f.Execute(e, args)
+							}
 						})
 						return
 					}
@@ -229,15 +255,14 @@ func (eh *handler) Handle(e *irc.Event) {
 
 		if !isPrivate {
 			eh.resetChannelInactivityTimeout(e)
-			stats.IncrementMessages(e.ReplyTarget())
+			stats.IncrementMessages(eh.cfg.IRC.Name, e.ReplyTarget())
 
-			bannedWords := eh.bannedWordsInMessage(e, tokens)
-			if len(bannedWords) > 0 {
-				label := "word"
-				if len(bannedWords) > 1 {
-					label = "words"
-				}
-				eh.irc.Kick(e.ReplyTarget(), e.From, fmt.Sprintf("banned %s: %s", label, strings.Join(bannedWords, ", ")))
+			if matches := eh.bannedWordsInMessage(e); len(matches) > 0 && eh.actOnBannedWords(e, matches) {
+				return
+			}
+
+			if rule := eh.spamRuleBroken(e); rule != nil {
+				eh.actOnSpam(e, *rule)
 				return
 			}
 		}
@@ -247,6 +272,15 @@ func (eh *handler) Handle(e *irc.Event) {
 			return
 		}
 
+		// tells are delivered whatever the recipient says, including commands
+		if !isPrivate && len(e.Message()) > 0 && eh.hasPendingTells(e, e.ReplyTarget()) {
+			go eh.deliverTells(e, e.ReplyTarget())
+		}
+
+		if !isPrivate && eh.registry.ExpandChannelAlias(e) {
+			tokens = commands.Tokens(e.Message())
+		}
+
 		if f := eh.FindMatchingCommand(e); f != nil {
 			f.IsAuthorized(e, e.ReplyTarget(), func(authorized bool) {
 				if !authorized {
@@ -260,18 +294,28 @@ func (eh *handler) Handle(e *irc.Event) {
 					return
 				}
 
+				// arguments are only checked for authorized users, so that others aren't told how to use the command
+				args, ok := commands.ParseArgs(eh.cfg, f, e)
+				if !ok {
+					return
+				}
+
+				if !isPrivate && !eh.isWithinCommandLimits(e, f, tokens) {
+					return
+				}
+
 				if !isPrivate {
 					go func() {
-						if err := firestore.Get().IncrementCommandUsage(e.ReplyTarget(), f.Name()); err != nil {
+						if err := storage.Network(eh.cfg.IRC.Name).IncrementCommandUsage(e.ReplyTarget(), f.Name()); err != nil {
 							logger.Errorf(e, "error incrementing command usage: %s", err)
 						}
 					}()
 				}
 
-				go f.Execute(e)
+				go f.Execute(e, args)
 			})
 		} else if !isPrivate && len(e.Message()) > 0 {
-			u, err := repository.GetUserByNick(e, e.ReplyTarget(), e.From, true)
+			u, err := repository.GetUserByIdentity(e, e.ReplyTarget(), e.From, e.Account, true)
 			if err != nil {
 				logger.Errorf(e, "unable to find or create user in order to update recent user messages, %s", err)
 			} else {
@@ -283,6 +327,151 @@ func (eh *handler) Handle(e *irc.Event) {
 	}
 }
 
+// isWithinCommandLimits checks the channel's limits on the command and records the use if it's within them. Otherwise,
+// users who typed the command are told when they can try again.
+func (eh *handler) isWithinCommandLimits(e *irc.Event, f commands.Command, tokens []string) bool {
+	logger := log.Logger()
+
+	ch, err := repository.GetChannel(e, e.ReplyTarget())
+	if err != nil {
+		logger.Errorf(e, "error retrieving channel for command limits, %s", err)
+		return true
+	}
+
+	limits := ch.CommandLimitsFor(f.Name())
+	if len(limits) == 0 {
+		return true
+	}
+
+	allowed, wait := eh.commandLimits.Allow(e.ReplyTarget(), userKey(e), limits)
+	if allowed {
+		return true
+	}
+
+	logger.Infof(e, "%s is limited in %s for %s, %s left", f.Name(), e.ReplyTarget(), e.From, wait)
+	if len(tokens) > 0 && strings.HasPrefix(tokens[0], eh.cfg.Commands.Prefix) {
+		// round so that the description reads as an hour rather than 60 minutes, with half a second of slack for the time
+		// it takes to describe
+		if wait > time.Minute {
+			wait = wait.Round(time.Minute)
+		} else {
+			wait = max(wait.Round(time.Second), time.Second)
+		}
+		retryAt := time.Now().Add(wait + time.Second/2)
+		f.Replyf(e, "Sorry, %s is limited in %s. Please try again in %s.", style.Bold(strings.TrimPrefix(tokens[0], eh.cfg.Commands.Prefix)), e.ReplyTarget(), elapse.FutureTimeDescriptionConcise(retryAt))
+	}
+	return false
+}
+
+const spamRulesCacheTTL = time.Minute
+
+type cachedSpamRules struct {
+	rules    []models.SpamRule
+	loadedAt time.Time
+}
+
+// spamRuleBroken checks the message against the channel's enabled spam rules, returning the first it breaks. Channel
+// operators and the bot's owner and admins are exempt.
+func (eh *handler) spamRuleBroken(e *irc.Event) *models.SpamRule {
+	channel := e.ReplyTarget()
+
+	rules, err := eh.channelSpamRules(channel)
+	if err != nil {
+		log.Logger().Errorf(e, "error getting spam rules for %s: %s", channel, err)
+		return nil
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+
+	if e.From == eh.cfg.IRC.Owner || slices.Contains(eh.cfg.IRC.Admins, e.From) {
+		return nil
+	}
+
+	if member := eh.irc.ChannelMember(channel, e.From); member != nil {
+		if member.Status == irc.ChannelStatusOperator || member.Status == irc.ChannelStatusHalfOperator {
+			return nil
+		}
+	}
+
+	members := make([]string, 0)
+	if slices.ContainsFunc(rules, func(r models.SpamRule) bool { return r.Rule == models.SpamRuleHighlight }) {
+		if state := eh.irc.ChannelState(channel); state != nil {
+			for _, u := range state.Members {
+				members = append(members, u.Mask.Nick)
+			}
+		}
+	}
+
+	return eh.spam.Check(channel, userKey(e), e.Message(), e.From, members, rules)
+}
+
+// userKey identifies the event's sender for spam rules and command limits, by account when it's known, otherwise by
+// nick and host, so that users sharing a gateway or bouncer host aren't counted together.
+func userKey(e *irc.Event) string {
+	if len(e.Account) > 0 {
+		return "$a:" + strings.ToLower(e.Account)
+	}
+	if host := eventHost(e); len(host) > 0 {
+		return fmt.Sprintf("%s@%s", strings.ToLower(e.From), host)
+	}
+	return e.From
+}
+
+// actOnSpam records a strike against the sender of the message that broke the rule, and takes the rule's action, or
+// the channel's strike ladder's when that's stronger.
+func (eh *handler) actOnSpam(e *irc.Event, rule models.SpamRule) {
+	channel := e.ReplyTarget()
+	reason := spamReasons[rule.Rule]
+	host := eventHost(e)
+	by := actions.Actor{Nick: eh.cfg.IRC.Nick, Source: models.AuditSourceSpam}
+
+	log.Logger().Warningf(e, "spam rule %s broken by %s in %s, action: %s", rule.Rule, e.From, channel, rule.Action)
+
+	// actions wait on storage and the channel's member list, so they mustn't block the event loop
+	go func() {
+		minimum := models.StrikeStep{Action: rule.Action, Duration: rule.Duration}
+		actions.StrikeAtLeast(eh.irc, channel, e.From, host, e.Account, models.StrikeSourceSpam, reason, reason, models.StrikeWeight(models.StrikeSourceSpam), minimum, by)
+	}()
+}
+
+// eventHost returns the host of the event's sender, or an empty string if the source isn't a user mask.
+func eventHost(e *irc.Event) string {
+	if !isUserMask(e.Source) {
+		return ""
+	}
+	return irc.ParseMask(e.Source).Host
+}
+
+func (eh *handler) channelSpamRules(channel string) ([]models.SpamRule, error) {
+	now := time.Now()
+	eh.RLock()
+	cached, ok := eh.spamRules[channel]
+	eh.RUnlock()
+	if ok && now.Sub(cached.loadedAt) < spamRulesCacheTTL {
+		return cached.rules, nil
+	}
+
+	ch, err := storage.Network(eh.cfg.IRC.Name).Channel(channel)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving channel: %w", err)
+	}
+
+	rules := make([]models.SpamRule, 0)
+	if ch != nil {
+		for _, r := range ch.SpamRuleSettings() {
+			if r.Enabled {
+				rules = append(rules, r)
+			}
+		}
+	}
+
+	eh.Lock()
+	eh.spamRules[channel] = cachedSpamRules{rules: rules, loadedAt: now}
+	eh.Unlock()
+	return rules, nil
+}
+
 func (eh *handler) findModeBypassCommand(e *irc.Event, mode modes.ChannelMode) commands.Command {
 	for _, f := range eh.registry.CommandsSortedForProcessing() {
 		if f.CanExecute(e) && mode.AllowCommand(f.Name()) {
@@ -408,7 +597,7 @@ func (eh *handler) channelInactivityDuration(channel string) (time.Duration, err
 		return cached.duration, nil
 	}
 
-	channelConfig, err := firestore.Get().Channel(channel)
+	channelConfig, err := storage.Network(eh.cfg.IRC.Name).Channel(channel)
 	if err != nil {
 		return 0, fmt.Errorf("error retrieving channel: %w", err)
 	}
@@ -427,69 +616,219 @@ func (eh *handler) channelInactivityDuration(channel string) (time.Duration, err
 	return duration, nil
 }
 
-// normalizeToken strips all non-alphanumeric characters and lowercases the result.
-func normalizeToken(s string) string {
-	var b strings.Builder
-	for _, r := range strings.ToLower(s) {
-		if unicode.IsLetter(r) || unicode.IsNumber(r) {
-			b.WriteRune(r)
+const bannedWordsCacheTTL = time.Minute
+
+type cachedBannedWords struct {
+	words    map[string]*models.BannedWord
+	unstored map[string]bool
+	loadedAt time.Time
+}
+
+// bannedWordsInMessage returns the channel's banned words that the message contains. The session holds the words
+// banned in the channel, and storage the settings for each, with words banned only in the session kicking on sight.
+func (eh *handler) bannedWordsInMessage(e *irc.Event) []*models.BannedWord {
+	logger := log.Logger()
+	channel := e.ReplyTarget()
+
+	sessionWords := eh.ctx.Session().BannedWords(channel)
+	stored, err := eh.channelBannedWords(channel, sessionWords)
+	if err != nil {
+		logger.Errorf(e, "error getting banned words for %s: %s", channel, err)
+	}
+
+	bannedWords := make([]*models.BannedWord, 0, len(stored))
+	for _, bw := range stored {
+		bannedWords = append(bannedWords, bw)
+	}
+	for word := range sessionWords {
+		if _, ok := stored[word]; !ok {
+			bannedWords = append(bannedWords, models.NewBannedWord(word))
 		}
 	}
-	return b.String()
+
+	matches := matchingBannedWords(e.Message(), bannedWords)
+	for _, bw := range matches {
+		logger.Warningf(e, "banned %s detected: %s", bw.MatchKind(), bw.Word)
+	}
+	return matches
 }
 
-func (eh *handler) bannedWordsInMessage(e *irc.Event, tokens []string) []string {
-	logger := log.Logger()
+// channelBannedWords returns the channel's stored banned words by word. They're reloaded before the cache expires when
+// the session has a word that wasn't there when they were loaded, so that a word's settings apply as soon as it's added.
+func (eh *handler) channelBannedWords(channel string, sessionWords map[string]bool) (map[string]*models.BannedWord, error) {
+	now := time.Now()
+	eh.RLock()
+	cached, ok := eh.bannedWords[channel]
+	eh.RUnlock()
+	if ok && now.Sub(cached.loadedAt) < bannedWordsCacheTTL && !hasNewBannedWord(cached, sessionWords) {
+		return cached.words, nil
+	}
 
-	bannedWords := eh.ctx.Session().BannedWords(e.ReplyTarget())
-	if len(bannedWords) == 0 {
-		return nil
+	bannedWords, err := storage.Network(eh.cfg.IRC.Name).BannedWords(channel)
+	if err != nil {
+		return nil, err
 	}
 
-	// normalize all tokens once
-	normalized := make([]string, 0, len(tokens))
-	for _, token := range tokens {
-		if n := normalizeToken(token); len(n) > 0 {
-			normalized = append(normalized, n)
+	words := make(map[string]*models.BannedWord, len(bannedWords))
+	for _, bw := range bannedWords {
+		words[bw.Word] = bw
+	}
+
+	unstored := make(map[string]bool)
+	for word := range sessionWords {
+		if _, ok := words[word]; !ok {
+			unstored[word] = true
 		}
 	}
 
-	matches := make(map[string]bool)
+	eh.Lock()
+	eh.bannedWords[channel] = cachedBannedWords{words: words, unstored: unstored, loadedAt: now}
+	eh.Unlock()
+	return words, nil
+}
 
-	for phrase := range bannedWords {
-		phraseWords := strings.Fields(phrase)
+func hasNewBannedWord(cached cachedBannedWords, sessionWords map[string]bool) bool {
+	for word := range sessionWords {
+		if _, ok := cached.words[word]; !ok && !cached.unstored[word] {
+			return true
+		}
+	}
+	return false
+}
 
-		if len(phraseWords) == 1 {
-			// single word: check each normalized token
-			for _, n := range normalized {
-				if n == phraseWords[0] {
-					logger.Warningf(e, "banned word detected: %s", phrase)
-					matches[phrase] = true
-					break
-				}
-			}
+// bannedWordPublicReason is all users are told when they're acted on for a banned word, so that it isn't repeated in
+// the channel. The words themselves are kept to the audit log and the ops notice.
+const bannedWordPublicReason = "use of a banned word"
+
+// actOnBannedWords reports matches in dry run to the channel's ops, and for the rest records a strike against the
+// sender and reports it to the ops. The channel's strike ladder decides what happens, but never less than the most
+// severe action of the matched entries. It reports whether any action was taken.
+func (eh *handler) actOnBannedWords(e *irc.Event, matches []*models.BannedWord) bool {
+	channel := e.ReplyTarget()
+	host := eventHost(e)
+
+	live := make([]*models.BannedWord, 0, len(matches))
+	dryRun := make([]string, 0)
+	for _, bw := range matches {
+		if bw.DryRun {
+			dryRun = append(dryRun, bw.Word)
 		} else {
-			// multi-word phrase: check consecutive normalized tokens
-			for i := 0; i <= len(normalized)-len(phraseWords); i++ {
-				match := true
-				for j, pw := range phraseWords {
-					if normalized[i+j] != pw {
-						match = false
-						break
-					}
-				}
-				if match {
-					logger.Warningf(e, "banned phrase detected: %s", phrase)
-					matches[phrase] = true
-					break
-				}
-			}
+			live = append(live, bw)
+		}
+	}
+
+	if len(dryRun) > 0 {
+		msg := fmt.Sprintf("[dry run] %s in %s matched banned %s: %s", style.Bold(e.From), channel, pluralize("word", len(dryRun)), strings.Join(dryRun, ", "))
+		go actions.NotifyOps(eh.irc, channel, msg)
+	}
+
+	if len(live) == 0 {
+		return false
+	}
+
+	var strongest models.StrikeStep
+	words := make([]string, 0, len(live))
+	for _, bw := range live {
+		words = append(words, bw.Word)
+		if step := (models.StrikeStep{Action: bw.ModerationAction(), Duration: bw.Duration}); step.Outranks(strongest) {
+			strongest = step
 		}
 	}
 
-	result := make([]string, 0, len(matches))
-	for word := range matches {
-		result = append(result, word)
+	reason := fmt.Sprintf("banned %s: %s", pluralize("word", len(words)), strings.Join(words, ", "))
+	by := actions.Actor{Nick: eh.cfg.IRC.Nick, Source: models.AuditSourceBannedWord}
+
+	// actions wait on storage and the channel's member list, so they mustn't block the event loop
+	go func() {
+		actions.NotifyOps(eh.irc, channel, fmt.Sprintf("%s in %s matched %s", style.Bold(e.From), channel, reason))
+		actions.StrikeAtLeast(eh.irc, channel, e.From, host, e.Account, models.StrikeSourceBannedWord, reason, bannedWordPublicReason, models.StrikeWeight(models.StrikeSourceBannedWord), strongest, by)
+	}()
+
+	return true
+}
+
+// checkEvasion scores a user joining the channel against its bans and recently banned and muted users, reporting likely
+// evaders to the channel's ops and, if the channel auto-quiets them, quietly silencing them. The bans come from the
+// channel's ban list as tracked through MODE changes, which is only requested when it hasn't been received yet.
+func (eh *handler) checkEvasion(e *irc.Event, channel string) {
+	logger := log.Logger()
+
+	if !isUserMask(e.Source) || e.From == eh.cfg.IRC.Nick || e.From == eh.cfg.IRC.Owner || slices.Contains(eh.cfg.IRC.Admins, e.From) {
+		return
+	}
+
+	fs := storage.Network(eh.cfg.IRC.Name)
+	ch, err := fs.Channel(channel)
+	if err != nil {
+		logger.Errorf(e, "evasion: error getting channel %s: %s", channel, err)
+		return
+	}
+	if ch == nil || !ch.EvasionDetection.Enabled || slices.Contains(ch.AutoVoiced, e.From) {
+		return
+	}
+
+	joiner := irc.ParseMask(e.Source)
+	key := strings.ToLower(channel + " " + joiner.String())
+	eh.RLock()
+	reportedAt, reported := eh.evasionReports[key]
+	eh.RUnlock()
+	if reported && time.Since(reportedAt) < evasionReportInterval {
+		return
+	}
+
+	entries, err := fs.AuditEntriesSince(channel, time.Now().Add(-evasionLookback))
+	if err != nil {
+		logger.Errorf(e, "evasion: error getting audit log for %s: %s", channel, err)
+	}
+
+	aliases := make([]string, 0)
+	users, err := fs.GetUsersByHost(channel, joiner.Host)
+	if err != nil {
+		logger.Errorf(e, "evasion: error getting users by host: %s", err)
+	}
+	if ident := normalizeIdent(joiner.UserID); !slices.Contains(genericIdents, ident) {
+		identUsers, err := fs.GetUsersByUserID(channel, joiner.UserID)
+		if err != nil {
+			logger.Errorf(e, "evasion: error getting users by ident: %s", err)
+		}
+		users = append(users, identUsers...)
+	}
+	for _, u := range users {
+		if !strings.EqualFold(u.Nick, joiner.Nick) {
+			aliases = append(aliases, u.Nick)
+		}
+	}
+
+	eh.irc.ListBans(channel, func(bans []*irc.BanEntry) {
+		match := bestEvasionMatch(joiner, aliases, evasionSuspects(bans, entries, time.Now()))
+		if match == nil || match.score < ch.EvasionDetection.ScoreThreshold() {
+			return
+		}
+
+		eh.Lock()
+		for k, at := range eh.evasionReports {
+			if time.Since(at) >= evasionReportInterval {
+				delete(eh.evasionReports, k)
+			}
+		}
+		eh.evasionReports[key] = time.Now()
+		eh.Unlock()
+
+		logger.Warningf(e, "evasion: %s in %s scored %d against %s: %s", joiner, channel, match.score, match.suspect.Why, strings.Join(match.signals, ", "))
+
+		msg := fmt.Sprintf("⚠️ Possible ban evasion in %s: %s (%s) scored %d, %s: %s", channel, style.Bold(joiner.Nick), joiner, match.score, match.suspect.Why, strings.Join(match.signals, ", "))
+		actions.NotifyOps(eh.irc, channel, msg)
+
+		if ch.EvasionDetection.AutoQuiet {
+			by := actions.Actor{Nick: eh.cfg.IRC.Nick, Source: models.AuditSourceEvasion}
+			actions.Quiet(eh.irc, channel, joiner.Nick, joiner.Host, ch.EvasionDetection.QuietDuration, "possible ban evasion", by)
+		}
+	})
+}
+
+func pluralize(noun string, n int) string {
+	if n == 1 {
+		return noun
 	}
-	return result
+	return noun + "s"
 }
@@ -0,0 +1,709 @@
+package events
+
+import (
+	"assistant/pkg/api/context"
+	"assistant/pkg/api/irc"
+	"assistant/pkg/api/irc/irctest"
+	"assistant/pkg/config"
+	"assistant/pkg/log"
+	"assistant/pkg/models"
+	"assistant/pkg/scheduler"
+	"assistant/pkg/storage"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const scenarioChannel = "#scenario"
+
+var scenarios atomic.Int32
+
+func TestMain(m *testing.M) {
+	log.InitializeConsoleLogger()
+
+	dir, err := os.MkdirTemp("", "events")
+	if err != nil {
+		panic(err)
+	}
+
+	cfg := &config.Config{
+		IRC:     config.IRCConfig{Nick: "assistant"},
+		Storage: config.StorageConfig{Backend: config.StorageBackendLocal, Path: filepath.Join(dir, "test.db")},
+		Queue:   config.QueueConfig{Backend: config.QueueBackendLocal, Path: filepath.Join(dir, "queue")},
+	}
+	if _, err = storage.Initialize(context.NewContext(), cfg); err != nil {
+		panic(err)
+	}
+	// scenarios only schedule tasks that are due long after they end, such as feed polls
+	if _, err = scheduler.Initialize(context.NewContext(), cfg); err != nil {
+		panic(err)
+	}
+
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+// startScenario connects an assistant to a fake server, with events going through a handler, and waits for it to join
+// the scenario channel. Each scenario gets a network of its own so that command registries, which hold on to the IRC
+// connection, aren't shared between them; the returned configuration names it.
+func startScenario(t *testing.T) (*irctest.Server, context.Context, *config.Config) {
+	t.Helper()
+
+	server := irctest.NewServer(t)
+	cfg := &config.Config{
+		IRC: config.IRCConfig{
+			Name:        fmt.Sprintf("%s-%d", t.Name(), scenarios.Add(1)),
+			Nick:        "assistant",
+			Username:    "assistant",
+			Owner:       "owner",
+			PostConnect: config.PostConnectConfig{AutoJoin: []string{scenarioChannel}},
+		},
+		Commands: config.CommandsConfig{Prefix: "!"},
+	}
+	server.Configure(cfg)
+
+	ctx := context.NewContext()
+	svc := irc.NewIRC(ctx)
+	if err := svc.Connect(cfg, func(context.Context, *config.Config, irc.IRC) {}, nil); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	t.Cleanup(svc.Disconnect)
+	// cleanups run last in first out: closing the server first ends the blocking read Disconnect waits for
+	t.Cleanup(server.Close)
+
+	ech := make(chan *irc.Event)
+	go svc.Listen(ech)
+
+	h := NewHandler(ctx, cfg, svc)
+	go func() {
+		for e := range ech {
+			h.Handle(e)
+		}
+	}()
+
+	server.WaitForMember(t, scenarioChannel, "assistant")
+	return server, ctx, cfg
+}
+
+// createScenarioChannel stores the scenario channel for commands that look it up. Scenarios share a store when no
+// networks are configured, so a channel left by an earlier scenario is reset.
+func createScenarioChannel(t *testing.T, cfg *config.Config) {
+	t.Helper()
+
+	store := storage.Network(cfg.IRC.Name)
+	ch := models.NewChannel(scenarioChannel, "")
+	if existing, _ := store.Channel(scenarioChannel); existing != nil {
+		if err := store.UpdateChannel(scenarioChannel, map[string]any{"roles": ch.Roles, "voice_requests": ch.VoiceRequests, "command_limits": ch.CommandLimits, "custom_commands": ch.CustomCommands, "spam_rules": ch.SpamRules, "strike_ladder": ch.StrikeLadder, "evasion_detection": ch.EvasionDetection, "ops_channel": ch.OpsChannel}); err != nil {
+			t.Fatalf("UpdateChannel() error = %v", err)
+		}
+		return
+	}
+	if err := store.CreateChannel(ch); err != nil {
+		t.Fatalf("CreateChannel() error = %v", err)
+	}
+}
+
+func TestScenarioCommandAuthorization(t *testing.T) {
+	server, _, _ := startScenario(t)
+
+	owner := server.AddUser("owner")
+	stranger := server.AddUser("stranger")
+	owner.Join(scenarioChannel)
+	stranger.Join(scenarioChannel)
+
+	owner.Say(scenarioChannel, "!echo hello there")
+	server.ExpectMessage(t, scenarioChannel, "hello there")
+
+	stranger.Say(scenarioChannel, "!echo hello there")
+	server.ExpectMessage(t, scenarioChannel, "not authorized")
+
+	// arguments are only checked once the sender is authorized
+	time.Sleep(1500 * time.Millisecond)
+	stranger.Say(scenarioChannel, "!kick")
+	server.ExpectMessage(t, scenarioChannel, "not authorized")
+	server.Refute(t, time.Second, "argument error for an unauthorized user", func(m *irctest.Message) bool {
+		return m.Command == "PRIVMSG" && strings.Contains(m.Trailing(), "Missing")
+	})
+
+	owner.Say(scenarioChannel, "!kick")
+	server.ExpectMessage(t, scenarioChannel, "Missing <nick>")
+}
+
+func TestScenarioCommandRateLimit(t *testing.T) {
+	server, _, _ := startScenario(t)
+
+	owner := server.AddUser("owner")
+	owner.Join(scenarioChannel)
+
+	owner.Say(scenarioChannel, "!echo first")
+	server.ExpectMessage(t, scenarioChannel, "first")
+
+	owner.Say(scenarioChannel, "!echo second")
+	server.Refute(t, 500*time.Millisecond, "reply to a rate limited command", func(m *irctest.Message) bool {
+		return m.Command == "PRIVMSG" && m.Trailing() == "second"
+	})
+}
+
+func TestScenarioBannedWordKicks(t *testing.T) {
+	server, ctx, _ := startScenario(t)
+	ctx.Session().AddBannedWord(scenarioChannel, "grapefruit")
+	server.SetModes(scenarioChannel, "+o", "assistant")
+
+	user := server.AddUser("user")
+	user.Join(scenarioChannel)
+	user.Say(scenarioChannel, "I had a Grapefruit for breakfast")
+
+	kick := server.ExpectCommand(t, "KICK", scenarioChannel, "user")
+	if kick.Trailing() != "use of a banned word" {
+		t.Fatalf("kick reason = %q", kick.Trailing())
+	}
+	if _, ok := server.Members(scenarioChannel)["user"]; ok {
+		t.Fatalf("user is still in %s", scenarioChannel)
+	}
+}
+
+func TestScenarioBannedPatternDryRunAndWarn(t *testing.T) {
+	server, _, cfg := startScenario(t)
+	server.SetModes(scenarioChannel, "+o", "assistant")
+
+	store := storage.Network(cfg.IRC.Name)
+	t.Cleanup(func() {
+		_ = store.RemoveBannedWord(scenarioChannel, "lemonade")
+		_ = store.RemoveBannedWord(scenarioChannel, "marmalade")
+	})
+
+	owner := server.AddUser("owner")
+	shopper := server.AddUser("shopper")
+	owner.Join(scenarioChannel)
+	shopper.Join(scenarioChannel)
+
+	owner.Say(scenarioChannel, "!bwadd --dry-run lemonade")
+	server.ExpectMessage(t, scenarioChannel, "updated banned words")
+	time.Sleep(1500 * time.Millisecond)
+	owner.Say(scenarioChannel, "!bwadd --kind=fuzzy --action=warn marmalade")
+	server.ExpectMessage(t, scenarioChannel, "updated banned words")
+
+	shopper.Say(scenarioChannel, "l3m0nade anyone?")
+	server.Refute(t, time.Second, "action on a dry run match", func(m *irctest.Message) bool {
+		return m.Command == "KICK" || (m.Command == "PRIVMSG" && strings.Contains(m.Trailing(), "warned"))
+	})
+
+	time.Sleep(1500 * time.Millisecond)
+	shopper.Say(scenarioChannel, "or m4rmalaade")
+	server.ExpectMessage(t, scenarioChannel, "shopper has been warned: use of a banned word")
+	if _, ok := server.Members(scenarioChannel)["shopper"]; !ok {
+		t.Fatalf("warned user was removed from %s", scenarioChannel)
+	}
+}
+
+func TestScenarioBannedWordActionIsLadderMinimum(t *testing.T) {
+	server, ctx, cfg := startScenario(t)
+	createScenarioChannel(t, cfg)
+	ctx.Session().AddBannedWord(scenarioChannel, "grapefruit")
+	server.SetModes(scenarioChannel, "+o", "assistant")
+
+	store := storage.Network(cfg.IRC.Name)
+	ladder := []models.StrikeStep{{Strikes: 1, Action: models.ModerationActionWarn}}
+	if err := store.UpdateChannel(scenarioChannel, map[string]any{"strike_ladder": ladder}); err != nil {
+		t.Fatalf("UpdateChannel() error = %v", err)
+	}
+	t.Cleanup(func() { store.UpdateChannel(scenarioChannel, map[string]any{"strike_ladder": []models.StrikeStep{}}) })
+
+	user := server.AddUser("user")
+	user.Join(scenarioChannel)
+	user.Say(scenarioChannel, "grapefruit again")
+
+	// the ladder only warns, but the entry kicks
+	kick := server.ExpectCommand(t, "KICK", scenarioChannel, "user")
+	if strings.Contains(kick.Trailing(), "grapefruit") {
+		t.Fatalf("kick reason = %q, repeats the banned word", kick.Trailing())
+	}
+}
+
+func TestScenarioBanEvasionIsReported(t *testing.T) {
+	server, _, cfg := startScenario(t)
+	createScenarioChannel(t, cfg)
+	server.ExpectCommand(t, "MODE", scenarioChannel, "+b")
+	server.SetModes(scenarioChannel, "+o", "assistant")
+	server.SetModes(scenarioChannel, "+b", "troll!*@troll.users.test")
+
+	store := storage.Network(cfg.IRC.Name)
+	detection := models.EvasionDetection{Enabled: true, AutoQuiet: true}
+	if err := store.UpdateChannel(scenarioChannel, map[string]any{"evasion_detection": detection, "ops_channel": "#scenario-ops"}); err != nil {
+		t.Fatalf("UpdateChannel() error = %v", err)
+	}
+	t.Cleanup(func() {
+		store.UpdateChannel(scenarioChannel, map[string]any{"evasion_detection": models.EvasionDetection{}, "ops_channel": ""})
+	})
+
+	bystander := server.AddUser("bystander")
+	bystander.Join(scenarioChannel)
+	server.Refute(t, 500*time.Millisecond, "report of an unrelated joiner", func(m *irctest.Message) bool {
+		return m.Command == "PRIVMSG" && strings.Contains(m.Trailing(), "bystander")
+	})
+
+	// a nick that only resembles the banned one isn't enough
+	lookalike := server.AddUser("troll_")
+	lookalike.Join(scenarioChannel)
+	server.Refute(t, 500*time.Millisecond, "report of a joiner resembling the banned user by nick alone", func(m *irctest.Message) bool {
+		return m.Command == "PRIVMSG" && strings.Contains(m.Trailing(), "troll_")
+	})
+
+	evader := server.AddUser("troll")
+	evader.ChangeNick("Tr0ll_")
+	evader.Join(scenarioChannel)
+
+	// moderation is sent ahead of messages, and only quiets the evader
+	server.ExpectCommand(t, "MODE", scenarioChannel, "+q", "Tr0ll_!*@troll.users.test")
+	report := server.ExpectMessage(t, "#scenario-ops", "Possible ban evasion")
+	if !strings.Contains(report.Trailing(), "nick variant of troll") {
+		t.Fatalf("report = %q", report.Trailing())
+	}
+	server.Refute(t, 500*time.Millisecond, "public accusation or ban list request", func(m *irctest.Message) bool {
+		return (m.Command == "PRIVMSG" && strings.EqualFold(m.Param(0), scenarioChannel)) || (m.Command == "MODE" && len(m.Params) == 2 && m.Params[1] == "+b")
+	})
+}
+
+func TestScenarioKickNeedsChannelStatus(t *testing.T) {
+	server, _, _ := startScenario(t)
+
+	owner := server.AddUser("owner")
+	target := server.AddUser("target")
+	owner.Join(scenarioChannel)
+	target.Join(scenarioChannel)
+
+	owner.Say(scenarioChannel, "!kick target")
+	server.ExpectMessage(t, scenarioChannel, "missing required permissions")
+
+	server.SetModes(scenarioChannel, "+h", "assistant")
+	time.Sleep(1500 * time.Millisecond)
+	owner.Say(scenarioChannel, "!kick target enough")
+	server.ExpectCommand(t, "KICK", scenarioChannel, "target", "enough")
+}
+
+func TestScenarioKickIsAudited(t *testing.T) {
+	server, _, cfg := startScenario(t)
+	server.SetModes(scenarioChannel, "+h", "assistant")
+
+	owner := server.AddUser("owner")
+	auditee := server.AddUser("auditee")
+	owner.Join(scenarioChannel)
+	auditee.Join(scenarioChannel)
+
+	owner.Say(scenarioChannel, "!kick auditee off topic")
+	server.ExpectCommand(t, "KICK", scenarioChannel, "auditee", "off topic")
+
+	store := storage.Network(cfg.IRC.Name)
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		entries, err := store.AuditEntries(scenarioChannel)
+		if err != nil {
+			t.Fatalf("AuditEntries() error = %v", err)
+		}
+		for _, entry := range entries {
+			if entry.Action == models.AuditActionKick && entry.Target == "auditee" {
+				if entry.Actor != "owner" || entry.Source != models.AuditSourceCommand || entry.Reason != "off topic" {
+					t.Fatalf("audit entry = %+v", entry)
+				}
+				return
+			}
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("kick wasn't recorded in the audit log")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+func TestScenarioVoiceRequest(t *testing.T) {
+	server, _, cfg := startScenario(t)
+	createScenarioChannel(t, cfg)
+	server.SetModes(scenarioChannel, "+m")
+
+	user := server.AddUser("quiet")
+	user.Join(scenarioChannel)
+
+	user.Say("assistant", "!voice "+scenarioChannel)
+	server.ExpectMessage(t, "quiet", "has been received")
+
+	time.Sleep(1500 * time.Millisecond)
+	user.Say("assistant", "!voice "+scenarioChannel)
+	server.ExpectMessage(t, "quiet", "already requested voice")
+}
+
+func TestScenarioChannelRoleGrant(t *testing.T) {
+	server, _, cfg := startScenario(t)
+	createScenarioChannel(t, cfg)
+
+	owner := server.AddUser("owner")
+	helper := server.AddUser("helper")
+	owner.Join(scenarioChannel)
+	helper.Join(scenarioChannel)
+
+	helper.Say(scenarioChannel, "!learn greeting Hello there")
+	server.ExpectMessage(t, scenarioChannel, "not authorized")
+
+	owner.Say(scenarioChannel, "!roles assign trusted helper")
+	server.ExpectMessage(t, scenarioChannel, "Added")
+	time.Sleep(1500 * time.Millisecond)
+	owner.Say(scenarioChannel, "!grant trusted learn")
+	server.ExpectMessage(t, scenarioChannel, "Granted")
+
+	time.Sleep(1500 * time.Millisecond)
+	helper.Say(scenarioChannel, "!learn greeting Hello there")
+	server.ExpectMessage(t, scenarioChannel, "learned")
+
+	changes, err := storage.Network(cfg.IRC.Name).RoleChanges(scenarioChannel)
+	if err != nil || len(changes) < 2 {
+		t.Fatalf("RoleChanges() = %d changes, error = %v", len(changes), err)
+	}
+	if changes[0].Action != models.RoleChangeGrant || changes[0].Subject != "learn" || changes[0].Actor != "owner" {
+		t.Fatalf("latest role change = %+v", changes[0])
+	}
+	if changes[1].Action != models.RoleChangeAssign || !models.IsRoleMemberMask(changes[1].Subject) {
+		t.Fatalf("role assigned to %q, want a host mask rather than the nick", changes[1].Subject)
+	}
+}
+
+func TestScenarioChannelRolesCannotReachBotAdminCommands(t *testing.T) {
+	server, _, cfg := startScenario(t)
+	createScenarioChannel(t, cfg)
+
+	op := server.AddUser("op")
+	op.Join(scenarioChannel)
+	server.SetModes(scenarioChannel, "+o", "op")
+	time.Sleep(500 * time.Millisecond)
+
+	op.Say(scenarioChannel, "!roles assign owner op")
+	server.ExpectMessage(t, scenarioChannel, "only the bot's owner and admins can change")
+
+	time.Sleep(1500 * time.Millisecond)
+	op.Say(scenarioChannel, "!grant regular say")
+	server.ExpectMessage(t, scenarioChannel, "can only be used by the bot's owner and admins")
+
+	store := storage.Network(cfg.IRC.Name)
+	ch, err := store.Channel(scenarioChannel)
+	if err != nil {
+		t.Fatalf("Channel() error = %v", err)
+	}
+	ch.AssignRole(models.ChannelRoleOwner, "*!*@*")
+	if err = store.UpdateChannel(scenarioChannel, map[string]any{"roles": ch.Roles}); err != nil {
+		t.Fatalf("UpdateChannel() error = %v", err)
+	}
+
+	time.Sleep(1500 * time.Millisecond)
+	op.Say(scenarioChannel, "!say "+scenarioChannel+" hijacked")
+	server.ExpectMessage(t, scenarioChannel, "not authorized")
+}
+
+func TestScenarioCommandLimit(t *testing.T) {
+	server, _, cfg := startScenario(t)
+	createScenarioChannel(t, cfg)
+
+	store := storage.Network(cfg.IRC.Name)
+	limits := []models.CommandLimit{{Command: "echo", Scope: models.CommandLimitScopeUser, Quota: 1, Window: "1h"}}
+	if err := store.UpdateChannel(scenarioChannel, map[string]any{"command_limits": limits}); err != nil {
+		t.Fatalf("UpdateChannel() error = %v", err)
+	}
+	t.Cleanup(func() {
+		_ = store.UpdateChannel(scenarioChannel, map[string]any{"command_limits": []models.CommandLimit{}})
+	})
+
+	owner := server.AddUser("owner")
+	owner.Join(scenarioChannel)
+
+	owner.Say(scenarioChannel, "!echo first")
+	server.ExpectMessage(t, scenarioChannel, "first")
+
+	time.Sleep(1500 * time.Millisecond)
+	owner.Say(scenarioChannel, "!echo second")
+	server.ExpectMessage(t, scenarioChannel, "try again in an hour")
+}
+
+func TestScenarioChannelAliasAndMacro(t *testing.T) {
+	server, _, cfg := startScenario(t)
+	createScenarioChannel(t, cfg)
+
+	owner := server.AddUser("owner")
+	owner.Join(scenarioChannel)
+
+	owner.Say(scenarioChannel, "!alias add shout !echo $nick says $*")
+	server.ExpectMessage(t, scenarioChannel, "Added")
+	time.Sleep(1500 * time.Millisecond)
+	owner.Say(scenarioChannel, "!shout hello there")
+	server.ExpectMessage(t, scenarioChannel, "owner says hello there")
+
+	time.Sleep(1500 * time.Millisecond)
+	owner.Say(scenarioChannel, "!macro add welcome reply Welcome to the channel, $1!")
+	server.ExpectMessage(t, scenarioChannel, "Added")
+	time.Sleep(1500 * time.Millisecond)
+	owner.Say(scenarioChannel, "!welcome newbie")
+	server.ExpectMessage(t, scenarioChannel, "Welcome to the channel, newbie!")
+
+	time.Sleep(1500 * time.Millisecond)
+	owner.Say(scenarioChannel, "!alias add echo !say")
+	server.ExpectMessage(t, scenarioChannel, "already a command")
+}
+
+func TestScenarioFactoids(t *testing.T) {
+	server, _, cfg := startScenario(t)
+	createScenarioChannel(t, cfg)
+	t.Cleanup(func() {
+		store := storage.Network(cfg.IRC.Name)
+		_ = store.DeleteFactoid(scenarioChannel, "rules")
+		_ = store.DeleteFactoid(scenarioChannel, "r")
+	})
+
+	owner := server.AddUser("owner")
+	owner.Join(scenarioChannel)
+
+	owner.Say(scenarioChannel, "!learn rules Be nice, $nick")
+	server.ExpectMessage(t, scenarioChannel, "learned")
+	time.Sleep(1500 * time.Millisecond)
+	owner.Say(scenarioChannel, "?rules")
+	server.ExpectMessage(t, scenarioChannel, "Be nice, owner")
+
+	time.Sleep(1500 * time.Millisecond)
+	owner.Say(scenarioChannel, "!learn r @rules")
+	server.ExpectMessage(t, scenarioChannel, "learned")
+	time.Sleep(1500 * time.Millisecond)
+	owner.Say(scenarioChannel, "?r newbie")
+	server.ExpectMessage(t, scenarioChannel, "newbie: Be nice, owner")
+
+	time.Sleep(1500 * time.Millisecond)
+	owner.Say(scenarioChannel, "!learn rules Be kind in $channel")
+	server.ExpectMessage(t, scenarioChannel, "version 2")
+	time.Sleep(1500 * time.Millisecond)
+	owner.Say(scenarioChannel, "!fact r")
+	server.ExpectMessage(t, scenarioChannel, "Be kind in "+scenarioChannel)
+}
+
+func TestScenarioTell(t *testing.T) {
+	server, _, cfg := startScenario(t)
+	createScenarioChannel(t, cfg)
+
+	owner := server.AddUser("owner")
+	bob := server.AddUser("bob")
+	owner.Join(scenarioChannel)
+	bob.Join(scenarioChannel)
+
+	bob.Say(scenarioChannel, "hello")
+	time.Sleep(500 * time.Millisecond)
+
+	owner.Say(scenarioChannel, "!tell bob the build is fixed")
+	server.ExpectMessage(t, scenarioChannel, "when they're next here")
+
+	time.Sleep(1500 * time.Millisecond)
+	bob.Say(scenarioChannel, "back again")
+	server.ExpectMessage(t, scenarioChannel, "the build is fixed")
+
+	time.Sleep(1500 * time.Millisecond)
+	owner.Say(scenarioChannel, "!tell bob the release is out")
+	server.ExpectMessage(t, scenarioChannel, "when they're next here")
+
+	time.Sleep(1500 * time.Millisecond)
+	bob.Say(scenarioChannel, "!tz")
+	server.ExpectMessage(t, scenarioChannel, "the release is out")
+
+	time.Sleep(1500 * time.Millisecond)
+	owner.Say(scenarioChannel, "!tell nobody hello")
+	server.ExpectMessage(t, scenarioChannel, "haven't seen")
+}
+
+func TestScenarioReminderTimeZone(t *testing.T) {
+	server, _, cfg := startScenario(t)
+	createScenarioChannel(t, cfg)
+
+	owner := server.AddUser("owner")
+	owner.Join(scenarioChannel)
+
+	owner.Say(scenarioChannel, "!tz europe/berlin")
+	server.ExpectMessage(t, scenarioChannel, "Europe/Berlin")
+
+	time.Sleep(1500 * time.Millisecond)
+	owner.Say(scenarioChannel, "!tz")
+	server.ExpectMessage(t, scenarioChannel, "where it's")
+
+	time.Sleep(1500 * time.Millisecond)
+	owner.Say(scenarioChannel, "!remind on 2020-01-01 stretch")
+	server.ExpectMessage(t, scenarioChannel, "already passed")
+}
+
+func TestScenarioReminderManagement(t *testing.T) {
+	server, _, cfg := startScenario(t)
+	createScenarioChannel(t, cfg)
+
+	owner := server.AddUser("owner")
+	owner.Join(scenarioChannel)
+	guest := server.AddUser("guest")
+	guest.Join(scenarioChannel)
+
+	owner.Say(scenarioChannel, "!remind every 5m stretch")
+	server.ExpectMessage(t, scenarioChannel, "more often than every 15 minutes")
+
+	guest.Say(scenarioChannel, "!remind "+scenarioChannel+" every monday at 10am standup")
+	server.ExpectMessage(t, scenarioChannel, "only channel operators")
+
+	time.Sleep(1500 * time.Millisecond)
+	owner.Say(scenarioChannel, "!snooze 15m")
+	server.ExpectMessage(t, scenarioChannel, "haven't been given a reminder")
+
+	time.Sleep(1500 * time.Millisecond)
+	owner.Say(scenarioChannel, "!reminders cancel abc123")
+	server.ExpectMessage(t, scenarioChannel, "no active reminders")
+}
+
+func TestScenarioPoll(t *testing.T) {
+	server, _, cfg := startScenario(t)
+	createScenarioChannel(t, cfg)
+
+	owner := server.AddUser("owner")
+	owner.Join(scenarioChannel)
+	guest := server.AddUser("guest")
+	guest.Join(scenarioChannel)
+
+	owner.Say(scenarioChannel, `!poll "Where should we eat?" pizza | sushi | tacos`)
+	server.ExpectMessage(t, scenarioChannel, "Where should we eat?")
+
+	guest.Say(scenarioChannel, "!vote 2")
+	server.ExpectMessage(t, scenarioChannel, "Voted")
+
+	time.Sleep(1500 * time.Millisecond)
+	guest.Say(scenarioChannel, "!vote 1")
+	server.ExpectMessage(t, scenarioChannel, "your vote to")
+
+	time.Sleep(1500 * time.Millisecond)
+	guest.Say(scenarioChannel, "!poll close")
+	server.ExpectMessage(t, scenarioChannel, "can close this poll")
+
+	time.Sleep(1500 * time.Millisecond)
+	owner.Say(scenarioChannel, "!poll close")
+	server.ExpectMessage(t, scenarioChannel, "Winner:")
+
+	time.Sleep(1500 * time.Millisecond)
+	guest.Say(scenarioChannel, "!vote 3")
+	server.ExpectMessage(t, scenarioChannel, "no open poll")
+}
+
+func TestScenarioFeed(t *testing.T) {
+	rss := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		fmt.Fprint(w, `<rss version="2.0"><channel><title>Scenario News</title><item><title>Old news</title><link>https://example.com/old</link></item></channel></rss>`)
+	}))
+	defer rss.Close()
+
+	server, _, cfg := startScenario(t)
+	createScenarioChannel(t, cfg)
+
+	owner := server.AddUser("owner")
+	owner.Join(scenarioChannel)
+	guest := server.AddUser("guest")
+	guest.Join(scenarioChannel)
+
+	guest.Say(scenarioChannel, "!feed add "+rss.URL)
+	server.ExpectMessage(t, scenarioChannel, "not authorized")
+
+	owner.Say(scenarioChannel, "!feed add "+rss.URL+" news | politics")
+	server.ExpectMessage(t, scenarioChannel, "Scenario News")
+
+	feeds, err := storage.Network(cfg.IRC.Name).Feeds(scenarioChannel)
+	if err != nil || len(feeds) != 1 {
+		t.Fatalf("Feeds() = %v, %v, want one feed", feeds, err)
+	}
+	if feeds[0].Filter != "news | politics" || !feeds[0].HasSeen("https://example.com/old") {
+		t.Errorf("unexpected feed %+v", feeds[0])
+	}
+
+	time.Sleep(1500 * time.Millisecond)
+	owner.Say(scenarioChannel, "!feed add "+rss.URL)
+	server.ExpectMessage(t, scenarioChannel, "already subscribed")
+
+	time.Sleep(1500 * time.Millisecond)
+	owner.Say(scenarioChannel, "!feed cap "+feeds[0].ID+" 2")
+	server.ExpectMessage(t, scenarioChannel, "up to 2 items an hour")
+
+	time.Sleep(1500 * time.Millisecond)
+	owner.Say(scenarioChannel, "!feed quiet "+feeds[0].ID+" 22:00-7am")
+	server.ExpectMessage(t, scenarioChannel, "22:00-07:00")
+
+	time.Sleep(1500 * time.Millisecond)
+	owner.Say(scenarioChannel, "!feed remove "+feeds[0].ID)
+	server.ExpectMessage(t, scenarioChannel, "unsubscribed")
+
+	time.Sleep(1500 * time.Millisecond)
+	owner.Say(scenarioChannel, "!feeds")
+	server.ExpectMessage(t, scenarioChannel, "isn't subscribed to any feeds")
+}
+
+func TestScenarioSpamRules(t *testing.T) {
+	server, _, cfg := startScenario(t)
+	createScenarioChannel(t, cfg)
+
+	store := storage.Network(cfg.IRC.Name)
+	rules := []models.SpamRule{
+		{Rule: models.SpamRuleRepeat, Enabled: true, Threshold: 3, Window: "1m", Action: models.ModerationActionWarn},
+		{Rule: models.SpamRuleCaps, Enabled: true, Threshold: 80, Action: models.ModerationActionKick},
+	}
+	// the ladder's first step is out of reach, so the rules' own actions are taken
+	ladder := []models.StrikeStep{{Strikes: 10, Action: models.ModerationActionBan}}
+	if err := store.UpdateChannel(scenarioChannel, map[string]any{"spam_rules": rules, "strike_ladder": ladder}); err != nil {
+		t.Fatalf("UpdateChannel() error = %v", err)
+	}
+	t.Cleanup(func() { store.UpdateChannel(scenarioChannel, map[string]any{"strike_ladder": []models.StrikeStep{}}) })
+
+	owner := server.AddUser("owner")
+	bob := server.AddUser("bob")
+	owner.Join(scenarioChannel)
+	bob.Join(scenarioChannel)
+
+	// the owner is exempt
+	owner.Say(scenarioChannel, "THIS IS VERY IMPORTANT NEWS")
+	server.Refute(t, time.Second, "kick of owner", func(m *irctest.Message) bool {
+		return m.Command == "KICK" && m.Param(1) == "owner"
+	})
+
+	for i := 0; i < 3; i++ {
+		bob.Say(scenarioChannel, "join my server")
+	}
+	server.ExpectMessage(t, scenarioChannel, "bob has been warned: repeating messages")
+
+	bob.Say(scenarioChannel, "WHY DID NOBODY JOIN MY SERVER")
+	server.ExpectCommand(t, "KICK", scenarioChannel, "bob")
+}
+
+func TestScenarioStrikeLadder(t *testing.T) {
+	server, _, cfg := startScenario(t)
+	createScenarioChannel(t, cfg)
+
+	store := storage.Network(cfg.IRC.Name)
+	ladder := []models.StrikeStep{
+		{Strikes: 1, Action: models.ModerationActionWarn},
+		{Strikes: 3, Action: models.ModerationActionKick},
+	}
+	if err := store.UpdateChannel(scenarioChannel, map[string]any{"strike_ladder": ladder}); err != nil {
+		t.Fatalf("UpdateChannel() error = %v", err)
+	}
+	t.Cleanup(func() { store.UpdateChannel(scenarioChannel, map[string]any{"strike_ladder": []models.StrikeStep{}}) })
+
+	owner := server.AddUser("owner")
+	troll := server.AddUser("troll")
+	owner.Join(scenarioChannel)
+	troll.Join(scenarioChannel)
+
+	owner.Say(scenarioChannel, "!warn troll spamming links")
+	server.ExpectMessage(t, scenarioChannel, "troll has been warned: spamming links (1 strike)")
+
+	time.Sleep(1500 * time.Millisecond)
+	owner.Say(scenarioChannel, "!warn troll 2 still spamming")
+	kick := server.ExpectCommand(t, "KICK", scenarioChannel, "troll")
+	if !strings.Contains(kick.Trailing(), "3 strikes") {
+		t.Fatalf("kick reason = %q, want the active strikes", kick.Trailing())
+	}
+}
@@ -0,0 +1,175 @@
+package events
+
+import (
+	"assistant/pkg/models"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// minCapsLetters is how many letters a line needs before the caps rule considers it, so that short exclamations and
+// acronyms aren't mistaken for shouting.
+const minCapsLetters = 12
+
+// spamSweepInterval is how often the lines of users who have gone quiet are forgotten.
+const spamSweepInterval = 10 * time.Minute
+
+type spamLine struct {
+	text string
+	at   time.Time
+}
+
+// spamCheck reports whether a line breaks the rule. Recent holds the user's lines in the channel within the longest
+// window a rule can have, oldest first and ending with the line itself, and members the nicks in the channel.
+type spamCheck func(rule models.SpamRule, recent []spamLine, members []string, now time.Time) bool
+
+var spamChecks = map[string]spamCheck{
+	models.SpamRuleFlood:     isFlood,
+	models.SpamRuleRepeat:    isRepeat,
+	models.SpamRuleHighlight: isMassHighlight,
+	models.SpamRuleCaps:      isShouting,
+}
+
+var spamReasons = map[string]string{
+	models.SpamRuleFlood:     "flooding",
+	models.SpamRuleRepeat:    "repeating messages",
+	models.SpamRuleHighlight: "mass highlighting",
+	models.SpamRuleCaps:      "excessive caps",
+}
+
+// spamDetector keeps each user's recent lines in memory to check them against channels' spam rules.
+type spamDetector struct {
+	mu        sync.Mutex
+	lines     map[string][]spamLine
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func newSpamDetector() *spamDetector {
+	return &spamDetector{
+		lines: make(map[string][]spamLine),
+		now:   time.Now,
+	}
+}
+
+// Check records the line and returns the first enabled rule it breaks, if any. The user's lines are forgotten once a
+// rule is broken so that a single burst is only acted on once.
+func (d *spamDetector) Check(channel, user, text, nick string, members []string, rules []models.SpamRule) *models.SpamRule {
+	now := d.now()
+	key := fmt.Sprintf("%s/%s", channel, user)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.sweep(now)
+
+	recent := append(d.recentLines(key, now), spamLine{text: text, at: now})
+	d.lines[key] = recent
+
+	others := make([]string, 0, len(members))
+	for _, m := range members {
+		if !strings.EqualFold(m, nick) {
+			others = append(others, m)
+		}
+	}
+
+	for _, rule := range rules {
+		if !rule.Enabled {
+			continue
+		}
+		check, ok := spamChecks[rule.Rule]
+		if !ok {
+			continue
+		}
+		if check(rule, recent, others, now) {
+			delete(d.lines, key)
+			return &rule
+		}
+	}
+
+	return nil
+}
+
+func (d *spamDetector) recentLines(key string, now time.Time) []spamLine {
+	lines := d.lines[key]
+	i := 0
+	for i < len(lines) && now.Sub(lines[i].at) >= models.MaxSpamRuleWindow {
+		i++
+	}
+	return lines[i:]
+}
+
+// sweep forgets users whose lines are all too old to count towards any rule.
+func (d *spamDetector) sweep(now time.Time) {
+	if now.Sub(d.lastSweep) < spamSweepInterval {
+		return
+	}
+	d.lastSweep = now
+
+	for key, lines := range d.lines {
+		if len(lines) == 0 || now.Sub(lines[len(lines)-1].at) >= models.MaxSpamRuleWindow {
+			delete(d.lines, key)
+		}
+	}
+}
+
+func linesWithin(recent []spamLine, window time.Duration, now time.Time) []spamLine {
+	i := 0
+	for i < len(recent) && now.Sub(recent[i].at) >= window {
+		i++
+	}
+	return recent[i:]
+}
+
+func isFlood(rule models.SpamRule, recent []spamLine, _ []string, now time.Time) bool {
+	return len(linesWithin(recent, rule.WindowDuration(), now)) >= rule.Threshold
+}
+
+func isRepeat(rule models.SpamRule, recent []spamLine, _ []string, now time.Time) bool {
+	lines := linesWithin(recent, rule.WindowDuration(), now)
+	last := normalizeSpamLine(lines[len(lines)-1].text)
+	if len(last) == 0 {
+		return false
+	}
+
+	repeats := 0
+	for _, l := range lines {
+		if normalizeSpamLine(l.text) == last {
+			repeats++
+		}
+	}
+	return repeats >= rule.Threshold
+}
+
+func isMassHighlight(rule models.SpamRule, recent []spamLine, members []string, _ time.Time) bool {
+	named := make(map[string]bool)
+	for _, token := range strings.Fields(recent[len(recent)-1].text) {
+		token = strings.Trim(token, ":,;.!?@+%&~\"'()<>")
+		for _, m := range members {
+			if strings.EqualFold(token, m) {
+				named[strings.ToLower(m)] = true
+			}
+		}
+	}
+	return len(named) >= rule.Threshold
+}
+
+func isShouting(rule models.SpamRule, recent []spamLine, _ []string, _ time.Time) bool {
+	letters, upper := 0, 0
+	for _, r := range recent[len(recent)-1].text {
+		if unicode.IsLetter(r) {
+			letters++
+			if unicode.IsUpper(r) {
+				upper++
+			}
+		}
+	}
+	return letters >= minCapsLetters && upper*100 >= rule.Threshold*letters
+}
+
+// normalizeSpamLine ignores case and spacing, so that a paste can't dodge the repeat rule with small variations.
+func normalizeSpamLine(s string) string {
+	return strings.Join(strings.Fields(strings.ToLower(s)), " ")
+}
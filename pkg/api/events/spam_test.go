@@ -0,0 +1,129 @@
+package events
+
+import (
+	"assistant/pkg/models"
+	"testing"
+	"time"
+)
+
+func newTestSpamDetector(now *time.Time) *spamDetector {
+	d := newSpamDetector()
+	d.now = func() time.Time { return *now }
+	return d
+}
+
+func TestSpamDetectorFlood(t *testing.T) {
+	now := time.Date(2026, time.July, 31, 12, 0, 0, 0, time.UTC)
+	d := newTestSpamDetector(&now)
+	rules := []models.SpamRule{{Rule: models.SpamRuleFlood, Enabled: true, Threshold: 4, Window: "10s", Action: models.ModerationActionKick}}
+
+	for i := 0; i < 3; i++ {
+		if rule := d.Check("#channel", "host", "line", "nick", nil, rules); rule != nil {
+			t.Fatalf("line %d broke %s", i+1, rule.Rule)
+		}
+		now = now.Add(2 * time.Second)
+	}
+
+	if rule := d.Check("#channel", "other", "line", "other", nil, rules); rule != nil {
+		t.Fatal("another user's line counted towards the flood")
+	}
+
+	rule := d.Check("#channel", "host", "line", "nick", nil, rules)
+	if rule == nil || rule.Rule != models.SpamRuleFlood {
+		t.Fatalf("fourth line = %v, want flood", rule)
+	}
+
+	// the burst was acted on, so it doesn't count again
+	if rule := d.Check("#channel", "host", "line", "nick", nil, rules); rule != nil {
+		t.Fatal("line after acting on the flood broke it again")
+	}
+}
+
+func TestSpamDetectorFloodWindow(t *testing.T) {
+	now := time.Date(2026, time.July, 31, 12, 0, 0, 0, time.UTC)
+	d := newTestSpamDetector(&now)
+	rules := []models.SpamRule{{Rule: models.SpamRuleFlood, Enabled: true, Threshold: 3, Window: "10s", Action: models.ModerationActionWarn}}
+
+	for i := 0; i < 5; i++ {
+		if rule := d.Check("#channel", "host", "line", "nick", nil, rules); rule != nil {
+			t.Fatalf("line %d at a steady pace broke %s", i+1, rule.Rule)
+		}
+		now = now.Add(6 * time.Second)
+	}
+}
+
+func TestSpamDetectorRepeat(t *testing.T) {
+	now := time.Date(2026, time.July, 31, 12, 0, 0, 0, time.UTC)
+	d := newTestSpamDetector(&now)
+	rules := []models.SpamRule{{Rule: models.SpamRuleRepeat, Enabled: true, Threshold: 3, Window: "1m", Action: models.ModerationActionWarn}}
+
+	lines := []string{"buy cheap stuff", "something else", "BUY  cheap stuff"}
+	for _, line := range lines {
+		if rule := d.Check("#channel", "host", line, "nick", nil, rules); rule != nil {
+			t.Fatalf("%q broke %s", line, rule.Rule)
+		}
+		now = now.Add(5 * time.Second)
+	}
+
+	if rule := d.Check("#channel", "host", "buy cheap stuff", "nick", nil, rules); rule == nil {
+		t.Fatal("third repeat wasn't detected")
+	}
+}
+
+func TestSpamDetectorHighlight(t *testing.T) {
+	now := time.Date(2026, time.July, 31, 12, 0, 0, 0, time.UTC)
+	d := newTestSpamDetector(&now)
+	rules := []models.SpamRule{{Rule: models.SpamRuleHighlight, Enabled: true, Threshold: 3, Action: models.ModerationActionKick}}
+	members := []string{"alice", "bob", "carol", "nick"}
+
+	if rule := d.Check("#channel", "host", "alice: bob, have you seen nick?", "nick", members, rules); rule != nil {
+		t.Fatal("naming two others and yourself was taken as mass highlighting")
+	}
+	if rule := d.Check("#channel", "host", "alice alice ALICE bob", "nick", members, rules); rule != nil {
+		t.Fatal("naming the same member repeatedly was taken as mass highlighting")
+	}
+	if rule := d.Check("#channel", "host", "alice bob @Carol check this out", "nick", members, rules); rule == nil {
+		t.Fatal("naming three members wasn't detected")
+	}
+}
+
+func TestSpamDetectorCaps(t *testing.T) {
+	now := time.Date(2026, time.July, 31, 12, 0, 0, 0, time.UTC)
+	d := newTestSpamDetector(&now)
+	rules := []models.SpamRule{{Rule: models.SpamRuleCaps, Enabled: true, Threshold: 80, Action: models.ModerationActionWarn}}
+
+	for _, line := range []string{"LOL", "NASA and the ESA launched it", "I can't BELIEVE this happened today"} {
+		if rule := d.Check("#channel", "host", line, "nick", nil, rules); rule != nil {
+			t.Fatalf("%q was taken as shouting", line)
+		}
+	}
+	if rule := d.Check("#channel", "host", "WHY WOULD ANYONE DO THIS?!", "nick", nil, rules); rule == nil {
+		t.Fatal("shouting wasn't detected")
+	}
+}
+
+func TestSpamDetectorDisabledRules(t *testing.T) {
+	now := time.Date(2026, time.July, 31, 12, 0, 0, 0, time.UTC)
+	d := newTestSpamDetector(&now)
+	rules := []models.SpamRule{{Rule: models.SpamRuleCaps, Threshold: 50, Action: models.ModerationActionWarn}}
+
+	if rule := d.Check("#channel", "host", "WHY WOULD ANYONE DO THIS?!", "nick", nil, rules); rule != nil {
+		t.Fatal("disabled rule was applied")
+	}
+}
+
+func TestSpamDetectorSweep(t *testing.T) {
+	now := time.Date(2026, time.July, 31, 12, 0, 0, 0, time.UTC)
+	d := newTestSpamDetector(&now)
+
+	d.Check("#channel", "host", "line", "nick", nil, nil)
+	now = now.Add(models.MaxSpamRuleWindow + spamSweepInterval)
+	d.Check("#channel", "other", "line", "other", nil, nil)
+
+	if _, ok := d.lines["#channel/host"]; ok {
+		t.Fatal("quiet user's lines weren't forgotten")
+	}
+	if len(d.lines) != 1 {
+		t.Fatalf("%d users tracked, want 1", len(d.lines))
+	}
+}
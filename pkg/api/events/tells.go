@@ -0,0 +1,80 @@
+package events
+
+import (
+	"assistant/pkg/api/elapse"
+	"assistant/pkg/api/irc"
+	"assistant/pkg/api/repository"
+	"assistant/pkg/api/style"
+	"assistant/pkg/log"
+	"assistant/pkg/models"
+	"fmt"
+	"strings"
+)
+
+// hasPendingTells returns whether the sender of e may have tells waiting in channel, so that deliveries are only
+// started for users who have some.
+func (eh *handler) hasPendingTells(e *irc.Event, channel string) bool {
+	if strings.EqualFold(e.From, eh.cfg.IRC.Nick) {
+		return false
+	}
+
+	pending, err := repository.HasPendingTells(e, channel, e.Mask())
+	if err != nil {
+		log.Logger().Errorf(e, "error checking for pending tells, %s", err)
+		return false
+	}
+	return pending
+}
+
+// deliverTells sends the sender of e the messages left for them in channel, privately if they've asked for that, and
+// removes them. Deliveries are serialized so that two quick messages from the recipient don't deliver twice.
+func (eh *handler) deliverTells(e *irc.Event, channel string) {
+	if strings.EqualFold(e.From, eh.cfg.IRC.Nick) {
+		return
+	}
+
+	eh.tellsMu.Lock()
+	defer eh.tellsMu.Unlock()
+
+	logger := log.Logger()
+
+	tells, err := repository.GetPendingTells(e, channel, e.Mask())
+	if err != nil {
+		logger.Errorf(e, "error retrieving pending tells, %s", err)
+		return
+	}
+
+	if len(tells) == 0 {
+		return
+	}
+
+	private := false
+	if u, err := repository.GetUserByIdentity(e, channel, e.From, e.Account, false); err != nil {
+		logger.Errorf(e, "error retrieving user for tell delivery, %s", err)
+	} else if u != nil {
+		private = u.TellDelivery == models.TellDeliveryPrivate
+	}
+
+	messages := make([]string, 0, len(tells))
+	for _, t := range tells {
+		// remove before sending, so that a failure can't cause the message to be delivered again and again
+		if err = repository.DeleteTell(e, channel, t.ID); err != nil {
+			logger.Errorf(e, "error deleting tell, %s", err)
+			continue
+		}
+
+		if private {
+			messages = append(messages, fmt.Sprintf("%s left you a message in %s %s: %s", style.Bold(t.From), channel, elapse.PastTimeDescription(t.CreatedAt), t.Message))
+		} else {
+			messages = append(messages, fmt.Sprintf("%s: %s left you a message %s: %s", e.From, style.Bold(t.From), elapse.PastTimeDescription(t.CreatedAt), t.Message))
+		}
+	}
+
+	target := channel
+	if private {
+		target = e.From
+	}
+
+	logger.Infof(e, "delivering %d tells to %s in %s", len(messages), e.From, target)
+	eh.irc.SendMessages(target, messages)
+}
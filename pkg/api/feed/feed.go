@@ -0,0 +1,303 @@
+package feed
+
+import (
+	"assistant/pkg/api/retriever"
+	"assistant/pkg/models"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html/charset"
+)
+
+const (
+	KindRSS      = "rss"
+	KindReddit   = "reddit"
+	KindMastodon = "mastodon"
+	KindBluesky  = "bluesky"
+)
+
+const fetchTimeout = 10000
+
+var (
+	subredditRegexp = regexp.MustCompile(`(?i)^(?:https?://(?:www\.|old\.|new\.)?reddit\.com)?/?r/([a-z0-9_]+)/?(?:\.rss)?$`)
+	blueskyRegexp   = regexp.MustCompile(`(?i)^https?://bsky\.app/profile/([^/?#]+)(?:/rss)?/?$`)
+	mastodonRegexp  = regexp.MustCompile(`(?i)^https?://([^/?#]+)/@([a-z0-9_]+)(?:\.rss)?/?$`)
+	markupRegexp    = regexp.MustCompile(`<[^>]*>`)
+)
+
+// Source is where a subscription's items come from.
+type Source struct {
+	Kind string
+	// URL is the RSS or Atom feed polled for items.
+	URL string
+	// Name describes the source until its feed gives a title.
+	Name string
+}
+
+// Document is a retrieved feed.
+type Document struct {
+	Title string
+	Items []Item
+}
+
+// Item is an entry in a feed. GUID is the item's stable identifier, falling back to its link when the feed doesn't
+// give one.
+type Item struct {
+	GUID        string
+	Title       string
+	URL         string
+	Description string
+	PublishedAt time.Time
+}
+
+// Resolve works out the feed behind a subscription URL. Subreddits, Mastodon accounts and Bluesky accounts are polled
+// through the RSS feeds those sites publish, and any other URL is taken to be an RSS or Atom feed.
+func Resolve(input string) (*Source, error) {
+	input = strings.TrimSpace(input)
+
+	if m := subredditRegexp.FindStringSubmatch(input); m != nil {
+		return &Source{Kind: KindReddit, URL: fmt.Sprintf("https://www.reddit.com/r/%s/.rss", m[1]), Name: "r/" + m[1]}, nil
+	}
+
+	if m := blueskyRegexp.FindStringSubmatch(input); m != nil {
+		return &Source{Kind: KindBluesky, URL: fmt.Sprintf("https://bsky.app/profile/%s/rss", m[1]), Name: "@" + m[1]}, nil
+	}
+
+	if m := mastodonRegexp.FindStringSubmatch(input); m != nil {
+		return &Source{Kind: KindMastodon, URL: fmt.Sprintf("https://%s/@%s.rss", m[1], m[2]), Name: fmt.Sprintf("@%s@%s", m[2], m[1])}, nil
+	}
+
+	u, err := url.Parse(input)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || len(u.Host) == 0 {
+		return nil, fmt.Errorf("invalid feed URL, %s", input)
+	}
+
+	return &Source{Kind: KindRSS, URL: u.String(), Name: strings.TrimPrefix(u.Hostname(), "www.")}, nil
+}
+
+// Subscribe fetches the source's feed and returns a new subscription to it, which remembers the items already in the
+// feed so that only newer ones are shared.
+func Subscribe(source *Source, input, filter, createdBy string) (*models.Feed, error) {
+	doc, err := Fetch(source.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	title := source.Name
+	if len(doc.Title) > 0 {
+		title = doc.Title
+	}
+
+	f := models.NewFeed(input, source.URL, source.Kind, title, filter, createdBy)
+	for _, item := range doc.Items {
+		f.MarkSeen(item.GUID, item.URL)
+	}
+	f.PolledAt = time.Now()
+
+	return f, nil
+}
+
+// Fetch retrieves and parses the feed at the URL.
+func Fetch(feedURL string) (*Document, error) {
+	params := retriever.DefaultParams(feedURL).WithTimeout(fetchTimeout).WithImpersonation(false)
+	params.Headers = map[string]string{
+		"Accept":     "application/rss+xml, application/atom+xml, application/xml;q=0.9, text/xml;q=0.8",
+		"User-Agent": "Mozilla/5.0 (compatible; assistant feed reader)",
+	}
+
+	body, err := retriever.NewBodyRetriever().RetrieveBody(nil, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if body.Response.StatusCode != 200 {
+		return nil, fmt.Errorf("unexpected status %d retrieving %s", body.Response.StatusCode, feedURL)
+	}
+
+	return Parse(body.Data)
+}
+
+type rssDocument struct {
+	Channel struct {
+		Title string    `xml:"title"`
+		Items []rssItem `xml:"item"`
+	} `xml:"channel"`
+	// RSS 1.0 (RDF) places items beside the channel rather than in it
+	Items []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string `xml:"title"`
+	Link        string `xml:"link"`
+	GUID        string `xml:"guid"`
+	Description string `xml:"description"`
+	PubDate     string `xml:"pubDate"`
+	Date        string `xml:"http://purl.org/dc/elements/1.1/ date"`
+}
+
+type atomDocument struct {
+	Title   string      `xml:"title"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomEntry struct {
+	Title     string     `xml:"title"`
+	ID        string     `xml:"id"`
+	Links     []atomLink `xml:"link"`
+	Summary   string     `xml:"summary"`
+	Content   string     `xml:"content"`
+	Published string     `xml:"published"`
+	Updated   string     `xml:"updated"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+}
+
+// Parse parses an RSS 2.0, RSS 1.0 or Atom document.
+func Parse(data []byte) (*Document, error) {
+	root, err := rootElement(data)
+	if err != nil {
+		return nil, err
+	}
+
+	switch strings.ToLower(root) {
+	case "rss", "rdf":
+		var doc rssDocument
+		if err := decode(data, &doc); err != nil {
+			return nil, err
+		}
+		items := append(doc.Channel.Items, doc.Items...)
+		return &Document{Title: clean(doc.Channel.Title), Items: rssItems(items)}, nil
+	case "feed":
+		var doc atomDocument
+		if err := decode(data, &doc); err != nil {
+			return nil, err
+		}
+		return &Document{Title: clean(doc.Title), Items: atomItems(doc.Entries)}, nil
+	default:
+		return nil, fmt.Errorf("unsupported feed format, %s", root)
+	}
+}
+
+func rootElement(data []byte) (string, error) {
+	decoder := newDecoder(data)
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return "", fmt.Errorf("invalid feed, %s", err)
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			return start.Name.Local, nil
+		}
+	}
+}
+
+func decode(data []byte, v any) error {
+	if err := newDecoder(data).Decode(v); err != nil {
+		return fmt.Errorf("invalid feed, %s", err)
+	}
+	return nil
+}
+
+func newDecoder(data []byte) *xml.Decoder {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.Strict = false
+	decoder.CharsetReader = charset.NewReaderLabel
+	return decoder
+}
+
+func rssItems(items []rssItem) []Item {
+	result := make([]Item, 0, len(items))
+	for _, i := range items {
+		item := Item{
+			GUID:        strings.TrimSpace(i.GUID),
+			Title:       clean(i.Title),
+			URL:         strings.TrimSpace(i.Link),
+			Description: clean(i.Description),
+			PublishedAt: parseDate(i.PubDate, i.Date),
+		}
+		if len(item.GUID) == 0 {
+			item.GUID = item.URL
+		}
+		if len(item.GUID) > 0 {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+func atomItems(entries []atomEntry) []Item {
+	result := make([]Item, 0, len(entries))
+	for _, e := range entries {
+		item := Item{
+			GUID:        strings.TrimSpace(e.ID),
+			Title:       clean(e.Title),
+			URL:         atomEntryLink(e.Links),
+			Description: clean(e.Summary),
+			PublishedAt: parseDate(e.Published, e.Updated),
+		}
+		if len(item.Description) == 0 {
+			item.Description = clean(e.Content)
+		}
+		if len(item.GUID) == 0 {
+			item.GUID = item.URL
+		}
+		if len(item.GUID) > 0 {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// atomEntryLink returns the entry's alternate link, which is the one without a rel or with rel="alternate".
+func atomEntryLink(links []atomLink) string {
+	for _, l := range links {
+		if len(l.Rel) == 0 || l.Rel == "alternate" {
+			return strings.TrimSpace(l.Href)
+		}
+	}
+	if len(links) > 0 {
+		return strings.TrimSpace(links[0].Href)
+	}
+	return ""
+}
+
+var dateLayouts = []string{
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC3339,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	"2 Jan 2006 15:04:05 -0700",
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+}
+
+func parseDate(values ...string) time.Time {
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if len(v) == 0 {
+			continue
+		}
+		for _, layout := range dateLayouts {
+			if t, err := time.Parse(layout, v); err == nil {
+				return t
+			}
+		}
+	}
+	return time.Time{}
+}
+
+// clean strips markup and entities from feed text, which is often HTML, and collapses whitespace.
+func clean(s string) string {
+	s = html.UnescapeString(markupRegexp.ReplaceAllString(s, " "))
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,206 @@
+package feed
+
+import (
+	"assistant/pkg/models"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		input string
+		kind  string
+		url   string
+		name  string
+	}{
+		{"https://www.reddit.com/r/golang/", KindReddit, "https://www.reddit.com/r/golang/.rss", "r/golang"},
+		{"r/worldnews", KindReddit, "https://www.reddit.com/r/worldnews/.rss", "r/worldnews"},
+		{"https://old.reddit.com/r/news", KindReddit, "https://www.reddit.com/r/news/.rss", "r/news"},
+		{"https://mastodon.social/@Gargron", KindMastodon, "https://mastodon.social/@Gargron.rss", "@Gargron@mastodon.social"},
+		{"https://bsky.app/profile/bsky.app", KindBluesky, "https://bsky.app/profile/bsky.app/rss", "@bsky.app"},
+		{"https://www.example.com/feed.xml", KindRSS, "https://www.example.com/feed.xml", "example.com"},
+	}
+
+	for _, tt := range tests {
+		source, err := Resolve(tt.input)
+		if err != nil {
+			t.Errorf("Resolve(%q) returned error: %s", tt.input, err)
+			continue
+		}
+		if source.Kind != tt.kind || source.URL != tt.url || source.Name != tt.name {
+			t.Errorf("Resolve(%q) = %+v, want %s %s %s", tt.input, source, tt.kind, tt.url, tt.name)
+		}
+	}
+
+	for _, input := range []string{"", "example.com/feed", "ftp://example.com/feed", "not a url"} {
+		if _, err := Resolve(input); err == nil {
+			t.Errorf("Resolve(%q) should fail", input)
+		}
+	}
+}
+
+func TestParseRSS(t *testing.T) {
+	data := []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0">
+  <channel>
+    <title>Example News</title>
+    <item>
+      <title>First &amp; foremost</title>
+      <link>https://example.com/first</link>
+      <guid isPermaLink="false">first-id</guid>
+      <description>&lt;p&gt;Some &lt;b&gt;bold&lt;/b&gt; news&lt;/p&gt;</description>
+      <pubDate>Tue, 10 Jun 2025 04:00:00 GMT</pubDate>
+    </item>
+    <item>
+      <title>Second</title>
+      <link>https://example.com/second</link>
+    </item>
+  </channel>
+</rss>`)
+
+	doc, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Parse returned error: %s", err)
+	}
+
+	if doc.Title != "Example News" {
+		t.Errorf("title = %q", doc.Title)
+	}
+	if len(doc.Items) != 2 {
+		t.Fatalf("got %d items, want 2", len(doc.Items))
+	}
+
+	first := doc.Items[0]
+	if first.GUID != "first-id" || first.Title != "First & foremost" || first.URL != "https://example.com/first" {
+		t.Errorf("first item = %+v", first)
+	}
+	if first.Description != "Some bold news" {
+		t.Errorf("description = %q", first.Description)
+	}
+	if !first.PublishedAt.Equal(time.Date(2025, 6, 10, 4, 0, 0, 0, time.UTC)) {
+		t.Errorf("published at = %s", first.PublishedAt)
+	}
+
+	if doc.Items[1].GUID != "https://example.com/second" {
+		t.Errorf("item without guid should use its link, got %q", doc.Items[1].GUID)
+	}
+}
+
+func TestParseRDF(t *testing.T) {
+	data := []byte(`<?xml version="1.0"?>
+<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns="http://purl.org/rss/1.0/" xmlns:dc="http://purl.org/dc/elements/1.1/">
+  <channel><title>RDF Feed</title></channel>
+  <item>
+    <title>An item</title>
+    <link>https://example.com/rdf</link>
+    <dc:date>2025-06-10T04:00:00Z</dc:date>
+  </item>
+</rdf:RDF>`)
+
+	doc, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Parse returned error: %s", err)
+	}
+
+	if doc.Title != "RDF Feed" || len(doc.Items) != 1 || doc.Items[0].URL != "https://example.com/rdf" {
+		t.Fatalf("unexpected document %+v", doc)
+	}
+	if doc.Items[0].PublishedAt.IsZero() {
+		t.Errorf("expected dc:date to be parsed")
+	}
+}
+
+func TestParseAtom(t *testing.T) {
+	data := []byte(`<?xml version="1.0" encoding="utf-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <title>Atom Feed</title>
+  <entry>
+    <title>Entry one</title>
+    <id>tag:example.com,2025:1</id>
+    <link rel="replies" href="https://example.com/one#comments"/>
+    <link href="https://example.com/one"/>
+    <content type="html">&lt;p&gt;Content only&lt;/p&gt;</content>
+    <updated>2025-06-10T04:00:00Z</updated>
+  </entry>
+</feed>`)
+
+	doc, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Parse returned error: %s", err)
+	}
+
+	if doc.Title != "Atom Feed" || len(doc.Items) != 1 {
+		t.Fatalf("unexpected document %+v", doc)
+	}
+
+	entry := doc.Items[0]
+	if entry.GUID != "tag:example.com,2025:1" || entry.URL != "https://example.com/one" || entry.Description != "Content only" {
+		t.Errorf("entry = %+v", entry)
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, data := range []string{"", "<html><body>not a feed</body></html>", "plain text"} {
+		if _, err := Parse([]byte(data)); err == nil {
+			t.Errorf("Parse(%q) should fail", data)
+		}
+	}
+}
+
+func TestSelect(t *testing.T) {
+	now := time.Date(2025, 6, 10, 12, 0, 0, 0, time.UTC)
+	items := []Item{
+		{GUID: "4", Title: "newest", URL: "https://example.com/4", PublishedAt: now.Add(-time.Minute)},
+		{GUID: "3", Title: "filtered out", URL: "https://example.com/3", PublishedAt: now.Add(-2 * time.Minute)},
+		{GUID: "2", Title: "older", URL: "https://example.com/2", PublishedAt: now.Add(-3 * time.Minute)},
+		{GUID: "1", Title: "seen", URL: "https://example.com/1", PublishedAt: now.Add(-4 * time.Minute)},
+		{GUID: "0", Title: "stale", URL: "https://example.com/0", PublishedAt: now.Add(-48 * time.Hour)},
+		{GUID: "5", Title: "seen by url", URL: "https://example.com/seen", PublishedAt: now.Add(-time.Minute)},
+	}
+
+	newFeed := func() *models.Feed {
+		f := models.NewFeed("u", "u", KindRSS, "t", "newest | older", "nick")
+		f.PolledAt = now.Add(-5 * time.Minute)
+		f.MarkSeen("1", "https://example.com/seen")
+		return f
+	}
+
+	guids := func(items []Item) []string {
+		result := make([]string, len(items))
+		for i, item := range items {
+			result[i] = item.GUID
+		}
+		return result
+	}
+
+	f := newFeed()
+	share, skip := Select(f, items, now)
+	if got := guids(share); !slices.Equal(got, []string{"2", "4"}) {
+		t.Errorf("shared %v, want [2 4]", got)
+	}
+	if got := guids(skip); !slices.Equal(got, []string{"3", "0"}) {
+		t.Errorf("skipped %v, want [3 0]", got)
+	}
+
+	f = newFeed()
+	f.MaxPostsPerHour = 1
+	share, _ = Select(f, items, now)
+	if got := guids(share); !slices.Equal(got, []string{"2"}) {
+		t.Errorf("rate capped feed shared %v, want [2]", got)
+	}
+
+	f = newFeed()
+	_ = f.SetQuietHours(11, 0, 13, 0, time.UTC)
+	share, skip = Select(f, items, now)
+	if len(share) != 0 || len(skip) != 2 {
+		t.Errorf("feed in quiet hours shared %v and skipped %v", guids(share), guids(skip))
+	}
+
+	f = newFeed()
+	f.PolledAt = time.Time{}
+	share, skip = Select(f, items, now)
+	if len(share) != 0 || len(skip) != 4 {
+		t.Errorf("first poll shared %v and skipped %v, want everything unseen skipped", guids(share), guids(skip))
+	}
+}
@@ -0,0 +1,50 @@
+package feed
+
+import (
+	"assistant/pkg/models"
+	"slices"
+	"time"
+)
+
+// Select picks which of a feed's items to share now, oldest first, and which to skip for good. Items already shared
+// or skipped, by GUID or URL, are ignored. Items that don't match the feed's filter or are older than
+// models.MaxFeedItemAge are skipped, as is everything in the feed the first time it's polled, so that subscribing
+// doesn't share a backlog. Items held back by the feed's rate cap or quiet hours are neither shared nor skipped, and
+// are considered again the next time the feed is polled.
+func Select(f *models.Feed, items []Item, now time.Time) ([]Item, []Item) {
+	share := make([]Item, 0)
+	skip := make([]Item, 0)
+
+	candidates := make([]Item, 0)
+	for _, item := range items {
+		if f.HasSeen(item.GUID) || (len(item.URL) > 0 && f.HasSeen(item.URL)) {
+			continue
+		}
+		if slices.ContainsFunc(candidates, func(c Item) bool { return c.GUID == item.GUID }) {
+			continue
+		}
+
+		switch {
+		case f.PolledAt.IsZero(),
+			len(item.URL) == 0,
+			!f.Matches(item.Title, item.Description),
+			!item.PublishedAt.IsZero() && now.Sub(item.PublishedAt) > models.MaxFeedItemAge:
+			skip = append(skip, item)
+		default:
+			candidates = append(candidates, item)
+		}
+	}
+
+	if f.InQuietHours(now) {
+		return share, skip
+	}
+
+	// feeds list their newest items first, but some are dated in an order of their own
+	slices.Reverse(candidates)
+	if !slices.ContainsFunc(candidates, func(i Item) bool { return i.PublishedAt.IsZero() }) {
+		slices.SortStableFunc(candidates, func(a, b Item) int { return a.PublishedAt.Compare(b.PublishedAt) })
+	}
+
+	share = append(share, candidates[:min(len(candidates), f.RemainingPosts(now))]...)
+	return share, skip
+}
@@ -0,0 +1,95 @@
+package irc
+
+import (
+	"strings"
+	"sync"
+
+	irce "github.com/thoj/go-ircevent"
+)
+
+const tagAccount = "account"
+const accountLoggedOut = "*"
+
+// accounts tracks the services account of each nick the connection has seen, learned from extended-join,
+// account-notify and account-tag. Nicks are compared case-insensitively.
+type accounts struct {
+	mu    sync.RWMutex
+	nicks map[string]string
+}
+
+func newAccounts() *accounts {
+	return &accounts{
+		nicks: make(map[string]string),
+	}
+}
+
+func (a *accounts) get(nick string) string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.nicks[strings.ToLower(nick)]
+}
+
+func (a *accounts) set(nick, account string) {
+	if len(nick) == 0 {
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if len(account) == 0 || account == accountLoggedOut {
+		delete(a.nicks, strings.ToLower(nick))
+		return
+	}
+
+	a.nicks[strings.ToLower(nick)] = account
+}
+
+func (a *accounts) rename(from, to string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	account, ok := a.nicks[strings.ToLower(from)]
+	if !ok {
+		return
+	}
+
+	delete(a.nicks, strings.ToLower(from))
+	a.nicks[strings.ToLower(to)] = account
+}
+
+func (a *accounts) reset() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.nicks = make(map[string]string)
+}
+
+// observe updates tracked accounts from an event and returns the services account of its sender, if known.
+func (a *accounts) observe(e *irce.Event) string {
+	if account, ok := e.Tags[tagAccount]; ok {
+		a.set(e.Nick, account)
+	}
+
+	switch e.Code {
+	case CodeJoin:
+		// extended-join: JOIN <channel> <account> :<real name>
+		if len(e.Arguments) >= 3 {
+			a.set(e.Nick, e.Arguments[1])
+		}
+	case CodeAccount:
+		if len(e.Arguments) > 0 {
+			a.set(e.Nick, e.Arguments[0])
+		}
+	case CodeNickChange:
+		if len(e.Arguments) > 0 {
+			a.rename(e.Nick, e.Arguments[len(e.Arguments)-1])
+			return a.get(e.Arguments[len(e.Arguments)-1])
+		}
+	case CodeQuit:
+		account := a.get(e.Nick)
+		a.set(e.Nick, "")
+		return account
+	}
+
+	return a.get(e.Nick)
+}
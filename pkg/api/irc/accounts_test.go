@@ -0,0 +1,45 @@
+package irc
+
+import (
+	"testing"
+
+	irce "github.com/thoj/go-ircevent"
+)
+
+func TestAccountsTrackExtendedJoinNotifyAndTags(t *testing.T) {
+	a := newAccounts()
+
+	a.observe(&irce.Event{Code: CodeJoin, Nick: "Alice", Arguments: []string{"#channel", "alice", "Alice Example"}})
+	if got := a.get("alice"); got != "alice" {
+		t.Fatalf("account after extended-join = %q, want alice", got)
+	}
+
+	if got := a.observe(&irce.Event{Code: CodeNickChange, Nick: "Alice", Arguments: []string{"Alice_"}}); got != "alice" {
+		t.Fatalf("account after nick change = %q, want alice", got)
+	}
+	if got := a.get("Alice"); got != "" {
+		t.Fatalf("old nick still has account %q", got)
+	}
+
+	a.observe(&irce.Event{Code: CodeAccount, Nick: "Alice_", Arguments: []string{"*"}})
+	if got := a.get("Alice_"); got != "" {
+		t.Fatalf("account after logout = %q, want empty", got)
+	}
+
+	got := a.observe(&irce.Event{Code: CodePrivateMessage, Nick: "bob", Arguments: []string{"#channel", "hi"}, Tags: map[string]string{tagAccount: "robert"}})
+	if got != "robert" {
+		t.Fatalf("account from account-tag = %q, want robert", got)
+	}
+
+	a.observe(&irce.Event{Code: CodeJoin, Nick: "carol", Arguments: []string{"#channel", "*", "Carol"}})
+	if got = a.get("carol"); got != "" {
+		t.Fatalf("account for logged out extended-join = %q, want empty", got)
+	}
+
+	if got = a.observe(&irce.Event{Code: CodeQuit, Nick: "bob", Arguments: []string{"bye"}}); got != "robert" {
+		t.Fatalf("account on quit = %q, want robert", got)
+	}
+	if got = a.get("bob"); got != "" {
+		t.Fatalf("account after quit = %q, want empty", got)
+	}
+}
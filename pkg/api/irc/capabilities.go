@@ -0,0 +1,241 @@
+package irc
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+const capabilityVersion = "302"
+const maxCapabilityRequestLength = 400
+
+// capabilityTimeout is how long auto joins wait for capability negotiation before going ahead without it, for servers
+// that don't answer CAP.
+const capabilityTimeout = 10 * time.Second
+
+// capabilities tracks IRCv3 capability negotiation for a connection. SASL is negotiated by the underlying connection,
+// which only knows how to request that one capability and sends the registration lines itself. The remaining
+// configured capabilities are listed once it has, so the server may well register the connection before they're
+// enabled. Nothing that needs them may run before wait returns, as auto joins don't. They're kept current through
+// cap-notify NEW and DEL messages.
+type capabilities struct {
+	mu          sync.RWMutex
+	wanted      []string
+	available   map[string]string
+	enabled     map[string]bool
+	listing     map[string]string
+	negotiating bool
+	listed      bool
+	pending     map[string]bool
+	settled     chan struct{}
+}
+
+func newCapabilities(wanted []string) *capabilities {
+	c := &capabilities{
+		wanted: make([]string, 0, len(wanted)),
+	}
+
+	for _, name := range wanted {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if len(name) > 0 && name != "sasl" {
+			c.wanted = append(c.wanted, name)
+		}
+	}
+
+	c.reset()
+	return c
+}
+
+// reset forgets everything learned from a previous connection.
+func (c *capabilities) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.available = make(map[string]string)
+	c.enabled = make(map[string]bool)
+	c.listing = nil
+	c.negotiating = false
+	c.listed = false
+	c.pending = nil
+	c.settled = make(chan struct{})
+	close(c.settled)
+}
+
+// begin starts negotiating the wanted capabilities and reports whether there are any to list. Until negotiation is
+// done, LS replies are answered with requests and wait blocks.
+func (c *capabilities) begin() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.settled = make(chan struct{})
+	c.listing = nil
+	c.listed = false
+	c.pending = nil
+	c.negotiating = len(c.wanted) > 0
+	if !c.negotiating {
+		close(c.settled)
+	}
+	return c.negotiating
+}
+
+// end reports whether negotiation has just finished, once the listing is complete and every request has been
+// acknowledged or rejected, so that CAP END is sent exactly once.
+func (c *capabilities) end() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.negotiating || !c.listed || len(c.pending) > 0 {
+		return false
+	}
+
+	c.negotiating = false
+	close(c.settled)
+	return true
+}
+
+// wait blocks until negotiation is done or the timeout passes, and reports whether it finished.
+func (c *capabilities) wait(timeout time.Duration) bool {
+	c.mu.RLock()
+	settled := c.settled
+	c.mu.RUnlock()
+
+	select {
+	case <-settled:
+		return true
+	default:
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-settled:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
+
+func (c *capabilities) isEnabled(name string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.enabled[strings.ToLower(name)]
+}
+
+// handle applies a CAP subcommand and its arguments (excluding the target) and returns the capabilities that should
+// now be requested.
+func (c *capabilities) handle(subcommand string, args []string) []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch strings.ToUpper(subcommand) {
+	case "LS":
+		// listings requested by the underlying connection for SASL aren't ours to answer
+		if !c.negotiating {
+			return nil
+		}
+
+		if c.listing == nil {
+			c.listing = make(map[string]string)
+		}
+
+		more := len(args) > 1 && args[0] == "*"
+		if len(args) > 0 {
+			for name, value := range parseCapabilities(args[len(args)-1]) {
+				c.listing[name] = value
+			}
+		}
+
+		if more {
+			return nil
+		}
+
+		c.available = c.listing
+		c.listing = nil
+		c.listed = true
+
+		requests := c.requestable()
+		c.pending = make(map[string]bool, len(requests))
+		for _, name := range requests {
+			c.pending[name] = true
+		}
+		return requests
+	case "NEW":
+		if len(args) > 0 {
+			for name, value := range parseCapabilities(args[len(args)-1]) {
+				c.available[name] = value
+			}
+		}
+		return c.requestable()
+	case "DEL":
+		if len(args) > 0 {
+			for name := range parseCapabilities(args[len(args)-1]) {
+				delete(c.available, name)
+				delete(c.enabled, name)
+			}
+		}
+	case "ACK":
+		if len(args) > 0 {
+			for _, name := range strings.Fields(args[len(args)-1]) {
+				if strings.HasPrefix(name, "-") {
+					delete(c.enabled, strings.ToLower(name[1:]))
+					delete(c.pending, strings.ToLower(name[1:]))
+					continue
+				}
+				c.enabled[strings.ToLower(name)] = true
+				delete(c.pending, strings.ToLower(name))
+			}
+		}
+	case "NAK":
+		if len(args) > 0 {
+			for _, name := range strings.Fields(args[len(args)-1]) {
+				delete(c.pending, strings.ToLower(strings.TrimPrefix(name, "-")))
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *capabilities) requestable() []string {
+	requests := make([]string, 0)
+	for _, name := range c.wanted {
+		if _, ok := c.available[name]; ok && !c.enabled[name] {
+			requests = append(requests, name)
+		}
+	}
+	return requests
+}
+
+// parseCapabilities parses a space-separated capability list, where each capability may carry a value (name=value).
+func parseCapabilities(list string) map[string]string {
+	caps := make(map[string]string)
+	for _, token := range strings.Fields(list) {
+		name, value, _ := strings.Cut(token, "=")
+		caps[strings.ToLower(name)] = value
+	}
+	return caps
+}
+
+// capabilityRequests groups capability names into CAP REQ lines that stay within the server's line length.
+func capabilityRequests(names []string) []string {
+	requests := make([]string, 0)
+
+	current := ""
+	for _, name := range names {
+		if len(current) > 0 && len(current)+1+len(name) > maxCapabilityRequestLength {
+			requests = append(requests, "CAP REQ :"+current)
+			current = ""
+		}
+		if len(current) > 0 {
+			current += " "
+		}
+		current += name
+	}
+
+	if len(current) > 0 {
+		requests = append(requests, "CAP REQ :"+current)
+	}
+
+	return requests
+}
@@ -0,0 +1,117 @@
+package irc
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCapabilitiesRequestsOfferedCapabilitiesAfterMultilineLS(t *testing.T) {
+	c := newCapabilities([]string{"account-notify", "Extended-Join", "sasl", "away-notify"})
+	c.begin()
+
+	if got := c.handle("LS", []string{"*", "multi-prefix sasl=PLAIN,EXTERNAL account-notify"}); got != nil {
+		t.Fatalf("handle(LS *) = %v, want nil until the listing completes", got)
+	}
+
+	got := c.handle("LS", []string{"extended-join cap-notify"})
+	want := []string{"account-notify", "extended-join"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("handle(LS) = %v, want %v", got, want)
+	}
+
+	if c.end() {
+		t.Fatal("end() = true while requests are unanswered")
+	}
+
+	c.handle("ACK", []string{"account-notify extended-join"})
+	if !c.isEnabled("account-notify") || !c.isEnabled("EXTENDED-JOIN") {
+		t.Fatal("acknowledged capabilities are not enabled")
+	}
+
+	if !c.end() || !c.wait(0) {
+		t.Fatal("negotiation did not end once every request was acknowledged")
+	}
+	if c.end() {
+		t.Fatal("end() = true a second time, want CAP END sent once")
+	}
+
+	if got = c.handle("NEW", []string{"away-notify"}); !reflect.DeepEqual(got, []string{"away-notify"}) {
+		t.Fatalf("handle(NEW) = %v, want [away-notify]", got)
+	}
+
+	c.handle("DEL", []string{"account-notify"})
+	if c.isEnabled("account-notify") {
+		t.Fatal("capability removed with DEL is still enabled")
+	}
+
+	c.handle("ACK", []string{"-extended-join"})
+	if c.isEnabled("extended-join") {
+		t.Fatal("capability disabled with ACK -name is still enabled")
+	}
+}
+
+func TestCapabilitiesIgnoreListingsOutsideNegotiation(t *testing.T) {
+	c := newCapabilities([]string{"account-notify"})
+
+	if got := c.handle("LS", []string{"sasl account-notify"}); got != nil {
+		t.Fatalf("handle(LS) before begin() = %v, want nil", got)
+	}
+	if c.end() {
+		t.Fatal("end() = true without negotiating")
+	}
+}
+
+func TestCapabilitiesEndAfterRejectedRequest(t *testing.T) {
+	c := newCapabilities([]string{"account-notify", "extended-join"})
+	c.begin()
+
+	if c.wait(0) {
+		t.Fatal("wait() returned before negotiation finished")
+	}
+
+	c.handle("LS", []string{"account-notify extended-join"})
+	c.handle("NAK", []string{"account-notify extended-join"})
+
+	if !c.end() {
+		t.Fatal("end() = false once every request was rejected")
+	}
+	if c.isEnabled("account-notify") {
+		t.Fatal("rejected capability is enabled")
+	}
+}
+
+func TestCapabilitiesWithNothingWantedDoNotNegotiate(t *testing.T) {
+	c := newCapabilities([]string{"sasl"})
+
+	if c.begin() {
+		t.Fatal("begin() = true with nothing to request")
+	}
+	if !c.wait(0) {
+		t.Fatal("wait() blocked with nothing to negotiate")
+	}
+}
+
+func TestCapabilityRequestsSplitsLongLists(t *testing.T) {
+	names := make([]string, 0)
+	for i := 0; i < 40; i++ {
+		names = append(names, "vendor.example/capability-"+strings.Repeat("x", i%5))
+	}
+
+	requests := capabilityRequests(names)
+	if len(requests) < 2 {
+		t.Fatalf("capabilityRequests() returned %d lines, want the list split", len(requests))
+	}
+
+	count := 0
+	for _, request := range requests {
+		if len(request) > maxCapabilityRequestLength+len("CAP REQ :") {
+			t.Fatalf("request is %d bytes, want at most %d", len(request), maxCapabilityRequestLength)
+		}
+		count += len(strings.Fields(strings.TrimPrefix(request, "CAP REQ :")))
+	}
+
+	if count != len(names) {
+		t.Fatalf("requested %d capabilities, want %d", count, len(names))
+	}
+}
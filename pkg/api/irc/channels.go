@@ -0,0 +1,543 @@
+package irc
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	irce "github.com/thoj/go-ircevent"
+)
+
+const (
+	ModeBan             = 'b'
+	ModeException       = 'e'
+	ModeQuiet           = 'q'
+	ModeInviteException = 'I'
+)
+
+const defaultPrefix = "(ohv)@%+"
+const defaultChannelModes = "beIq,k,l,imnpst"
+const operatorStatusSymbols = "~&@"
+
+// ChannelState is a snapshot of what the connection knows about a channel it has joined.
+type ChannelState struct {
+	Name       string
+	Topic      string
+	Modes      map[string]string
+	Members    []*User
+	Bans       []*BanEntry
+	Quiets     []*BanEntry
+	Exceptions []*BanEntry
+}
+
+type channelMember struct {
+	nick     string
+	userID   string
+	host     string
+	prefixes string
+}
+
+type channelState struct {
+	name         string
+	topic        string
+	modes        map[rune]string
+	members      map[string]*channelMember
+	names        map[string]*channelMember
+	synced       bool
+	lists        map[rune][]*BanEntry
+	pendingLists map[rune][]*BanEntry
+	listsSynced  map[rune]bool
+}
+
+func newChannelState(name string) *channelState {
+	return &channelState{
+		name:         name,
+		modes:        make(map[rune]string),
+		members:      make(map[string]*channelMember),
+		lists:        make(map[rune][]*BanEntry),
+		pendingLists: make(map[rune][]*BanEntry),
+		listsSynced:  make(map[rune]bool),
+	}
+}
+
+// channels keeps per-channel membership, status prefixes, modes, mode lists and topics current from the events the
+// connection receives, so that lookups don't need a NAMES, WHO or MODE round trip. Channel and nick keys are compared
+// case-insensitively. Mode parsing follows the PREFIX and CHANMODES tokens the server advertises in ISUPPORT.
+type channels struct {
+	mu             sync.RWMutex
+	channels       map[string]*channelState
+	prefixModes    string
+	prefixSymbols  string
+	listModes      string
+	paramModes     string
+	setParamModes  string
+	listNumerics   map[string]rune
+	endListNumbers map[string]rune
+}
+
+func newChannels() *channels {
+	c := &channels{
+		channels: make(map[string]*channelState),
+		listNumerics: map[string]rune{
+			CodeBanListReply:    ModeBan,
+			CodeExceptListReply: ModeException,
+			CodeQuietListReply:  ModeQuiet,
+			CodeInviteListReply: ModeInviteException,
+		},
+		endListNumbers: map[string]rune{
+			CodeEndOfBanList:    ModeBan,
+			CodeEndOfExceptList: ModeException,
+			CodeEndOfQuietList:  ModeQuiet,
+			CodeEndOfInviteList: ModeInviteException,
+		},
+	}
+	c.setPrefix(defaultPrefix)
+	c.setChannelModes(defaultChannelModes)
+	return c
+}
+
+func (c *channels) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.channels = make(map[string]*channelState)
+	c.setPrefix(defaultPrefix)
+	c.setChannelModes(defaultChannelModes)
+}
+
+func (c *channels) setPrefix(value string) {
+	modes, symbols, ok := strings.Cut(strings.TrimPrefix(value, "("), ")")
+	if !ok || len(modes) != len(symbols) {
+		return
+	}
+	c.prefixModes = modes
+	c.prefixSymbols = symbols
+}
+
+func (c *channels) setChannelModes(value string) {
+	groups := strings.Split(value, ",")
+	if len(groups) < 4 {
+		return
+	}
+	c.listModes = groups[0]
+	c.paramModes = groups[1]
+	c.setParamModes = groups[2]
+}
+
+// supportsQuiet returns whether the server has a quiet list mode, rather than using q as a status prefix.
+func (c *channels) supportsQuiet() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return strings.ContainsRune(c.listModes, ModeQuiet) && !strings.ContainsRune(c.prefixModes, ModeQuiet)
+}
+
+// requestedLists returns the mode lists that can be requested for a channel, excluding any list mode the server also
+// uses as a status prefix.
+func (c *channels) requestedLists() []rune {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	lists := make([]rune, 0)
+	for _, mode := range []rune{ModeBan, ModeException, ModeQuiet} {
+		if strings.ContainsRune(c.listModes, mode) && !strings.ContainsRune(c.prefixModes, mode) {
+			lists = append(lists, mode)
+		}
+	}
+	return lists
+}
+
+// observe applies an event to the tracked state. self is the connection's current nick.
+func (c *channels) observe(e *irce.Event, self string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch e.Code {
+	case CodeISupport:
+		for _, token := range e.Arguments {
+			name, value, _ := strings.Cut(token, "=")
+			switch name {
+			case "PREFIX":
+				c.setPrefix(value)
+			case "CHANMODES":
+				c.setChannelModes(value)
+			}
+		}
+	case CodeJoin:
+		if len(e.Arguments) == 0 {
+			return
+		}
+		if strings.EqualFold(e.Nick, self) {
+			c.channels[strings.ToLower(e.Arguments[0])] = newChannelState(e.Arguments[0])
+		}
+		if ch := c.channel(e.Arguments[0]); ch != nil {
+			m := c.member(ch, e.Nick)
+			m.userID, m.host = e.User, e.Host
+		}
+	case CodePart:
+		if len(e.Arguments) > 0 {
+			c.removeMember(e.Arguments[0], e.Nick, self)
+		}
+	case CodeKick:
+		if len(e.Arguments) > 1 {
+			c.removeMember(e.Arguments[0], e.Arguments[1], self)
+		}
+	case CodeQuit:
+		for _, ch := range c.channels {
+			delete(ch.members, strings.ToLower(e.Nick))
+		}
+	case CodeNickChange:
+		if len(e.Arguments) == 0 {
+			return
+		}
+		to := e.Arguments[len(e.Arguments)-1]
+		for _, ch := range c.channels {
+			if m, ok := ch.members[strings.ToLower(e.Nick)]; ok {
+				delete(ch.members, strings.ToLower(e.Nick))
+				m.nick = to
+				ch.members[strings.ToLower(to)] = m
+			}
+		}
+	case CodeMode:
+		if len(e.Arguments) > 1 {
+			if ch := c.channel(e.Arguments[0]); ch != nil {
+				c.applyModes(ch, e.Arguments[1], e.Arguments[2:], e.Nick, true)
+			}
+		}
+	case CodeChannelModes:
+		if len(e.Arguments) > 2 {
+			if ch := c.channel(e.Arguments[1]); ch != nil {
+				ch.modes = make(map[rune]string)
+				c.applyModes(ch, e.Arguments[2], e.Arguments[3:], "", false)
+			}
+		}
+	case CodeTopicReply:
+		if len(e.Arguments) > 2 {
+			if ch := c.channel(e.Arguments[1]); ch != nil {
+				ch.topic = e.Arguments[len(e.Arguments)-1]
+			}
+		}
+	case CodeNoTopic:
+		if len(e.Arguments) > 1 {
+			if ch := c.channel(e.Arguments[1]); ch != nil {
+				ch.topic = ""
+			}
+		}
+	case CodeTopic:
+		if len(e.Arguments) > 1 {
+			if ch := c.channel(e.Arguments[0]); ch != nil {
+				ch.topic = e.Arguments[len(e.Arguments)-1]
+			}
+		}
+	case CodeNamesReply:
+		// RPL_NAMREPLY: <me> <symbol> <channel> :<[prefixes]nick[!user@host]> ...
+		if len(e.Arguments) < 4 {
+			return
+		}
+		ch := c.channel(e.Arguments[2])
+		if ch == nil {
+			return
+		}
+		if ch.names == nil {
+			ch.names = make(map[string]*channelMember)
+		}
+		for _, name := range strings.Fields(e.Arguments[len(e.Arguments)-1]) {
+			m := c.parseName(name)
+			ch.names[strings.ToLower(m.nick)] = m
+		}
+	case CodeEndOfNames:
+		if len(e.Arguments) < 2 {
+			return
+		}
+		ch := c.channel(e.Arguments[1])
+		if ch == nil {
+			return
+		}
+		if ch.names == nil {
+			ch.names = make(map[string]*channelMember)
+		}
+		for key, m := range ch.names {
+			// keep hosts learned from JOIN and WHO when NAMES doesn't include them
+			if previous, ok := ch.members[key]; ok && len(m.host) == 0 {
+				m.userID, m.host = previous.userID, previous.host
+			}
+		}
+		ch.members = ch.names
+		ch.names = nil
+		ch.synced = true
+	case CodeWhoReply:
+		// RPL_WHOREPLY: <me> <channel> <user> <host> <server> <nick> <flags> :<hopcount> <real name>
+		if len(e.Arguments) < 7 {
+			return
+		}
+		if ch := c.channel(e.Arguments[1]); ch != nil {
+			if m, ok := ch.members[strings.ToLower(e.Arguments[5])]; ok {
+				m.userID, m.host = e.Arguments[2], e.Arguments[3]
+			}
+		}
+	default:
+		if mode, ok := c.listNumerics[e.Code]; ok {
+			c.addListReply(mode, e)
+			return
+		}
+		if mode, ok := c.endListNumbers[e.Code]; ok && len(e.Arguments) > 1 {
+			if ch := c.channel(e.Arguments[1]); ch != nil {
+				ch.lists[mode] = ch.pendingLists[mode]
+				if ch.lists[mode] == nil {
+					ch.lists[mode] = make([]*BanEntry, 0)
+				}
+				delete(ch.pendingLists, mode)
+				ch.listsSynced[mode] = true
+			}
+			return
+		}
+
+		// keep hosts current from any message sent by a channel member
+		if len(e.Host) > 0 && len(e.Nick) > 0 {
+			for _, ch := range c.channels {
+				if m, ok := ch.members[strings.ToLower(e.Nick)]; ok {
+					m.userID, m.host = e.User, e.Host
+				}
+			}
+		}
+	}
+}
+
+func (c *channels) channel(name string) *channelState {
+	return c.channels[strings.ToLower(name)]
+}
+
+func (c *channels) member(ch *channelState, nick string) *channelMember {
+	m, ok := ch.members[strings.ToLower(nick)]
+	if !ok {
+		m = &channelMember{nick: nick}
+		ch.members[strings.ToLower(nick)] = m
+	}
+	return m
+}
+
+func (c *channels) removeMember(channel, nick, self string) {
+	if strings.EqualFold(nick, self) {
+		delete(c.channels, strings.ToLower(channel))
+		return
+	}
+	if ch := c.channel(channel); ch != nil {
+		delete(ch.members, strings.ToLower(nick))
+	}
+}
+
+// parseName parses a NAMES entry, which carries every status prefix with multi-prefix and the full mask with
+// userhost-in-names.
+func (c *channels) parseName(name string) *channelMember {
+	m := &channelMember{}
+	for len(name) > 0 && strings.ContainsRune(c.prefixSymbols, rune(name[0])) {
+		m.prefixes += name[:1]
+		name = name[1:]
+	}
+
+	if mask := ParseMask(name); mask != nil && strings.Contains(name, "!") {
+		m.nick, m.userID, m.host = mask.Nick, mask.UserID, mask.Host
+	} else {
+		m.nick = name
+	}
+
+	return m
+}
+
+func (c *channels) addListReply(mode rune, e *irce.Event) {
+	// list replies carry the channel as the second argument; the quiet list adds the mode letter before the mask
+	if len(e.Arguments) < 3 {
+		return
+	}
+	ch := c.channel(e.Arguments[1])
+	if ch == nil {
+		return
+	}
+
+	args := e.Arguments[2:]
+	if mode == ModeQuiet && len(args) > 1 && args[0] == string(ModeQuiet) {
+		args = args[1:]
+	}
+
+	entry := &BanEntry{Mask: args[0]}
+	if len(args) > 1 {
+		entry.SetBy = args[1]
+	}
+	if len(args) > 2 {
+		if ts, err := strconv.ParseInt(args[2], 10, 64); err == nil {
+			t := time.Unix(ts, 0)
+			entry.SetAt = &t
+		}
+	}
+
+	ch.pendingLists[mode] = append(ch.pendingLists[mode], entry)
+}
+
+// applyModes applies a mode string and its parameters to a channel.
+func (c *channels) applyModes(ch *channelState, modes string, params []string, setBy string, tracked bool) {
+	adding := true
+	next := func() string {
+		if len(params) == 0 {
+			return ""
+		}
+		p := params[0]
+		params = params[1:]
+		return p
+	}
+
+	for _, mode := range modes {
+		switch {
+		case mode == '+':
+			adding = true
+		case mode == '-':
+			adding = false
+		case strings.ContainsRune(c.prefixModes, mode):
+			nick := next()
+			m, ok := ch.members[strings.ToLower(nick)]
+			if !ok {
+				continue
+			}
+			symbol := c.prefixSymbols[strings.IndexRune(c.prefixModes, mode)]
+			m.prefixes = strings.ReplaceAll(m.prefixes, string(symbol), "")
+			if adding {
+				m.prefixes += string(symbol)
+			}
+		case strings.ContainsRune(c.listModes, mode):
+			mask := next()
+			if len(mask) == 0 || !tracked {
+				continue
+			}
+			entries := ch.lists[mode]
+			for i, entry := range entries {
+				if strings.EqualFold(entry.Mask, mask) {
+					entries = append(entries[:i:i], entries[i+1:]...)
+					break
+				}
+			}
+			if adding {
+				now := time.Now()
+				entries = append(entries, &BanEntry{Mask: mask, SetBy: setBy, SetAt: &now})
+			}
+			ch.lists[mode] = entries
+		case strings.ContainsRune(c.paramModes, mode):
+			p := next()
+			if adding {
+				ch.modes[mode] = p
+			} else {
+				delete(ch.modes, mode)
+			}
+		case strings.ContainsRune(c.setParamModes, mode):
+			if adding {
+				ch.modes[mode] = next()
+			} else {
+				delete(ch.modes, mode)
+			}
+		default:
+			if adding {
+				ch.modes[mode] = ""
+			} else {
+				delete(ch.modes, mode)
+			}
+		}
+	}
+}
+
+// status returns the highest channel status for a member's prefixes. Prefixes ranked above operator, such as owner
+// and admin, count as operator.
+func (c *channels) status(prefixes string) ChannelStatus {
+	switch {
+	case strings.ContainsAny(prefixes, operatorStatusSymbols):
+		return ChannelStatusOperator
+	case strings.Contains(prefixes, string(ChannelStatusHalfOperator)):
+		return ChannelStatusHalfOperator
+	case strings.Contains(prefixes, string(ChannelStatusVoice)):
+		return ChannelStatusVoice
+	}
+	return ChannelStatusNone
+}
+
+func (c *channels) isJoined(channel string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.channel(channel) != nil
+}
+
+// keys returns the key of every joined channel that has one, by lower-cased channel name.
+func (c *channels) keys() map[string]string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	keys := make(map[string]string)
+	for name, ch := range c.channels {
+		if key := ch.modes['k']; len(key) > 0 {
+			keys[name] = key
+		}
+	}
+	return keys
+}
+
+func (c *channels) isSynced(channel string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	ch := c.channel(channel)
+	return ch != nil && ch.synced
+}
+
+func (c *channels) isListSynced(channel string, mode rune) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	ch := c.channel(channel)
+	return ch != nil && ch.listsSynced[mode]
+}
+
+// snapshot returns a copy of a channel's state, or nil when the channel isn't joined or its member list hasn't been
+// received yet.
+func (c *channels) snapshot(channel string) *ChannelState {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	ch := c.channel(channel)
+	if ch == nil || !ch.synced {
+		return nil
+	}
+
+	state := &ChannelState{
+		Name:       ch.name,
+		Topic:      ch.topic,
+		Modes:      make(map[string]string, len(ch.modes)),
+		Members:    make([]*User, 0, len(ch.members)),
+		Bans:       copyBanEntries(ch.lists[ModeBan]),
+		Quiets:     copyBanEntries(ch.lists[ModeQuiet]),
+		Exceptions: copyBanEntries(ch.lists[ModeException]),
+	}
+
+	for mode, param := range ch.modes {
+		state.Modes[string(mode)] = param
+	}
+
+	for _, m := range ch.members {
+		state.Members = append(state.Members, &User{
+			Mask:   &Mask{Nick: m.nick, UserID: m.userID, Host: m.host},
+			Status: c.status(m.prefixes),
+		})
+	}
+
+	sort.Slice(state.Members, func(i, j int) bool {
+		return strings.ToLower(state.Members[i].Mask.Nick) < strings.ToLower(state.Members[j].Mask.Nick)
+	})
+
+	return state
+}
+
+func copyBanEntries(entries []*BanEntry) []*BanEntry {
+	copied := make([]*BanEntry, 0, len(entries))
+	for _, entry := range entries {
+		e := *entry
+		copied = append(copied, &e)
+	}
+	return copied
+}
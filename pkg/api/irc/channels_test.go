@@ -0,0 +1,90 @@
+package irc
+
+import (
+	"testing"
+
+	irce "github.com/thoj/go-ircevent"
+)
+
+func observeAll(c *channels, self string, events ...*irce.Event) {
+	for _, e := range events {
+		c.observe(e, self)
+	}
+}
+
+func TestChannelsTrackMembershipAndStatus(t *testing.T) {
+	c := newChannels()
+
+	observeAll(c, "bot",
+		&irce.Event{Code: CodeISupport, Arguments: []string{"bot", "PREFIX=(qaohv)~&@%+", "CHANMODES=beI,k,l,imnst", "are supported by this server"}},
+		&irce.Event{Code: CodeJoin, Nick: "bot", User: "bot", Host: "bot.host", Arguments: []string{"#chan"}},
+		&irce.Event{Code: CodeNamesReply, Arguments: []string{"bot", "=", "#chan", "@bot ~&owner +voiced alice"}},
+		&irce.Event{Code: CodeEndOfNames, Arguments: []string{"bot", "#chan", "End of /NAMES list."}},
+	)
+
+	state := c.snapshot("#CHAN")
+	if state == nil || len(state.Members) != 4 {
+		t.Fatalf("snapshot = %+v, want 4 members", state)
+	}
+
+	status := func(nick string) ChannelStatus {
+		for _, u := range c.snapshot("#chan").Members {
+			if u.Mask.Nick == nick {
+				return u.Status
+			}
+		}
+		t.Fatalf("%s is not a member", nick)
+		return ""
+	}
+
+	if status("owner") != ChannelStatusOperator || status("voiced") != ChannelStatusVoice || status("alice") != ChannelStatusNone {
+		t.Fatalf("unexpected statuses: %+v", c.snapshot("#chan").Members)
+	}
+
+	observeAll(c, "bot",
+		&irce.Event{Code: CodeMode, Nick: "owner", Arguments: []string{"#chan", "+hk-v", "alice", "secret", "voiced"}},
+		&irce.Event{Code: CodeNickChange, Nick: "alice", Arguments: []string{"alicia"}},
+		&irce.Event{Code: CodeKick, Nick: "owner", Arguments: []string{"#chan", "voiced", "bye"}},
+	)
+
+	if status("alicia") != ChannelStatusHalfOperator {
+		t.Fatalf("alicia status = %q, want half-operator", status("alicia"))
+	}
+	if state = c.snapshot("#chan"); state.Modes["k"] != "secret" || len(state.Members) != 3 {
+		t.Fatalf("snapshot = %+v, want key and 3 members", state)
+	}
+
+	observeAll(c, "bot", &irce.Event{Code: CodePart, Nick: "bot", Arguments: []string{"#chan"}})
+	if c.snapshot("#chan") != nil {
+		t.Fatal("channel is still tracked after the bot parted")
+	}
+}
+
+func TestChannelsTrackBanLists(t *testing.T) {
+	c := newChannels()
+
+	observeAll(c, "bot",
+		&irce.Event{Code: CodeJoin, Nick: "bot", Arguments: []string{"#chan"}},
+		&irce.Event{Code: CodeEndOfNames, Arguments: []string{"bot", "#chan", "End of /NAMES list."}},
+		&irce.Event{Code: CodeBanListReply, Arguments: []string{"bot", "#chan", "*!*@old.host", "op", "1700000000"}},
+		&irce.Event{Code: CodeEndOfBanList, Arguments: []string{"bot", "#chan", "End of channel ban list"}},
+		&irce.Event{Code: CodeQuietListReply, Arguments: []string{"bot", "#chan", "q", "*!*@quiet.host", "op", "1700000000"}},
+		&irce.Event{Code: CodeEndOfQuietList, Arguments: []string{"bot", "#chan", "q", "End of channel quiet list"}},
+	)
+
+	if !c.isListSynced("#chan", ModeBan) {
+		t.Fatal("ban list is not synced")
+	}
+
+	observeAll(c, "bot",
+		&irce.Event{Code: CodeMode, Nick: "op", Arguments: []string{"#chan", "+b-b", "*!*@new.host", "*!*@old.host"}},
+	)
+
+	state := c.snapshot("#chan")
+	if len(state.Bans) != 1 || state.Bans[0].Mask != "*!*@new.host" || state.Bans[0].SetBy != "op" {
+		t.Fatalf("bans = %+v, want only *!*@new.host set by op", state.Bans)
+	}
+	if len(state.Quiets) != 1 || state.Quiets[0].Mask != "*!*@quiet.host" || state.Quiets[0].SetAt == nil {
+		t.Fatalf("quiets = %+v, want *!*@quiet.host", state.Quiets)
+	}
+}
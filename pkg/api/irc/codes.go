@@ -8,23 +8,40 @@ const (
 	CodePrivateMessage = "PRIVMSG"
 	CodeQuit           = "QUIT"
 	CodeError          = "ERROR"
+	CodeCapability     = "CAP"
+	CodeAccount        = "ACCOUNT"
+	CodePart           = "PART"
+	CodeKick           = "KICK"
+	CodeMode           = "MODE"
+	CodeTopic          = "TOPIC"
 )
 
 const (
-	CodeTopicReply   = "332"
-	CodeNoTopic      = "331"
-	CodeWhoIsReply   = "311"
-	CodeEndOfWho     = "315"
-	CodeEndOfWhoIs   = "318"
-	CodeWhoReply     = "352"
-	CodeNamesReply   = "353"
-	CodeBanListReply = "367"
-	CodeEndOfBanList = "368"
-	CodeEndOfNames   = "366"
-	CodeEndOfMotd    = "376"
-	CodeNickReserved = "432"
-	CodeNickInUse    = "433"
-	CodeBanned       = "474"
+	CodeWelcome         = "001"
+	CodeISupport        = "005"
+	CodeChannelModes    = "324"
+	CodeTopicReply      = "332"
+	CodeNoTopic         = "331"
+	CodeWhoIsReply      = "311"
+	CodeEndOfWho        = "315"
+	CodeEndOfWhoIs      = "318"
+	CodeWhoReply        = "352"
+	CodeNamesReply      = "353"
+	CodeBanListReply    = "367"
+	CodeEndOfBanList    = "368"
+	CodeInviteListReply = "346"
+	CodeEndOfInviteList = "347"
+	CodeExceptListReply = "348"
+	CodeEndOfExceptList = "349"
+	CodeQuietListReply  = "728"
+	CodeEndOfQuietList  = "729"
+	CodeEndOfNames      = "366"
+	CodeEndOfMotd       = "376"
+	CodeNickReserved    = "432"
+	CodeNickInUse       = "433"
+	CodeBanned          = "474"
+	CodeDisplayedHost   = "396"
+	CodeSASLSuccess     = "903"
 )
 
 const (
@@ -22,6 +22,8 @@ type Event struct {
 	From      string
 	Username  string
 	Source    string
+	Account   string
+	Network   string
 	Arguments []string
 	Metadata  map[string]any
 }
@@ -59,11 +61,13 @@ func (e *Event) Mask() *Mask {
 	mask := ParseMask(e.Source)
 	if mask == nil {
 		return &Mask{
-			Nick:   e.From,
-			UserID: e.Username,
-			Host:   e.Source,
+			Nick:    e.From,
+			UserID:  e.Username,
+			Host:    e.Source,
+			Account: e.Account,
 		}
 	}
+	mask.Account = e.Account
 	return mask
 }
 
@@ -86,6 +90,9 @@ func (e *Event) Labels() map[string]string {
 	labels["raw"] = e.Raw
 	labels["from"] = e.From
 	labels["source"] = e.Source
+	if len(e.Network) > 0 {
+		labels["network"] = e.Network
+	}
 	labels["arguments"] = fmt.Sprintf("[%s]", strings.Join(e.Arguments, ", "))
 	labels["is_private_message"] = fmt.Sprintf("%t", e.IsPrivateMessage())
 
@@ -9,6 +9,8 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	irce "github.com/thoj/go-ircevent"
@@ -86,30 +88,54 @@ type IRC interface {
 	Down(channel, nick string)
 	Voice(channel, nick string)
 	Mute(channel, nick string)
+	Quiet(channel, mask string) bool
+	Unquiet(channel, mask string)
 	Kick(channel, nick, reason string)
 	Ban(channel, mask string)
 	Unban(channel, mask string)
 	ListBans(channel string, callback func(bans []*BanEntry))
 	GetTopic(channel string, callback func(topic string))
 	SetTopic(channel, topic string)
+	HasCapability(name string) bool
+	Account(nick string) string
+	ChannelState(channel string) *ChannelState
+	ChannelMember(channel, nick string) *User
+	Network() string
+	Reconnect(delay time.Duration)
 	Disconnect()
 }
 
-const maxMessageLength = 400
-
 func NewIRC(ctx context.Context) IRC {
 	return &service{
-		ctx: ctx,
+		ctx:  ctx,
+		keys: make(map[string]string),
 	}
 }
 
 type service struct {
-	ctx           context.Context
-	cfg           *config.Config
-	conn          *irce.Connection
-	requests      *ircRequestManager
-	ech           chan *Event
-	recoverNeeded bool
+	ctx            context.Context
+	cfg            *config.Config
+	conn           *irce.Connection
+	requests       *ircRequestManager
+	ech            atomic.Pointer[chan *Event]
+	caps           *capabilities
+	accounts       *accounts
+	channels       *channels
+	queue          *sendQueue
+	source         atomic.Value
+	nick           atomic.Pointer[string]
+	keys           map[string]string
+	keysMu         sync.Mutex
+	requestedDelay atomic.Pointer[time.Duration]
+	stopped        atomic.Bool
+
+	// session counts links, and the flags below are reset whenever the link is re-established.
+	session       atomic.Uint64
+	recoverNeeded atomic.Bool
+	authenticated atomic.Bool
+	identified    atomic.Bool
+	registered    atomic.Bool
+	postConnected atomic.Bool
 }
 
 func (s *service) Connect(cfg *config.Config, connectCallback func(ctx context.Context, cfg *config.Config, irc IRC), joinChannelCallback func(channel string, mask *Mask)) error {
@@ -119,56 +145,137 @@ func (s *service) Connect(cfg *config.Config, connectCallback func(ctx context.C
 	s.conn.RealName = cfg.IRC.RealName
 	s.conn.Debug = false
 	s.conn.VerboseCallbackHandler = false
-	s.requests = newIRCRequestManager(s.conn, s.conn.SendRaw, ircRequestTimeout)
+	s.conn.KeepAlive = pingInterval
+	s.conn.PingFreq = pingInterval
+	s.conn.Timeout = pingTimeout
+	if s.queue != nil {
+		s.queue.close()
+	}
+	s.queue = newSendQueue(cfg.IRC.FloodControl, func(line string) { s.conn.SendRaw(line) })
+	go s.queue.run()
+
+	s.requests = newIRCRequestManager(s.conn, func(command string) {
+		s.queue.enqueue(sendPriorityControl, "", command)
+	}, ircRequestTimeout)
+	s.caps = newCapabilities(cfg.IRC.Capabilities)
+	s.accounts = newAccounts()
+	s.channels = newChannels()
+	s.source.Store("")
 
 	if cfg.IRC.TLS {
 		s.conn.UseTLS = cfg.IRC.TLS
 		s.conn.TLSConfig = newTLSConfig(cfg.IRC.Server)
+
+		if len(cfg.IRC.SASL.CertFile) > 0 {
+			cert, err := tls.LoadX509KeyPair(cfg.IRC.SASL.CertFile, cfg.IRC.SASL.KeyFile)
+			if err != nil {
+				return fmt.Errorf("error loading client certificate, %s", err)
+			}
+			s.conn.TLSConfig.Certificates = []tls.Certificate{cert}
+		}
 	}
 
-	if len(cfg.IRC.NickServ.Password) > 0 {
-		s.respondOnce(CodeNickInUse, func(event *irce.Event) bool {
-			log.Logger().Debugf(nil, "nick %s already in use, marking as recover needed", cfg.IRC.Nick)
-			s.recoverNeeded = true
-			return true
+	if cfg.IRC.SASL.IsEnabled() {
+		if cfg.IRC.SASL.Mechanism == config.SASLMechanismExternal && !cfg.IRC.TLS {
+			return fmt.Errorf("SASL %s requires TLS", config.SASLMechanismExternal)
+		}
+
+		s.conn.UseSASL = true
+		s.conn.SASLMech = strings.ToUpper(cfg.IRC.SASL.Mechanism)
+		s.conn.SASLLogin = cfg.IRC.SASL.Username
+		if len(s.conn.SASLLogin) == 0 {
+			s.conn.SASLLogin = cfg.IRC.Nick
+		}
+		s.conn.SASLPassword = cfg.IRC.SASL.Password
+		if len(s.conn.SASLPassword) == 0 {
+			s.conn.SASLPassword = cfg.IRC.NickServ.Password
+		}
+
+		s.conn.AddCallback(CodeSASLSuccess, func(event *irce.Event) {
+			log.Logger().Infof(nil, "authenticated with SASL %s", s.conn.SASLMech)
+			s.authenticated.Store(true)
 		})
+	}
+
+	s.conn.AddCallback(CodeWelcome, func(event *irce.Event) {
+		s.registered.Store(true)
+		s.accounts.reset()
+		s.channels.reset()
+	})
+
+	s.conn.AddCallback(CodeCapability, func(event *irce.Event) {
+		if len(event.Arguments) < 2 {
+			return
+		}
+
+		subcommand := strings.ToUpper(event.Arguments[1])
+		switch subcommand {
+		case "ACK":
+			log.Logger().Infof(nil, "capabilities acknowledged: %s", event.Message())
+		case "NAK":
+			log.Logger().Warningf(nil, "capabilities rejected: %s", event.Message())
+		}
+
+		for _, request := range capabilityRequests(s.caps.handle(subcommand, event.Arguments[2:])) {
+			s.conn.SendRaw(request)
+		}
+		// CAP END only ends the registration it suspended, which the server may have finished before it saw CAP LS
+		if s.caps.end() && !s.registered.Load() {
+			s.conn.SendRaw("CAP END")
+		}
+	})
+
+	s.conn.AddCallback(CodeNickInUse, func(event *irce.Event) {
+		log.Logger().Debugf(nil, "nick %s already in use, marking as recover needed", cfg.IRC.Nick)
+		s.recoverNeeded.Store(true)
+	})
 
-		s.respondOnce(CodeNickReserved, func(event *irce.Event) bool {
+	s.conn.AddCallback(CodeEndOfMotd, func(event *irce.Event) {
+		if s.recoverNeeded.CompareAndSwap(true, false) {
+			log.Logger().Debugf(nil, "reached end of MOTD and recover is needed, trying to regain %s", cfg.IRC.Nick)
+			s.regainNick()
+		}
+	})
+
+	if len(cfg.IRC.NickServ.Password) > 0 {
+		s.conn.AddCallback(CodeNickReserved, func(event *irce.Event) {
 			log.Logger().Debugf(nil, "nick %s already in use, need to release", cfg.IRC.Nick)
 			s.conn.Privmsgf(cfg.IRC.NickServ.Recipient, cfg.IRC.NickServ.ReleaseCommand, cfg.IRC.Nick, cfg.IRC.NickServ.Password)
-			return true
 		})
 
-		s.respondOnce(CodeEndOfMotd, func(event *irce.Event) bool {
-			if s.recoverNeeded {
-				log.Logger().Debugf(nil, "reached end of MOTD and recover is needed, trying to recover %s with NickServ", cfg.IRC.Nick)
-				s.conn.Privmsgf(cfg.IRC.NickServ.Recipient, cfg.IRC.NickServ.RecoverCommand, cfg.IRC.Nick, cfg.IRC.NickServ.Password)
+		s.conn.AddCallback(CodeNotice, func(event *irce.Event) {
+			if s.authenticated.Load() || s.identified.Load() {
+				return
 			}
-			return true
-		})
-
-		s.respondOnce(CodeNotice, func(event *irce.Event) bool {
 			if strings.Contains(event.Message(), cfg.IRC.NickServ.IdentifyPattern) {
 				s.conn.Privmsgf(cfg.IRC.NickServ.Recipient, cfg.IRC.NickServ.IdentifyCommand, cfg.IRC.NickServ.Password)
-				return true
+				s.identified.Store(true)
 			}
-			return false
 		})
 	}
 
 	if len(cfg.IRC.PostConnect.Code) > 0 {
-		s.respondOnce(cfg.IRC.PostConnect.Code, func(event *irce.Event) bool {
+		s.conn.AddCallback(cfg.IRC.PostConnect.Code, func(event *irce.Event) {
+			if !s.postConnected.CompareAndSwap(false, true) {
+				return
+			}
 			for _, command := range cfg.IRC.PostConnect.Commands {
 				s.conn.SendRawf(command, cfg.IRC.Nick)
 			}
-			for _, channel := range cfg.IRC.PostConnect.AutoJoin {
-				s.conn.Join(channel)
-			}
+			// joins wait for capabilities so that the first JOINs already carry accounts, without holding up the
+			// reader that the acknowledgements arrive on
+			go func() {
+				if !s.caps.wait(capabilityTimeout) {
+					log.Logger().Warningf(nil, "capability negotiation did not finish, joining without it")
+				}
+				s.autoJoin()
+			}()
 			connectCallback(s.ctx, s.cfg, s)
-			return true
 		})
 	}
 
+	s.conn.AddCallback("*", s.dispatch)
+
 	if joinChannelCallback != nil {
 		s.conn.AddCallback(CodeJoin, func(e *irce.Event) {
 			m := ParseMask(e.Source)
@@ -176,18 +283,44 @@ func (s *service) Connect(cfg *config.Config, connectCallback func(ctx context.C
 				log.Logger().Warningf(nil, "ignoring JOIN event with malformed source mask %q", e.Source)
 				return
 			}
-			joinChannelCallback(e.Message(), m)
+			if len(e.Arguments) == 0 {
+				return
+			}
+			m.Account = s.accounts.observe(e)
+			joinChannelCallback(e.Arguments[0], m)
 		})
 	}
 
 	err := s.conn.Connect(fmt.Sprintf("%s:%d", cfg.IRC.Server, cfg.IRC.Port))
+	if err != nil && s.conn.UseSASL && s.conn.Connected() {
+		log.Logger().Warningf(nil, "SASL authentication failed, falling back to NickServ: %s", err)
+		s.conn.SendRaw("QUIT")
+		select {
+		case <-s.conn.ErrorChan():
+		case <-time.After(ircRequestTimeout):
+		}
+		s.conn.Disconnect()
+		s.conn.UseSASL = false
+		s.caps.reset()
+		err = s.conn.Reconnect()
+	}
 	if err != nil {
 		return err
 	}
 
+	s.negotiateCapabilities()
 	return nil
 }
 
+// negotiateCapabilities lists the server's capabilities after the underlying connection has sent the registration
+// lines. Servers that haven't registered the connection yet hold it open until CAP END, but those that already have
+// enable the capabilities afterwards, so they can't be relied on until the negotiation settles.
+func (s *service) negotiateCapabilities() {
+	if s.caps.begin() {
+		s.conn.SendRawf("CAP LS %s", capabilityVersion)
+	}
+}
+
 func newTLSConfig(serverName string) *tls.Config {
 	return &tls.Config{
 		ServerName: serverName,
@@ -195,33 +328,144 @@ func newTLSConfig(serverName string) *tls.Config {
 	}
 }
 
-func (s *service) respondOnce(code string, callback func(event *irce.Event) bool) {
-	var id int
-	id = s.conn.AddCallback(code, func(event *irce.Event) {
-		if callback(event) {
-			s.conn.RemoveCallback(code, id)
-		}
-	})
+// Network returns the name of the network this connection is configured for, empty when only one network is configured.
+func (s *service) Network() string {
+	return s.cfg.IRC.Name
+}
+
+func (s *service) HasCapability(name string) bool {
+	return s.caps != nil && s.caps.isEnabled(name)
+}
+
+// Account returns the services account nick is logged in to, or an empty string when it is unknown or the server
+// doesn't support account tracking.
+func (s *service) Account(nick string) string {
+	if s.accounts == nil {
+		return ""
+	}
+	return s.accounts.get(nick)
 }
 
 func (s *service) Listen(ech chan *Event) {
-	s.ech = ech
+	s.ech.Store(&ech)
+	s.supervise()
+}
+
+// dispatch updates the tracked account and channel state from an event before passing it on to the listener, so that
+// state lookups made while handling the event already reflect it.
+func (s *service) dispatch(event *irce.Event) {
+	if event.Code == CodeWelcome && len(event.Arguments) > 0 {
+		s.setNick(event.Arguments[0])
+	}
+
+	self := s.currentNick()
+	account := s.accounts.observe(event)
+	s.channels.observe(event, self)
+	s.observeSource(event)
+
+	if event.Code == CodeNickChange && strings.EqualFold(event.Nick, self) {
+		s.setNick(event.Message())
+	}
+
+	if event.Code == CodeJoin && len(event.Arguments) > 0 && strings.EqualFold(event.Nick, self) {
+		s.requestChannelState(event.Arguments[0])
+	}
+
+	e := createEvent(event)
+	e.Account = account
+	e.Network = s.cfg.IRC.Name
+	// the listener is set after Connect has already registered this callback
+	if ech := s.ech.Load(); ech != nil {
+		*ech <- e
+	}
+}
+
+// currentNick returns the nick the connection is using. It's tracked here rather than read from the underlying
+// connection, which updates its own copy from callbacks that run alongside dispatch.
+func (s *service) currentNick() string {
+	if nick := s.nick.Load(); nick != nil {
+		return *nick
+	}
+	return s.cfg.IRC.Nick
+}
 
-	s.conn.AddCallback("*", func(event *irce.Event) {
-		if s.ech != nil {
-			s.ech <- createEvent(event)
+func (s *service) setNick(nick string) {
+	s.nick.Store(&nick)
+}
+
+// observeSource keeps track of the prefix the server puts on our own messages, which counts against the line length
+// when they're relayed.
+func (s *service) observeSource(event *irce.Event) {
+	switch {
+	case event.Code == CodeDisplayedHost && len(event.Arguments) > 1:
+		if source := s.source.Load().(string); strings.Contains(source, "@") {
+			s.source.Store(source[:strings.LastIndex(source, "@")+1] + event.Arguments[1])
 		}
-	})
+	case len(event.Host) > 0 && strings.EqualFold(event.Nick, s.currentNick()):
+		s.source.Store(event.Source)
+	}
+}
+
+// messageSource returns our own nick!user@host, assuming the longest user and host the server allows when they haven't
+// been seen yet.
+func (s *service) messageSource() string {
+	nick := s.currentNick()
+	if source, _ := s.source.Load().(string); strings.HasPrefix(strings.ToLower(source), strings.ToLower(nick)+"!") {
+		return source
+	}
+	return fmt.Sprintf("%s!%s@%s", nick, strings.Repeat("u", maxUsernameLength), strings.Repeat("h", maxHostLength))
+}
+
+// requestChannelState asks for what NAMES doesn't include after joining a channel: hosts, modes and mode lists.
+func (s *service) requestChannelState(channel string) {
+	s.queue.enqueue(sendPriorityControl, channel, fmt.Sprintf("WHO %s", channel))
+	s.queue.enqueue(sendPriorityControl, channel, fmt.Sprintf("MODE %s", channel))
+	for _, mode := range s.channels.requestedLists() {
+		s.queue.enqueue(sendPriorityControl, channel, fmt.Sprintf("MODE %s +%c", channel, mode))
+	}
+}
 
-	s.conn.Loop()
+// ChannelState returns a snapshot of a joined channel, or nil when its member list hasn't been received yet.
+func (s *service) ChannelState(channel string) *ChannelState {
+	if s.channels == nil {
+		return nil
+	}
+
+	state := s.channels.snapshot(channel)
+	if state == nil {
+		return nil
+	}
+
+	for _, u := range state.Members {
+		u.Mask.Account = s.Account(u.Mask.Nick)
+	}
+
+	return state
+}
+
+// ChannelMember returns a member of a joined channel with their current status, or nil when they aren't in it or the
+// channel isn't tracked.
+func (s *service) ChannelMember(channel, nick string) *User {
+	state := s.ChannelState(channel)
+	if state == nil {
+		return nil
+	}
+
+	for _, u := range state.Members {
+		if strings.EqualFold(u.Mask.Nick, nick) {
+			return u
+		}
+	}
+
+	return nil
 }
 
 func (s *service) Join(channel string) {
-	s.conn.Join(channel)
+	s.queue.enqueue(sendPriorityControl, channel, fmt.Sprintf("JOIN %s", channel))
 }
 
 func (s *service) Part(channel string) {
-	s.conn.Part(channel)
+	s.queue.enqueue(sendPriorityControl, channel, fmt.Sprintf("PART %s", channel))
 }
 
 var multipleSpacesRegex = regexp.MustCompile(`\s{2,}`)
@@ -233,45 +477,27 @@ func (s *service) SendMessage(target, message string) {
 	message = strings.TrimSpace(message)
 	message = multipleSpacesRegex.ReplaceAllString(message, " ")
 
-	if len(message) < maxMessageLength {
-		s.conn.Privmsg(target, message)
-		return
-	}
-
-	words := strings.Split(message, " ")
-	messages := make([]string, 0)
-	current := ""
-
-	for _, word := range words {
-		if len(current)+len(word) > maxMessageLength {
-			messages = append(messages, current)
-			current = ""
-		}
-		if len(current) > 0 {
-			current += " "
-		}
-		current += word
-	}
-
-	if len(current) > 0 {
-		messages = append(messages, current)
+	for _, m := range splitMessage(message, messageBudget(s.messageSource(), target)) {
+		s.privmsg(target, m)
 	}
+}
 
-	for _, m := range messages {
-		s.conn.Privmsg(target, m)
-	}
+func (s *service) privmsg(target, message string) {
+	s.queue.enqueue(sendPriorityChatter, target, fmt.Sprintf("PRIVMSG %s :%s", target, message))
 }
 
 func (s *service) SendMessages(target string, messages []string) {
-	go func() {
-		for _, message := range messages {
-			s.SendMessage(target, message)
-			time.Sleep(50 * time.Millisecond)
-		}
-	}()
+	for _, message := range messages {
+		s.SendMessage(target, message)
+	}
 }
 
 func (s *service) ListUsers(channel string, callback func(users []*User)) {
+	if state := s.ChannelState(channel); state != nil {
+		callback(state.Members)
+		return
+	}
+
 	allUsers := make([]*User, 0)
 	s.requests.run(requestKey("NAMES", channel), fmt.Sprintf("NAMES %s", channel), map[string]func(*irce.Event) bool{
 		CodeNamesReply: func(e *irce.Event) bool {
@@ -305,6 +531,16 @@ func (s *service) ListUsersByMask(channel, mask string, callback func(users []*U
 		return
 	}
 
+	if state := s.ChannelState(channel); state != nil && hasAllHosts(state.Members) {
+		for _, u := range state.Members {
+			if m.Matches(u.Mask) {
+				matchingUsers = append(matchingUsers, u)
+			}
+		}
+		callback(matchingUsers)
+		return
+	}
+
 	s.requests.run(requestKey("WHO", channel), fmt.Sprintf("WHO %s", channel), map[string]func(*irce.Event) bool{
 		CodeWhoReply: func(e *irce.Event) bool {
 			if !eventArgumentEquals(e, 1, channel) || len(e.Arguments) < 7 {
@@ -349,6 +585,14 @@ func (s *service) ListUsersByMask(channel, mask string, callback func(users []*U
 
 func (s *service) GetUser(channel, nick string, callback func(user *User)) {
 	logger := log.Logger()
+
+	if s.channels.isSynced(channel) {
+		user := s.ChannelMember(channel, nick)
+		if user == nil || len(user.Mask.Host) > 0 {
+			callback(user)
+			return
+		}
+	}
 	var user *User
 
 	s.requests.run(requestKey("WHOIS", nick), fmt.Sprintf("WHOIS %s", nick), map[string]func(*irce.Event) bool{
@@ -392,35 +636,69 @@ func (s *service) GetUser(channel, nick string, callback func(user *User)) {
 	})
 }
 
+func hasAllHosts(users []*User) bool {
+	for _, u := range users {
+		if len(u.Mask.Host) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
 func (s *service) Up(channel, nick string) {
-	s.conn.Privmsgf(s.cfg.IRC.ChanServ.Recipient, s.cfg.IRC.ChanServ.UpCommand, channel, nick)
+	s.moderate(channel, fmt.Sprintf("PRIVMSG %s :%s", s.cfg.IRC.ChanServ.Recipient, fmt.Sprintf(s.cfg.IRC.ChanServ.UpCommand, channel, nick)))
 }
 
 func (s *service) Down(channel, nick string) {
-	s.conn.Privmsgf(s.cfg.IRC.ChanServ.Recipient, s.cfg.IRC.ChanServ.DownCommand, channel, nick)
+	s.moderate(channel, fmt.Sprintf("PRIVMSG %s :%s", s.cfg.IRC.ChanServ.Recipient, fmt.Sprintf(s.cfg.IRC.ChanServ.DownCommand, channel, nick)))
 }
 
 func (s *service) Voice(channel, nick string) {
-	s.conn.Mode(channel, "+v", nick)
+	s.moderate(channel, fmt.Sprintf("MODE %s +v %s", channel, nick))
 }
 
 func (s *service) Mute(channel, nick string) {
-	s.conn.Mode(channel, "-v", nick)
+	s.moderate(channel, fmt.Sprintf("MODE %s -v %s", channel, nick))
+}
+
+// Quiet sets a quiet on the mask, which stops matching users speaking without devoicing anyone. It reports whether the
+// server supports quiets at all.
+func (s *service) Quiet(channel, mask string) bool {
+	if !s.channels.supportsQuiet() {
+		return false
+	}
+	s.moderate(channel, fmt.Sprintf("MODE %s +%c %s", channel, ModeQuiet, mask))
+	return true
+}
+
+func (s *service) Unquiet(channel, mask string) {
+	s.moderate(channel, fmt.Sprintf("MODE %s -%c %s", channel, ModeQuiet, mask))
 }
 
 func (s *service) Kick(channel, nick, reason string) {
-	s.conn.Kick(nick, channel, reason)
+	s.moderate(channel, fmt.Sprintf("KICK %s %s :%s", channel, nick, reason))
 }
 
 func (s *service) Ban(channel, mask string) {
-	s.conn.Mode(channel, "+b", mask)
+	s.moderate(channel, fmt.Sprintf("MODE %s +b %s", channel, mask))
 }
 
 func (s *service) Unban(channel, mask string) {
-	s.conn.Mode(channel, "-b", mask)
+	s.moderate(channel, fmt.Sprintf("MODE %s -b %s", channel, mask))
+}
+
+func (s *service) moderate(channel, line string) {
+	s.queue.enqueue(sendPriorityModeration, channel, line)
 }
 
 func (s *service) ListBans(channel string, callback func(bans []*BanEntry)) {
+	if s.channels.isListSynced(channel, ModeBan) {
+		if state := s.ChannelState(channel); state != nil {
+			callback(state.Bans)
+			return
+		}
+	}
+
 	bans := make([]*BanEntry, 0)
 
 	s.requests.run(requestKey("MODE+B", channel), fmt.Sprintf("MODE %s +b", channel), map[string]func(*irce.Event) bool{
@@ -457,6 +735,11 @@ func (s *service) ListBans(channel string, callback func(bans []*BanEntry)) {
 }
 
 func (s *service) GetTopic(channel string, callback func(topic string)) {
+	if state := s.ChannelState(channel); state != nil {
+		callback(state.Topic)
+		return
+	}
+
 	topic := ""
 	s.requests.run(requestKey("TOPIC", channel), fmt.Sprintf("TOPIC %s", channel), map[string]func(*irce.Event) bool{
 		CodeTopicReply: func(e *irce.Event) bool {
@@ -478,10 +761,14 @@ func (s *service) GetTopic(channel string, callback func(topic string)) {
 }
 
 func (s *service) SetTopic(channel, topic string) {
-	s.conn.SendRawf("TOPIC %s :%s", channel, topic)
+	s.queue.enqueue(sendPriorityControl, channel, fmt.Sprintf("TOPIC %s :%s", channel, topic))
 }
 
 func (s *service) Disconnect() {
+	s.stopped.Store(true)
+	if s.queue != nil {
+		s.queue.close()
+	}
 	s.conn.ClearCallback("*")
 	s.conn.Disconnect()
 }
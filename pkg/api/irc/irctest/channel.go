@@ -0,0 +1,202 @@
+package irctest
+
+import (
+	"sort"
+	"strings"
+)
+
+// The server advertises the same PREFIX and CHANMODES tokens as most networks.
+const (
+	prefixModes   = "ohv"
+	prefixSymbols = "@%+"
+	listModes     = "beIq"
+	keyModes      = "k"
+	paramModes    = "l"
+	iSupport      = "PREFIX=(ohv)@%+ CHANMODES=beIq,k,l,imnpst NETWORK=Test"
+)
+
+type channel struct {
+	name    string
+	topic   string
+	modes   map[rune]string
+	members map[string]string
+	lists   map[rune][]*listEntry
+}
+
+type listEntry struct {
+	mask  string
+	setBy string
+}
+
+func newChannel(name string) *channel {
+	return &channel{
+		name:    name,
+		modes:   make(map[rune]string),
+		members: make(map[string]string),
+		lists:   make(map[rune][]*listEntry),
+	}
+}
+
+// applyModes applies a mode change and returns the parts that changed anything, in the form they're relayed to members.
+func (ch *channel) applyModes(modes string, params []string, setBy string) (string, []string) {
+	adding := true
+	next := func() string {
+		if len(params) == 0 {
+			return ""
+		}
+		p := params[0]
+		params = params[1:]
+		return p
+	}
+
+	var applied strings.Builder
+	appliedParams := make([]string, 0)
+	sign := byte(0)
+	record := func(mode rune, param string) {
+		s := byte('-')
+		if adding {
+			s = '+'
+		}
+		if s != sign {
+			applied.WriteByte(s)
+			sign = s
+		}
+		applied.WriteRune(mode)
+		if len(param) > 0 {
+			appliedParams = append(appliedParams, param)
+		}
+	}
+
+	for _, mode := range modes {
+		switch {
+		case mode == '+':
+			adding = true
+		case mode == '-':
+			adding = false
+		case strings.ContainsRune(prefixModes, mode):
+			nick := next()
+			key := strings.ToLower(nick)
+			prefixes, ok := ch.members[key]
+			if !ok {
+				continue
+			}
+			symbol := string(prefixSymbols[strings.IndexRune(prefixModes, mode)])
+			prefixes = strings.ReplaceAll(prefixes, symbol, "")
+			if adding {
+				prefixes = sortPrefixes(prefixes + symbol)
+			}
+			ch.members[key] = prefixes
+			record(mode, nick)
+		case strings.ContainsRune(listModes, mode):
+			mask := next()
+			if len(mask) == 0 {
+				continue
+			}
+			entries := ch.lists[mode]
+			found := -1
+			for i, entry := range entries {
+				if strings.EqualFold(entry.mask, mask) {
+					found = i
+				}
+			}
+			if adding && found < 0 {
+				ch.lists[mode] = append(entries, &listEntry{mask: mask, setBy: setBy})
+				record(mode, mask)
+			} else if !adding && found >= 0 {
+				ch.lists[mode] = append(entries[:found:found], entries[found+1:]...)
+				record(mode, mask)
+			}
+		case strings.ContainsRune(keyModes, mode):
+			key := next()
+			if adding {
+				ch.modes[mode] = key
+			} else {
+				delete(ch.modes, mode)
+			}
+			record(mode, key)
+		case strings.ContainsRune(paramModes, mode):
+			if adding {
+				ch.modes[mode] = next()
+				record(mode, ch.modes[mode])
+			} else {
+				delete(ch.modes, mode)
+				record(mode, "")
+			}
+		default:
+			if adding {
+				ch.modes[mode] = ""
+			} else {
+				delete(ch.modes, mode)
+			}
+			record(mode, "")
+		}
+	}
+
+	return applied.String(), appliedParams
+}
+
+// modeString returns the channel's modes and their parameters as RPL_CHANNELMODEIS shows them.
+func (ch *channel) modeString() []string {
+	modes := make([]rune, 0, len(ch.modes))
+	for mode := range ch.modes {
+		modes = append(modes, mode)
+	}
+	sort.Slice(modes, func(i, j int) bool { return modes[i] < modes[j] })
+
+	params := make([]string, 0)
+	var sb strings.Builder
+	sb.WriteString("+")
+	for _, mode := range modes {
+		sb.WriteRune(mode)
+		if len(ch.modes[mode]) > 0 {
+			params = append(params, ch.modes[mode])
+		}
+	}
+	return append([]string{sb.String()}, params...)
+}
+
+// isListed returns whether a mask matches an entry of one of the channel's lists.
+func (ch *channel) isListed(mode rune, mask string) bool {
+	for _, entry := range ch.lists[mode] {
+		if matchMask(entry.mask, mask) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchMask matches a nick!user@host mask against a pattern with * and ? wildcards, ignoring case.
+func matchMask(pattern, mask string) bool {
+	pattern, mask = strings.ToLower(pattern), strings.ToLower(mask)
+	for len(pattern) > 0 {
+		switch pattern[0] {
+		case '*':
+			for i := len(mask); i >= 0; i-- {
+				if matchMask(pattern[1:], mask[i:]) {
+					return true
+				}
+			}
+			return false
+		case '?':
+			if len(mask) == 0 {
+				return false
+			}
+		default:
+			if len(mask) == 0 || mask[0] != pattern[0] {
+				return false
+			}
+		}
+		pattern, mask = pattern[1:], mask[1:]
+	}
+	return len(mask) == 0
+}
+
+func sortPrefixes(prefixes string) string {
+	var sb strings.Builder
+	for _, symbol := range prefixSymbols {
+		if strings.ContainsRune(prefixes, symbol) {
+			sb.WriteRune(symbol)
+		}
+	}
+	return sb.String()
+}
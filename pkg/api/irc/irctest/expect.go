@@ -0,0 +1,125 @@
+package irctest
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+const pollInterval = 5 * time.Millisecond
+
+// Sent returns every line received from real connections so far.
+func (s *Server) Sent() []*Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]*Message{}, s.sent...)
+}
+
+// Expect waits for a line from a real connection that matches and returns it. Lines are consumed in order: lines sent
+// before the match are passed over and won't be matched by later calls.
+func (s *Server) Expect(t testing.TB, description string, match func(m *Message) bool) *Message {
+	t.Helper()
+
+	deadline := time.Now().Add(s.Timeout)
+	for {
+		s.mu.Lock()
+		for i := s.cursor; i < len(s.sent); i++ {
+			if match(s.sent[i]) {
+				s.cursor = i + 1
+				m := s.sent[i]
+				s.mu.Unlock()
+				return m
+			}
+		}
+		s.mu.Unlock()
+
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s, received:\n%s", description, s.dump())
+			return nil
+		}
+		time.Sleep(pollInterval)
+	}
+}
+
+// ExpectCommand waits for a command whose parameters start with params.
+func (s *Server) ExpectCommand(t testing.TB, command string, params ...string) *Message {
+	t.Helper()
+
+	return s.Expect(t, strings.Join(append([]string{command}, params...), " "), func(m *Message) bool {
+		if m.Command != command || len(m.Params) < len(params) {
+			return false
+		}
+		for i, p := range params {
+			if !strings.EqualFold(m.Params[i], p) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// ExpectMessage waits for a PRIVMSG to target containing text.
+func (s *Server) ExpectMessage(t testing.TB, target, text string) *Message {
+	t.Helper()
+
+	return s.Expect(t, "PRIVMSG "+target+" containing "+text, func(m *Message) bool {
+		return m.Command == "PRIVMSG" && strings.EqualFold(m.Param(0), target) && strings.Contains(m.Trailing(), text)
+	})
+}
+
+// Refute fails the test when a matching line is received within the given time.
+func (s *Server) Refute(t testing.TB, within time.Duration, description string, match func(m *Message) bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(within)
+	for time.Now().Before(deadline) {
+		s.mu.Lock()
+		for i := s.cursor; i < len(s.sent); i++ {
+			if match(s.sent[i]) {
+				s.mu.Unlock()
+				t.Fatalf("unexpected %s: %s", description, s.sent[i])
+				return
+			}
+		}
+		s.mu.Unlock()
+		time.Sleep(pollInterval)
+	}
+}
+
+// WaitForMember waits until nick has joined channel.
+func (s *Server) WaitForMember(t testing.TB, channel, nick string) {
+	t.Helper()
+
+	deadline := time.Now().Add(s.Timeout)
+	for {
+		s.mu.Lock()
+		ch := s.channel(channel)
+		joined := ch != nil && s.isMember(ch, nick)
+		s.mu.Unlock()
+
+		if joined {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s to join %s", nick, channel)
+			return
+		}
+		time.Sleep(pollInterval)
+	}
+}
+
+func (s *Server) dump() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	lines := make([]string, 0, len(s.sent))
+	for i, m := range s.sent {
+		marker := " "
+		if i < s.cursor {
+			marker = "-"
+		}
+		lines = append(lines, marker+" "+m.String())
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,86 @@
+package irctest
+
+import (
+	"strings"
+)
+
+// Message is a line exchanged with the server, split into its source, command and parameters.
+type Message struct {
+	Source  string
+	Command string
+	Params  []string
+}
+
+// ParseMessage parses a raw line. Message tags are dropped.
+func ParseMessage(line string) *Message {
+	line = strings.TrimRight(line, "\r\n")
+	if strings.HasPrefix(line, "@") {
+		_, line, _ = strings.Cut(line, " ")
+	}
+
+	m := &Message{Params: make([]string, 0)}
+	if strings.HasPrefix(line, ":") {
+		m.Source, line, _ = strings.Cut(line[1:], " ")
+	}
+
+	for len(line) > 0 {
+		if strings.HasPrefix(line, ":") {
+			m.Params = append(m.Params, line[1:])
+			break
+		}
+		var param string
+		param, line, _ = strings.Cut(line, " ")
+		if len(param) == 0 {
+			continue
+		}
+		if len(m.Command) == 0 {
+			m.Command = strings.ToUpper(param)
+		} else {
+			m.Params = append(m.Params, param)
+		}
+	}
+
+	return m
+}
+
+// Param returns the i-th parameter, or an empty string when there are fewer.
+func (m *Message) Param(i int) string {
+	if i < len(m.Params) {
+		return m.Params[i]
+	}
+	return ""
+}
+
+// Trailing returns the last parameter, which is the text of messages, notices and kick reasons.
+func (m *Message) Trailing() string {
+	if len(m.Params) == 0 {
+		return ""
+	}
+	return m.Params[len(m.Params)-1]
+}
+
+// Nick returns the nick part of the source.
+func (m *Message) Nick() string {
+	nick, _, _ := strings.Cut(m.Source, "!")
+	return nick
+}
+
+func (m *Message) String() string {
+	var sb strings.Builder
+	if len(m.Source) > 0 {
+		sb.WriteString(":" + m.Source + " ")
+	}
+	sb.WriteString(m.Command)
+	for i, p := range m.Params {
+		sb.WriteString(" ")
+		if i == len(m.Params)-1 && (len(p) == 0 || strings.Contains(p, " ") || strings.HasPrefix(p, ":")) {
+			sb.WriteString(":")
+		}
+		sb.WriteString(p)
+	}
+	return sb.String()
+}
+
+func newMessage(source, command string, params ...string) *Message {
+	return &Message{Source: source, Command: command, Params: params}
+}
@@ -0,0 +1,590 @@
+// Package irctest provides an in-process IRC server for end-to-end tests. It speaks enough of the protocol for the
+// assistant to register, join channels and moderate them: registration, JOIN, PART, NAMES, WHO, WHOIS, MODE with
+// status, key and list modes, TOPIC, PRIVMSG, NOTICE, KICK and QUIT. Tests add simulated users that join channels and
+// talk, and assert on the lines the assistant sends back.
+package irctest
+
+import (
+	"assistant/pkg/config"
+	"bufio"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const serverName = "irc.test"
+const defaultTimeout = 5 * time.Second
+
+// Server is a fake IRC server listening on a local port. Every line received from a real connection is recorded so
+// that tests can wait for the assistant to send it.
+type Server struct {
+	t        testing.TB
+	listener net.Listener
+
+	// Timeout is how long Expect waits for a line before failing the test.
+	Timeout time.Duration
+
+	mu       sync.Mutex
+	clients  map[string]*client
+	conns    map[*client]bool
+	channels map[string]*channel
+	sent     []*Message
+	cursor   int
+	closed   bool
+}
+
+type client struct {
+	server     *Server
+	conn       net.Conn
+	out        chan string
+	nick       string
+	user       string
+	host       string
+	realName   string
+	registered bool
+	received   []*Message
+}
+
+// NewServer starts a server on a random local port. It's closed when the test ends.
+func NewServer(t testing.TB) *Server {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("error listening, %s", err)
+	}
+
+	s := &Server{
+		t:        t,
+		listener: listener,
+		Timeout:  defaultTimeout,
+		clients:  make(map[string]*client),
+		conns:    make(map[*client]bool),
+		channels: make(map[string]*channel),
+	}
+	t.Cleanup(s.Close)
+
+	go s.accept()
+	return s
+}
+
+// Configure points cfg at the server and makes sure the post connect commands and auto join run once it has welcomed
+// the connection.
+func (s *Server) Configure(cfg *config.Config) {
+	addr := s.listener.Addr().(*net.TCPAddr)
+	cfg.IRC.Server = addr.IP.String()
+	cfg.IRC.ServerName = serverName
+	cfg.IRC.Port = addr.Port
+	cfg.IRC.TLS = false
+	if len(cfg.IRC.PostConnect.Code) == 0 {
+		cfg.IRC.PostConnect.Code = "376"
+	}
+}
+
+func (s *Server) Close() {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return
+	}
+	s.closed = true
+	conns := make([]*client, 0, len(s.conns))
+	for c := range s.conns {
+		conns = append(conns, c)
+	}
+	s.mu.Unlock()
+
+	s.listener.Close()
+	for _, c := range conns {
+		c.conn.Close()
+	}
+}
+
+func (s *Server) accept() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		c := &client{
+			server: s,
+			conn:   conn,
+			out:    make(chan string, 1024),
+			host:   "localhost",
+		}
+
+		s.mu.Lock()
+		s.conns[c] = true
+		s.mu.Unlock()
+
+		go c.write()
+		go c.read()
+	}
+}
+
+func (c *client) read() {
+	scanner := bufio.NewScanner(c.conn)
+	for scanner.Scan() {
+		m := ParseMessage(scanner.Text())
+		if len(m.Command) == 0 {
+			continue
+		}
+
+		c.server.mu.Lock()
+		c.server.sent = append(c.server.sent, m)
+		c.server.handle(c, m)
+		c.server.mu.Unlock()
+	}
+
+	c.server.mu.Lock()
+	c.server.quit(c, "Connection closed")
+	delete(c.server.conns, c)
+	c.server.mu.Unlock()
+	close(c.out)
+}
+
+func (c *client) write() {
+	for line := range c.out {
+		if _, err := c.conn.Write([]byte(line + "\r\n")); err != nil {
+			return
+		}
+	}
+}
+
+func (c *client) mask() string {
+	return fmt.Sprintf("%s!%s@%s", c.nick, c.user, c.host)
+}
+
+// deliver sends m to the client, or records it for a simulated user.
+func (c *client) deliver(m *Message) {
+	if c.conn == nil {
+		c.received = append(c.received, m)
+		return
+	}
+
+	select {
+	case c.out <- m.String():
+	default:
+		c.server.t.Errorf("dropping line for %s, its queue is full: %s", c.nick, m)
+	}
+}
+
+func (c *client) numeric(code string, params ...string) {
+	target := c.nick
+	if len(target) == 0 {
+		target = "*"
+	}
+	c.deliver(newMessage(serverName, code, append([]string{target}, params...)...))
+}
+
+// handle processes a line from a client. The server lock is held.
+func (s *Server) handle(c *client, m *Message) {
+	if !c.registered {
+		switch m.Command {
+		case "CAP", "NICK", "USER", "PASS", "PING", "QUIT":
+		default:
+			c.numeric("451", "You have not registered")
+			return
+		}
+	}
+
+	switch m.Command {
+	case "CAP":
+		switch strings.ToUpper(m.Param(0)) {
+		case "LS":
+			c.deliver(newMessage(serverName, "CAP", "*", "LS", ""))
+		case "REQ":
+			c.deliver(newMessage(serverName, "CAP", "*", "NAK", m.Trailing()))
+		}
+	case "PASS", "PONG":
+	case "NICK":
+		s.changeNick(c, m.Param(0))
+	case "USER":
+		if len(m.Params) < 4 {
+			c.numeric("461", "USER", "Not enough parameters")
+			return
+		}
+		c.user = m.Param(0)
+		c.realName = m.Trailing()
+		s.register(c)
+	case "PING":
+		c.deliver(newMessage(serverName, "PONG", serverName, m.Param(0)))
+	case "JOIN":
+		keys := strings.Split(m.Param(1), ",")
+		for i, name := range strings.Split(m.Param(0), ",") {
+			key := ""
+			if i < len(keys) {
+				key = keys[i]
+			}
+			s.join(c, name, key)
+		}
+	case "PART":
+		for _, name := range strings.Split(m.Param(0), ",") {
+			s.part(c, name, m.Param(1))
+		}
+	case "KICK":
+		s.kick(c, m.Param(0), m.Param(1), m.Param(2))
+	case "MODE":
+		s.mode(c, m.Param(0), m.Param(1), m.Params[min(2, len(m.Params)):])
+	case "TOPIC":
+		s.topic(c, m)
+	case "NAMES":
+		if ch := s.channel(m.Param(0)); ch != nil {
+			s.names(c, ch)
+		} else {
+			c.numeric("366", m.Param(0), "End of /NAMES list.")
+		}
+	case "WHO":
+		s.who(c, m.Param(0))
+	case "WHOIS":
+		s.whois(c, m.Param(len(m.Params)-1))
+	case "PRIVMSG", "NOTICE":
+		s.message(c, m.Command, m.Param(0), m.Param(1))
+	case "QUIT":
+		s.quit(c, m.Param(0))
+	default:
+		c.numeric("421", m.Command, "Unknown command")
+	}
+}
+
+func (s *Server) register(c *client) {
+	if c.registered || len(c.nick) == 0 || len(c.user) == 0 {
+		return
+	}
+
+	c.registered = true
+	c.numeric("001", fmt.Sprintf("Welcome to the Test IRC Network %s", c.mask()))
+	c.numeric("002", fmt.Sprintf("Your host is %s", serverName))
+	c.numeric("005", append(strings.Fields(iSupport), "are supported by this server")...)
+	c.numeric("375", fmt.Sprintf("- %s Message of the day -", serverName))
+	c.numeric("376", "End of /MOTD command.")
+}
+
+func (s *Server) changeNick(c *client, nick string) {
+	if len(nick) == 0 {
+		c.numeric("431", "No nickname given")
+		return
+	}
+	if other, ok := s.clients[strings.ToLower(nick)]; ok && other != c {
+		c.numeric("433", nick, "Nickname is already in use")
+		return
+	}
+
+	if !c.registered {
+		if len(c.nick) > 0 {
+			delete(s.clients, strings.ToLower(c.nick))
+		}
+		c.nick = nick
+		s.clients[strings.ToLower(nick)] = c
+		s.register(c)
+		return
+	}
+
+	m := newMessage(c.mask(), "NICK", nick)
+	s.broadcastToPeers(c, m, true)
+
+	delete(s.clients, strings.ToLower(c.nick))
+	for _, ch := range s.channels {
+		if prefixes, ok := ch.members[strings.ToLower(c.nick)]; ok {
+			delete(ch.members, strings.ToLower(c.nick))
+			ch.members[strings.ToLower(nick)] = prefixes
+		}
+	}
+	c.nick = nick
+	s.clients[strings.ToLower(nick)] = c
+}
+
+func (s *Server) channel(name string) *channel {
+	return s.channels[strings.ToLower(name)]
+}
+
+func (s *Server) join(c *client, name, key string) {
+	if !strings.HasPrefix(name, "#") {
+		c.numeric("403", name, "No such channel")
+		return
+	}
+
+	ch := s.channel(name)
+	if ch == nil {
+		ch = newChannel(name)
+		s.channels[strings.ToLower(name)] = ch
+	}
+	if _, ok := ch.members[strings.ToLower(c.nick)]; ok {
+		return
+	}
+	if k, ok := ch.modes['k']; ok && k != key {
+		c.numeric("475", ch.name, "Cannot join channel (+k)")
+		return
+	}
+	if ch.isListed('b', c.mask()) && !ch.isListed('e', c.mask()) {
+		c.numeric("474", ch.name, "Cannot join channel (+b)")
+		return
+	}
+
+	ch.members[strings.ToLower(c.nick)] = ""
+	s.broadcast(ch, newMessage(c.mask(), "JOIN", ch.name), nil)
+	if len(ch.topic) > 0 {
+		c.numeric("332", ch.name, ch.topic)
+	}
+	s.names(c, ch)
+}
+
+func (s *Server) part(c *client, name, reason string) {
+	ch := s.channel(name)
+	if ch == nil || !s.isMember(ch, c.nick) {
+		c.numeric("442", name, "You're not on that channel")
+		return
+	}
+
+	s.broadcast(ch, newMessage(c.mask(), "PART", ch.name, reason), nil)
+	delete(ch.members, strings.ToLower(c.nick))
+}
+
+func (s *Server) kick(c *client, name, nick, reason string) {
+	ch := s.channel(name)
+	if ch == nil {
+		c.numeric("403", name, "No such channel")
+		return
+	}
+	if !s.isOperator(ch, c) {
+		c.numeric("482", ch.name, "You're not channel operator")
+		return
+	}
+	target, ok := s.clients[strings.ToLower(nick)]
+	if !ok || !s.isMember(ch, nick) {
+		c.numeric("441", nick, ch.name, "They aren't on that channel")
+		return
+	}
+
+	s.broadcast(ch, newMessage(c.mask(), "KICK", ch.name, target.nick, reason), nil)
+	delete(ch.members, strings.ToLower(target.nick))
+}
+
+func (s *Server) mode(c *client, target, modes string, params []string) {
+	ch := s.channel(target)
+	if ch == nil {
+		if !strings.HasPrefix(target, "#") {
+			return
+		}
+		c.numeric("403", target, "No such channel")
+		return
+	}
+
+	if len(modes) == 0 {
+		c.numeric("324", append([]string{ch.name}, ch.modeString()...)...)
+		return
+	}
+
+	if mode, ok := listQuery(modes, params); ok {
+		s.list(c, ch, mode)
+		return
+	}
+
+	if !s.isOperator(ch, c) {
+		c.numeric("482", ch.name, "You're not channel operator")
+		return
+	}
+	s.applyModes(ch, c.mask(), modes, params)
+}
+
+// listQuery returns the list mode a MODE request asks for, when it only asks to see a list.
+func listQuery(modes string, params []string) (rune, bool) {
+	query := strings.TrimPrefix(modes, "+")
+	if len(params) > 0 || len(query) != 1 || !strings.Contains(listModes, query) {
+		return 0, false
+	}
+	return rune(query[0]), true
+}
+
+func (s *Server) list(c *client, ch *channel, mode rune) {
+	codes := map[rune][2]string{
+		'b': {"367", "368"},
+		'e': {"348", "349"},
+		'I': {"346", "347"},
+		'q': {"728", "729"},
+	}[mode]
+
+	for _, entry := range ch.lists[mode] {
+		params := []string{ch.name}
+		if mode == 'q' {
+			params = append(params, "q")
+		}
+		c.numeric(codes[0], append(params, entry.mask, entry.setBy, "0")...)
+	}
+
+	params := []string{ch.name}
+	if mode == 'q' {
+		params = append(params, "q")
+	}
+	c.numeric(codes[1], append(params, "End of channel list")...)
+}
+
+func (s *Server) applyModes(ch *channel, source, modes string, params []string) {
+	nick, _, _ := strings.Cut(source, "!")
+	applied, appliedParams := ch.applyModes(modes, params, nick)
+	if len(applied) > 0 {
+		s.broadcast(ch, newMessage(source, "MODE", append([]string{ch.name, applied}, appliedParams...)...), nil)
+	}
+}
+
+func (s *Server) topic(c *client, m *Message) {
+	ch := s.channel(m.Param(0))
+	if ch == nil {
+		c.numeric("403", m.Param(0), "No such channel")
+		return
+	}
+
+	if len(m.Params) < 2 {
+		if len(ch.topic) == 0 {
+			c.numeric("331", ch.name, "No topic is set")
+		} else {
+			c.numeric("332", ch.name, ch.topic)
+		}
+		return
+	}
+
+	if _, ok := ch.modes['t']; ok && !s.isOperator(ch, c) {
+		c.numeric("482", ch.name, "You're not channel operator")
+		return
+	}
+	ch.topic = m.Param(1)
+	s.broadcast(ch, newMessage(c.mask(), "TOPIC", ch.name, ch.topic), nil)
+}
+
+func (s *Server) names(c *client, ch *channel) {
+	names := make([]string, 0, len(ch.members))
+	for key, prefixes := range ch.members {
+		if member, ok := s.clients[key]; ok {
+			names = append(names, prefixes+member.nick)
+		}
+	}
+
+	c.numeric("353", "=", ch.name, strings.Join(names, " "))
+	c.numeric("366", ch.name, "End of /NAMES list.")
+}
+
+func (s *Server) who(c *client, target string) {
+	reply := func(channelName string, member *client, prefixes string) {
+		c.numeric("352", channelName, member.user, member.host, serverName, member.nick, "H"+prefixes, "0 "+member.realName)
+	}
+
+	if ch := s.channel(target); ch != nil {
+		for key, prefixes := range ch.members {
+			if member, ok := s.clients[key]; ok {
+				reply(ch.name, member, prefixes)
+			}
+		}
+	} else if member, ok := s.clients[strings.ToLower(target)]; ok {
+		reply("*", member, "")
+	}
+
+	c.numeric("315", target, "End of /WHO list.")
+}
+
+func (s *Server) whois(c *client, nick string) {
+	member, ok := s.clients[strings.ToLower(nick)]
+	if !ok {
+		c.numeric("401", nick, "No such nick/channel")
+		c.numeric("318", nick, "End of /WHOIS list.")
+		return
+	}
+
+	c.numeric("311", member.nick, member.user, member.host, "*", member.realName)
+	channels := make([]string, 0)
+	for _, ch := range s.channels {
+		if prefixes, ok := ch.members[strings.ToLower(member.nick)]; ok {
+			channels = append(channels, prefixes+ch.name)
+		}
+	}
+	if len(channels) > 0 {
+		c.numeric("319", member.nick, strings.Join(channels, " "))
+	}
+	c.numeric("318", member.nick, "End of /WHOIS list.")
+}
+
+func (s *Server) message(c *client, command, target, text string) {
+	m := newMessage(c.mask(), command, target, text)
+
+	if ch := s.channel(target); ch != nil {
+		prefixes, member := ch.members[strings.ToLower(c.nick)]
+		_, moderated := ch.modes['m']
+		silenced := (ch.isListed('b', c.mask()) || ch.isListed('q', c.mask())) && !ch.isListed('e', c.mask())
+		if len(prefixes) == 0 && (silenced || (moderated && member)) {
+			c.numeric("404", ch.name, "Cannot send to channel")
+			return
+		}
+		s.broadcast(ch, m, c)
+		return
+	}
+
+	recipient, ok := s.clients[strings.ToLower(target)]
+	if !ok {
+		if command == "PRIVMSG" {
+			c.numeric("401", target, "No such nick/channel")
+		}
+		return
+	}
+	recipient.deliver(m)
+}
+
+func (s *Server) quit(c *client, reason string) {
+	if existing, ok := s.clients[strings.ToLower(c.nick)]; !ok || existing != c {
+		return
+	}
+
+	s.broadcastToPeers(c, newMessage(c.mask(), "QUIT", reason), false)
+	for _, ch := range s.channels {
+		delete(ch.members, strings.ToLower(c.nick))
+	}
+	delete(s.clients, strings.ToLower(c.nick))
+
+	if c.conn != nil {
+		c.deliver(newMessage("", "ERROR", fmt.Sprintf("Closing Link: %s (%s)", c.host, reason)))
+		go func() {
+			// give the writer a moment to flush the ERROR line
+			time.Sleep(50 * time.Millisecond)
+			c.conn.Close()
+		}()
+	}
+}
+
+// broadcast delivers m to every member of ch except the one it came from.
+func (s *Server) broadcast(ch *channel, m *Message, except *client) {
+	for key := range ch.members {
+		if member, ok := s.clients[key]; ok && member != except {
+			member.deliver(m)
+		}
+	}
+}
+
+// broadcastToPeers delivers m once to everyone sharing a channel with c, and to c itself when self is set.
+func (s *Server) broadcastToPeers(c *client, m *Message, self bool) {
+	seen := map[*client]bool{c: true}
+	if self {
+		c.deliver(m)
+	}
+	for _, ch := range s.channels {
+		if !s.isMember(ch, c.nick) {
+			continue
+		}
+		for key := range ch.members {
+			if member, ok := s.clients[key]; ok && !seen[member] {
+				seen[member] = true
+				member.deliver(m)
+			}
+		}
+	}
+}
+
+func (s *Server) isMember(ch *channel, nick string) bool {
+	_, ok := ch.members[strings.ToLower(nick)]
+	return ok
+}
+
+func (s *Server) isOperator(ch *channel, c *client) bool {
+	return strings.ContainsAny(ch.members[strings.ToLower(c.nick)], "@%")
+}
@@ -0,0 +1,140 @@
+package irctest
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// User is a simulated user. It has no connection of its own: what it does goes through the server as if it had sent
+// the line, and what it's sent is recorded.
+type User struct {
+	server *Server
+	client *client
+}
+
+// AddUser connects a simulated user with the nick as its username and a host of its own.
+func (s *Server) AddUser(nick string) *User {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.clients[strings.ToLower(nick)]; ok {
+		s.t.Fatalf("nick %s is already connected", nick)
+	}
+
+	c := &client{
+		server:     s,
+		nick:       nick,
+		user:       nick,
+		host:       fmt.Sprintf("%s.users.test", strings.ToLower(nick)),
+		realName:   nick,
+		registered: true,
+	}
+	s.clients[strings.ToLower(nick)] = c
+	return &User{server: s, client: c}
+}
+
+// Send processes a raw line as if the user had sent it.
+func (u *User) Send(line string) {
+	u.server.mu.Lock()
+	defer u.server.mu.Unlock()
+
+	u.server.handle(u.client, ParseMessage(line))
+}
+
+func (u *User) Join(channel string) {
+	u.Send("JOIN " + channel)
+}
+
+func (u *User) Part(channel string) {
+	u.Send("PART " + channel)
+}
+
+// Say sends a PRIVMSG to a channel or nick.
+func (u *User) Say(target, text string) {
+	u.Send(fmt.Sprintf("PRIVMSG %s :%s", target, text))
+}
+
+func (u *User) Quit(reason string) {
+	u.Send("QUIT :" + reason)
+}
+
+func (u *User) ChangeNick(nick string) {
+	u.Send("NICK " + nick)
+}
+
+func (u *User) Nick() string {
+	u.server.mu.Lock()
+	defer u.server.mu.Unlock()
+
+	return u.client.nick
+}
+
+func (u *User) Mask() string {
+	u.server.mu.Lock()
+	defer u.server.mu.Unlock()
+
+	return u.client.mask()
+}
+
+// Received returns the lines the user has been sent.
+func (u *User) Received() []*Message {
+	u.server.mu.Lock()
+	defer u.server.mu.Unlock()
+
+	return append([]*Message{}, u.client.received...)
+}
+
+// SetModes changes a channel's modes on behalf of the server, without needing an operator to do it.
+func (s *Server) SetModes(channel, modes string, params ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ch := s.channel(channel)
+	if ch == nil {
+		ch = newChannel(channel)
+		s.channels[strings.ToLower(channel)] = ch
+	}
+	s.applyModes(ch, serverName, modes, params)
+}
+
+// Members returns the nicks in a channel with their status prefixes.
+func (s *Server) Members(channel string) map[string]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	members := make(map[string]string)
+	if ch := s.channel(channel); ch != nil {
+		for key, prefixes := range ch.members {
+			if c, ok := s.clients[key]; ok {
+				members[c.nick] = prefixes
+			}
+		}
+	}
+	return members
+}
+
+// List returns the masks on one of a channel's lists, such as its bans.
+func (s *Server) List(channel string, mode rune) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	masks := make([]string, 0)
+	if ch := s.channel(channel); ch != nil {
+		for _, entry := range ch.lists[mode] {
+			masks = append(masks, entry.mask)
+		}
+	}
+	sort.Strings(masks)
+	return masks
+}
+
+func (s *Server) Topic(channel string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if ch := s.channel(channel); ch != nil {
+		return ch.topic
+	}
+	return ""
+}
@@ -4,13 +4,19 @@ import (
 	"assistant/pkg/log"
 	"fmt"
 	"regexp"
+	"slices"
 	"strings"
 )
 
+// sharedHosts are host patterns that many unrelated users share, such as web gateways, bouncer services and services
+// cloak prefixes.
+var sharedHosts = []string{"gateway/*", "user/*", "unaffiliated/*", "*.irccloud.com", "*.kiwiirc.com", "*.mibbit.com"}
+
 type Mask struct {
-	Nick   string
-	UserID string
-	Host   string
+	Nick    string
+	UserID  string
+	Host    string
+	Account string
 }
 
 func (m *Mask) String() string {
@@ -82,3 +88,37 @@ func (m *Mask) Matches(other *Mask) bool {
 
 	return re.MatchString(other.String())
 }
+
+// MatchWildcard reports whether s matches the pattern, ignoring case, where * matches any run of characters and ? any
+// one character, as in ban masks.
+func MatchWildcard(pattern, s string) bool {
+	p, r := []rune(strings.ToLower(pattern)), []rune(strings.ToLower(s))
+	pi, ri := 0, 0
+	star, mark := -1, 0
+	for ri < len(r) {
+		switch {
+		case pi < len(p) && (p[pi] == '?' || p[pi] == r[ri]):
+			pi++
+			ri++
+		case pi < len(p) && p[pi] == '*':
+			star, mark = pi, ri
+			pi++
+		case star >= 0:
+			pi = star + 1
+			mark++
+			ri = mark
+		default:
+			return false
+		}
+	}
+	for pi < len(p) && p[pi] == '*' {
+		pi++
+	}
+	return pi == len(p)
+}
+
+// IsSharedHost reports whether the host belongs to a gateway, bouncer service or cloak prefix that many users share, so
+// that it doesn't identify anyone by itself.
+func IsSharedHost(host string) bool {
+	return slices.ContainsFunc(sharedHosts, func(pattern string) bool { return MatchWildcard(pattern, host) })
+}
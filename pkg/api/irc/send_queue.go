@@ -0,0 +1,231 @@
+package irc
+
+import (
+	"assistant/pkg/config"
+	"assistant/pkg/log"
+	"sync"
+	"time"
+)
+
+const defaultFloodBurst = 5
+const defaultFloodRate = 1.0
+const defaultFloodMaxAge = 30 * time.Second
+const sendQueueReportInterval = 30 * time.Second
+
+type sendPriority int
+
+const (
+	// sendPriorityModeration lines (KICK, MODE and ChanServ requests) go out before anything else.
+	sendPriorityModeration sendPriority = iota
+	// sendPriorityControl lines (JOIN, PART, WHO, TOPIC and the like) are sent in order and never dropped.
+	sendPriorityControl
+	// sendPriorityChatter lines are messages, shared round-robin between targets and dropped once stale.
+	sendPriorityChatter
+)
+
+type queuedLine struct {
+	line     string
+	queuedAt time.Time
+}
+
+// sendQueue paces outbound lines with a token bucket so that bursts of output don't get the connection killed for
+// excess flood. Moderation lines jump the queue, control lines follow in order, and chatter is taken from each target in
+// turn so that a long reply in one channel doesn't hold up every other channel.
+type sendQueue struct {
+	mu         sync.Mutex
+	burst      float64
+	rate       float64
+	maxAge     time.Duration
+	tokens     float64
+	updated    time.Time
+	moderation []queuedLine
+	control    []queuedLine
+	chatter    map[string][]queuedLine
+	targets    []string
+	dropped    int
+	wake       chan struct{}
+	stop       chan struct{}
+	stopOnce   sync.Once
+	send       func(string)
+}
+
+func newSendQueue(cfg config.FloodControlConfig, send func(string)) *sendQueue {
+	burst := float64(cfg.Burst)
+	if burst <= 0 {
+		burst = defaultFloodBurst
+	}
+
+	rate := cfg.Rate
+	if rate <= 0 {
+		rate = defaultFloodRate
+	}
+
+	maxAge := time.Duration(cfg.MaxAgeSeconds) * time.Second
+	if maxAge <= 0 {
+		maxAge = defaultFloodMaxAge
+	}
+
+	return &sendQueue{
+		burst:   burst,
+		rate:    rate,
+		maxAge:  maxAge,
+		tokens:  burst,
+		updated: time.Now(),
+		chatter: make(map[string][]queuedLine),
+		wake:    make(chan struct{}, 1),
+		stop:    make(chan struct{}),
+		send:    send,
+	}
+}
+
+func (q *sendQueue) enqueue(priority sendPriority, target, line string) {
+	q.mu.Lock()
+
+	l := queuedLine{line: line, queuedAt: time.Now()}
+	switch priority {
+	case sendPriorityModeration:
+		q.moderation = append(q.moderation, l)
+	case sendPriorityControl:
+		q.control = append(q.control, l)
+	default:
+		if len(q.chatter[target]) == 0 {
+			q.targets = append(q.targets, target)
+		}
+		q.chatter[target] = append(q.chatter[target], l)
+	}
+
+	q.mu.Unlock()
+
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+// next takes the line to send at now. When nothing can be sent it returns how long to wait for the next token, or
+// zero if the queue is empty.
+func (q *sendQueue) next(now time.Time) (string, bool, time.Duration) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if elapsed := now.Sub(q.updated).Seconds(); elapsed > 0 {
+		q.tokens += elapsed * q.rate
+		if q.tokens > q.burst {
+			q.tokens = q.burst
+		}
+	}
+	q.updated = now
+
+	q.dropStale(now)
+	if q.depth() == 0 {
+		return "", false, 0
+	}
+
+	if q.tokens < 1 {
+		return "", false, time.Duration((1 - q.tokens) / q.rate * float64(time.Second))
+	}
+	q.tokens--
+
+	var l queuedLine
+	switch {
+	case len(q.moderation) > 0:
+		l, q.moderation = q.moderation[0], q.moderation[1:]
+	case len(q.control) > 0:
+		l, q.control = q.control[0], q.control[1:]
+	default:
+		target := q.targets[0]
+		q.targets = q.targets[1:]
+		l, q.chatter[target] = q.chatter[target][0], q.chatter[target][1:]
+		if len(q.chatter[target]) > 0 {
+			q.targets = append(q.targets, target)
+		} else {
+			delete(q.chatter, target)
+		}
+	}
+
+	return l.line, true, 0
+}
+
+func (q *sendQueue) dropStale(now time.Time) {
+	targets := make([]string, 0, len(q.targets))
+	for _, target := range q.targets {
+		lines := q.chatter[target]
+		for len(lines) > 0 && now.Sub(lines[0].queuedAt) > q.maxAge {
+			lines = lines[1:]
+			q.dropped++
+		}
+
+		if len(lines) == 0 {
+			delete(q.chatter, target)
+			continue
+		}
+
+		q.chatter[target] = lines
+		targets = append(targets, target)
+	}
+	q.targets = targets
+}
+
+func (q *sendQueue) depth() int {
+	depth := len(q.moderation) + len(q.control)
+	for _, lines := range q.chatter {
+		depth += len(lines)
+	}
+	return depth
+}
+
+// stats returns the number of queued lines, the number of targets with queued chatter, and the number of stale lines
+// dropped since the last call.
+func (q *sendQueue) stats() (int, int, int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	dropped := q.dropped
+	q.dropped = 0
+	return q.depth(), len(q.targets), dropped
+}
+
+func (q *sendQueue) run() {
+	logger := log.Logger()
+	var reportedAt time.Time
+
+	for {
+		line, ok, wait := q.next(time.Now())
+		if ok {
+			q.send(line)
+		}
+
+		if time.Since(reportedAt) >= sendQueueReportInterval || !ok {
+			depth, targets, dropped := q.stats()
+			if dropped > 0 {
+				logger.Warningf(nil, "send queue dropped %d stale lines, %d queued for %d targets", dropped, depth, targets)
+			}
+			if depth > 0 && time.Since(reportedAt) >= sendQueueReportInterval {
+				logger.Infof(nil, "send queue depth %d (%d targets)", depth, targets)
+				reportedAt = time.Now()
+			}
+		}
+
+		if ok {
+			continue
+		}
+
+		var timer <-chan time.Time
+		if wait > 0 {
+			timer = time.After(wait)
+		}
+
+		select {
+		case <-q.stop:
+			return
+		case <-q.wake:
+		case <-timer:
+		}
+	}
+}
+
+func (q *sendQueue) close() {
+	q.stopOnce.Do(func() {
+		close(q.stop)
+	})
+}
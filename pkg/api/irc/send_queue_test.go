@@ -0,0 +1,74 @@
+package irc
+
+import (
+	"assistant/pkg/config"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func drain(q *sendQueue, now time.Time) []string {
+	lines := make([]string, 0)
+	for {
+		line, ok, _ := q.next(now)
+		if !ok {
+			return lines
+		}
+		lines = append(lines, line)
+	}
+}
+
+func TestSendQueuePrioritizesModerationAndRoundRobinsTargets(t *testing.T) {
+	q := newSendQueue(config.FloodControlConfig{Burst: 10, Rate: 1}, nil)
+
+	q.enqueue(sendPriorityChatter, "#a", "a1")
+	q.enqueue(sendPriorityChatter, "#a", "a2")
+	q.enqueue(sendPriorityChatter, "#a", "a3")
+	q.enqueue(sendPriorityChatter, "#b", "b1")
+	q.enqueue(sendPriorityControl, "#a", "WHO #a")
+	q.enqueue(sendPriorityModeration, "#b", "KICK #b nick :bye")
+
+	got := drain(q, time.Now())
+	want := []string{"KICK #b nick :bye", "WHO #a", "a1", "b1", "a2", "a3"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("send order = %v, want %v", got, want)
+	}
+}
+
+func TestSendQueueLimitsRate(t *testing.T) {
+	q := newSendQueue(config.FloodControlConfig{Burst: 2, Rate: 0.5}, nil)
+	now := q.updated
+
+	for _, line := range []string{"one", "two", "three"} {
+		q.enqueue(sendPriorityControl, "", line)
+	}
+
+	if got := drain(q, now); len(got) != 2 {
+		t.Fatalf("sent %d lines in the burst, want 2", len(got))
+	}
+
+	_, ok, wait := q.next(now)
+	if ok || wait != 2*time.Second {
+		t.Fatalf("next() = ok %t, wait %s, want a 2s wait", ok, wait)
+	}
+
+	if line, ok, _ := q.next(now.Add(2 * time.Second)); !ok || line != "three" {
+		t.Fatalf("next() after refill = %q, %t, want three", line, ok)
+	}
+}
+
+func TestSendQueueDropsStaleChatter(t *testing.T) {
+	q := newSendQueue(config.FloodControlConfig{Burst: 5, Rate: 1, MaxAgeSeconds: 10}, nil)
+
+	q.enqueue(sendPriorityChatter, "#a", "stale")
+	q.enqueue(sendPriorityControl, "", "JOIN #b")
+
+	got := drain(q, time.Now().Add(time.Minute))
+	if !reflect.DeepEqual(got, []string{"JOIN #b"}) {
+		t.Fatalf("sent %v, want only the control line", got)
+	}
+
+	if depth, targets, dropped := q.stats(); depth != 0 || targets != 0 || dropped != 1 {
+		t.Fatalf("stats() = %d, %d, %d, want 0, 0, 1", depth, targets, dropped)
+	}
+}
@@ -0,0 +1,228 @@
+package irc
+
+import (
+	"assistant/pkg/api/style"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// maxLineLength is the protocol limit for a line, including the source prefix the server adds when relaying it and the
+// trailing CRLF.
+const maxLineLength = 512
+
+// maxHostLength is assumed for our own host until the server has shown it to us.
+const maxHostLength = 63
+
+// maxUsernameLength is assumed for our own username until the server has shown it to us.
+const maxUsernameLength = 10
+
+const zeroWidthJoiner = '\u200d'
+
+// messageBudget returns how many bytes of text fit in a PRIVMSG to target once the server prefixes it with source.
+func messageBudget(source, target string) int {
+	return maxLineLength - len("\r\n") - len(fmt.Sprintf(":%s PRIVMSG %s :", source, target))
+}
+
+// formatting is the set of mIRC formatting codes active at a point in a message.
+type formatting struct {
+	bold          bool
+	italics       bool
+	underline     bool
+	strikethrough bool
+	monospace     bool
+	color         string
+}
+
+func (f *formatting) apply(code string) {
+	switch code[:1] {
+	case style.StyleBold:
+		f.bold = !f.bold
+	case style.StyleItalics:
+		f.italics = !f.italics
+	case style.StyleUnderline:
+		f.underline = !f.underline
+	case style.StyleStrikethrough:
+		f.strikethrough = !f.strikethrough
+	case style.StyleMonospace:
+		f.monospace = !f.monospace
+	case style.StyleColor:
+		// Colors are kept as two digits so that re-opening them can't swallow a digit at the start of the next line.
+		colors := strings.Split(code[1:], ",")
+		for i, c := range colors {
+			if len(c) == 1 {
+				colors[i] = "0" + c
+			}
+		}
+		f.color = strings.Join(colors, ",")
+	case style.StyleReset:
+		*f = formatting{}
+	}
+}
+
+// codes returns the formatting codes that re-open f at the start of a new line.
+func (f formatting) codes() string {
+	var sb strings.Builder
+	if f.bold {
+		sb.WriteString(style.StyleBold)
+	}
+	if f.italics {
+		sb.WriteString(style.StyleItalics)
+	}
+	if f.underline {
+		sb.WriteString(style.StyleUnderline)
+	}
+	if f.strikethrough {
+		sb.WriteString(style.StyleStrikethrough)
+	}
+	if f.monospace {
+		sb.WriteString(style.StyleMonospace)
+	}
+	if len(f.color) > 0 {
+		sb.WriteString(style.StyleColor + f.color)
+	}
+	return sb.String()
+}
+
+// splitMessage splits message into lines of at most limit bytes. Lines are broken between words where possible and
+// otherwise between grapheme clusters, never inside a UTF-8 sequence or a formatting code, and formatting that is still
+// active at the end of a line is re-opened at the start of the next one.
+func splitMessage(message string, limit int) []string {
+	if len(message) <= limit {
+		return []string{message}
+	}
+
+	lines := make([]string, 0)
+	state := formatting{}
+	var current strings.Builder
+	prefix := 0
+
+	flush := func() {
+		lines = append(lines, current.String())
+		current.Reset()
+		current.WriteString(state.codes())
+		prefix = current.Len()
+	}
+
+	for _, word := range strings.Split(message, " ") {
+		separator := 0
+		if current.Len() > prefix {
+			separator = 1
+		}
+
+		if current.Len()+separator+len(word) > limit && current.Len() > prefix {
+			flush()
+			separator = 0
+		}
+
+		if separator > 0 {
+			current.WriteString(" ")
+		}
+
+		for _, unit := range splitUnits(word) {
+			if current.Len()+len(unit) > limit && current.Len() > prefix {
+				flush()
+			}
+			current.WriteString(unit)
+			if isFormattingCode(unit) {
+				state.apply(unit)
+			}
+		}
+	}
+
+	if current.Len() > prefix {
+		lines = append(lines, current.String())
+	}
+
+	return lines
+}
+
+// splitUnits breaks s into the pieces a line may be broken between: formatting codes and grapheme clusters.
+func splitUnits(s string) []string {
+	units := make([]string, 0, len(s))
+	for i := 0; i < len(s); {
+		n := formattingCodeLength(s[i:])
+		if n == 0 {
+			n = graphemeLength(s[i:])
+		}
+		units = append(units, s[i:i+n])
+		i += n
+	}
+	return units
+}
+
+func isFormattingCode(s string) bool {
+	return len(s) > 0 && formattingCodeLength(s) == len(s)
+}
+
+// formattingCodeLength returns the length of the formatting code s starts with, including any color digits, or zero.
+func formattingCodeLength(s string) int {
+	switch s[:1] {
+	case style.StyleBold, style.StyleItalics, style.StyleUnderline, style.StyleStrikethrough, style.StyleMonospace, style.StyleReset:
+		return 1
+	case style.StyleColor:
+		n := 1 + countDigits(s[1:], 2)
+		if n > 1 && n+1 < len(s) && s[n] == ',' {
+			if digits := countDigits(s[n+1:], 2); digits > 0 {
+				n += 1 + digits
+			}
+		}
+		return n
+	}
+	return 0
+}
+
+func countDigits(s string, max int) int {
+	n := 0
+	for n < len(s) && n < max && s[n] >= '0' && s[n] <= '9' {
+		n++
+	}
+	return n
+}
+
+// graphemeLength returns the length of the grapheme cluster s starts with. It keeps combining marks, variation
+// selectors, emoji modifiers and tags with their base, joins zero width joiner sequences and pairs regional indicators,
+// which covers what chat clients render as a single character.
+func graphemeLength(s string) int {
+	r, n := utf8.DecodeRuneInString(s)
+	if r == utf8.RuneError {
+		return n
+	}
+
+	regionalIndicators := 0
+	if isRegionalIndicator(r) {
+		regionalIndicators++
+	}
+
+	joined := r == zeroWidthJoiner
+	for n < len(s) {
+		next, size := utf8.DecodeRuneInString(s[n:])
+		switch {
+		case next == utf8.RuneError:
+			return n
+		case joined, isGraphemeExtender(next):
+		case isRegionalIndicator(next) && regionalIndicators == 1:
+			regionalIndicators++
+		default:
+			return n
+		}
+		joined = next == zeroWidthJoiner
+		n += size
+	}
+
+	return n
+}
+
+func isGraphemeExtender(r rune) bool {
+	return r == zeroWidthJoiner ||
+		unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc) ||
+		(r >= 0xFE00 && r <= 0xFE0F) ||
+		(r >= 0x1F3FB && r <= 0x1F3FF) ||
+		(r >= 0xE0020 && r <= 0xE007F) ||
+		(r >= 0xE0100 && r <= 0xE01EF)
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1F1E6 && r <= 0x1F1FF
+}
@@ -0,0 +1,57 @@
+package irc
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestMessageBudget(t *testing.T) {
+	if got := messageBudget("bot!~bot@host", "#chan"); got != 512-2-len(":bot!~bot@host PRIVMSG #chan :") {
+		t.Fatalf("messageBudget() = %d", got)
+	}
+}
+
+func TestSplitMessageBreaksBetweenWords(t *testing.T) {
+	lines := splitMessage("one two three four", 9)
+	want := []string{"one two", "three", "four"}
+	if strings.Join(lines, "|") != strings.Join(want, "|") {
+		t.Fatalf("splitMessage() = %q, want %q", lines, want)
+	}
+}
+
+func TestSplitMessageKeepsMultibyteCharactersWhole(t *testing.T) {
+	message := strings.Repeat("e\u0301", 10) + strings.Repeat("\U0001F469\u200d\U0001F469\u200d\U0001F467", 3) + strings.Repeat("\U0001F1F3\U0001F1F1", 3)
+	for _, limit := range []int{4, 7, 19, 20} {
+		lines := splitMessage(message, limit)
+		if strings.Join(lines, "") != message {
+			t.Fatalf("limit %d: lines %q don't rejoin into the message", limit, lines)
+		}
+		for _, line := range lines {
+			if len(line) > limit && len(splitUnits(line)) > 1 {
+				t.Fatalf("limit %d: line %q is %d bytes", limit, line, len(line))
+			}
+			if !utf8.ValidString(line) {
+				t.Fatalf("limit %d: line %q isn't valid UTF-8", limit, line)
+			}
+			if strings.HasPrefix(line, "\u0301") || strings.HasPrefix(line, "\u200d") || strings.HasPrefix(line, "\U0001F1F1") {
+				t.Fatalf("limit %d: line %q starts inside a grapheme cluster", limit, line)
+			}
+		}
+	}
+}
+
+func TestSplitMessageCarriesFormatting(t *testing.T) {
+	lines := splitMessage("\x02bold \x034,2red\x0F plain", 10)
+	want := []string{"\x02bold", "\x02\x034,2red\x0F", "plain"}
+	if strings.Join(lines, "|") != strings.Join(want, "|") {
+		t.Fatalf("splitMessage() = %q, want %q", lines, want)
+	}
+
+	lines = splitMessage("\x1D"+strings.Repeat("a", 12), 8)
+	for i, line := range lines {
+		if len(line) > 8 || (i > 0 && !strings.HasPrefix(line, "\x1D")) {
+			t.Fatalf("line %d = %q, want at most 8 bytes re-opening italics", i, line)
+		}
+	}
+}
@@ -0,0 +1,245 @@
+package irc
+
+import (
+	"assistant/pkg/log"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"strings"
+	"time"
+)
+
+const defaultReconnectDelay = 5 * time.Second
+const maxReconnectDelay = 5 * time.Minute
+
+// pingInterval is how often the server is pinged, and pingTimeout how long after that the link is given up on when
+// nothing has been received.
+const pingInterval = 2 * time.Minute
+const pingTimeout = 1 * time.Minute
+
+const nickRegainDelay = 5 * time.Second
+const rejoinDelay = 15 * time.Second
+const maxRejoinAttempts = 3
+
+// backoff produces jittered exponential delays between reconnect attempts.
+type backoff struct {
+	base     time.Duration
+	max      time.Duration
+	attempts int
+	jitter   func() float64
+}
+
+func newBackoff(base, max time.Duration) *backoff {
+	return &backoff{
+		base:   base,
+		max:    max,
+		jitter: rand.Float64,
+	}
+}
+
+// next returns the delay before the next attempt, somewhere in the upper half of the current step so that connections
+// dropped together don't all come back at once.
+func (b *backoff) next() time.Duration {
+	d := b.max
+	if b.attempts < 32 {
+		if step := b.base << b.attempts; step > 0 && step < b.max {
+			d = step
+		}
+	}
+	b.attempts++
+	return d/2 + time.Duration(b.jitter()*float64(d/2))
+}
+
+func (b *backoff) reset() {
+	b.attempts = 0
+}
+
+// supervise keeps the connection alive until Disconnect is called. Closed links and ping timeouts are followed by a
+// reconnect with jittered exponential backoff, and the registration callbacks restore the nick, post connect commands
+// and channels once the server has welcomed us again.
+func (s *service) supervise() {
+	logger := log.Logger()
+	b := newBackoff(s.reconnectDelay(), maxReconnectDelay)
+
+	for {
+		err := <-s.conn.ErrorChan()
+		if s.stopped.Load() {
+			return
+		}
+
+		// tear down the goroutines of the dead link before dialing again
+		s.conn.Disconnect()
+
+		if s.registered.Load() {
+			b.reset()
+		}
+		s.resetSession()
+
+		delay := b.next()
+		if requested := s.requestedDelay.Swap(nil); requested != nil {
+			delay = *requested
+		}
+		logger.Warningf(nil, "connection to %s lost, %s, reconnecting in %s", s.cfg.IRC.Server, describeLinkError(err), delay)
+
+		for {
+			time.Sleep(delay)
+			if s.stopped.Load() {
+				return
+			}
+
+			if err = s.conn.Reconnect(); err == nil {
+				logger.Infof(nil, "reconnected to %s", s.cfg.IRC.Server)
+				s.negotiateCapabilities()
+				break
+			}
+
+			if s.conn.Connected() {
+				s.conn.Disconnect()
+			}
+			delay = b.next()
+			logger.Warningf(nil, "error reconnecting to %s, %s, retrying in %s", s.cfg.IRC.Server, err, delay)
+		}
+	}
+}
+
+func describeLinkError(err error) string {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return "ping timeout"
+	}
+	if err == nil {
+		return "link closed"
+	}
+	return err.Error()
+}
+
+func (s *service) reconnectDelay() time.Duration {
+	if s.cfg.IRC.ReconnectDelay > 0 {
+		return time.Duration(s.cfg.IRC.ReconnectDelay) * time.Second
+	}
+	return defaultReconnectDelay
+}
+
+// resetSession forgets what was learned about the previous link, keeping the keys of the channels it had joined so
+// they can be rejoined.
+func (s *service) resetSession() {
+	s.keysMu.Lock()
+	for channel, key := range s.channels.keys() {
+		s.keys[channel] = key
+	}
+	s.keysMu.Unlock()
+
+	s.session.Add(1)
+	s.nick.Store(nil)
+	s.caps.reset()
+	s.recoverNeeded.Store(false)
+	s.identified.Store(false)
+	s.registered.Store(false)
+	s.postConnected.Store(false)
+	s.authenticated.Store(false)
+}
+
+// Reconnect closes the link and has the supervisor re-establish it after delay. The QUIT is queued as chatter so that
+// replies already waiting to be sent go out first.
+func (s *service) Reconnect(delay time.Duration) {
+	s.requestedDelay.Store(&delay)
+	s.queue.enqueue(sendPriorityChatter, "", "QUIT :reconnecting")
+}
+
+// regainNick takes the configured nick back when the connection had to settle for another one, asking NickServ to
+// recover and release it first when we can identify for it.
+func (s *service) regainNick() {
+	nick := s.cfg.IRC.Nick
+	if strings.EqualFold(s.currentNick(), nick) {
+		return
+	}
+
+	log.Logger().Infof(nil, "connected as %s, regaining %s", s.currentNick(), nick)
+	if len(s.cfg.IRC.NickServ.Password) > 0 {
+		s.conn.Privmsgf(s.cfg.IRC.NickServ.Recipient, s.cfg.IRC.NickServ.RecoverCommand, nick, s.cfg.IRC.NickServ.Password)
+		s.conn.Privmsgf(s.cfg.IRC.NickServ.Recipient, s.cfg.IRC.NickServ.ReleaseCommand, nick, s.cfg.IRC.NickServ.Password)
+	}
+
+	session := s.session.Load()
+	time.AfterFunc(nickRegainDelay, func() {
+		if s.session.Load() == session && !strings.EqualFold(s.currentNick(), nick) {
+			s.conn.Nick(nick)
+		}
+	})
+}
+
+// autoJoin joins the configured channels. Entries may carry a key after the channel name.
+func (s *service) autoJoin() {
+	for _, entry := range s.cfg.IRC.PostConnect.AutoJoin {
+		channel, key := parseAutoJoin(entry)
+		if len(key) > 0 {
+			s.keysMu.Lock()
+			s.keys[strings.ToLower(channel)] = key
+			s.keysMu.Unlock()
+		}
+		s.conn.Join(entry)
+	}
+	go s.verifyChannels(s.session.Load())
+}
+
+func parseAutoJoin(entry string) (string, string) {
+	fields := strings.Fields(entry)
+	if len(fields) == 0 {
+		return "", ""
+	}
+	if len(fields) == 1 {
+		return fields[0], ""
+	}
+	return fields[0], fields[1]
+}
+
+// verifyChannels checks that every auto join channel was actually joined, retrying the ones that weren't with their key.
+// It gives up when the link it was started for is replaced.
+func (s *service) verifyChannels(session uint64) {
+	logger := log.Logger()
+
+	var missing []string
+	for attempt := 0; attempt <= maxRejoinAttempts; attempt++ {
+		time.Sleep(rejoinDelay)
+		if s.stopped.Load() || s.session.Load() != session {
+			return
+		}
+
+		missing = s.missingChannels()
+		if len(missing) == 0 || attempt == maxRejoinAttempts {
+			break
+		}
+
+		for _, channel := range missing {
+			logger.Warningf(nil, "not in auto join channel %s, retrying", channel)
+			s.conn.Join(s.joinArgument(channel))
+		}
+	}
+
+	for _, channel := range missing {
+		logger.Errorf(nil, "unable to rejoin %s after %d attempts", channel, maxRejoinAttempts)
+	}
+}
+
+func (s *service) missingChannels() []string {
+	missing := make([]string, 0)
+	for _, entry := range s.cfg.IRC.PostConnect.AutoJoin {
+		channel, _ := parseAutoJoin(entry)
+		if len(channel) > 0 && !s.channels.isJoined(channel) {
+			missing = append(missing, channel)
+		}
+	}
+	return missing
+}
+
+// joinArgument returns the JOIN argument for a channel, including its key when one is known.
+func (s *service) joinArgument(channel string) string {
+	s.keysMu.Lock()
+	defer s.keysMu.Unlock()
+
+	if key, ok := s.keys[strings.ToLower(channel)]; ok {
+		return fmt.Sprintf("%s %s", channel, key)
+	}
+	return channel
+}
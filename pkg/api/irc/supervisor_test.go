@@ -0,0 +1,64 @@
+package irc
+
+import (
+	"assistant/pkg/config"
+	"testing"
+	"time"
+
+	irce "github.com/thoj/go-ircevent"
+)
+
+func TestBackoffGrowsToMax(t *testing.T) {
+	b := newBackoff(time.Second, 10*time.Second)
+	b.jitter = func() float64 { return 1 }
+
+	want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second, 10 * time.Second}
+	for i, w := range want {
+		if got := b.next(); got != w {
+			t.Fatalf("attempt %d: next() = %s, want %s", i, got, w)
+		}
+	}
+
+	b.reset()
+	b.jitter = func() float64 { return 0 }
+	if got := b.next(); got != 500*time.Millisecond {
+		t.Fatalf("next() after reset = %s, want 500ms", got)
+	}
+}
+
+func TestRejoinUsesKnownKeys(t *testing.T) {
+	s := &service{
+		cfg: &config.Config{IRC: config.IRCConfig{PostConnect: config.PostConnectConfig{
+			AutoJoin: []string{"#open", "#keyed", "#configured letmein"},
+		}}},
+		caps:     newCapabilities(nil),
+		channels: newChannels(),
+		keys:     make(map[string]string),
+	}
+
+	observeAll(s.channels, "bot",
+		&irce.Event{Code: CodeJoin, Nick: "bot", Arguments: []string{"#open"}},
+		&irce.Event{Code: CodeJoin, Nick: "bot", Arguments: []string{"#Keyed"}},
+		&irce.Event{Code: CodeChannelModes, Arguments: []string{"bot", "#keyed", "+nk", "secret"}},
+	)
+	s.resetSession()
+	s.channels.reset()
+
+	observeAll(s.channels, "bot", &irce.Event{Code: CodeJoin, Nick: "bot", Arguments: []string{"#open"}})
+	s.keys["#configured"] = "letmein"
+
+	missing := s.missingChannels()
+	if len(missing) != 2 || missing[0] != "#keyed" || missing[1] != "#configured" {
+		t.Fatalf("missingChannels() = %v, want #keyed and #configured", missing)
+	}
+
+	if got := s.joinArgument("#keyed"); got != "#keyed secret" {
+		t.Fatalf("joinArgument(#keyed) = %q", got)
+	}
+	if got := s.joinArgument("#configured"); got != "#configured letmein" {
+		t.Fatalf("joinArgument(#configured) = %q", got)
+	}
+	if got := s.joinArgument("#open"); got != "#open" {
+		t.Fatalf("joinArgument(#open) = %q", got)
+	}
+}
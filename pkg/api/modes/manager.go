@@ -6,7 +6,8 @@ import (
 	"time"
 )
 
-var manager *ChannelModeManager
+var managersMu sync.Mutex
+var managers = make(map[string]*ChannelModeManager)
 
 type ChannelModeManager struct {
 	sync.RWMutex
@@ -15,13 +16,19 @@ type ChannelModeManager struct {
 	cooldowns map[string]map[string]time.Time
 }
 
-func GetManager() *ChannelModeManager {
-	if manager == nil {
+// GetManager returns the mode manager for the channels of a network.
+func GetManager(network string) *ChannelModeManager {
+	managersMu.Lock()
+	defer managersMu.Unlock()
+
+	manager, ok := managers[network]
+	if !ok {
 		manager = &ChannelModeManager{
 			modes:     make(map[string]ChannelMode),
 			timers:    make(map[string]*time.Timer),
 			cooldowns: make(map[string]map[string]time.Time),
 		}
+		managers[network] = manager
 	}
 	return manager
 }
@@ -299,7 +299,7 @@ func (t *TriviaMode) showResultsAndEnd() {
 	t.sendResults()
 	time.Sleep(1 * time.Second)
 
-	GetManager().Deactivate(t.channel)
+	GetManager(t.ircs.Network()).Deactivate(t.channel)
 }
 
 func (t *TriviaMode) sendResults() {
@@ -2,14 +2,13 @@ package repository
 
 import (
 	"assistant/pkg/api/irc"
-	"assistant/pkg/firestore"
 	"assistant/pkg/log"
 	"assistant/pkg/models"
 )
 
 func GetAssistant(e *irc.Event, createIfNotExists bool) (*models.Assistant, error) {
 	logger := log.Logger()
-	fs := firestore.Get()
+	fs := store(e)
 
 	assistant, err := fs.Assistant()
 	if err != nil {
@@ -2,7 +2,6 @@ package repository
 
 import (
 	"assistant/pkg/api/irc"
-	"assistant/pkg/firestore"
 	"assistant/pkg/log"
 	"assistant/pkg/models"
 	"cmp"
@@ -14,7 +13,7 @@ import (
 )
 
 func GetAllChannels(e *irc.Event) ([]*models.Channel, error) {
-	fs := firestore.Get()
+	fs := store(e)
 
 	channels, err := fs.Channels()
 	if err != nil {
@@ -33,7 +32,7 @@ func GetAllChannels(e *irc.Event) ([]*models.Channel, error) {
 }
 
 func GetChannel(e *irc.Event, channel string) (*models.Channel, error) {
-	fs := firestore.Get()
+	fs := store(e)
 
 	ch, err := fs.Channel(channel)
 	if err != nil {
@@ -58,6 +57,26 @@ func populateChannelDefaults(ch *models.Channel) {
 		ch.VoiceRequests = make([]models.VoiceRequest, 0)
 	}
 
+	if ch.Roles == nil {
+		ch.Roles = make([]models.ChannelRole, 0)
+	}
+
+	if ch.CommandLimits == nil {
+		ch.CommandLimits = make([]models.CommandLimit, 0)
+	}
+
+	if ch.CustomCommands == nil {
+		ch.CustomCommands = make([]models.CustomCommand, 0)
+	}
+
+	if ch.SpamRules == nil {
+		ch.SpamRules = make([]models.SpamRule, 0)
+	}
+
+	if ch.StrikeLadder == nil {
+		ch.StrikeLadder = make([]models.StrikeStep, 0)
+	}
+
 	slices.SortFunc(ch.VoiceRequests, func(a, b models.VoiceRequest) int {
 		return cmp.Compare(a.RequestedAt.Unix(), b.RequestedAt.Unix())
 	})
@@ -87,7 +106,7 @@ func AddChannelAutoVoiceUser(e *irc.Event, ch *models.Channel, nick string) {
 
 func UpdateChannelVoiceRequests(e *irc.Event, ch *models.Channel) error {
 	logger := log.Logger()
-	fs := firestore.Get()
+	fs := store(e)
 
 	if err := fs.UpdateChannel(ch.Name, map[string]any{"voice_requests": ch.VoiceRequests, "updated_at": time.Now()}); err != nil {
 		logger.Errorf(e, "error updating channel, %s", err)
@@ -99,7 +118,7 @@ func UpdateChannelVoiceRequests(e *irc.Event, ch *models.Channel) error {
 
 func UpdateChannelAutoVoiced(e *irc.Event, ch *models.Channel) error {
 	logger := log.Logger()
-	fs := firestore.Get()
+	fs := store(e)
 
 	if err := fs.UpdateChannel(ch.Name, map[string]any{"auto_voiced": ch.AutoVoiced, "updated_at": time.Now()}); err != nil {
 		logger.Errorf(e, "error updating channel, %s", err)
@@ -109,9 +128,21 @@ func UpdateChannelAutoVoiced(e *irc.Event, ch *models.Channel) error {
 	return nil
 }
 
+func UpdateChannelCustomCommands(e *irc.Event, ch *models.Channel) error {
+	logger := log.Logger()
+	fs := store(e)
+
+	if err := fs.UpdateChannel(ch.Name, map[string]any{"custom_commands": ch.CustomCommands, "updated_at": time.Now()}); err != nil {
+		logger.Errorf(e, "error updating channel, %s", err)
+		return err
+	}
+
+	return nil
+}
+
 func UpdateChannelDisabledCommands(e *irc.Event, ch *models.Channel) error {
 	logger := log.Logger()
-	fs := firestore.Get()
+	fs := store(e)
 
 	if err := fs.UpdateChannel(ch.Name, map[string]any{"disabled_commands": ch.DisabledCommands, "updated_at": time.Now()}); err != nil {
 		logger.Errorf(e, "error updating channel, %s", err)
@@ -191,8 +222,8 @@ type quoteSearchResult struct {
 	quote *models.Quote
 }
 
-func FindUserQuotesWithContent(channel, nick string, keywords []string) ([]*models.Quote, error) {
-	fs := firestore.Get()
+func FindUserQuotesWithContent(e *irc.Event, channel, nick string, keywords []string) ([]*models.Quote, error) {
+	fs := store(e)
 	matching, err := fs.FindUserQuotesWithContent(channel, nick, keywords)
 	if err != nil {
 		return nil, err
@@ -200,17 +231,17 @@ func FindUserQuotesWithContent(channel, nick string, keywords []string) ([]*mode
 	return rankQuoteSearchResults(matching, keywords)
 }
 
-func FindUserQuotes(channel, nick string) ([]*models.Quote, error) {
-	fs := firestore.Get()
+func FindUserQuotes(e *irc.Event, channel, nick string) ([]*models.Quote, error) {
+	fs := store(e)
 	return fs.FindUserQuotes(channel, nick)
 }
 
-func FindChannelQuotes(channel string) ([]*models.Quote, error) {
-	return firestore.Get().Quotes(channel)
+func FindChannelQuotes(e *irc.Event, channel string) ([]*models.Quote, error) {
+	return store(e).Quotes(channel)
 }
 
-func FindQuotes(channel string, keywords []string) ([]*models.Quote, error) {
-	fs := firestore.Get()
+func FindQuotes(e *irc.Event, channel string, keywords []string) ([]*models.Quote, error) {
+	fs := store(e)
 	matching, err := fs.FindQuotes(channel, keywords)
 	if err != nil {
 		return nil, err
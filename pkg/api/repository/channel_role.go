@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"assistant/pkg/api/irc"
+	"assistant/pkg/log"
+	"assistant/pkg/models"
+	"time"
+)
+
+func UpdateChannelRoles(e *irc.Event, ch *models.Channel) error {
+	logger := log.Logger()
+	fs := store(e)
+
+	if err := fs.UpdateChannel(ch.Name, map[string]any{"roles": ch.Roles, "updated_at": time.Now()}); err != nil {
+		logger.Errorf(e, "error updating channel, %s", err)
+		return err
+	}
+
+	return nil
+}
+
+// AddRoleChange records a change to a channel's roles in its audit log.
+func AddRoleChange(e *irc.Event, channel string, change *models.RoleChange) error {
+	logger := log.Logger()
+	logger.Infof(e, "role change in %s by %s: %s %s %s", channel, change.Actor, change.Action, change.Role, change.Subject)
+
+	if err := store(e).AddRoleChange(channel, change); err != nil {
+		logger.Errorf(e, "error adding role change, %s", err)
+		return err
+	}
+
+	return nil
+}
+
+func GetRoleChanges(e *irc.Event, channel string) ([]*models.RoleChange, error) {
+	return store(e).RoleChanges(channel)
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"assistant/pkg/api/irc"
+	"assistant/pkg/models"
+	"fmt"
+)
+
+func GetFactoid(e *irc.Event, channel, key string) (*models.Factoid, error) {
+	return store(e).Factoid(channel, models.NormalizeFactoidKey(key))
+}
+
+// ResolveFactoid returns the factoid with the key, following aliases to the factoid with the value. It returns nil if
+// there's no such factoid or an alias leads nowhere.
+func ResolveFactoid(e *irc.Event, channel, key string) (*models.Factoid, error) {
+	fs := store(e)
+	key = models.NormalizeFactoidKey(key)
+
+	for range models.MaxFactoidAliasDepth {
+		f, err := fs.Factoid(channel, key)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving factoid %s, %w", key, err)
+		}
+		if f == nil || !f.IsAlias() {
+			return f, nil
+		}
+		key = f.AliasOf
+	}
+
+	return nil, nil
+}
+
+func GetFactoids(e *irc.Event, channel string) ([]*models.Factoid, error) {
+	return store(e).Factoids(channel)
+}
+
+func FindFactoids(e *irc.Event, channel string, keywords []string) ([]*models.Factoid, error) {
+	return store(e).FindFactoids(channel, keywords)
+}
+
+func SetFactoid(e *irc.Event, channel string, factoid *models.Factoid) error {
+	return store(e).SetFactoid(channel, factoid)
+}
+
+func DeleteFactoid(e *irc.Event, channel, key string) error {
+	return store(e).DeleteFactoid(channel, models.NormalizeFactoidKey(key))
+}
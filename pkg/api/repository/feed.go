@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"assistant/pkg/api/irc"
+	"assistant/pkg/models"
+	"assistant/pkg/scheduler"
+	"time"
+)
+
+func GetFeed(e *irc.Event, channel, id string) (*models.Feed, error) {
+	return store(e).Feed(channel, id)
+}
+
+// GetFeeds returns the channel's feed subscriptions, oldest first.
+func GetFeeds(e *irc.Event, channel string) ([]*models.Feed, error) {
+	return store(e).Feeds(channel)
+}
+
+func SetFeed(e *irc.Event, channel string, feed *models.Feed) error {
+	return store(e).SetFeed(channel, feed)
+}
+
+func RemoveFeed(e *irc.Event, channel, id string) error {
+	return store(e).DeleteFeed(channel, id)
+}
+
+// ScheduleFeedsTask schedules the channel's feed polling, which is only kept running while the channel has feeds. A
+// poll that's still pending keeps its due time, so scheduling it again is deduplicated.
+func ScheduleFeedsTask(e *irc.Event, channel string) error {
+	fs := store(e)
+
+	task, err := fs.Task(fs.PersistentChannelTaskPath(channel, models.ChannelFeedsTaskID))
+	if err != nil {
+		return err
+	}
+
+	if task == nil || !task.DueAt.After(time.Now()) {
+		task = models.NewPersistentTask(models.ChannelFeedsTaskID, channel, models.TaskTypePersistentChannelFeeds, time.Now().Add(models.FeedPollInterval))
+		if err = fs.SetTask(task); err != nil {
+			return err
+		}
+	}
+
+	task.Data = models.PersistentTaskData{Channel: channel}
+	_, err = scheduler.Get().CreateTask(task)
+	return err
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"assistant/pkg/api/irc"
+	"assistant/pkg/models"
+)
+
+func GetPoll(e *irc.Event, channel, id string) (*models.Poll, error) {
+	return store(e).Poll(channel, id)
+}
+
+// GetPolls returns the channel's polls, newest first.
+func GetPolls(e *irc.Event, channel string) ([]*models.Poll, error) {
+	return store(e).Polls(channel)
+}
+
+// GetOpenPoll returns the channel's open poll, if it has one.
+func GetOpenPoll(e *irc.Event, channel string) (*models.Poll, error) {
+	polls, err := store(e).Polls(channel)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range polls {
+		if p.IsOpen() {
+			return p, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// GetLastClosedPoll returns the channel's most recently created poll that has closed, if it has one.
+func GetLastClosedPoll(e *irc.Event, channel string) (*models.Poll, error) {
+	polls, err := store(e).Polls(channel)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range polls {
+		if !p.IsOpen() {
+			return p, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func AddPoll(e *irc.Event, channel string, poll *models.Poll) error {
+	return store(e).CreatePoll(channel, poll)
+}
+
+func UpdatePollVotes(e *irc.Event, channel string, poll *models.Poll) error {
+	return store(e).UpdatePoll(channel, poll, map[string]any{"votes": poll.Votes})
+}
+
+func ClosePoll(e *irc.Event, channel string, poll *models.Poll) error {
+	return store(e).UpdatePoll(channel, poll, map[string]any{"status": poll.Status, "closed_by": poll.ClosedBy, "closed_at": poll.ClosedAt})
+}
+
+// CancelPollCloseTask cancels the task scheduled to close the poll, and the scheduler task behind it, if it is still
+// pending.
+func CancelPollCloseTask(e *irc.Event, channel string, poll *models.Poll) error {
+	if len(poll.CloseTaskID) == 0 {
+		return nil
+	}
+
+	s := store(e)
+	data := models.PollCloseTaskData{Channel: channel, PollID: poll.ID}
+
+	task, err := s.Task(s.TaskPath(&models.Task{ID: poll.CloseTaskID, Type: models.TaskTypePollClose, Data: data}))
+	if err != nil || task == nil || task.Status != models.TaskStatusPending {
+		return err
+	}
+
+	task.Data = data
+	task.Status = models.TaskStatusCancelled
+	return s.CompleteTask(task)
+}
@@ -1,8 +1,8 @@
 package repository
 
 import (
-	"assistant/pkg/firestore"
 	"assistant/pkg/models"
+	"assistant/pkg/storage"
 	"fmt"
 	"net/url"
 )
@@ -10,7 +10,7 @@ import (
 const submissionURL = "https://archive.is/submit/?url=%s"
 
 func GetShortcut(sourceURL, redirectURL string) (*models.Shortcut, error) {
-	fs := firestore.Get()
+	fs := storage.Get()
 
 	shortcut := models.NewShortcut(sourceURL, redirectURL)
 
@@ -42,7 +42,7 @@ func GetArchiveShortcutID(sourceURL string) (string, error) {
 }
 
 func GetShortcutSource(id string) (string, error) {
-	fs := firestore.Get()
+	fs := storage.Get()
 
 	shortcut, err := fs.Shortcut(id)
 	if err != nil {
@@ -57,7 +57,7 @@ func GetShortcutSource(id string) (string, error) {
 }
 
 func RemoveShortcut(id string) error {
-	fs := firestore.Get()
+	fs := storage.Get()
 
 	shortcut, err := fs.Shortcut(id)
 	if err != nil {
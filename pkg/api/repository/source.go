@@ -3,9 +3,9 @@ package repository
 import (
 	"assistant/pkg/api/style"
 	"assistant/pkg/api/text"
-	"assistant/pkg/firestore"
 	"assistant/pkg/models"
 	"assistant/pkg/slicesx"
+	"assistant/pkg/storage"
 	"fmt"
 	"regexp"
 	"slices"
@@ -16,7 +16,7 @@ import (
 var httpRegex = regexp.MustCompile(`^https?://(?:www\.)?(.*?)/`)
 
 func AddSource(source *models.Source) error {
-	return firestore.Get().CreateSource(source)
+	return storage.Get().CreateSource(source)
 }
 
 func FindSource(input string) (*models.Source, error) {
@@ -54,7 +54,7 @@ func FindSourceByIdentities(inputs []string) (*models.Source, string, error) {
 		return nil, "", nil
 	}
 
-	sources, err := firestore.Get().FindSourcesByIdentities(identities)
+	sources, err := storage.Get().FindSourcesByIdentities(identities)
 	if err != nil {
 		return nil, "", err
 	}
@@ -111,7 +111,7 @@ func findSourceByDomain(url string) (*models.Source, error) {
 		domain = m[1]
 	}
 
-	sources, err := firestore.Get().FindSourcesByDomain(domain)
+	sources, err := storage.Get().FindSourcesByDomain(domain)
 	if err != nil {
 		return nil, err
 	}
@@ -134,7 +134,7 @@ func findSourceByKeywords(keywords []string, requireAll bool) ([]*models.Source,
 		kw[i] = strings.TrimSpace(strings.ToLower(k))
 	}
 
-	sk, err := firestore.Get().FindSourcesByKeywords(kw)
+	sk, err := storage.Get().FindSourcesByKeywords(kw)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"assistant/pkg/api/irc"
+	"assistant/pkg/storage"
+)
+
+// store returns the storage for the network the event was received on.
+func store(e *irc.Event) storage.Store {
+	if e == nil {
+		return storage.Get()
+	}
+	return storage.Network(e.Network)
+}
@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"assistant/pkg/api/irc"
+	"assistant/pkg/models"
+)
+
+// GetUserStrikes returns the active strikes recorded against the user in the channel, by account or host, newest
+// first.
+func GetUserStrikes(e *irc.Event, channel, nick, host, account string) ([]*models.Strike, error) {
+	return store(e).UserStrikes(channel, nick, host, account)
+}
@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"assistant/pkg/api/irc"
+	"assistant/pkg/models"
+	"strings"
+	"sync"
+	"time"
+)
+
+// pendingTells keeps the undelivered tells of each channel in memory, keyed by network and channel, so that checking
+// whether someone who speaks or joins has any doesn't read storage. A channel's tells are loaded the first time it's
+// checked and kept current by AddTell, DeleteTell and GetPendingTells.
+var pendingTells = struct {
+	sync.Mutex
+	channels map[string]map[string]*models.Tell
+}{channels: make(map[string]map[string]*models.Tell)}
+
+func pendingTellsKey(e *irc.Event, channel string) string {
+	network := ""
+	if e != nil {
+		network = e.Network
+	}
+	return network + "/" + strings.ToLower(channel)
+}
+
+// setPendingTells replaces the remembered tells of the channel.
+func setPendingTells(e *irc.Event, channel string, tells []*models.Tell) {
+	byID := make(map[string]*models.Tell, len(tells))
+	for _, t := range tells {
+		byID[t.ID] = t
+	}
+
+	pendingTells.Lock()
+	defer pendingTells.Unlock()
+	pendingTells.channels[pendingTellsKey(e, channel)] = byID
+}
+
+func AddTell(e *irc.Event, channel string, tell *models.Tell) error {
+	if err := store(e).CreateTell(channel, tell); err != nil {
+		return err
+	}
+
+	pendingTells.Lock()
+	defer pendingTells.Unlock()
+	if byID, ok := pendingTells.channels[pendingTellsKey(e, channel)]; ok {
+		byID[tell.ID] = tell
+	}
+
+	return nil
+}
+
+// HasPendingTells returns whether the channel may have undelivered tells addressed to the user with the mask, without
+// reading storage once the channel's tells have been loaded.
+func HasPendingTells(e *irc.Event, channel string, mask *irc.Mask) (bool, error) {
+	pendingTells.Lock()
+	byID, ok := pendingTells.channels[pendingTellsKey(e, channel)]
+	if ok {
+		defer pendingTells.Unlock()
+		for _, t := range byID {
+			if t.IsFor(mask) {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+	pendingTells.Unlock()
+
+	tells, err := store(e).Tells(channel)
+	if err != nil {
+		return false, err
+	}
+	setPendingTells(e, channel, tells)
+
+	for _, t := range tells {
+		if t.IsFor(mask) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// GetPendingTells returns the channel's undelivered tells addressed to the user with the mask, oldest first.
+func GetPendingTells(e *irc.Event, channel string, mask *irc.Mask) ([]*models.Tell, error) {
+	tells, err := store(e).Tells(channel)
+	if err != nil {
+		return nil, err
+	}
+	setPendingTells(e, channel, tells)
+
+	pending := make([]*models.Tell, 0)
+	for _, t := range tells {
+		if t.IsFor(mask) {
+			pending = append(pending, t)
+		}
+	}
+
+	return pending, nil
+}
+
+func DeleteTell(e *irc.Event, channel, id string) error {
+	if err := store(e).DeleteTell(channel, id); err != nil {
+		return err
+	}
+
+	pendingTells.Lock()
+	defer pendingTells.Unlock()
+	if byID, ok := pendingTells.channels[pendingTellsKey(e, channel)]; ok {
+		delete(byID, id)
+	}
+
+	return nil
+}
+
+func UpdateUserTellDelivery(e *irc.Event, channel string, u *models.User) error {
+	return store(e).UpdateUser(channel, u, map[string]any{"tell_delivery": u.TellDelivery, "updated_at": time.Now()})
+}
@@ -2,7 +2,6 @@ package repository
 
 import (
 	"assistant/pkg/api/irc"
-	"assistant/pkg/firestore"
 	"assistant/pkg/log"
 	"assistant/pkg/models"
 	"fmt"
@@ -19,7 +18,7 @@ const (
 )
 
 func CreateUserFromNickChange(e *irc.Event, oldMask, newMask *irc.Mask) error {
-	fs := firestore.Get()
+	fs := store(e)
 	logger := log.Logger()
 
 	logger.Debugf(e, "attempting to create user: %s", newMask.String())
@@ -52,12 +51,12 @@ func CreateUserFromNickChange(e *irc.Event, oldMask, newMask *irc.Mask) error {
 }
 
 func GetUsersByHost(e *irc.Event, channel, host string) ([]*models.User, error) {
-	fs := firestore.Get()
+	fs := store(e)
 	return fs.GetUsersByHost(channel, host)
 }
 
 func GetUserByNick(e *irc.Event, channel, nick string, createIfNotExists bool) (*models.User, error) {
-	fs := firestore.Get()
+	fs := store(e)
 	u, err := fs.GetUserByNick(channel, nick)
 	if err != nil {
 		return nil, err
@@ -74,15 +73,66 @@ func GetUserByNick(e *irc.Event, channel, nick string, createIfNotExists bool) (
 	return u, nil
 }
 
+// GetUserByAccount returns the user linked to a services account. When more than one user in the channel carries
+// the account, such as after nick changes, the earliest created one holds the history and is returned.
+func GetUserByAccount(e *irc.Event, channel, account string) (*models.User, error) {
+	if len(account) == 0 {
+		return nil, nil
+	}
+
+	users, err := store(e).GetUsersByAccount(channel, account)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(users) == 0 {
+		return nil, nil
+	}
+
+	sort.SliceStable(users, func(i, j int) bool {
+		return users[i].CreatedAt.Before(users[j].CreatedAt)
+	})
+
+	return users[0], nil
+}
+
+// GetUserByIdentity prefers the user linked to account, when one is known, and otherwise falls back to the nick. A
+// user found by nick that isn't linked to an account yet is linked to it, so its history follows the account from
+// then on.
+func GetUserByIdentity(e *irc.Event, channel, nick, account string, createIfNotExists bool) (*models.User, error) {
+	u, err := GetUserByAccount(e, channel, account)
+	if err != nil {
+		return nil, err
+	}
+
+	if u != nil {
+		return u, nil
+	}
+
+	u, err = GetUserByNick(e, channel, nick, createIfNotExists)
+	if err != nil || u == nil {
+		return u, err
+	}
+
+	if len(account) > 0 && len(u.Account) == 0 {
+		u.Account = account
+		if err = store(e).UpdateUser(channel, u, map[string]any{"account": u.Account, "updated_at": time.Now()}); err != nil {
+			return nil, err
+		}
+	}
+
+	return u, nil
+}
+
 func GetUserByMask(e *irc.Event, channel string, mask *irc.Mask, createIfNotExists bool) (*models.User, error) {
-	u, err := firestore.Get().GetUser(channel, mask)
+	u, err := store(e).GetUser(channel, mask)
 	if err != nil {
 		return nil, err
 	}
 
 	if u == nil && createIfNotExists {
 		u = models.NewUser(mask)
-		err = firestore.Get().CreateUser(channel, u)
+		err = store(e).CreateUser(channel, u)
 		if err != nil {
 			return nil, err
 		}
@@ -103,7 +153,7 @@ func AddRecentUserMessage(e *irc.Event, u *models.User) error {
 		u.RecentMessages = u.RecentMessages[1:]
 	}
 
-	fs := firestore.Get()
+	fs := store(e)
 	return fs.UpdateUser(channel, u, map[string]interface{}{"recent_messages": u.RecentMessages, "updated_at": time.Now()})
 }
 
@@ -134,24 +184,29 @@ func FindRecentUserMessage(e *irc.Event, u *models.User, input string) (models.R
 }
 
 func UpdateUserIsAutoVoiced(e *irc.Event, channel string, u *models.User) error {
-	fs := firestore.Get()
+	fs := store(e)
 	return fs.UpdateUser(channel, u, map[string]interface{}{"is_auto_voiced": u.IsAutoVoiced, "updated_at": time.Now()})
 }
 
+func UpdateUserTimeZone(e *irc.Event, channel string, u *models.User) error {
+	fs := store(e)
+	return fs.UpdateUser(channel, u, map[string]interface{}{"time_zone": u.TimeZone, "updated_at": time.Now()})
+}
+
 func IncrementUserKarma(e *irc.Event, u *models.User) error {
 	u.Karma++
-	fs := firestore.Get()
+	fs := store(e)
 	return fs.UpdateUser(e.ReplyTarget(), u, map[string]interface{}{"karma": u.Karma, "updated_at": time.Now()})
 }
 
 func DecrementUserKarma(e *irc.Event, u *models.User) error {
 	u.Karma--
-	fs := firestore.Get()
+	fs := store(e)
 	return fs.UpdateUser(e.ReplyTarget(), u, map[string]interface{}{"karma": u.Karma, "updated_at": time.Now()})
 }
 
 func GetMostRecentUserKarmaHistoryFromSender(e *irc.Event, channel, recipient, sender string) (*models.KarmaHistory, error) {
-	kh, err := firestore.Get().KarmaHistory(channel, recipient)
+	kh, err := store(e).KarmaHistory(channel, recipient)
 	if err != nil {
 		return nil, err
 	}
@@ -169,8 +224,8 @@ func GetMostRecentUserKarmaHistoryFromSender(e *irc.Event, channel, recipient, s
 	return nil, nil
 }
 
-func AddUserKarmaHistory(e *irc.Event, channel, from, to, operation, reason string) (int, error) {
-	u, err := GetUserByNick(nil, channel, to, true)
+func AddUserKarmaHistory(e *irc.Event, channel, from, to, toAccount, operation, reason string) (int, error) {
+	u, err := GetUserByIdentity(nil, channel, to, toAccount, true)
 	if err != nil {
 		return 0, err
 	}
@@ -192,15 +247,44 @@ func AddUserKarmaHistory(e *irc.Event, channel, from, to, operation, reason stri
 	}
 
 	kh := models.NewKarmaHistory(from, op, 1, reason)
-	return u.Karma, firestore.Get().SaveKarmaHistory(channel, to, kh)
+	return u.Karma, store(e).SaveKarmaHistory(channel, u.Nick, kh)
+}
+
+// PersonalNoteOwner returns the key personal notes are stored under for the sender of e. Senders logged in to a
+// services account keep their notes under the account, prefixed with a character nicks can't start with, and any
+// notes previously kept under their nick are moved there.
+func PersonalNoteOwner(e *irc.Event) string {
+	if len(e.Account) == 0 {
+		return e.From
+	}
+
+	owner := "~" + e.Account
+
+	notes, err := store(e).PersonalNotes(e.From)
+	if err != nil {
+		log.Logger().Errorf(e, "error retrieving personal notes for %s: %s", e.From, err)
+		return owner
+	}
+
+	for _, n := range notes {
+		if err = store(e).SetPersonalNote(owner, n); err != nil {
+			log.Logger().Errorf(e, "error moving personal note %s to %s: %s", n.ID, owner, err)
+			continue
+		}
+		if err = store(e).DeletePersonalNote(e.From, n.ID); err != nil {
+			log.Logger().Errorf(e, "error removing moved personal note %s: %s", n.ID, err)
+		}
+	}
+
+	return owner
 }
 
 func GetPersonalNote(e *irc.Event, nick, id string) (*models.PersonalNote, error) {
-	return firestore.Get().PersonalNote(nick, id)
+	return store(e).PersonalNote(nick, id)
 }
 
 func GetPersonalNotes(e *irc.Event, nick string) ([]*models.PersonalNote, error) {
-	return firestore.Get().PersonalNotes(nick)
+	return store(e).PersonalNotes(nick)
 }
 
 type personalNoteSearchResult struct {
@@ -209,7 +293,7 @@ type personalNoteSearchResult struct {
 }
 
 func GetPersonalNotesMatchingKeywords(e *irc.Event, nick string, keywords []string) ([]*models.PersonalNote, error) {
-	matching, err := firestore.Get().PersonalNotesMatchingKeywords(nick, keywords)
+	matching, err := store(e).PersonalNotesMatchingKeywords(nick, keywords)
 	if err != nil {
 		return nil, err
 	}
@@ -254,13 +338,13 @@ func GetPersonalNotesMatchingKeywords(e *irc.Event, nick string, keywords []stri
 }
 
 func GetPersonalNotesMatchingSource(e *irc.Event, nick, source string) ([]*models.PersonalNote, error) {
-	return firestore.Get().PersonalNotesMatchingSource(nick, source)
+	return store(e).PersonalNotesMatchingSource(nick, source)
 }
 
 func AddPersonalNote(e *irc.Event, nick string, note *models.PersonalNote) error {
-	return firestore.Get().CreatePersonalNote(nick, note)
+	return store(e).CreatePersonalNote(nick, note)
 }
 
 func DeletePersonalNote(e *irc.Event, nick, id string) error {
-	return firestore.Get().DeletePersonalNote(nick, id)
+	return store(e).DeletePersonalNote(nick, id)
 }
@@ -2,21 +2,27 @@ package stats
 
 import "sync"
 
+type channelKey struct {
+	network string
+	channel string
+}
+
 var (
 	mu       sync.Mutex
-	messages = make(map[string]int)
+	messages = make(map[channelKey]int)
 )
 
-func IncrementMessages(channel string) {
+func IncrementMessages(network, channel string) {
 	mu.Lock()
-	messages[channel]++
+	messages[channelKey{network, channel}]++
 	mu.Unlock()
 }
 
-func ReadAndResetMessages(channel string) int {
+func ReadAndResetMessages(network, channel string) int {
 	mu.Lock()
-	count := messages[channel]
-	messages[channel] = 0
+	key := channelKey{network, channel}
+	count := messages[key]
+	messages[key] = 0
 	mu.Unlock()
 	return count
 }
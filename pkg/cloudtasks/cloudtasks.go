@@ -71,7 +71,7 @@ func (ct *CloudTasks) CreateTask(task *models.Task) (string, error) {
 	// deduplication (multiple stale tasks rescheduling to the same due time
 	// will get AlreadyExists). Other tasks use current nanos for uniqueness.
 	taskID := task.ID
-	isPersistent := task.Type == models.TaskTypePersistentChannel || task.Type == models.TaskTypePersistentChannelStats
+	isPersistent := task.Type == models.TaskTypePersistentChannel || task.Type == models.TaskTypePersistentChannelStats || task.Type == models.TaskTypePersistentChannelFeeds
 	if isPersistent {
 		channel := task.Data.(models.PersistentTaskData).Channel
 		taskID = fmt.Sprintf("%s-%s", taskID, strings.ReplaceAll(channel, "#", ""))
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"time"
 
@@ -11,6 +12,7 @@ type Config struct {
 	IRC            IRCConfig
 	Web            WebConfig
 	Queue          QueueConfig
+	Storage        StorageConfig
 	Reddit         RedditConfig
 	GoogleCloud    GoogleCloudConfig `yaml:"google_cloud"`
 	Currency       APIKeyConfig
@@ -33,6 +35,7 @@ type Config struct {
 }
 
 type IRCConfig struct {
+	Name           string
 	Owner          string
 	Admins         []string
 	Server         string
@@ -40,13 +43,17 @@ type IRCConfig struct {
 	Port           int
 	TLS            bool
 	Nick           string
-	Username       string            `yaml:"user_name"`
-	RealName       string            `yaml:"real_name"`
-	ReconnectDelay int               `yaml:"reconnect_delay"`
-	NickServ       NickServConfig    `yaml:"nickserv"`
-	ChanServ       ChanServConfig    `yaml:"chanserv"`
-	PostConnect    PostConnectConfig `yaml:"post_connect"`
-	Inactivity     InactivityConfig  `yaml:"inactivity"`
+	Username       string             `yaml:"user_name"`
+	RealName       string             `yaml:"real_name"`
+	ReconnectDelay int                `yaml:"reconnect_delay"`
+	SASL           SASLConfig         `yaml:"sasl"`
+	NickServ       NickServConfig     `yaml:"nickserv"`
+	ChanServ       ChanServConfig     `yaml:"chanserv"`
+	PostConnect    PostConnectConfig  `yaml:"post_connect"`
+	Inactivity     InactivityConfig   `yaml:"inactivity"`
+	FloodControl   FloodControlConfig `yaml:"flood_control"`
+	Capabilities   []string
+	Networks       []IRCConfig
 }
 
 func (c IRCConfig) IsOwnerOrAdmin(nick string) bool {
@@ -63,6 +70,75 @@ func (c IRCConfig) IsOwnerOrAdmin(nick string) bool {
 	return false
 }
 
+// Networks returns a copy of the config for each network the assistant connects to. When irc.networks is empty the
+// config itself is the only network, which keeps single network deployments on their existing storage paths. Each
+// listed network inherits the nick, user and real names, owner, admins, inactivity, flood control and reconnect settings
+// of the irc section unless it sets its own.
+func (c *Config) Networks() []*Config {
+	if len(c.IRC.Networks) == 0 {
+		return []*Config{c}
+	}
+
+	networks := make([]*Config, 0, len(c.IRC.Networks))
+	for _, n := range c.IRC.Networks {
+		if len(n.Nick) == 0 {
+			n.Nick = c.IRC.Nick
+		}
+		if len(n.Username) == 0 {
+			n.Username = c.IRC.Username
+		}
+		if len(n.RealName) == 0 {
+			n.RealName = c.IRC.RealName
+		}
+		if len(n.Owner) == 0 {
+			n.Owner = c.IRC.Owner
+		}
+		if len(n.Admins) == 0 {
+			n.Admins = c.IRC.Admins
+		}
+		if len(n.Inactivity.DefaultDuration) == 0 {
+			n.Inactivity = c.IRC.Inactivity
+		}
+		if n.FloodControl == (FloodControlConfig{}) {
+			n.FloodControl = c.IRC.FloodControl
+		}
+		if n.ReconnectDelay == 0 {
+			n.ReconnectDelay = c.IRC.ReconnectDelay
+		}
+		n.Networks = nil
+
+		network := *c
+		network.IRC = n
+		networks = append(networks, &network)
+	}
+
+	return networks
+}
+
+// Network returns the config for the named network, or nil if there is no such network.
+func (c *Config) Network(name string) *Config {
+	for _, n := range c.Networks() {
+		if n.IRC.Name == name {
+			return n
+		}
+	}
+	return nil
+}
+
+func (c *Config) validateNetworks() error {
+	names := make(map[string]bool)
+	for _, n := range c.IRC.Networks {
+		if len(n.Name) == 0 {
+			return fmt.Errorf("irc network for %s has no name", n.Server)
+		}
+		if names[n.Name] {
+			return fmt.Errorf("duplicate irc network %s", n.Name)
+		}
+		names[n.Name] = true
+	}
+	return nil
+}
+
 type WebConfig struct {
 	Domain          string
 	Port            int
@@ -86,6 +162,31 @@ func (d DashboardConfig) SessionExpiryDuration() time.Duration {
 	return dur
 }
 
+const SASLMechanismPlain = "PLAIN"
+const SASLMechanismExternal = "EXTERNAL"
+
+// SASLConfig enables SASL authentication during connection registration. PLAIN defaults to the configured nick and
+// NickServ password; EXTERNAL authenticates with the client certificate presented over TLS.
+type SASLConfig struct {
+	Mechanism string
+	Username  string
+	Password  string
+	CertFile  string `yaml:"cert_file"`
+	KeyFile   string `yaml:"key_file"`
+}
+
+func (c SASLConfig) IsEnabled() bool {
+	return len(c.Mechanism) > 0
+}
+
+// FloodControlConfig paces outbound lines: up to Burst lines can be sent back to back, refilled at Rate lines per
+// second. Messages still queued after MaxAgeSeconds are dropped.
+type FloodControlConfig struct {
+	Burst         int
+	Rate          float64
+	MaxAgeSeconds int `yaml:"max_age_seconds"`
+}
+
 type NickServConfig struct {
 	Recipient       string
 	IdentifyPattern string `yaml:"identify_pattern"`
@@ -162,9 +263,30 @@ type ImgflipConfig struct {
 	Password string `yaml:"password"`
 }
 
+const QueueBackendPubSub = "pubsub"
+const QueueBackendLocal = "local"
+
 type QueueConfig struct {
 	Topic        string
 	Subscription string
+	Backend      string // top-level queue section only
+	Path         string // top-level queue section only
+}
+
+func (q QueueConfig) IsLocal() bool {
+	return q.Backend == QueueBackendLocal
+}
+
+const StorageBackendFirestore = "firestore"
+const StorageBackendLocal = "local"
+
+type StorageConfig struct {
+	Backend string
+	Path    string
+}
+
+func (s StorageConfig) IsLocal() bool {
+	return s.Backend == StorageBackendLocal
 }
 
 type MerriamWebsterConfig struct {
@@ -222,5 +344,9 @@ func ReadConfig(filename string) (*Config, error) {
 		return nil, err
 	}
 
+	if err = cfg.validateNetworks(); err != nil {
+		return nil, err
+	}
+
 	return cfg, nil
 }
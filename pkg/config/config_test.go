@@ -0,0 +1,53 @@
+package config
+
+import "testing"
+
+func TestNetworksWithoutList(t *testing.T) {
+	cfg := &Config{IRC: IRCConfig{Nick: "assistant", Server: "irc.example.com"}}
+
+	networks := cfg.Networks()
+	if len(networks) != 1 || networks[0] != cfg {
+		t.Fatalf("Networks() = %v, want the config itself", networks)
+	}
+}
+
+func TestNetworksInheritDefaults(t *testing.T) {
+	cfg := &Config{IRC: IRCConfig{
+		Nick:   "assistant",
+		Owner:  "owner",
+		Admins: []string{"admin"},
+		Networks: []IRCConfig{
+			{Name: "one", Server: "irc.one.net"},
+			{Name: "two", Server: "irc.two.net", Nick: "helper", Admins: []string{"other"}},
+		},
+	}}
+
+	networks := cfg.Networks()
+	if len(networks) != 2 {
+		t.Fatalf("Networks() returned %d networks, want 2", len(networks))
+	}
+
+	if n := networks[0].IRC; n.Nick != "assistant" || n.Owner != "owner" || len(n.Admins) != 1 || n.Admins[0] != "admin" {
+		t.Fatalf("network one = %+v, want inherited nick, owner and admins", n)
+	}
+
+	if n := networks[1].IRC; n.Nick != "helper" || n.Owner != "owner" || n.Admins[0] != "other" || n.Networks != nil {
+		t.Fatalf("network two = %+v, want its own nick and admins", n)
+	}
+
+	if cfg.Network("two").IRC.Server != "irc.two.net" {
+		t.Fatalf("Network(two) = %+v", cfg.Network("two").IRC)
+	}
+}
+
+func TestValidateNetworks(t *testing.T) {
+	cfg := &Config{IRC: IRCConfig{Networks: []IRCConfig{{Name: "one"}, {Name: "one"}}}}
+	if err := cfg.validateNetworks(); err == nil {
+		t.Fatalf("validateNetworks() with duplicate names should fail")
+	}
+
+	cfg = &Config{IRC: IRCConfig{Networks: []IRCConfig{{Server: "irc.one.net"}}}}
+	if err := cfg.validateNetworks(); err == nil {
+		t.Fatalf("validateNetworks() without a name should fail")
+	}
+}
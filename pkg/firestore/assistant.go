@@ -6,22 +6,22 @@ import (
 )
 
 func (fs *Firestore) Assistant() (*models.Assistant, error) {
-	path := fmt.Sprintf("%s/%s", pathAssistants, fs.cfg.IRC.Nick)
+	path := fs.root()
 	return get[models.Assistant](fs.ctx, fs.client, path)
 }
 
 func (fs *Firestore) CreateAssistant() (*models.Assistant, error) {
-	path := fmt.Sprintf("%s/%s/", pathAssistants, fs.cfg.IRC.Nick)
+	path := fmt.Sprintf("%s/", fs.root())
 	assistant := models.NewAssistant(fs.cfg.IRC.Nick)
 	return assistant, create(fs.ctx, fs.client, path, assistant)
 }
 
 func (fs *Firestore) SetAssistant(assistant *models.Assistant) error {
-	path := fmt.Sprintf("%s/%s", pathAssistants, fs.cfg.IRC.Nick)
+	path := fs.root()
 	return set(fs.ctx, fs.client, path, assistant)
 }
 
 func (fs *Firestore) UpdateAssistant(fields map[string]any) error {
-	path := fmt.Sprintf("%s/%s", pathAssistants, fs.cfg.IRC.Nick)
+	path := fs.root()
 	return update(fs.ctx, fs.client, path, fields)
 }
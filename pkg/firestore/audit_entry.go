@@ -0,0 +1,44 @@
+package firestore
+
+import (
+	"assistant/pkg/models"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+)
+
+const pathAuditLog = "audit-log"
+
+func (fs *Firestore) AddAuditEntry(channel string, entry *models.AuditEntry) error {
+	path := fmt.Sprintf("%s/%s/%s/%s/%s", fs.root(), pathChannels, channel, pathAuditLog, entry.ID)
+	return create(fs.ctx, fs.client, path, entry)
+}
+
+func (fs *Firestore) AuditEntries(channel string) ([]*models.AuditEntry, error) {
+	path := fmt.Sprintf("%s/%s/%s/%s", fs.root(), pathChannels, channel, pathAuditLog)
+
+	criteria := QueryCriteria{
+		Path: path,
+		OrderBy: []OrderBy{
+			{Field: "created_at", Direction: firestore.Desc},
+		},
+	}
+
+	return query[models.AuditEntry](fs.ctx, fs.client, criteria)
+}
+
+// AuditEntriesSince returns the channel's audit entries created at or after since, newest first.
+func (fs *Firestore) AuditEntriesSince(channel string, since time.Time) ([]*models.AuditEntry, error) {
+	path := fmt.Sprintf("%s/%s/%s/%s", fs.root(), pathChannels, channel, pathAuditLog)
+
+	criteria := QueryCriteria{
+		Path:   path,
+		Filter: createPropertyFilter("created_at", GreaterThanOrEqual, since),
+		OrderBy: []OrderBy{
+			{Field: "created_at", Direction: firestore.Desc},
+		},
+	}
+
+	return query[models.AuditEntry](fs.ctx, fs.client, criteria)
+}
@@ -10,73 +10,93 @@ import (
 )
 
 func (fs *Firestore) BannedWords(channel string) ([]*models.BannedWord, error) {
-	path := fmt.Sprintf("%s/%s/%s/%s/%s", pathAssistants, fs.cfg.IRC.Nick, pathChannels, channel, pathBannedWords)
+	path := fmt.Sprintf("%s/%s/%s/%s", fs.root(), pathChannels, channel, pathBannedWords)
 	return list[models.BannedWord](fs.ctx, fs.client, path)
 }
 
-func (fs *Firestore) AddBannedWord(channel, word string) error {
-	id := fmt.Sprintf("%s-%s", models.PrefixBannedWord, uuid.NewString())
-	path := fmt.Sprintf("%s/%s/%s/%s/%s/%s", pathAssistants, fs.cfg.IRC.Nick, pathChannels, channel, pathBannedWords, id)
-	return create(fs.ctx, fs.client, path, &models.BannedWord{ID: id, Word: strings.ToLower(word)})
+// AddBannedWord stores the banned word, replacing the settings of any entry for the same word.
+func (fs *Firestore) AddBannedWord(channel string, bw *models.BannedWord) error {
+	bw.Normalize()
+
+	existing, err := fs.findBannedWord(channel, bw.Word)
+	if err != nil {
+		return err
+	}
+
+	if existing != nil {
+		bw.ID = existing.ID
+		path := fmt.Sprintf("%s/%s/%s/%s/%s", fs.root(), pathChannels, channel, pathBannedWords, bw.ID)
+		return update(fs.ctx, fs.client, path, map[string]any{"kind": bw.Kind, "action": bw.Action, "duration": bw.Duration, "dry_run": bw.DryRun})
+	}
+
+	bw.ID = fmt.Sprintf("%s-%s", models.PrefixBannedWord, uuid.NewString())
+	path := fmt.Sprintf("%s/%s/%s/%s/%s", fs.root(), pathChannels, channel, pathBannedWords, bw.ID)
+	return create(fs.ctx, fs.client, path, bw)
 }
 
-func (fs *Firestore) UpdateBannedWord(channel, oldWord, newWord string) error {
-	logger := log.Logger()
-	path := fmt.Sprintf("%s/%s/%s/%s/%s", pathAssistants, fs.cfg.IRC.Nick, pathChannels, channel, pathBannedWords)
+// findBannedWord returns the entry for the word, which is stored lowercase unless it's a regex.
+func (fs *Firestore) findBannedWord(channel, word string) (*models.BannedWord, error) {
+	path := fmt.Sprintf("%s/%s/%s/%s", fs.root(), pathChannels, channel, pathBannedWords)
 
 	criteria := QueryCriteria{
 		Path:   path,
-		Filter: createPropertyFilter("word", Equal, strings.ToLower(oldWord)),
+		Filter: createPropertyFilter("word", In, []string{word, strings.ToLower(word)}),
+		Limit:  1,
 	}
 
 	bannedWords, err := query[models.BannedWord](fs.ctx, fs.client, criteria)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if len(bannedWords) == 0 {
-		return fmt.Errorf("banned word not found")
+		return nil, nil
 	}
 
-	docPath := fmt.Sprintf("%s/%s", path, bannedWords[0].ID)
-	logger.Debugf(nil, "updating banned word %s to %s", oldWord, newWord)
-	return update(fs.ctx, fs.client, docPath, map[string]any{"word": strings.ToLower(newWord)})
+	return bannedWords[0], nil
 }
 
-func (fs *Firestore) IsBannedWord(channel, word string) (bool, error) {
-	path := fmt.Sprintf("%s/%s/%s/%s/%s", pathAssistants, fs.cfg.IRC.Nick, pathChannels, channel, pathBannedWords)
+func (fs *Firestore) UpdateBannedWord(channel, oldWord, newWord string) error {
+	logger := log.Logger()
 
-	criteria := QueryCriteria{
-		Path:   path,
-		Filter: createPropertyFilter("word", Equal, strings.ToLower(word)),
+	bw, err := fs.findBannedWord(channel, oldWord)
+	if err != nil {
+		return err
+	}
+
+	if bw == nil {
+		return fmt.Errorf("banned word not found")
 	}
 
-	ok, err := exists[models.BannedWord](fs.ctx, fs.client, criteria)
-	return ok, err
+	bw.Word = newWord
+	bw.Normalize()
+
+	docPath := fmt.Sprintf("%s/%s/%s/%s/%s", fs.root(), pathChannels, channel, pathBannedWords, bw.ID)
+	logger.Debugf(nil, "updating banned word %s to %s", oldWord, newWord)
+	return update(fs.ctx, fs.client, docPath, map[string]any{"word": bw.Word})
+}
+
+func (fs *Firestore) IsBannedWord(channel, word string) (bool, error) {
+	bw, err := fs.findBannedWord(channel, word)
+	return bw != nil, err
 }
 
 func (fs *Firestore) RemoveBannedWord(channel, word string) error {
 	logger := log.Logger()
-	path := fmt.Sprintf("%s/%s/%s/%s/%s", pathAssistants, fs.cfg.IRC.Nick, pathChannels, channel, pathBannedWords)
-
-	criteria := QueryCriteria{
-		Path:   path,
-		Filter: createPropertyFilter("word", Equal, strings.ToLower(word)),
-	}
 
-	bannedWords, err := query[models.BannedWord](fs.ctx, fs.client, criteria)
+	bw, err := fs.findBannedWord(channel, word)
 	if err != nil {
 		logger.Rawf(log.Warning, "error querying banned words, %s", err)
 		return err
 	}
 
-	if len(bannedWords) == 0 {
+	if bw == nil {
 		logger.Rawf(log.Debug, "no matching banned words found")
 		return nil
 	}
 
-	logger.Rawf(log.Debug, "removing %s", bannedWords[0].ID)
+	logger.Rawf(log.Debug, "removing %s", bw.ID)
 
-	path = fmt.Sprintf("%s/%s", path, bannedWords[0].ID)
+	path := fmt.Sprintf("%s/%s/%s/%s/%s", fs.root(), pathChannels, channel, pathBannedWords, bw.ID)
 	return remove(fs.ctx, fs.client, path)
 }
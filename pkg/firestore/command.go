@@ -8,7 +8,7 @@ import (
 )
 
 func (fs *Firestore) IncrementCommandUsage(channel, commandName string) error {
-	path := fmt.Sprintf("%s/%s/%s/%s/%s/%s", pathAssistants, fs.cfg.IRC.Nick, pathChannels, channel, pathCommandUsage, commandName)
+	path := fmt.Sprintf("%s/%s/%s/%s/%s", fs.root(), pathChannels, channel, pathCommandUsage, commandName)
 	doc := fs.client.Doc(path)
 	_, err := doc.Set(fs.ctx, map[string]any{
 		"name":  commandName,
@@ -18,6 +18,6 @@ func (fs *Firestore) IncrementCommandUsage(channel, commandName string) error {
 }
 
 func (fs *Firestore) ListCommandUsage(channel string) ([]*models.CommandUsage, error) {
-	path := fmt.Sprintf("%s/%s/%s/%s/%s", pathAssistants, fs.cfg.IRC.Nick, pathChannels, channel, pathCommandUsage)
+	path := fmt.Sprintf("%s/%s/%s/%s", fs.root(), pathChannels, channel, pathCommandUsage)
 	return list[models.CommandUsage](fs.ctx, fs.client, path)
 }
@@ -8,12 +8,12 @@ import (
 )
 
 func (fs *Firestore) CommunityNote(channel, id string) (*models.CommunityNote, error) {
-	path := fmt.Sprintf("%s/%s/%s/%s/%s/%s", pathAssistants, fs.cfg.IRC.Nick, pathChannels, channel, pathNotes, id)
+	path := fmt.Sprintf("%s/%s/%s/%s/%s", fs.root(), pathChannels, channel, pathNotes, id)
 	return get[models.CommunityNote](fs.ctx, fs.client, path)
 }
 
 func (fs *Firestore) CommunityNotes(channel string) ([]*models.CommunityNote, error) {
-	path := fmt.Sprintf("%s/%s/%s/%s/%s", pathAssistants, fs.cfg.IRC.Nick, pathChannels, channel, pathNotes)
+	path := fmt.Sprintf("%s/%s/%s/%s", fs.root(), pathChannels, channel, pathNotes)
 
 	criteria := QueryCriteria{
 		Path: path,
@@ -29,7 +29,7 @@ func (fs *Firestore) CommunityNotes(channel string) ([]*models.CommunityNote, er
 }
 
 func (fs *Firestore) CommunityNoteForSource(channel, source string) (*models.CommunityNote, error) {
-	path := fmt.Sprintf("%s/%s/%s/%s/%s", pathAssistants, fs.cfg.IRC.Nick, pathChannels, channel, pathNotes)
+	path := fmt.Sprintf("%s/%s/%s/%s", fs.root(), pathChannels, channel, pathNotes)
 
 	criteria := QueryCriteria{
 		Path: path,
@@ -63,16 +63,16 @@ func (fs *Firestore) CommunityNoteForSource(channel, source string) (*models.Com
 }
 
 func (fs *Firestore) CreateCommunityNote(channel string, note *models.CommunityNote) error {
-	path := fmt.Sprintf("%s/%s/%s/%s/%s/%s", pathAssistants, fs.cfg.IRC.Nick, pathChannels, channel, pathNotes, note.ID)
+	path := fmt.Sprintf("%s/%s/%s/%s/%s", fs.root(), pathChannels, channel, pathNotes, note.ID)
 	return create(fs.ctx, fs.client, path, note)
 }
 
 func (fs *Firestore) SetCommunityNote(channel string, note *models.CommunityNote) error {
-	path := fmt.Sprintf("%s/%s/%s/%s/%s/%s", pathAssistants, fs.cfg.IRC.Nick, pathChannels, channel, pathNotes, note.ID)
+	path := fmt.Sprintf("%s/%s/%s/%s/%s", fs.root(), pathChannels, channel, pathNotes, note.ID)
 	return set(fs.ctx, fs.client, path, note)
 }
 
 func (fs *Firestore) DeleteCommunityNote(channel, id string) error {
-	path := fmt.Sprintf("%s/%s/%s/%s/%s/%s", pathAssistants, fs.cfg.IRC.Nick, pathChannels, channel, pathNotes, id)
+	path := fmt.Sprintf("%s/%s/%s/%s/%s", fs.root(), pathChannels, channel, pathNotes, id)
 	return remove(fs.ctx, fs.client, path)
 }
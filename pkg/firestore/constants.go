@@ -2,6 +2,7 @@ package firestore
 
 const (
 	pathAssistants            = "assistants"
+	pathNetworks              = "networks"
 	pathChannels              = "channels"
 	pathBannedWords           = "banned-words"
 	pathUsers                 = "users"
@@ -9,14 +9,16 @@ import (
 	"strings"
 )
 
+// disinformationSources caches the sources of each channel, keyed by the path of its collection so that channels of
+// the same name on different networks stay apart.
 var disinformationSources map[string][]string
 
 func (fs *Firestore) pathToDisinformationSources(channel string) string {
-	return fmt.Sprintf("%s/%s/%s/%s/%s", pathAssistants, fs.cfg.IRC.Nick, pathChannels, channel, pathDisinformationSources)
+	return fmt.Sprintf("%s/%s/%s/%s", fs.root(), pathChannels, channel, pathDisinformationSources)
 }
 
 func (fs *Firestore) pathToDisinformationSource(channel string, source *models.DisinformationSource) string {
-	return fmt.Sprintf("%s/%s/%s/%s/%s/%s", pathAssistants, fs.cfg.IRC.Nick, pathChannels, channel, pathDisinformationSources, source.ID)
+	return fmt.Sprintf("%s/%s/%s/%s/%s", fs.root(), pathChannels, channel, pathDisinformationSources, source.ID)
 }
 
 func (fs *Firestore) DisinformationSources(channel string) ([]*models.DisinformationSource, error) {
@@ -57,10 +59,10 @@ func (fs *Firestore) AddDisinformationSource(channel, source string) error {
 	if disinformationSources == nil {
 		disinformationSources = make(map[string][]string)
 	}
-	if len(disinformationSources[channel]) == 0 {
-		disinformationSources[channel] = make([]string, 0)
+	if len(disinformationSources[fs.pathToDisinformationSources(channel)]) == 0 {
+		disinformationSources[fs.pathToDisinformationSources(channel)] = make([]string, 0)
 	}
-	disinformationSources[channel] = append(disinformationSources[channel], source)
+	disinformationSources[fs.pathToDisinformationSources(channel)] = append(disinformationSources[fs.pathToDisinformationSources(channel)], source)
 
 	ds := models.NewDisinformationSource(source)
 	return create(fs.ctx, fs.client, fs.pathToDisinformationSource(channel, ds), ds)
@@ -75,7 +77,7 @@ func (fs *Firestore) DeleteDisinformationSource(channel, source string) error {
 		return nil
 	}
 
-	disinformationSources[channel] = slices.DeleteFunc(disinformationSources[channel], func(s string) bool {
+	disinformationSources[fs.pathToDisinformationSources(channel)] = slices.DeleteFunc(disinformationSources[fs.pathToDisinformationSources(channel)], func(s string) bool {
 		return s == source
 	})
 
@@ -97,7 +99,7 @@ func (fs *Firestore) IsDisinformationSource(channel, source string) bool {
 
 	source = strings.TrimSpace(strings.ToLower(source))
 
-	if prefixes, ok := disinformationSources[channel]; ok {
+	if prefixes, ok := disinformationSources[fs.pathToDisinformationSources(channel)]; ok {
 		for _, prefix := range prefixes {
 			if strings.HasPrefix(source, prefix) {
 				return true
@@ -113,7 +115,7 @@ func (fs *Firestore) ReloadDisinformationSources(channel string) error {
 		disinformationSources = make(map[string][]string)
 	}
 
-	disinformationSources[channel] = make([]string, 0)
+	disinformationSources[fs.pathToDisinformationSources(channel)] = make([]string, 0)
 
 	disinformation, err := fs.DisinformationSources(channel)
 	if err != nil {
@@ -121,7 +123,7 @@ func (fs *Firestore) ReloadDisinformationSources(channel string) error {
 	}
 
 	for _, d := range disinformation {
-		disinformationSources[channel] = append(disinformationSources[channel], d.Source)
+		disinformationSources[fs.pathToDisinformationSources(channel)] = append(disinformationSources[fs.pathToDisinformationSources(channel)], d.Source)
 	}
 
 	return nil
@@ -0,0 +1,56 @@
+package firestore
+
+import (
+	"assistant/pkg/models"
+	"fmt"
+
+	"cloud.google.com/go/firestore"
+)
+
+const pathFactoids = "factoids"
+
+func (fs *Firestore) Factoid(channel, key string) (*models.Factoid, error) {
+	path := fmt.Sprintf("%s/%s/%s/%s/%s", fs.root(), pathChannels, channel, pathFactoids, key)
+	return get[models.Factoid](fs.ctx, fs.client, path)
+}
+
+func (fs *Firestore) Factoids(channel string) ([]*models.Factoid, error) {
+	path := fmt.Sprintf("%s/%s/%s/%s", fs.root(), pathChannels, channel, pathFactoids)
+
+	criteria := QueryCriteria{
+		Path: path,
+		OrderBy: []OrderBy{
+			{Field: "key", Direction: firestore.Asc},
+		},
+	}
+
+	return query[models.Factoid](fs.ctx, fs.client, criteria)
+}
+
+func (fs *Firestore) FindFactoids(channel string, keywords []string) ([]*models.Factoid, error) {
+	path := fmt.Sprintf("%s/%s/%s/%s", fs.root(), pathChannels, channel, pathFactoids)
+
+	criteria := QueryCriteria{
+		Path: path,
+		Filter: firestore.PropertyFilter{
+			Path:     "keywords",
+			Operator: ArrayContainsAny,
+			Value:    keywords,
+		},
+		OrderBy: []OrderBy{
+			{Field: "key", Direction: firestore.Asc},
+		},
+	}
+
+	return query[models.Factoid](fs.ctx, fs.client, criteria)
+}
+
+func (fs *Firestore) SetFactoid(channel string, factoid *models.Factoid) error {
+	path := fmt.Sprintf("%s/%s/%s/%s/%s", fs.root(), pathChannels, channel, pathFactoids, factoid.Key)
+	return set(fs.ctx, fs.client, path, factoid)
+}
+
+func (fs *Firestore) DeleteFactoid(channel, key string) error {
+	path := fmt.Sprintf("%s/%s/%s/%s/%s", fs.root(), pathChannels, channel, pathFactoids, key)
+	return remove(fs.ctx, fs.client, path)
+}
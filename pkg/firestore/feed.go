@@ -0,0 +1,37 @@
+package firestore
+
+import (
+	"assistant/pkg/models"
+	"fmt"
+
+	"cloud.google.com/go/firestore"
+)
+
+const pathFeeds = "feeds"
+
+func (fs *Firestore) pathToFeeds(channel string) string {
+	return fmt.Sprintf("%s/%s/%s/%s", fs.root(), pathChannels, channel, pathFeeds)
+}
+
+func (fs *Firestore) Feed(channel, id string) (*models.Feed, error) {
+	return get[models.Feed](fs.ctx, fs.client, fmt.Sprintf("%s/%s", fs.pathToFeeds(channel), id))
+}
+
+func (fs *Firestore) Feeds(channel string) ([]*models.Feed, error) {
+	criteria := QueryCriteria{
+		Path: fs.pathToFeeds(channel),
+		OrderBy: []OrderBy{
+			{Field: "created_at", Direction: firestore.Asc},
+		},
+	}
+
+	return query[models.Feed](fs.ctx, fs.client, criteria)
+}
+
+func (fs *Firestore) SetFeed(channel string, feed *models.Feed) error {
+	return set(fs.ctx, fs.client, fmt.Sprintf("%s/%s", fs.pathToFeeds(channel), feed.ID), feed)
+}
+
+func (fs *Firestore) DeleteFeed(channel, id string) error {
+	return remove(fs.ctx, fs.client, fmt.Sprintf("%s/%s", fs.pathToFeeds(channel), id))
+}
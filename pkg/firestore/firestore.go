@@ -16,14 +16,6 @@ type Firestore struct {
 	client *firestore.Client
 }
 
-func Get() *Firestore {
-	if instance == nil {
-		panic("firestore is not initialized")
-	}
-
-	return instance
-}
-
 func Initialize(ctx context.Context, cfg *config.Config) (*Firestore, error) {
 	if instance != nil {
 		return instance, nil
@@ -45,6 +37,24 @@ func Initialize(ctx context.Context, cfg *config.Config) (*Firestore, error) {
 	return instance, nil
 }
 
+// WithNetwork returns a store sharing the same client whose documents are kept under the network named in cfg.
+func (fs *Firestore) WithNetwork(cfg *config.Config) *Firestore {
+	return &Firestore{
+		ctx:    fs.ctx,
+		cfg:    cfg,
+		client: fs.client,
+	}
+}
+
+// root returns the document everything is stored under. Named networks get their own document below the assistant so
+// that channels of the same name on different networks don't collide.
+func (fs *Firestore) root() string {
+	if len(fs.cfg.IRC.Name) == 0 {
+		return fmt.Sprintf("%s/%s", pathAssistants, fs.cfg.IRC.Nick)
+	}
+	return fmt.Sprintf("%s/%s/%s/%s", pathAssistants, fs.cfg.IRC.Nick, pathNetworks, fs.cfg.IRC.Name)
+}
+
 func (fs *Firestore) Close() error {
 	return fs.client.Close()
 }
@@ -7,7 +7,7 @@ import (
 )
 
 func (fs *Firestore) KarmaHistory(channel, nick string) ([]*models.KarmaHistory, error) {
-	path := fmt.Sprintf("%s/%s/%s/%s/%s/%s/%s", pathAssistants, fs.cfg.IRC.Nick, pathChannels, channel, pathUsers, nick, pathKarmaHistory)
+	path := fmt.Sprintf("%s/%s/%s/%s/%s/%s", fs.root(), pathChannels, channel, pathUsers, nick, pathKarmaHistory)
 	criteria := QueryCriteria{
 		Path: path,
 		OrderBy: []OrderBy{
@@ -22,6 +22,6 @@ func (fs *Firestore) KarmaHistory(channel, nick string) ([]*models.KarmaHistory,
 }
 
 func (fs *Firestore) SaveKarmaHistory(channel, nick string, kh *models.KarmaHistory) error {
-	path := fmt.Sprintf("%s/%s/%s/%s/%s/%s/%s/%s", pathAssistants, fs.cfg.IRC.Nick, pathChannels, channel, pathUsers, nick, pathKarmaHistory, kh.ID)
+	path := fmt.Sprintf("%s/%s/%s/%s/%s/%s/%s", fs.root(), pathChannels, channel, pathUsers, nick, pathKarmaHistory, kh.ID)
 	return set(fs.ctx, fs.client, path, kh)
 }
@@ -7,17 +7,17 @@ import (
 )
 
 func (fs *Firestore) CreateLLMResponse(r *models.LLMResponse) error {
-	path := fmt.Sprintf("%s/%s/%s/%s", pathAssistants, fs.cfg.IRC.Nick, pathLLMResponses, r.ID)
+	path := fmt.Sprintf("%s/%s/%s", fs.root(), pathLLMResponses, r.ID)
 	return create(fs.ctx, fs.client, path, r)
 }
 
 func (fs *Firestore) LLMResponse(id string) (*models.LLMResponse, error) {
-	path := fmt.Sprintf("%s/%s/%s/%s", pathAssistants, fs.cfg.IRC.Nick, pathLLMResponses, id)
+	path := fmt.Sprintf("%s/%s/%s", fs.root(), pathLLMResponses, id)
 	return get[models.LLMResponse](fs.ctx, fs.client, path)
 }
 
 func (fs *Firestore) UpdateLLMResponse(id, content string) error {
-	path := fmt.Sprintf("%s/%s/%s/%s", pathAssistants, fs.cfg.IRC.Nick, pathLLMResponses, id)
+	path := fmt.Sprintf("%s/%s/%s", fs.root(), pathLLMResponses, id)
 	return update(fs.ctx, fs.client, path, map[string]any{
 		"content":  content,
 		"complete": true,
@@ -25,7 +25,7 @@ func (fs *Firestore) UpdateLLMResponse(id, content string) error {
 }
 
 func (fs *Firestore) LLMResponsesBySession(sessionID string) ([]*models.LLMResponse, error) {
-	path := fmt.Sprintf("%s/%s/%s", pathAssistants, fs.cfg.IRC.Nick, pathLLMResponses)
+	path := fmt.Sprintf("%s/%s", fs.root(), pathLLMResponses)
 	return query[models.LLMResponse](fs.ctx, fs.client, QueryCriteria{
 		Path:   path,
 		Filter: createPropertyFilter("session_id", Equal, sessionID),
@@ -7,7 +7,7 @@ import (
 )
 
 func (fs *Firestore) PersistentChannelTaskPath(channel, id string) string {
-	return fmt.Sprintf("%s/%s/%s/%s/%s/%s", pathAssistants, fs.cfg.IRC.Nick, pathChannels, channel, pathTasks, id)
+	return fmt.Sprintf("%s/%s/%s/%s/%s", fs.root(), pathChannels, channel, pathTasks, id)
 }
 
 func (fs *Firestore) SetPersistentChannelTaskDue(channel, id string, duration time.Duration) error {
@@ -19,6 +19,7 @@ func (fs *Firestore) SetPersistentChannelTaskDue(channel, id string, duration ti
 
 	if task == nil {
 		task = models.NewPersistentTask(id, channel, models.TaskTypePersistentChannel, time.Now().Add(duration))
+		task.Network = fs.cfg.IRC.Name
 		return create[models.Task](fs.ctx, fs.client, path, task)
 	}
 
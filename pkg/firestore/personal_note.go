@@ -8,12 +8,12 @@ import (
 )
 
 func (fs *Firestore) PersonalNote(nick, id string) (*models.PersonalNote, error) {
-	path := fmt.Sprintf("%s/%s/%s/%s/%s/%s", pathAssistants, fs.cfg.IRC.Nick, pathUsers, nick, pathNotes, id)
+	path := fmt.Sprintf("%s/%s/%s/%s/%s", fs.root(), pathUsers, nick, pathNotes, id)
 	return get[models.PersonalNote](fs.ctx, fs.client, path)
 }
 
 func (fs *Firestore) PersonalNotes(nick string) ([]*models.PersonalNote, error) {
-	path := fmt.Sprintf("%s/%s/%s/%s/%s", pathAssistants, fs.cfg.IRC.Nick, pathUsers, nick, pathNotes)
+	path := fmt.Sprintf("%s/%s/%s/%s", fs.root(), pathUsers, nick, pathNotes)
 
 	criteria := QueryCriteria{
 		Path: path,
@@ -29,7 +29,7 @@ func (fs *Firestore) PersonalNotes(nick string) ([]*models.PersonalNote, error)
 }
 
 func (fs *Firestore) PersonalNotesMatchingKeywords(nick string, keywords []string) ([]*models.PersonalNote, error) {
-	path := fmt.Sprintf("%s/%s/%s/%s/%s", pathAssistants, fs.cfg.IRC.Nick, pathUsers, nick, pathNotes)
+	path := fmt.Sprintf("%s/%s/%s/%s", fs.root(), pathUsers, nick, pathNotes)
 
 	criteria := QueryCriteria{
 		Path: path,
@@ -50,7 +50,7 @@ func (fs *Firestore) PersonalNotesMatchingKeywords(nick string, keywords []strin
 }
 
 func (fs *Firestore) PersonalNotesMatchingSource(nick, source string) ([]*models.PersonalNote, error) {
-	path := fmt.Sprintf("%s/%s/%s/%s/%s", pathAssistants, fs.cfg.IRC.Nick, pathUsers, nick, pathNotes)
+	path := fmt.Sprintf("%s/%s/%s/%s", fs.root(), pathUsers, nick, pathNotes)
 
 	criteria := QueryCriteria{
 		Path: path,
@@ -80,7 +80,7 @@ func (fs *Firestore) PersonalNotesMatchingSource(nick, source string) ([]*models
 }
 
 func (fs *Firestore) CreatePersonalNote(nick string, note *models.PersonalNote) error {
-	usersPath := fmt.Sprintf("%s/%s/%s/%s", pathAssistants, fs.cfg.IRC.Nick, pathUsers, nick)
+	usersPath := fmt.Sprintf("%s/%s/%s", fs.root(), pathUsers, nick)
 	user, err := get[models.User](fs.ctx, fs.client, usersPath)
 	if err != nil {
 		return err
@@ -93,16 +93,16 @@ func (fs *Firestore) CreatePersonalNote(nick string, note *models.PersonalNote)
 		}
 	}
 
-	path := fmt.Sprintf("%s/%s/%s/%s/%s/%s", pathAssistants, fs.cfg.IRC.Nick, pathUsers, nick, pathNotes, note.ID)
+	path := fmt.Sprintf("%s/%s/%s/%s/%s", fs.root(), pathUsers, nick, pathNotes, note.ID)
 	return create(fs.ctx, fs.client, path, note)
 }
 
 func (fs *Firestore) SetPersonalNote(nick string, note *models.PersonalNote) error {
-	path := fmt.Sprintf("%s/%s/%s/%s/%s/%s", pathAssistants, fs.cfg.IRC.Nick, pathUsers, nick, pathNotes, note.ID)
+	path := fmt.Sprintf("%s/%s/%s/%s/%s", fs.root(), pathUsers, nick, pathNotes, note.ID)
 	return set(fs.ctx, fs.client, path, note)
 }
 
 func (fs *Firestore) DeletePersonalNote(nick, id string) error {
-	path := fmt.Sprintf("%s/%s/%s/%s/%s/%s", pathAssistants, fs.cfg.IRC.Nick, pathUsers, nick, pathNotes, id)
+	path := fmt.Sprintf("%s/%s/%s/%s/%s", fs.root(), pathUsers, nick, pathNotes, id)
 	return remove(fs.ctx, fs.client, path)
 }
@@ -0,0 +1,37 @@
+package firestore
+
+import (
+	"assistant/pkg/models"
+	"fmt"
+
+	"cloud.google.com/go/firestore"
+)
+
+const pathPolls = "polls"
+
+func (fs *Firestore) pathToPolls(channel string) string {
+	return fmt.Sprintf("%s/%s/%s/%s", fs.root(), pathChannels, channel, pathPolls)
+}
+
+func (fs *Firestore) Poll(channel, id string) (*models.Poll, error) {
+	return get[models.Poll](fs.ctx, fs.client, fmt.Sprintf("%s/%s", fs.pathToPolls(channel), id))
+}
+
+func (fs *Firestore) Polls(channel string) ([]*models.Poll, error) {
+	criteria := QueryCriteria{
+		Path: fs.pathToPolls(channel),
+		OrderBy: []OrderBy{
+			{Field: "created_at", Direction: firestore.Desc},
+		},
+	}
+
+	return query[models.Poll](fs.ctx, fs.client, criteria)
+}
+
+func (fs *Firestore) CreatePoll(channel string, poll *models.Poll) error {
+	return create(fs.ctx, fs.client, fmt.Sprintf("%s/%s", fs.pathToPolls(channel), poll.ID), poll)
+}
+
+func (fs *Firestore) UpdatePoll(channel string, poll *models.Poll, fields map[string]any) error {
+	return update(fs.ctx, fs.client, fmt.Sprintf("%s/%s", fs.pathToPolls(channel), poll.ID), fields)
+}
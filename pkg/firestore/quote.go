@@ -7,12 +7,12 @@ import (
 )
 
 func (fs *Firestore) Quotes(channel string) ([]*models.Quote, error) {
-	path := fmt.Sprintf("%s/%s/%s/%s/%s", pathAssistants, fs.cfg.IRC.Nick, pathChannels, channel, pathQuotes)
+	path := fmt.Sprintf("%s/%s/%s/%s", fs.root(), pathChannels, channel, pathQuotes)
 	return list[models.Quote](fs.ctx, fs.client, path)
 }
 
 func (fs *Firestore) FindUserQuotes(channel, nick string) ([]*models.Quote, error) {
-	path := fmt.Sprintf("%s/%s/%s/%s/%s", pathAssistants, fs.cfg.IRC.Nick, pathChannels, channel, pathQuotes)
+	path := fmt.Sprintf("%s/%s/%s/%s", fs.root(), pathChannels, channel, pathQuotes)
 
 	criteria := QueryCriteria{
 		Path: path,
@@ -33,7 +33,7 @@ func (fs *Firestore) FindUserQuotes(channel, nick string) ([]*models.Quote, erro
 }
 
 func (fs *Firestore) FindUserQuotesWithContent(channel, nick string, keywords []string) ([]*models.Quote, error) {
-	path := fmt.Sprintf("%s/%s/%s/%s/%s", pathAssistants, fs.cfg.IRC.Nick, pathChannels, channel, pathQuotes)
+	path := fmt.Sprintf("%s/%s/%s/%s", fs.root(), pathChannels, channel, pathQuotes)
 
 	criteria := QueryCriteria{
 		Path: path,
@@ -63,7 +63,7 @@ func (fs *Firestore) FindUserQuotesWithContent(channel, nick string, keywords []
 }
 
 func (fs *Firestore) FindQuotes(channel string, keywords []string) ([]*models.Quote, error) {
-	path := fmt.Sprintf("%s/%s/%s/%s/%s", pathAssistants, fs.cfg.IRC.Nick, pathChannels, channel, pathQuotes)
+	path := fmt.Sprintf("%s/%s/%s/%s", fs.root(), pathChannels, channel, pathQuotes)
 
 	criteria := QueryCriteria{
 		Path: path,
@@ -84,11 +84,11 @@ func (fs *Firestore) FindQuotes(channel string, keywords []string) ([]*models.Qu
 }
 
 func (fs *Firestore) CreateQuote(channel string, quote *models.Quote) error {
-	path := fmt.Sprintf("%s/%s/%s/%s/%s/%s", pathAssistants, fs.cfg.IRC.Nick, pathChannels, channel, pathQuotes, quote.ID)
+	path := fmt.Sprintf("%s/%s/%s/%s/%s", fs.root(), pathChannels, channel, pathQuotes, quote.ID)
 	return create(fs.ctx, fs.client, path, quote)
 }
 
 func (fs *Firestore) UpdateQuote(channel string, quote *models.Quote, fields map[string]any) error {
-	path := fmt.Sprintf("%s/%s/%s/%s/%s/%s", pathAssistants, fs.cfg.IRC.Nick, pathChannels, channel, pathQuotes, quote.ID)
+	path := fmt.Sprintf("%s/%s/%s/%s/%s", fs.root(), pathChannels, channel, pathQuotes, quote.ID)
 	return update(fs.ctx, fs.client, path, fields)
 }
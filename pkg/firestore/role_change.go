@@ -0,0 +1,28 @@
+package firestore
+
+import (
+	"assistant/pkg/models"
+	"fmt"
+
+	"cloud.google.com/go/firestore"
+)
+
+const pathRoleChanges = "role-changes"
+
+func (fs *Firestore) AddRoleChange(channel string, change *models.RoleChange) error {
+	path := fmt.Sprintf("%s/%s/%s/%s/%s", fs.root(), pathChannels, channel, pathRoleChanges, change.ID)
+	return create(fs.ctx, fs.client, path, change)
+}
+
+func (fs *Firestore) RoleChanges(channel string) ([]*models.RoleChange, error) {
+	path := fmt.Sprintf("%s/%s/%s/%s", fs.root(), pathChannels, channel, pathRoleChanges)
+
+	criteria := QueryCriteria{
+		Path: path,
+		OrderBy: []OrderBy{
+			{Field: "created_at", Direction: firestore.Desc},
+		},
+	}
+
+	return query[models.RoleChange](fs.ctx, fs.client, criteria)
+}
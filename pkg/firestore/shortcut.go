@@ -6,21 +6,21 @@ import (
 )
 
 func (fs *Firestore) Shortcut(id string) (*models.Shortcut, error) {
-	path := fmt.Sprintf("%s/%s/%s/%s", pathAssistants, fs.cfg.IRC.Nick, pathShortcuts, id)
+	path := fmt.Sprintf("%s/%s/%s", fs.root(), pathShortcuts, id)
 	return get[models.Shortcut](fs.ctx, fs.client, path)
 }
 
 func (fs *Firestore) Shortcuts() ([]*models.Shortcut, error) {
-	path := fmt.Sprintf("%s/%s/%s", pathAssistants, fs.cfg.IRC.Nick, pathShortcuts)
+	path := fmt.Sprintf("%s/%s", fs.root(), pathShortcuts)
 	return list[models.Shortcut](fs.ctx, fs.client, path)
 }
 
 func (fs *Firestore) CreateShortcut(shortcut *models.Shortcut) error {
-	path := fmt.Sprintf("%s/%s/%s/%s", pathAssistants, fs.cfg.IRC.Nick, pathShortcuts, shortcut.ID)
+	path := fmt.Sprintf("%s/%s/%s", fs.root(), pathShortcuts, shortcut.ID)
 	return create(fs.ctx, fs.client, path, shortcut)
 }
 
 func (fs *Firestore) RemoveShortcut(id string) error {
-	path := fmt.Sprintf("%s/%s/%s/%s", pathAssistants, fs.cfg.IRC.Nick, pathShortcuts, id)
+	path := fmt.Sprintf("%s/%s/%s", fs.root(), pathShortcuts, id)
 	return remove(fs.ctx, fs.client, path)
 }
@@ -146,7 +146,7 @@ func (fs *Firestore) DeleteUnknownSource(domain string) error {
 }
 
 func (fs *Firestore) pathToUnknownSources() string {
-	return fmt.Sprintf("%s/%s/%s", pathAssistants, fs.cfg.IRC.Nick, pathUnknownSources)
+	return fmt.Sprintf("%s/%s", fs.root(), pathUnknownSources)
 }
 
 func (fs *Firestore) pathToUnknownSource(domain string) string {
@@ -193,7 +193,7 @@ func (fs *Firestore) FindSourcesByKeywords(input []string) ([]*models.Source, er
 }
 
 func (fs *Firestore) pathToSources() string {
-	return fmt.Sprintf("%s/%s/%s", pathAssistants, fs.cfg.IRC.Nick, pathSources)
+	return fmt.Sprintf("%s/%s", fs.root(), pathSources)
 }
 
 func (fs *Firestore) pathToSource(id string) string {
@@ -0,0 +1,68 @@
+package firestore
+
+import (
+	"assistant/pkg/models"
+	"fmt"
+	"slices"
+	"time"
+
+	"cloud.google.com/go/firestore"
+)
+
+const pathStrikes = "strikes"
+
+func (fs *Firestore) pathToStrikes(channel string) string {
+	return fmt.Sprintf("%s/%s/%s/%s", fs.root(), pathChannels, channel, pathStrikes)
+}
+
+func (fs *Firestore) Strikes(channel string) ([]*models.Strike, error) {
+	criteria := QueryCriteria{
+		Path: fs.pathToStrikes(channel),
+		OrderBy: []OrderBy{
+			{Field: "created_at", Direction: firestore.Desc},
+		},
+	}
+
+	return query[models.Strike](fs.ctx, fs.client, criteria)
+}
+
+// UserStrikes returns the active strikes recorded against the user, by account or host, newest first.
+func (fs *Firestore) UserStrikes(channel, nick, host, account string) ([]*models.Strike, error) {
+	// any strike recorded under the user's account, host or nick, narrowed down below, since which of them identifies
+	// the user depends on what each strike recorded
+	identities := []firestore.EntityFilter{createPropertyFilter("nick", Equal, nick)}
+	if len(host) > 0 {
+		identities = append(identities, createPropertyFilter("host", Equal, host))
+	}
+	if len(account) > 0 {
+		identities = append(identities, createPropertyFilter("account", Equal, account))
+	}
+
+	criteria := QueryCriteria{
+		Path: fs.pathToStrikes(channel),
+		Filter: firestore.AndFilter{
+			Filters: []firestore.EntityFilter{
+				createPropertyFilter("expires_at", GreaterThan, time.Now()),
+				firestore.OrFilter{Filters: identities},
+			},
+		},
+	}
+
+	strikes, err := query[models.Strike](fs.ctx, fs.client, criteria)
+	if err != nil {
+		return nil, err
+	}
+
+	// sorted here rather than in the query, which would need a composite index
+	strikes = slices.DeleteFunc(strikes, func(s *models.Strike) bool { return !s.IsFor(nick, host, account) })
+	slices.SortFunc(strikes, func(a, b *models.Strike) int { return b.CreatedAt.Compare(a.CreatedAt) })
+	return strikes, nil
+}
+
+func (fs *Firestore) AddStrike(channel string, strike *models.Strike) error {
+	return create(fs.ctx, fs.client, fmt.Sprintf("%s/%s", fs.pathToStrikes(channel), strike.ID), strike)
+}
+
+func (fs *Firestore) DeleteStrike(channel, id string) error {
+	return remove(fs.ctx, fs.client, fmt.Sprintf("%s/%s", fs.pathToStrikes(channel), id))
+}
@@ -2,27 +2,34 @@ package firestore
 
 import (
 	"assistant/pkg/api/irc"
-	"assistant/pkg/cloudtasks"
 	"assistant/pkg/log"
 	"assistant/pkg/models"
+	"assistant/pkg/scheduler"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"cloud.google.com/go/firestore"
 )
 
 func (fs *Firestore) Task(path string) (*models.Task, error) {
-	return get[models.Task](fs.ctx, fs.client, path)
+	task, err := get[models.Task](fs.ctx, fs.client, path)
+	if task != nil {
+		// tasks stored before networks were configured don't record one
+		task.Network = fs.cfg.IRC.Name
+	}
+	return task, err
 }
 
 func (fs *Firestore) SetTask(task *models.Task) error {
+	task.Network = fs.cfg.IRC.Name
 	return set(fs.ctx, fs.client, fs.TaskPath(task), task)
 }
 
 func (fs *Firestore) TaskPath(task *models.Task) string {
 	switch task.Type {
 	case models.TaskTypeReconnect:
-		return fmt.Sprintf("%s/%s/%s/%s", pathAssistants, fs.cfg.IRC.Nick, pathTasks, task.ID)
+		return fmt.Sprintf("%s/%s/%s", fs.root(), pathTasks, task.ID)
 	case models.TaskTypeReminder:
 		data := task.Data.(models.ReminderTaskData)
 		return fmt.Sprintf("%s/%s", fs.tasksPath(data.User, data.Destination, task.Type), task.ID)
@@ -35,7 +42,7 @@ func (fs *Firestore) TaskPath(task *models.Task) string {
 	case models.TaskTypeNotifyVoiceRequests:
 		data := task.Data.(models.NotifyVoiceRequestsTaskData)
 		return fmt.Sprintf("%s/%s", fs.tasksPath("", data.Channel, task.Type), task.ID)
-	case models.TaskTypePersistentChannel, models.TaskTypePersistentChannelStats:
+	case models.TaskTypePersistentChannel, models.TaskTypePersistentChannelStats, models.TaskTypePersistentChannelFeeds:
 		data := task.Data.(models.PersistentTaskData)
 		return fs.PersistentChannelTaskPath(data.Channel, task.ID)
 	case models.TaskTypeDisinformationMutePenaltyRemoval:
@@ -44,6 +51,9 @@ func (fs *Firestore) TaskPath(task *models.Task) string {
 	case models.TaskTypeDisinformationBanPenaltyRemoval:
 		data := task.Data.(models.DisinformationBanPenaltyRemovalTaskData)
 		return fmt.Sprintf("%s/%s", fs.tasksPath("", data.Channel, task.Type), task.ID)
+	case models.TaskTypePollClose:
+		data := task.Data.(models.PollCloseTaskData)
+		return fmt.Sprintf("%s/%s", fs.tasksPath("", data.Channel, task.Type), task.ID)
 	}
 	return "unknown"
 }
@@ -52,12 +62,12 @@ func (fs *Firestore) tasksPath(user, destination, taskType string) string {
 	switch taskType {
 	case models.TaskTypeReminder:
 		if !irc.IsChannel(destination) {
-			return fmt.Sprintf("%s/%s/%s/%s/%s", pathAssistants, fs.cfg.IRC.Nick, pathUsers, user, pathTasks)
+			return fmt.Sprintf("%s/%s/%s/%s", fs.root(), pathUsers, user, pathTasks)
 		} else {
-			return fmt.Sprintf("%s/%s/%s/%s/%s/%s/%s", pathAssistants, fs.cfg.IRC.Nick, pathChannels, destination, pathUsers, user, pathTasks)
+			return fmt.Sprintf("%s/%s/%s/%s/%s/%s", fs.root(), pathChannels, destination, pathUsers, user, pathTasks)
 		}
-	case models.TaskTypeBanRemoval, models.TaskTypeMuteRemoval, models.TaskTypeNotifyVoiceRequests, models.TaskTypeDisinformationMutePenaltyRemoval, models.TaskTypeDisinformationBanPenaltyRemoval:
-		return fmt.Sprintf("%s/%s/%s/%s/%s", pathAssistants, fs.cfg.IRC.Nick, pathChannels, destination, pathTasks)
+	case models.TaskTypeBanRemoval, models.TaskTypeMuteRemoval, models.TaskTypeNotifyVoiceRequests, models.TaskTypeDisinformationMutePenaltyRemoval, models.TaskTypeDisinformationBanPenaltyRemoval, models.TaskTypePollClose:
+		return fmt.Sprintf("%s/%s/%s/%s", fs.root(), pathChannels, destination, pathTasks)
 	default:
 		log.Logger().Errorf(nil, "can't create path for unknown task type: %s", taskType)
 		return "unknown"
@@ -67,6 +77,7 @@ func (fs *Firestore) tasksPath(user, destination, taskType string) string {
 func (fs *Firestore) AddTask(task *models.Task) error {
 	logger := log.Logger()
 
+	task.Network = fs.cfg.IRC.Name
 	path := fs.TaskPath(task)
 	logger.Debugf(nil, "creating task %s: %s", task.Type, path)
 
@@ -75,7 +86,7 @@ func (fs *Firestore) AddTask(task *models.Task) error {
 		return err
 	}
 
-	cloudTaskName, err := cloudtasks.Get().CreateTask(task)
+	cloudTaskName, err := scheduler.Get().CreateTask(task)
 	if err != nil {
 		logger.Errorf(nil, "error creating cloud task %s: %s", task.ID, err)
 		return err
@@ -96,7 +107,7 @@ func (fs *Firestore) CompleteTask(task *models.Task) error {
 	logger.Debugf(nil, "completing task %s: %s", task.ID, path)
 
 	if task.Status == models.TaskStatusCancelled && len(task.CloudTaskName) > 0 {
-		if err := cloudtasks.Get().DeleteTask(task.CloudTaskName); err != nil {
+		if err := scheduler.Get().DeleteTask(task.CloudTaskName); err != nil {
 			logger.Warningf(nil, "error deleting cloud task %s: %s", task.CloudTaskName, err)
 		}
 	}
@@ -104,6 +115,26 @@ func (fs *Firestore) CompleteTask(task *models.Task) error {
 	return update(fs.ctx, fs.client, path, map[string]interface{}{"status": task.Status, "runs": task.Runs})
 }
 
+// RescheduleTask replaces the scheduled run of an existing task with one at its current due time and stores the task.
+func (fs *Firestore) RescheduleTask(task *models.Task) error {
+	logger := log.Logger()
+
+	if len(task.CloudTaskName) > 0 {
+		if err := scheduler.Get().DeleteTask(task.CloudTaskName); err != nil {
+			logger.Warningf(nil, "error deleting cloud task %s: %s", task.CloudTaskName, err)
+		}
+	}
+
+	cloudTaskName, err := scheduler.Get().CreateTask(task)
+	if err != nil {
+		logger.Errorf(nil, "error creating cloud task %s: %s", task.ID, err)
+		return err
+	}
+
+	task.CloudTaskName = cloudTaskName
+	return fs.SetTask(task)
+}
+
 func (fs *Firestore) GetPendingTasks(user, destination, taskType string) ([]*models.Task, error) {
 	path := fs.tasksPath(user, destination, taskType)
 
@@ -131,6 +162,37 @@ func (fs *Firestore) GetPendingTasks(user, destination, taskType string) ([]*mod
 	return fs.populateTaskData(tasks)
 }
 
+// LastDeliveredReminder returns the user's reminder in the destination that was most recently delivered, if that was
+// after since.
+func (fs *Firestore) LastDeliveredReminder(user, destination string, since time.Time) (*models.Task, error) {
+	criteria := QueryCriteria{
+		Path:   fs.tasksPath(user, destination, models.TaskTypeReminder),
+		Filter: createPropertyFilter("data.delivered_at", GreaterThan, since),
+		OrderBy: []OrderBy{
+			{
+				Field:     "data.delivered_at",
+				Direction: firestore.Desc,
+			},
+		},
+		Limit: 1,
+	}
+
+	tasks, err := query[models.Task](fs.ctx, fs.client, criteria)
+	if err != nil {
+		return nil, err
+	}
+
+	if tasks, err = fs.populateTaskData(tasks); err != nil {
+		return nil, err
+	}
+
+	if len(tasks) == 0 {
+		return nil, nil
+	}
+
+	return tasks[0], nil
+}
+
 func (fs *Firestore) populateTaskData(tasks []*models.Task) ([]*models.Task, error) {
 	for _, task := range tasks {
 		d, err := json.Marshal(task.Data.(map[string]any))
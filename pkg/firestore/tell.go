@@ -0,0 +1,33 @@
+package firestore
+
+import (
+	"assistant/pkg/models"
+	"fmt"
+
+	"cloud.google.com/go/firestore"
+)
+
+const pathTells = "tells"
+
+func (fs *Firestore) Tells(channel string) ([]*models.Tell, error) {
+	path := fmt.Sprintf("%s/%s/%s/%s", fs.root(), pathChannels, channel, pathTells)
+
+	criteria := QueryCriteria{
+		Path: path,
+		OrderBy: []OrderBy{
+			{Field: "created_at", Direction: firestore.Asc},
+		},
+	}
+
+	return query[models.Tell](fs.ctx, fs.client, criteria)
+}
+
+func (fs *Firestore) CreateTell(channel string, tell *models.Tell) error {
+	path := fmt.Sprintf("%s/%s/%s/%s/%s", fs.root(), pathChannels, channel, pathTells, tell.ID)
+	return create(fs.ctx, fs.client, path, tell)
+}
+
+func (fs *Firestore) DeleteTell(channel, id string) error {
+	path := fmt.Sprintf("%s/%s/%s/%s/%s", fs.root(), pathChannels, channel, pathTells, id)
+	return remove(fs.ctx, fs.client, path)
+}
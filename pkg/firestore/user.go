@@ -22,7 +22,7 @@ func (fs *Firestore) GetUser(channel string, mask *irc.Mask) (*models.User, erro
 }
 
 func (fs *Firestore) GetAllMatchingUsers(channel string, mask *irc.Mask) ([]*models.User, error) {
-	path := fmt.Sprintf("%s/%s/%s/%s/%s", pathAssistants, fs.cfg.IRC.Nick, pathChannels, channel, pathUsers)
+	path := fmt.Sprintf("%s/%s/%s/%s", fs.root(), pathChannels, channel, pathUsers)
 
 	criteria := QueryCriteria{
 		Path: path,
@@ -46,7 +46,7 @@ func (fs *Firestore) GetAllMatchingUsers(channel string, mask *irc.Mask) ([]*mod
 }
 
 func (fs *Firestore) GetUsersByHost(channel, host string) ([]*models.User, error) {
-	path := fmt.Sprintf("%s/%s/%s/%s/%s", pathAssistants, fs.cfg.IRC.Nick, pathChannels, channel, pathUsers)
+	path := fmt.Sprintf("%s/%s/%s/%s", fs.root(), pathChannels, channel, pathUsers)
 
 	criteria := QueryCriteria{
 		Path: path,
@@ -61,7 +61,7 @@ func (fs *Firestore) GetUsersByHost(channel, host string) ([]*models.User, error
 }
 
 func (fs *Firestore) GetUsersByUserID(channel, userID string) ([]*models.User, error) {
-	path := fmt.Sprintf("%s/%s/%s/%s/%s", pathAssistants, fs.cfg.IRC.Nick, pathChannels, channel, pathUsers)
+	path := fmt.Sprintf("%s/%s/%s/%s", fs.root(), pathChannels, channel, pathUsers)
 
 	criteria := QueryCriteria{
 		Path: path,
@@ -75,8 +75,23 @@ func (fs *Firestore) GetUsersByUserID(channel, userID string) ([]*models.User, e
 	return query[models.User](fs.ctx, fs.client, criteria)
 }
 
+func (fs *Firestore) GetUsersByAccount(channel, account string) ([]*models.User, error) {
+	path := fmt.Sprintf("%s/%s/%s/%s", fs.root(), pathChannels, channel, pathUsers)
+
+	criteria := QueryCriteria{
+		Path: path,
+		Filter: firestore.PropertyFilter{
+			Path:     "account",
+			Operator: Equal,
+			Value:    account,
+		},
+	}
+
+	return query[models.User](fs.ctx, fs.client, criteria)
+}
+
 func (fs *Firestore) GetUsersByMask(channel, nick, userID, host string) ([]*models.User, error) {
-	path := fmt.Sprintf("%s/%s/%s/%s/%s", pathAssistants, fs.cfg.IRC.Nick, pathChannels, channel, pathUsers)
+	path := fmt.Sprintf("%s/%s/%s/%s", fs.root(), pathChannels, channel, pathUsers)
 
 	isWild := func(s string) bool { return s == "" || s == "*" || strings.Contains(s, "*") }
 
@@ -106,26 +121,26 @@ func (fs *Firestore) GetUsersByMask(channel, nick, userID, host string) ([]*mode
 }
 
 func (fs *Firestore) GetAllUsers(channel string) ([]*models.User, error) {
-	path := fmt.Sprintf("%s/%s/%s/%s/%s", pathAssistants, fs.cfg.IRC.Nick, pathChannels, channel, pathUsers)
+	path := fmt.Sprintf("%s/%s/%s/%s", fs.root(), pathChannels, channel, pathUsers)
 	return list[models.User](fs.ctx, fs.client, path)
 }
 
 func (fs *Firestore) GetUserByNick(channel, nick string) (*models.User, error) {
-	path := fmt.Sprintf("%s/%s/%s/%s/%s/%s", pathAssistants, fs.cfg.IRC.Nick, pathChannels, channel, pathUsers, nick)
+	path := fmt.Sprintf("%s/%s/%s/%s/%s", fs.root(), pathChannels, channel, pathUsers, nick)
 	return get[models.User](fs.ctx, fs.client, path)
 }
 
 func (fs *Firestore) CreateUser(channel string, user *models.User) error {
-	path := fmt.Sprintf("%s/%s/%s/%s/%s/%s", pathAssistants, fs.cfg.IRC.Nick, pathChannels, channel, pathUsers, user.Nick)
+	path := fmt.Sprintf("%s/%s/%s/%s/%s", fs.root(), pathChannels, channel, pathUsers, user.Nick)
 	return create(fs.ctx, fs.client, path, user)
 }
 
 func (fs *Firestore) SetUser(channel string, user *models.User) error {
-	path := fmt.Sprintf("%s/%s/%s/%s/%s/%s", pathAssistants, fs.cfg.IRC.Nick, pathChannels, channel, pathUsers, user.Nick)
+	path := fmt.Sprintf("%s/%s/%s/%s/%s", fs.root(), pathChannels, channel, pathUsers, user.Nick)
 	return set(fs.ctx, fs.client, path, user)
 }
 
 func (fs *Firestore) UpdateUser(channel string, user *models.User, fields map[string]any) error {
-	path := fmt.Sprintf("%s/%s/%s/%s/%s/%s", pathAssistants, fs.cfg.IRC.Nick, pathChannels, channel, pathUsers, user.Nick)
+	path := fmt.Sprintf("%s/%s/%s/%s/%s", fs.root(), pathChannels, channel, pathUsers, user.Nick)
 	return update(fs.ctx, fs.client, path, fields)
 }
@@ -0,0 +1,179 @@
+package localdb
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+	bolterrors "go.etcd.io/bbolt/errors"
+)
+
+const openTimeout = 5 * time.Second
+
+// ErrLocked is returned when a shared file stays locked by another process for longer than the open timeout.
+var ErrLocked = errors.New("database is locked by another process")
+
+var handlesMu sync.Mutex
+var handles = map[string]*bolt.DB{}
+
+// DB is a bbolt file. A DB from Open holds the file open for the life of the process. A DB from OpenShared opens it
+// for the duration of each transaction instead, so that the assistant, proxy and web processes on a single host can
+// share it. bbolt's file lock serializes writers across processes and the mutex avoids lock polling between goroutines
+// of the same process.
+type DB struct {
+	path   string
+	shared bool
+	mu     sync.RWMutex
+	held   *bolt.DB
+}
+
+// Open creates the file at path if needed, ensures the given buckets exist and keeps it open for the life of the
+// process. Other processes can't open the file while it's held, so only files used by a single process should be
+// opened this way. Opening the same path again in the process returns a DB sharing the same handle.
+func Open(path string, buckets ...[]byte) (*DB, error) {
+	if err := createDir(path); err != nil {
+		return nil, err
+	}
+
+	handlesMu.Lock()
+	defer handlesMu.Unlock()
+
+	b, ok := handles[path]
+	if !ok {
+		var err error
+		b, err = bolt.Open(path, 0o600, &bolt.Options{Timeout: openTimeout})
+		if err != nil {
+			return nil, openError(path, err)
+		}
+		handles[path] = b
+	}
+
+	db := &DB{path: path, held: b}
+	if err := db.createBuckets(buckets); err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// OpenShared creates the file at path if needed and ensures the given buckets exist. The file is opened for each
+// transaction and closed after it, so that processes on the same host can take turns using it. A transaction that
+// can't get the file lock within the open timeout fails with ErrLocked.
+func OpenShared(path string, buckets ...[]byte) (*DB, error) {
+	if err := createDir(path); err != nil {
+		return nil, err
+	}
+
+	db := &DB{path: path, shared: true}
+	if err := db.createBuckets(buckets); err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
+
+func createDir(path string) error {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("error creating directory %s, %s", dir, err)
+		}
+	}
+	return nil
+}
+
+func (db *DB) createBuckets(buckets [][]byte) error {
+	err := db.Update(func(tx *bolt.Tx) error {
+		for _, b := range buckets {
+			if _, err := tx.CreateBucketIfNotExists(b); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error initializing %s, %w", db.path, err)
+	}
+	return nil
+}
+
+func (db *DB) Path() string {
+	return db.path
+}
+
+// ModTime returns when the file was last written, which lets pollers skip opening a shared file that hasn't changed.
+func (db *DB) ModTime() (time.Time, error) {
+	info, err := os.Stat(db.path)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return info.ModTime(), nil
+}
+
+// Close releases a held file. It does nothing for shared files, which are closed after each transaction.
+func (db *DB) Close() error {
+	if db.shared {
+		return nil
+	}
+
+	handlesMu.Lock()
+	defer handlesMu.Unlock()
+
+	if handles[db.path] != db.held {
+		return nil
+	}
+	delete(handles, db.path)
+	return db.held.Close()
+}
+
+func (db *DB) View(fn func(tx *bolt.Tx) error) error {
+	if !db.shared {
+		return db.held.View(fn)
+	}
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	b, err := db.open(true)
+	if err != nil {
+		return err
+	}
+	defer b.Close()
+
+	return b.View(fn)
+}
+
+func (db *DB) Update(fn func(tx *bolt.Tx) error) error {
+	if !db.shared {
+		return db.held.Update(fn)
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	b, err := db.open(false)
+	if err != nil {
+		return err
+	}
+	defer b.Close()
+
+	return b.Update(fn)
+}
+
+func (db *DB) open(readOnly bool) (*bolt.DB, error) {
+	b, err := bolt.Open(db.path, 0o600, &bolt.Options{Timeout: openTimeout, ReadOnly: readOnly})
+	if err != nil {
+		return nil, openError(db.path, err)
+	}
+	return b, nil
+}
+
+func openError(path string, err error) error {
+	if errors.Is(err, bolterrors.ErrTimeout) {
+		return fmt.Errorf("error opening %s, %w", path, ErrLocked)
+	}
+	return fmt.Errorf("error opening %s, %s", path, err)
+}
@@ -0,0 +1,146 @@
+package log
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// InitializeConsoleLogger initializes a logger that only writes to stdout, for deployments without Google Cloud.
+func InitializeConsoleLogger() Log {
+	if logger != nil {
+		return logger
+	}
+
+	logger = &consoleLogger{}
+	return logger
+}
+
+type consoleLogger struct{}
+
+func (cl *consoleLogger) Close() error {
+	return nil
+}
+
+func (cl *consoleLogger) Log(l Labeler, message string, severity Severity) {
+	fmt.Printf("%s [%s] %s%s\n", timestamp(), severityMarker(severity), message, formatLabels(l))
+}
+
+func (cl *consoleLogger) Rawf(severity Severity, format string, args ...any) {
+	fmt.Printf("%s [ ] %s\n", timestamp(), fmt.Sprintf(format, args...))
+}
+
+func (cl *consoleLogger) Default(l Labeler, message any) {
+	cl.Defaultf(l, "%s", message)
+}
+
+func (cl *consoleLogger) Defaultf(l Labeler, format string, args ...any) {
+	cl.Log(l, fmt.Sprintf(format, args...), Default)
+}
+
+func (cl *consoleLogger) Debug(l Labeler, message any) {
+	cl.Debugf(l, "%s", message)
+}
+
+func (cl *consoleLogger) Debugf(l Labeler, format string, args ...any) {
+	cl.Log(l, fmt.Sprintf(format, args...), Debug)
+}
+
+func (cl *consoleLogger) Info(l Labeler, message any) {
+	cl.Infof(l, "%s", message)
+}
+
+func (cl *consoleLogger) Infof(l Labeler, format string, args ...any) {
+	cl.Log(l, fmt.Sprintf(format, args...), Info)
+}
+
+func (cl *consoleLogger) Notice(l Labeler, message any) {
+	cl.Noticef(l, "%s", message)
+}
+
+func (cl *consoleLogger) Noticef(l Labeler, format string, args ...any) {
+	cl.Log(l, fmt.Sprintf(format, args...), Notice)
+}
+
+func (cl *consoleLogger) Warning(l Labeler, message any) {
+	cl.Warningf(l, "%s", message)
+}
+
+func (cl *consoleLogger) Warningf(l Labeler, format string, args ...any) {
+	cl.Log(l, fmt.Sprintf(format, args...), Warning)
+}
+
+func (cl *consoleLogger) Error(l Labeler, message any) {
+	cl.Errorf(l, "%s", message)
+}
+
+func (cl *consoleLogger) Errorf(l Labeler, format string, args ...any) {
+	cl.Log(l, fmt.Sprintf(format, args...), Error)
+}
+
+func (cl *consoleLogger) Critical(l Labeler, message any) {
+	cl.Criticalf(l, "%s", message)
+}
+
+func (cl *consoleLogger) Criticalf(l Labeler, format string, args ...any) {
+	cl.Log(l, fmt.Sprintf(format, args...), Critical)
+}
+
+func (cl *consoleLogger) Alert(l Labeler, message any) {
+	cl.Alertf(l, "%s", message)
+}
+
+func (cl *consoleLogger) Alertf(l Labeler, format string, args ...any) {
+	cl.Log(l, fmt.Sprintf(format, args...), Alert)
+}
+
+func (cl *consoleLogger) Emergency(l Labeler, message any) {
+	cl.Emergencyf(l, "%s", message)
+}
+
+func (cl *consoleLogger) Emergencyf(l Labeler, format string, args ...any) {
+	cl.Log(l, fmt.Sprintf(format, args...), Emergency)
+}
+
+func severityMarker(severity Severity) string {
+	switch severity {
+	case Debug:
+		return "D"
+	case Info:
+		return "I"
+	case Notice:
+		return "N"
+	case Warning:
+		return "W"
+	case Error:
+		return "E"
+	case Critical:
+		return "X"
+	case Alert:
+		return "Y"
+	case Emergency:
+		return "Z"
+	default:
+		return "-"
+	}
+}
+
+func formatLabels(l Labeler) string {
+	labels := safeLabels(l)
+	if len(labels) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(labels))
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprintf("%s=%s", k, labels[k]))
+	}
+
+	return " {" + strings.Join(parts, ", ") + "}"
+}
@@ -0,0 +1,101 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const auditEntryIDPrefix = "audit"
+
+const (
+	AuditActionKick                = "kick"
+	AuditActionBan                 = "ban"
+	AuditActionUnban               = "unban"
+	AuditActionMute                = "mute"
+	AuditActionUnmute              = "unmute"
+	AuditActionWarn                = "warn"
+	AuditActionStrike              = "strike"
+	AuditActionPardon              = "pardon"
+	AuditActionAutoVoice           = "auto_voice"
+	AuditActionAutoVoiceRemove     = "auto_voice_remove"
+	AuditActionTopic               = "topic"
+	AuditActionBannedWordAdd       = "banned_word_add"
+	AuditActionBannedWordRemove    = "banned_word_remove"
+	AuditActionDisinfoSourceAdd    = "disinfo_source_add"
+	AuditActionDisinfoSourceRemove = "disinfo_source_remove"
+)
+
+var AuditActions = []string{
+	AuditActionKick, AuditActionBan, AuditActionUnban, AuditActionMute, AuditActionUnmute, AuditActionWarn,
+	AuditActionStrike, AuditActionPardon, AuditActionAutoVoice, AuditActionAutoVoiceRemove, AuditActionTopic,
+	AuditActionBannedWordAdd, AuditActionBannedWordRemove, AuditActionDisinfoSourceAdd, AuditActionDisinfoSourceRemove,
+}
+
+// Audit sources say how a moderation action came about: run as a command, from the dashboard, by one of the automatic
+// rules, or when a scheduled penalty ran out.
+const (
+	AuditSourceCommand        = "command"
+	AuditSourceDashboard      = "dashboard"
+	AuditSourceBannedWord     = "banned_word"
+	AuditSourceSpam           = "spam"
+	AuditSourceDisinformation = "disinformation"
+	AuditSourceStrikeLadder   = "strike_ladder"
+	AuditSourceSchedule       = "schedule"
+	AuditSourceEvasion        = "evasion"
+)
+
+var AuditSources = []string{
+	AuditSourceCommand, AuditSourceDashboard, AuditSourceBannedWord, AuditSourceSpam, AuditSourceDisinformation,
+	AuditSourceStrikeLadder, AuditSourceSchedule, AuditSourceEvasion,
+}
+
+// AuditEntry records a moderation action in a channel's audit log. Actor is the nick of whoever took the action, or
+// the bot's own nick for automatic ones. Target is the mask, nick, word or source the action applied to.
+type AuditEntry struct {
+	ID        string    `firestore:"id" json:"id"`
+	Action    string    `firestore:"action" json:"action"`
+	Actor     string    `firestore:"actor" json:"actor"`
+	Source    string    `firestore:"source" json:"source"`
+	Target    string    `firestore:"target" json:"target"`
+	Reason    string    `firestore:"reason" json:"reason"`
+	Duration  string    `firestore:"duration" json:"duration"`
+	CreatedAt time.Time `firestore:"created_at" json:"created_at"`
+}
+
+func NewAuditEntry(action, actor, source, target, reason, duration string) *AuditEntry {
+	return &AuditEntry{
+		ID:        fmt.Sprintf("%s-%s", auditEntryIDPrefix, uuid.NewString()),
+		Action:    action,
+		Actor:     actor,
+		Source:    source,
+		Target:    target,
+		Reason:    reason,
+		Duration:  duration,
+		CreatedAt: time.Now(),
+	}
+}
+
+// Matches returns whether the query appears, ignoring case, in the entry's actor, target or reason.
+func (a *AuditEntry) Matches(query string) bool {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if len(query) == 0 {
+		return true
+	}
+	for _, field := range []string{a.Actor, a.Target, a.Reason} {
+		if strings.Contains(strings.ToLower(field), query) {
+			return true
+		}
+	}
+	return false
+}
+
+// AuditTarget returns the mask for a user in the audit log, or just their nick if their host isn't known.
+func AuditTarget(nick, host string) string {
+	if len(host) == 0 {
+		return nick
+	}
+	return fmt.Sprintf("%s!*@%s", nick, host)
+}
@@ -0,0 +1,35 @@
+package models
+
+import "testing"
+
+func TestAuditEntryMatches(t *testing.T) {
+	entry := NewAuditEntry(AuditActionBan, "Alice", AuditSourceCommand, "*!*@spam.example.com", "Posting invite links", "1h")
+
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{"", true},
+		{"alice", true},
+		{"SPAM.example", true},
+		{"invite", true},
+		{"  invite ", true},
+		{"1h", false},
+		{"bob", false},
+	}
+
+	for _, tt := range tests {
+		if got := entry.Matches(tt.query); got != tt.want {
+			t.Errorf("Matches(%q) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestAuditTarget(t *testing.T) {
+	if got := AuditTarget("nick", "host.example.com"); got != "nick!*@host.example.com" {
+		t.Errorf("AuditTarget() = %q", got)
+	}
+	if got := AuditTarget("nick", ""); got != "nick" {
+		t.Errorf("AuditTarget() without a host = %q", got)
+	}
+}
@@ -11,6 +11,7 @@ const AuthTokenExpiry = 5 * time.Minute
 
 type AuthToken struct {
 	Token     string    `firestore:"token"`
+	Network   string    `firestore:"network"`
 	Nick      string    `firestore:"nick"`
 	Channel   string    `firestore:"channel"`
 	Used      bool      `firestore:"used"`
@@ -18,7 +19,7 @@ type AuthToken struct {
 	ExpiresAt time.Time `firestore:"expires_at"`
 }
 
-func NewAuthToken(nick, channel string) (*AuthToken, error) {
+func NewAuthToken(network, nick, channel string) (*AuthToken, error) {
 	b := make([]byte, AuthTokenLength)
 	if _, err := rand.Read(b); err != nil {
 		return nil, err
@@ -27,6 +28,7 @@ func NewAuthToken(nick, channel string) (*AuthToken, error) {
 	now := time.Now()
 	return &AuthToken{
 		Token:     hex.EncodeToString(b),
+		Network:   network,
 		Nick:      nick,
 		Channel:   channel,
 		Used:      false,
@@ -1,8 +1,113 @@
 package models
 
+import (
+	"assistant/pkg/api/elapse"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
 const PrefixBannedWord = "banned-word"
 
+// Banned word kinds. Words and phrases match whole tokens, fuzzy words also match close misspellings, and regexes
+// match anywhere in the message. All of them are matched against messages with confusable letters, leetspeak and
+// separator padding normalized away.
+const (
+	BannedWordKindWord   = "word"
+	BannedWordKindPhrase = "phrase"
+	BannedWordKindRegex  = "regex"
+	BannedWordKindFuzzy  = "fuzzy"
+)
+
+var BannedWordKinds = []string{BannedWordKindWord, BannedWordKindPhrase, BannedWordKindRegex, BannedWordKindFuzzy}
+
+// MaxBannedPatternLength bounds regexes, which are compiled for every channel they're banned in.
+const MaxBannedPatternLength = 256
+
+// BannedWord is a word, phrase or pattern banned in a channel. Action is what happens to users whose messages match,
+// with Duration applying to mutes and bans. In channels with a strike ladder, it's the least that happens, and the
+// ladder can go further. In a dry run, matches are only reported to the channel's ops.
 type BannedWord struct {
-	ID   string `firestore:"id"`
-	Word string `firestore:"word"`
+	ID       string `firestore:"id" json:"id"`
+	Word     string `firestore:"word" json:"word"`
+	Kind     string `firestore:"kind" json:"kind"`
+	Action   string `firestore:"action" json:"action"`
+	Duration string `firestore:"duration" json:"duration"`
+	DryRun   bool   `firestore:"dry_run" json:"dry_run"`
+}
+
+// NewBannedWord returns a banned word of the kind its text implies, that kicks users who say it.
+func NewBannedWord(word string) *BannedWord {
+	bw := &BannedWord{Word: word, Action: ModerationActionKick}
+	bw.Kind = bw.MatchKind()
+	bw.Normalize()
+	return bw
+}
+
+// MatchKind returns the entry's kind. Entries stored before kinds existed are words or phrases, by their text.
+func (bw *BannedWord) MatchKind() string {
+	if len(bw.Kind) > 0 {
+		return bw.Kind
+	}
+	if len(strings.Fields(bw.Word)) > 1 {
+		return BannedWordKindPhrase
+	}
+	return BannedWordKindWord
+}
+
+// ModerationAction returns the entry's action, kicking for entries stored before actions existed.
+func (bw *BannedWord) ModerationAction() string {
+	if len(bw.Action) > 0 {
+		return bw.Action
+	}
+	return ModerationActionKick
+}
+
+// Normalize lowercases everything but regexes, whose escapes are case-sensitive. Matching ignores case regardless.
+func (bw *BannedWord) Normalize() {
+	bw.Word = strings.TrimSpace(bw.Word)
+	if bw.MatchKind() != BannedWordKindRegex {
+		bw.Word = strings.ToLower(bw.Word)
+	}
+}
+
+// Settings describes how the entry is matched and acted on, such as "regex, mute 10m, dry run".
+func (bw *BannedWord) Settings() string {
+	settings := fmt.Sprintf("%s, %s", bw.MatchKind(), bw.ModerationAction())
+	if len(bw.Duration) > 0 {
+		settings += " " + bw.Duration
+	}
+	if bw.DryRun {
+		settings += ", dry run"
+	}
+	return settings
+}
+
+func (bw *BannedWord) Validate() error {
+	if len(bw.Word) == 0 {
+		return fmt.Errorf("missing word")
+	}
+	kind := bw.MatchKind()
+	if !slices.Contains(BannedWordKinds, kind) {
+		return fmt.Errorf("invalid kind, %s", kind)
+	}
+	if (kind == BannedWordKindWord || kind == BannedWordKindFuzzy) && len(strings.Fields(bw.Word)) > 1 {
+		return fmt.Errorf("a %s can't contain spaces, use a phrase instead", kind)
+	}
+	if kind == BannedWordKindRegex {
+		if len(bw.Word) > MaxBannedPatternLength {
+			return fmt.Errorf("regex can be at most %d characters", MaxBannedPatternLength)
+		}
+		if _, err := regexp.Compile(bw.Word); err != nil {
+			return fmt.Errorf("invalid regex, %s", err)
+		}
+	}
+	if !slices.Contains(ModerationActions, bw.ModerationAction()) {
+		return fmt.Errorf("invalid action, %s", bw.Action)
+	}
+	if len(bw.Duration) > 0 && !elapse.IsDuration(bw.Duration) {
+		return fmt.Errorf("invalid duration, %s", bw.Duration)
+	}
+	return nil
 }
@@ -0,0 +1,57 @@
+package models
+
+import "testing"
+
+func TestNewBannedWord(t *testing.T) {
+	bw := NewBannedWord("  Grapefruit ")
+	if bw.Word != "grapefruit" || bw.Kind != BannedWordKindWord || bw.Action != ModerationActionKick {
+		t.Errorf("NewBannedWord() = %+v, want a kicking word", bw)
+	}
+
+	if bw := NewBannedWord("Go Away"); bw.Kind != BannedWordKindPhrase || bw.Word != "go away" {
+		t.Errorf("NewBannedWord() = %+v, want a phrase", bw)
+	}
+}
+
+func TestBannedWordDefaults(t *testing.T) {
+	// entries stored before kinds and actions existed
+	bw := &BannedWord{Word: "word"}
+	if bw.MatchKind() != BannedWordKindWord || bw.ModerationAction() != ModerationActionKick {
+		t.Errorf("legacy entry = %s, %s, want a kicking word", bw.MatchKind(), bw.ModerationAction())
+	}
+
+	regex := &BannedWord{Word: ` \D+ `, Kind: BannedWordKindRegex}
+	regex.Normalize()
+	if regex.Word != `\D+` {
+		t.Errorf("Normalize() = %q, want the regex's case kept", regex.Word)
+	}
+}
+
+func TestBannedWordValidate(t *testing.T) {
+	valid := []BannedWord{
+		{Word: "word"},
+		{Word: "a phrase", Kind: BannedWordKindPhrase, Action: ModerationActionWarn},
+		{Word: `fr[e3]{2}`, Kind: BannedWordKindRegex, Action: ModerationActionMute, Duration: "10m", DryRun: true},
+		{Word: "fuzzy", Kind: BannedWordKindFuzzy, Action: ModerationActionBan},
+	}
+	for _, bw := range valid {
+		if err := bw.Validate(); err != nil {
+			t.Errorf("Validate(%+v) = %v, want nil", bw, err)
+		}
+	}
+
+	invalid := []BannedWord{
+		{},
+		{Word: "word", Kind: "glob"},
+		{Word: "two words", Kind: BannedWordKindWord},
+		{Word: "two words", Kind: BannedWordKindFuzzy},
+		{Word: "(", Kind: BannedWordKindRegex},
+		{Word: "word", Action: "shame"},
+		{Word: "word", Action: ModerationActionMute, Duration: "soon"},
+	}
+	for _, bw := range invalid {
+		if err := bw.Validate(); err == nil {
+			t.Errorf("Validate(%+v) = nil, want an error", bw)
+		}
+	}
+}
@@ -13,6 +13,14 @@ type Channel struct {
 	IntroMessages             []string                   `firestore:"intro_messages" json:"intro_messages"`
 	VoiceRequestNotifications []VoiceRequestNotification `firestore:"voice_request_notifications" json:"voice_request_notifications"`
 	InactivityDuration        string                     `firestore:"inactivity_duration" json:"inactivity_duration"`
+	Roles                     []ChannelRole              `firestore:"roles" json:"roles"`
+	CommandLimits             []CommandLimit             `firestore:"command_limits" json:"command_limits"`
+	CustomCommands            []CustomCommand            `firestore:"custom_commands" json:"custom_commands"`
+	SpamRules                 []SpamRule                 `firestore:"spam_rules" json:"spam_rules"`
+	StrikeLadder              []StrikeStep               `firestore:"strike_ladder" json:"strike_ladder"`
+	StrikeExpiry              string                     `firestore:"strike_expiry" json:"strike_expiry"`
+	EvasionDetection          EvasionDetection           `firestore:"evasion_detection" json:"evasion_detection"`
+	OpsChannel                string                     `firestore:"ops_channel" json:"ops_channel"`
 	CreatedAt                 time.Time                  `firestore:"created_at" json:"created_at"`
 	UpdatedAt                 time.Time                  `firestore:"updated_at" json:"updated_at"`
 }
@@ -44,6 +52,10 @@ func NewChannel(name string, inactivityDuration string) *Channel {
 		Name:               name,
 		AutoVoiced:         []string{},
 		DisabledCommands:   make([]string, 0),
+		CommandLimits:      make([]CommandLimit, 0),
+		CustomCommands:     make([]CustomCommand, 0),
+		SpamRules:          make([]SpamRule, 0),
+		StrikeLadder:       make([]StrikeStep, 0),
 		InactivityDuration: inactivityDuration,
 		CreatedAt:          time.Now(),
 		UpdatedAt:          time.Now(),
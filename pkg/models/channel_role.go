@@ -0,0 +1,267 @@
+package models
+
+import (
+	"assistant/pkg/api/irc"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ChannelRoleOwner     = "owner"
+	ChannelRoleModerator = "moderator"
+	ChannelRoleTrusted   = "trusted"
+	ChannelRoleRegular   = "regular"
+)
+
+// BuiltInChannelRoles are the roles every channel has, from most to least privileged. Channels can add roles of their
+// own alongside them.
+var BuiltInChannelRoles = []string{ChannelRoleOwner, ChannelRoleModerator, ChannelRoleTrusted, ChannelRoleRegular}
+
+// AccountMemberPrefix marks a role member that's a services account rather than a host mask, as in the $a: extban.
+const AccountMemberPrefix = "$a:"
+
+const roleChangeIDPrefix = "role-change"
+
+const (
+	RoleChangeCreate   = "create"
+	RoleChangeDelete   = "delete"
+	RoleChangeAssign   = "assign"
+	RoleChangeUnassign = "unassign"
+	RoleChangeGrant    = "grant"
+	RoleChangeRevoke   = "revoke"
+	RoleChangeReset    = "reset"
+)
+
+// ChannelRole is a role in a channel, its members, and the commands it's been granted or had revoked. Commands that are
+// neither fall back to channel status and the configured owner and admins.
+type ChannelRole struct {
+	Name    string   `firestore:"name" json:"name"`
+	Members []string `firestore:"members" json:"members"`
+	Granted []string `firestore:"granted" json:"granted"`
+	Revoked []string `firestore:"revoked" json:"revoked"`
+}
+
+func NewChannelRole(name string) ChannelRole {
+	return ChannelRole{
+		Name:    name,
+		Members: make([]string, 0),
+		Granted: make([]string, 0),
+		Revoked: make([]string, 0),
+	}
+}
+
+func (r ChannelRole) IsBuiltIn() bool {
+	return slices.Contains(BuiltInChannelRoles, r.Name)
+}
+
+// HasMember returns whether the user with the nick!user@host mask or services account is a member of the role. Account
+// members only match the account and host mask members match the mask. Bare nicks, which anyone can take, never match.
+func (r ChannelRole) HasMember(mask, account string) bool {
+	for _, m := range r.Members {
+		if a, ok := strings.CutPrefix(m, AccountMemberPrefix); ok {
+			if len(account) > 0 && strings.EqualFold(a, account) {
+				return true
+			}
+		} else if IsRoleMemberMask(m) && IsRoleMemberMask(mask) && irc.MatchWildcard(m, mask) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsRoleMemberMask returns whether the member is a nick!user@host mask rather than a services account or a bare nick.
+func IsRoleMemberMask(member string) bool {
+	return strings.Contains(member, "!") && strings.Contains(member, "@") && irc.ParseMask(member) != nil
+}
+
+// IsValidRoleMember returns whether the member can be stored in a role: a services account or a host mask.
+func IsValidRoleMember(member string) bool {
+	if a, ok := strings.CutPrefix(member, AccountMemberPrefix); ok {
+		return len(a) > 0
+	}
+	return IsRoleMemberMask(member)
+}
+
+// RoleChange records a change to a channel's roles.
+type RoleChange struct {
+	ID        string    `firestore:"id" json:"id"`
+	Actor     string    `firestore:"actor" json:"actor"`
+	Action    string    `firestore:"action" json:"action"`
+	Role      string    `firestore:"role" json:"role"`
+	Subject   string    `firestore:"subject" json:"subject"`
+	CreatedAt time.Time `firestore:"created_at" json:"created_at"`
+}
+
+func NewRoleChange(actor, action, role, subject string) *RoleChange {
+	return &RoleChange{
+		ID:        fmt.Sprintf("%s-%s", roleChangeIDPrefix, uuid.NewString()),
+		Actor:     actor,
+		Action:    action,
+		Role:      role,
+		Subject:   subject,
+		CreatedAt: time.Now(),
+	}
+}
+
+// AllRoles returns the channel's roles, built-in roles first and in order of privilege, then custom roles by name.
+// Built-in roles that haven't been changed yet are included empty.
+func (ch *Channel) AllRoles() []ChannelRole {
+	roles := make([]ChannelRole, 0, len(BuiltInChannelRoles)+len(ch.Roles))
+	for _, name := range BuiltInChannelRoles {
+		if r := ch.Role(name); r != nil {
+			roles = append(roles, *r)
+		} else {
+			roles = append(roles, NewChannelRole(name))
+		}
+	}
+
+	custom := make([]ChannelRole, 0)
+	for _, r := range ch.Roles {
+		if !r.IsBuiltIn() {
+			custom = append(custom, r)
+		}
+	}
+	slices.SortFunc(custom, func(a, b ChannelRole) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return append(roles, custom...)
+}
+
+// Role returns the stored role with the name, or nil if the channel has none.
+func (ch *Channel) Role(name string) *ChannelRole {
+	for i := range ch.Roles {
+		if strings.EqualFold(ch.Roles[i].Name, name) {
+			return &ch.Roles[i]
+		}
+	}
+	return nil
+}
+
+// HasRole returns whether the role is built in or has been created in the channel.
+func (ch *Channel) HasRole(name string) bool {
+	return slices.Contains(BuiltInChannelRoles, strings.ToLower(name)) || ch.Role(name) != nil
+}
+
+// RolesFor returns the roles the user with the host mask or services account is a member of.
+func (ch *Channel) RolesFor(mask, account string) []ChannelRole {
+	roles := make([]ChannelRole, 0)
+	for _, r := range ch.Roles {
+		if r.HasMember(mask, account) {
+			roles = append(roles, r)
+		}
+	}
+	return roles
+}
+
+// CommandPermission decides whether the user with the host mask or services account may use the command from the roles
+// it holds. Owners may use every command the matrix applies to, and a grant in any role wins over a revoke in another.
+// When none of the roles says anything about the command, decided is false and the caller falls back to its other
+// checks.
+func (ch *Channel) CommandPermission(mask, account, command string) (granted, decided bool) {
+	for _, r := range ch.RolesFor(mask, account) {
+		if r.Name == ChannelRoleOwner || slices.Contains(r.Granted, command) {
+			return true, true
+		}
+		if slices.Contains(r.Revoked, command) {
+			decided = true
+		}
+	}
+	return false, decided
+}
+
+// CreateRole adds a custom role. It returns false if a role with the name already exists.
+func (ch *Channel) CreateRole(name string) bool {
+	if ch.HasRole(name) {
+		return false
+	}
+	ch.Roles = append(ch.Roles, NewChannelRole(strings.ToLower(name)))
+	return true
+}
+
+// DeleteRole removes a custom role. Built-in roles can't be deleted.
+func (ch *Channel) DeleteRole(name string) bool {
+	r := ch.Role(name)
+	if r == nil || r.IsBuiltIn() {
+		return false
+	}
+	ch.Roles = slices.DeleteFunc(ch.Roles, func(r ChannelRole) bool {
+		return strings.EqualFold(r.Name, name)
+	})
+	return true
+}
+
+// AssignRole adds a member, a host mask or an AccountMemberPrefix account, to a role. It returns false if the member
+// isn't one of those, the role doesn't exist or the member already has it.
+func (ch *Channel) AssignRole(name, member string) bool {
+	if !IsValidRoleMember(member) {
+		return false
+	}
+	r := ch.editableRole(name)
+	if r == nil || slices.ContainsFunc(r.Members, func(m string) bool { return strings.EqualFold(m, member) }) {
+		return false
+	}
+	r.Members = append(r.Members, member)
+	return true
+}
+
+// UnassignRole removes a member from a role. It returns false if the member didn't have it.
+func (ch *Channel) UnassignRole(name, member string) bool {
+	r := ch.Role(name)
+	if r == nil {
+		return false
+	}
+	n := len(r.Members)
+	r.Members = slices.DeleteFunc(r.Members, func(m string) bool { return strings.EqualFold(m, member) })
+	return len(r.Members) < n
+}
+
+// GrantCommand grants a command to a role, replacing a revoke of it.
+func (ch *Channel) GrantCommand(name, command string) bool {
+	r := ch.editableRole(name)
+	if r == nil || slices.Contains(r.Granted, command) {
+		return false
+	}
+	r.Revoked = slices.DeleteFunc(r.Revoked, func(c string) bool { return c == command })
+	r.Granted = append(r.Granted, command)
+	return true
+}
+
+// RevokeCommand revokes a command from a role, replacing a grant of it.
+func (ch *Channel) RevokeCommand(name, command string) bool {
+	r := ch.editableRole(name)
+	if r == nil || slices.Contains(r.Revoked, command) {
+		return false
+	}
+	r.Granted = slices.DeleteFunc(r.Granted, func(c string) bool { return c == command })
+	r.Revoked = append(r.Revoked, command)
+	return true
+}
+
+// ResetCommand clears a role's grant or revoke of a command, so it falls back to the default checks again.
+func (ch *Channel) ResetCommand(name, command string) bool {
+	r := ch.Role(name)
+	if r == nil {
+		return false
+	}
+	n := len(r.Granted) + len(r.Revoked)
+	r.Granted = slices.DeleteFunc(r.Granted, func(c string) bool { return c == command })
+	r.Revoked = slices.DeleteFunc(r.Revoked, func(c string) bool { return c == command })
+	return len(r.Granted)+len(r.Revoked) < n
+}
+
+// editableRole returns the stored role with the name, storing a built-in role the first time it's changed.
+func (ch *Channel) editableRole(name string) *ChannelRole {
+	if r := ch.Role(name); r != nil {
+		return r
+	}
+	if !slices.Contains(BuiltInChannelRoles, strings.ToLower(name)) {
+		return nil
+	}
+	ch.Roles = append(ch.Roles, NewChannelRole(strings.ToLower(name)))
+	return &ch.Roles[len(ch.Roles)-1]
+}
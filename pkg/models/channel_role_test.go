@@ -0,0 +1,74 @@
+package models
+
+import "testing"
+
+func TestChannelCommandPermission(t *testing.T) {
+	ch := NewChannel("#channel", "")
+	ch.AssignRole(ChannelRoleOwner, "*!*@boss.example")
+	ch.AssignRole(ChannelRoleTrusted, AccountMemberPrefix+"alice")
+	ch.AssignRole(ChannelRoleRegular, "*!bob@*.example")
+	ch.AssignRole(ChannelRoleRegular, AccountMemberPrefix+"alice")
+	ch.GrantCommand(ChannelRoleTrusted, "kick")
+	ch.RevokeCommand(ChannelRoleRegular, "kick")
+
+	tests := []struct {
+		name          string
+		mask, account string
+		granted       bool
+		decided       bool
+	}{
+		{"owner may use everything", "Boss!boss@BOSS.example", "", true, true},
+		{"grant wins over revoke", "alice2!a@host", "alice", true, true},
+		{"account member needs the account", "alice!alice@host", "", false, false},
+		{"revoked", "bob!bob@home.example", "", false, true},
+		{"mask member needs the mask", "boss!boss@elsewhere", "", false, false},
+		{"no roles", "carol!carol@host", "", false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			granted, decided := ch.CommandPermission(tt.mask, tt.account, "kick")
+			if granted != tt.granted || decided != tt.decided {
+				t.Fatalf("CommandPermission() = %v, %v, want %v, %v", granted, decided, tt.granted, tt.decided)
+			}
+		})
+	}
+}
+
+func TestChannelRoleChanges(t *testing.T) {
+	ch := NewChannel("#channel", "")
+
+	if ch.CreateRole(ChannelRoleModerator) {
+		t.Fatalf("created a role with a built-in name")
+	}
+	if !ch.CreateRole("Helpers") || ch.CreateRole("helpers") {
+		t.Fatalf("expected to create helpers once")
+	}
+	if ch.DeleteRole(ChannelRoleModerator) {
+		t.Fatalf("deleted a built-in role")
+	}
+	if ch.AssignRole("unknown", "*!*@host") {
+		t.Fatalf("assigned a role that doesn't exist")
+	}
+	if ch.AssignRole(ChannelRoleRegular, "bob") {
+		t.Fatalf("assigned a role to a bare nick")
+	}
+
+	ch.GrantCommand("helpers", "echo")
+	ch.RevokeCommand("helpers", "echo")
+	r := ch.Role("helpers")
+	if len(r.Granted) != 0 || len(r.Revoked) != 1 {
+		t.Fatalf("revoke didn't replace grant: %+v", r)
+	}
+	if !ch.ResetCommand("helpers", "echo") || len(ch.Role("helpers").Revoked) != 0 {
+		t.Fatalf("reset didn't clear revoke")
+	}
+
+	roles := ch.AllRoles()
+	if len(roles) != len(BuiltInChannelRoles)+1 || roles[0].Name != ChannelRoleOwner || roles[len(roles)-1].Name != "helpers" {
+		t.Fatalf("AllRoles() = %+v", roles)
+	}
+
+	if !ch.DeleteRole("helpers") || ch.HasRole("helpers") {
+		t.Fatalf("expected to delete helpers")
+	}
+}
@@ -1,12 +1,24 @@
 package models
 
 type CommandInfo struct {
-	Name         string   `json:"name"`
-	Description  string   `json:"description"`
-	Triggers     []string `json:"triggers"`
-	Usages       []string `json:"usages"`
-	RequiresAuth bool     `json:"requires_auth"`
-	AllowDM      bool     `json:"allow_dm"`
+	Name         string            `json:"name"`
+	Description  string            `json:"description"`
+	Triggers     []string          `json:"triggers"`
+	Usages       []string          `json:"usages"`
+	Arguments    []CommandArgument `json:"arguments,omitempty"`
+	RequiresAuth bool              `json:"requires_auth"`
+	AllowDM      bool              `json:"allow_dm"`
+}
+
+// CommandArgument describes a positional argument or --flag of a command, or of one of its subcommands.
+type CommandArgument struct {
+	Name       string   `json:"name"`
+	Type       string   `json:"type"`
+	Optional   bool     `json:"optional"`
+	Default    string   `json:"default,omitempty"`
+	Choices    []string `json:"choices,omitempty"`
+	Flag       bool     `json:"flag,omitempty"`
+	Subcommand string   `json:"subcommand,omitempty"`
 }
 
 type CommandUsage struct {
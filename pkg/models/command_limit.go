@@ -0,0 +1,88 @@
+package models
+
+import (
+	"assistant/pkg/api/elapse"
+	"fmt"
+	"slices"
+	"time"
+)
+
+const (
+	CommandLimitScopeUser    = "user"
+	CommandLimitScopeChannel = "channel"
+)
+
+// CommandLimit throttles a command in a channel, either for each user or for the channel as a whole. Cooldown is the
+// least time between uses, and Quota the most uses in any rolling Window. Either can be left unset.
+type CommandLimit struct {
+	Command  string `firestore:"command" json:"command"`
+	Scope    string `firestore:"scope" json:"scope"`
+	Cooldown string `firestore:"cooldown" json:"cooldown"`
+	Quota    int    `firestore:"quota" json:"quota"`
+	Window   string `firestore:"window" json:"window"`
+}
+
+func (l CommandLimit) Validate() error {
+	if len(l.Command) == 0 {
+		return fmt.Errorf("command is required")
+	}
+	if l.Scope != CommandLimitScopeUser && l.Scope != CommandLimitScopeChannel {
+		return fmt.Errorf("invalid scope, %s", l.Scope)
+	}
+	if len(l.Cooldown) > 0 && !elapse.IsDuration(l.Cooldown) {
+		return fmt.Errorf("invalid cooldown, %s", l.Cooldown)
+	}
+	if l.Quota < 0 {
+		return fmt.Errorf("invalid quota, %d", l.Quota)
+	}
+	if l.Quota > 0 && !elapse.IsDuration(l.Window) {
+		return fmt.Errorf("invalid window, %s", l.Window)
+	}
+	if len(l.Cooldown) == 0 && l.Quota == 0 {
+		return fmt.Errorf("a cooldown or quota is required")
+	}
+	return nil
+}
+
+func (l CommandLimit) CooldownDuration() time.Duration {
+	d, _ := elapse.ParseDuration(l.Cooldown)
+	return d
+}
+
+func (l CommandLimit) WindowDuration() time.Duration {
+	if l.Quota == 0 {
+		return 0
+	}
+	d, _ := elapse.ParseDuration(l.Window)
+	return d
+}
+
+// CommandLimitsFor returns the limits on the command, at most one for each scope.
+func (ch *Channel) CommandLimitsFor(command string) []CommandLimit {
+	limits := make([]CommandLimit, 0)
+	for _, l := range ch.CommandLimits {
+		if l.Command == command {
+			limits = append(limits, l)
+		}
+	}
+	return limits
+}
+
+// SetCommandLimit adds the limit, replacing any on the same command and scope.
+func (ch *Channel) SetCommandLimit(limit CommandLimit) {
+	for i, l := range ch.CommandLimits {
+		if l.Command == limit.Command && l.Scope == limit.Scope {
+			ch.CommandLimits[i] = limit
+			return
+		}
+	}
+	ch.CommandLimits = append(ch.CommandLimits, limit)
+}
+
+func (ch *Channel) RemoveCommandLimit(command, scope string) bool {
+	n := len(ch.CommandLimits)
+	ch.CommandLimits = slices.DeleteFunc(ch.CommandLimits, func(l CommandLimit) bool {
+		return l.Command == command && l.Scope == scope
+	})
+	return len(ch.CommandLimits) < n
+}
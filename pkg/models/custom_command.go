@@ -0,0 +1,114 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// CustomCommandTypeAlias expands to another command line, such as !ukraine, with any arguments appended.
+	CustomCommandTypeAlias = "alias"
+	// CustomCommandTypeReddit searches a subreddit for a recent post on the topic given as arguments.
+	CustomCommandTypeReddit = "reddit"
+	// CustomCommandTypeReply replies with a fixed message.
+	CustomCommandTypeReply = "reply"
+)
+
+var customCommandTriggerRegexp = regexp.MustCompile(`^[a-z0-9_-]{1,24}$`)
+var subredditRegexp = regexp.MustCompile(`^[A-Za-z0-9_]{2,21}$`)
+var customCommandPlaceholderRegexp = regexp.MustCompile(`\$(nick|\*|[1-9])`)
+
+// CustomCommand is an alias or macro defined for a channel. Alias and reply values can be parameterized with $nick,
+// $1 to $9 for single arguments, and $* for all of them.
+type CustomCommand struct {
+	Trigger   string    `firestore:"trigger" json:"trigger"`
+	Type      string    `firestore:"type" json:"type"`
+	Value     string    `firestore:"value" json:"value"`
+	CreatedBy string    `firestore:"created_by" json:"created_by"`
+	CreatedAt time.Time `firestore:"created_at" json:"created_at"`
+}
+
+func NewCustomCommand(trigger, commandType, value, createdBy string) CustomCommand {
+	return CustomCommand{
+		Trigger:   strings.ToLower(trigger),
+		Type:      commandType,
+		Value:     strings.TrimSpace(value),
+		CreatedBy: createdBy,
+		CreatedAt: time.Now(),
+	}
+}
+
+func (cc CustomCommand) Validate() error {
+	if !customCommandTriggerRegexp.MatchString(cc.Trigger) {
+		return fmt.Errorf("invalid trigger, %s", cc.Trigger)
+	}
+	if len(cc.Value) == 0 {
+		return fmt.Errorf("a value is required")
+	}
+
+	switch cc.Type {
+	case CustomCommandTypeAlias, CustomCommandTypeReply:
+	case CustomCommandTypeReddit:
+		if !subredditRegexp.MatchString(cc.Value) {
+			return fmt.Errorf("invalid subreddit, %s", cc.Value)
+		}
+	default:
+		return fmt.Errorf("invalid type, %s", cc.Type)
+	}
+	return nil
+}
+
+// IsParameterized returns whether the value has placeholders for the nick or arguments.
+func (cc CustomCommand) IsParameterized() bool {
+	return customCommandPlaceholderRegexp.MatchString(cc.Value)
+}
+
+// Expand returns the value with its placeholders replaced. Arguments that aren't given are left empty.
+func (cc CustomCommand) Expand(nick string, args []string) string {
+	expanded := customCommandPlaceholderRegexp.ReplaceAllStringFunc(cc.Value, func(p string) string {
+		switch p[1:] {
+		case "nick":
+			return nick
+		case "*":
+			return strings.Join(args, " ")
+		default:
+			i, _ := strconv.Atoi(p[1:])
+			if i <= len(args) {
+				return args[i-1]
+			}
+			return ""
+		}
+	})
+	return strings.Join(strings.Fields(expanded), " ")
+}
+
+func (ch *Channel) CustomCommand(trigger string) *CustomCommand {
+	for i := range ch.CustomCommands {
+		if ch.CustomCommands[i].Trigger == strings.ToLower(trigger) {
+			return &ch.CustomCommands[i]
+		}
+	}
+	return nil
+}
+
+// AddCustomCommand adds the command unless one with the same trigger exists.
+func (ch *Channel) AddCustomCommand(cc CustomCommand) bool {
+	if ch.CustomCommand(cc.Trigger) != nil {
+		return false
+	}
+	ch.CustomCommands = append(ch.CustomCommands, cc)
+	return true
+}
+
+// RemoveCustomCommand removes the command with the trigger if it's one of the given types.
+func (ch *Channel) RemoveCustomCommand(trigger string, types ...string) bool {
+	n := len(ch.CustomCommands)
+	ch.CustomCommands = slices.DeleteFunc(ch.CustomCommands, func(cc CustomCommand) bool {
+		return cc.Trigger == strings.ToLower(trigger) && slices.Contains(types, cc.Type)
+	})
+	return len(ch.CustomCommands) < n
+}
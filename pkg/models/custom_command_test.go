@@ -0,0 +1,54 @@
+package models
+
+import "testing"
+
+func TestCustomCommandExpand(t *testing.T) {
+	cc := NewCustomCommand("Greet", CustomCommandTypeReply, "Hi $1, $nick says $* ($2$3)", "owner")
+	if cc.Trigger != "greet" {
+		t.Fatalf("trigger = %s, want greet", cc.Trigger)
+	}
+	if !cc.IsParameterized() {
+		t.Fatal("reply with placeholders is not parameterized")
+	}
+
+	got := cc.Expand("owner", []string{"bob", "welcome"})
+	if want := "Hi bob, owner says bob welcome (welcome)"; got != want {
+		t.Fatalf("expanded = %q, want %q", got, want)
+	}
+}
+
+func TestCustomCommandValidate(t *testing.T) {
+	tests := []struct {
+		cc    CustomCommand
+		valid bool
+	}{
+		{NewCustomCommand("ukr", CustomCommandTypeAlias, "!ukraine", ""), true},
+		{NewCustomCommand("tech", CustomCommandTypeReddit, "technology", ""), true},
+		{NewCustomCommand("tech", CustomCommandTypeReddit, "not a subreddit", ""), false},
+		{NewCustomCommand("two words", CustomCommandTypeReply, "hello", ""), false},
+		{NewCustomCommand("empty", CustomCommandTypeReply, " ", ""), false},
+		{NewCustomCommand("other", "other", "hello", ""), false},
+	}
+
+	for _, test := range tests {
+		if err := test.cc.Validate(); (err == nil) != test.valid {
+			t.Fatalf("Validate(%+v) = %v, want valid %t", test.cc, err, test.valid)
+		}
+	}
+}
+
+func TestChannelCustomCommands(t *testing.T) {
+	ch := NewChannel("#channel", "")
+	if !ch.AddCustomCommand(NewCustomCommand("ukr", CustomCommandTypeAlias, "!ukraine", "")) {
+		t.Fatal("unable to add alias")
+	}
+	if ch.AddCustomCommand(NewCustomCommand("UKR", CustomCommandTypeReply, "hello", "")) {
+		t.Fatal("added a second command with the same trigger")
+	}
+	if ch.RemoveCustomCommand("ukr", CustomCommandTypeReply) {
+		t.Fatal("removed an alias as a macro")
+	}
+	if !ch.RemoveCustomCommand("ukr", CustomCommandTypeAlias) || ch.CustomCommand("ukr") != nil {
+		t.Fatal("unable to remove alias")
+	}
+}
@@ -23,12 +23,14 @@ const (
 type DashboardRequestTaskData struct {
 	RequestID string `json:"request_id"`
 	Action    string `json:"action"`
+	Network   string `json:"network,omitempty"`
 	Channel   string `json:"channel"`
 	Nick      string `json:"nick,omitempty"`
 	Mask      string `json:"mask,omitempty"`
 	Duration  string `json:"duration,omitempty"`
 	Reason    string `json:"reason,omitempty"`
 	Topic     string `json:"topic,omitempty"`
+	Actor     string `json:"actor,omitempty"`
 }
 
 type DashboardResponseTaskData struct {
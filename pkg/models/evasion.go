@@ -0,0 +1,49 @@
+package models
+
+import (
+	"assistant/pkg/api/elapse"
+	"assistant/pkg/api/irc"
+	"fmt"
+)
+
+// DefaultEvasionThreshold is the score from which joiners are reported as likely ban evaders, enough for a shared host
+// or a similar nick on the same network.
+const DefaultEvasionThreshold = 50
+
+// MaxEvasionScore is the score of a joiner who matches on every signal.
+const MaxEvasionScore = 100
+
+// EvasionDetection configures the scoring of joiners against the channel's bans and recently banned and muted users.
+// Likely evaders are reported to the channel's ops, and with AutoQuiet muted as well, for QuietDuration if it's set.
+type EvasionDetection struct {
+	Enabled       bool   `firestore:"enabled" json:"enabled"`
+	Threshold     int    `firestore:"threshold" json:"threshold"`
+	AutoQuiet     bool   `firestore:"auto_quiet" json:"auto_quiet"`
+	QuietDuration string `firestore:"quiet_duration" json:"quiet_duration"`
+}
+
+// ScoreThreshold returns the score from which joiners are reported, falling back to the default if it isn't set.
+func (d EvasionDetection) ScoreThreshold() int {
+	if d.Threshold > 0 {
+		return d.Threshold
+	}
+	return DefaultEvasionThreshold
+}
+
+func (d EvasionDetection) Validate() error {
+	if d.Threshold < 0 || d.Threshold > MaxEvasionScore {
+		return fmt.Errorf("invalid threshold, %d", d.Threshold)
+	}
+	if len(d.QuietDuration) > 0 && !elapse.IsDuration(d.QuietDuration) {
+		return fmt.Errorf("invalid quiet duration, %s", d.QuietDuration)
+	}
+	return nil
+}
+
+// ValidateOpsChannel checks that the ops channel, if there is one, is a channel.
+func ValidateOpsChannel(channel string) error {
+	if len(channel) > 0 && !irc.IsChannel(channel) {
+		return fmt.Errorf("%s is not a channel", channel)
+	}
+	return nil
+}
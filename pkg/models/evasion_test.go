@@ -0,0 +1,39 @@
+package models
+
+import "testing"
+
+func TestEvasionDetectionScoreThreshold(t *testing.T) {
+	if got := (EvasionDetection{}).ScoreThreshold(); got != DefaultEvasionThreshold {
+		t.Errorf("default threshold = %d, want %d", got, DefaultEvasionThreshold)
+	}
+	if got := (EvasionDetection{Threshold: 80}).ScoreThreshold(); got != 80 {
+		t.Errorf("threshold = %d, want 80", got)
+	}
+}
+
+func TestEvasionDetectionValidate(t *testing.T) {
+	valid := []EvasionDetection{
+		{},
+		{Enabled: true, Threshold: 70, AutoQuiet: true, QuietDuration: "1h"},
+	}
+	for _, d := range valid {
+		if err := d.Validate(); err != nil {
+			t.Errorf("Validate(%+v) = %v, want nil", d, err)
+		}
+	}
+
+	invalid := []EvasionDetection{
+		{Threshold: -1},
+		{Threshold: MaxEvasionScore + 1},
+		{AutoQuiet: true, QuietDuration: "a while"},
+	}
+	for _, d := range invalid {
+		if err := d.Validate(); err == nil {
+			t.Errorf("Validate(%+v) = nil, want an error", d)
+		}
+	}
+
+	if err := ValidateOpsChannel("ops"); err == nil {
+		t.Error("ValidateOpsChannel(ops) = nil, want an error")
+	}
+}
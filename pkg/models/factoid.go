@@ -0,0 +1,104 @@
+package models
+
+import (
+	"assistant/pkg/api/text"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// MaxFactoidAliasDepth is how many aliases are followed when resolving a factoid, so that alias loops end.
+const MaxFactoidAliasDepth = 5
+
+var factoidKeyRegexp = regexp.MustCompile(`^[a-z0-9][a-z0-9_.-]{0,31}$`)
+
+// Factoid is a channel's answer to a recurring question, triggered by its key. A factoid is either a value, kept with
+// its earlier versions, or an alias of another factoid.
+type Factoid struct {
+	Key       string           `firestore:"key" json:"key"`
+	Value     string           `firestore:"value" json:"value"`
+	AliasOf   string           `firestore:"alias_of" json:"alias_of"`
+	Keywords  []string         `firestore:"keywords" json:"keywords"`
+	Versions  []FactoidVersion `firestore:"versions" json:"versions"`
+	Locked    bool             `firestore:"locked" json:"locked"`
+	LockedBy  string           `firestore:"locked_by" json:"locked_by"`
+	CreatedBy string           `firestore:"created_by" json:"created_by"`
+	CreatedAt time.Time        `firestore:"created_at" json:"created_at"`
+	UpdatedBy string           `firestore:"updated_by" json:"updated_by"`
+	UpdatedAt time.Time        `firestore:"updated_at" json:"updated_at"`
+}
+
+// FactoidVersion is a value a factoid had, or has if it's the latest.
+type FactoidVersion struct {
+	Value     string    `firestore:"value" json:"value"`
+	AliasOf   string    `firestore:"alias_of" json:"alias_of"`
+	Author    string    `firestore:"author" json:"author"`
+	CreatedAt time.Time `firestore:"created_at" json:"created_at"`
+}
+
+func IsValidFactoidKey(key string) bool {
+	return factoidKeyRegexp.MatchString(key)
+}
+
+func NormalizeFactoidKey(key string) string {
+	return strings.ToLower(strings.TrimSpace(key))
+}
+
+func NewFactoid(key, author string) *Factoid {
+	now := time.Now()
+	return &Factoid{
+		Key:       NormalizeFactoidKey(key),
+		Keywords:  make([]string, 0),
+		Versions:  make([]FactoidVersion, 0),
+		CreatedBy: author,
+		CreatedAt: now,
+		UpdatedBy: author,
+		UpdatedAt: now,
+	}
+}
+
+func (f *Factoid) IsAlias() bool {
+	return len(f.AliasOf) > 0
+}
+
+// SetValue makes value the factoid's latest version.
+func (f *Factoid) SetValue(value, author string) {
+	f.Value = strings.TrimSpace(value)
+	f.AliasOf = ""
+	f.addVersion(author)
+}
+
+// SetAlias makes the factoid an alias of the factoid with the key.
+func (f *Factoid) SetAlias(key, author string) {
+	f.Value = ""
+	f.AliasOf = NormalizeFactoidKey(key)
+	f.addVersion(author)
+}
+
+func (f *Factoid) addVersion(author string) {
+	now := time.Now()
+	f.Versions = append(f.Versions, FactoidVersion{Value: f.Value, AliasOf: f.AliasOf, Author: author, CreatedAt: now})
+	f.UpdatedBy = author
+	f.UpdatedAt = now
+	f.Keywords = factoidKeywords(f.Key, f.Value)
+}
+
+// Expand returns the value with $nick and $channel replaced.
+func (f *Factoid) Expand(nick, channel string) string {
+	return strings.NewReplacer("$nick", nick, "$channel", channel).Replace(f.Value)
+}
+
+func factoidKeywords(key, value string) []string {
+	keywords := []string{key}
+	for _, part := range strings.FieldsFunc(key, func(r rune) bool { return r == '_' || r == '-' || r == '.' }) {
+		keywords = append(keywords, part)
+	}
+	for _, keyword := range text.ParseKeywords(value) {
+		if len(keyword) > 0 {
+			keywords = append(keywords, keyword)
+		}
+	}
+	slices.Sort(keywords)
+	return slices.Compact(keywords)
+}
@@ -0,0 +1,70 @@
+package models
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestIsValidFactoidKey(t *testing.T) {
+	tests := []struct {
+		key  string
+		want bool
+	}{
+		{"rules", true},
+		{"faq.irc-1", true},
+		{"", false},
+		{"-rules", false},
+		{"Rules", false},
+		{"two words", false},
+		{"abcdefghijklmnopqrstuvwxyz0123456", false},
+	}
+	for _, tt := range tests {
+		if got := IsValidFactoidKey(tt.key); got != tt.want {
+			t.Errorf("IsValidFactoidKey(%q) = %v, want %v", tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestFactoidVersions(t *testing.T) {
+	f := NewFactoid("Rules", "alice")
+	if f.Key != "rules" {
+		t.Fatalf("Key = %q, want %q", f.Key, "rules")
+	}
+
+	f.SetValue("Be nice", "alice")
+	f.SetAlias("guidelines", "bob")
+	f.SetValue("Be kind", "carol")
+
+	if len(f.Versions) != 3 {
+		t.Fatalf("len(Versions) = %d, want 3", len(f.Versions))
+	}
+	if f.IsAlias() || f.Value != "Be kind" {
+		t.Errorf("latest = %q alias %q, want value %q", f.Value, f.AliasOf, "Be kind")
+	}
+	if f.Versions[1].AliasOf != "guidelines" || f.Versions[1].Author != "bob" {
+		t.Errorf("Versions[1] = %+v, want alias of guidelines by bob", f.Versions[1])
+	}
+	if f.CreatedBy != "alice" || f.UpdatedBy != "carol" {
+		t.Errorf("CreatedBy, UpdatedBy = %q, %q, want alice, carol", f.CreatedBy, f.UpdatedBy)
+	}
+}
+
+func TestFactoidKeywords(t *testing.T) {
+	f := NewFactoid("irc-rules", "alice")
+	f.SetValue("Please be respectful", "alice")
+
+	for _, keyword := range []string{"irc-rules", "irc", "rules", "respectful"} {
+		if !slices.Contains(f.Keywords, keyword) {
+			t.Errorf("Keywords = %v, missing %q", f.Keywords, keyword)
+		}
+	}
+}
+
+func TestFactoidExpand(t *testing.T) {
+	f := NewFactoid("welcome", "alice")
+	f.SetValue("Welcome to $channel, $nick!", "alice")
+
+	if got, want := f.Expand("bob", "#test"), "Welcome to #test, bob!"; got != want {
+		t.Errorf("Expand() = %q, want %q", got, want)
+	}
+}
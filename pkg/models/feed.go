@@ -0,0 +1,193 @@
+package models
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const ChannelFeedsTaskID = "channel-feeds"
+
+// FeedPollInterval is how often a channel's feeds are checked for new items.
+const FeedPollInterval = 5 * time.Minute
+
+const (
+	DefaultFeedMaxPostsPerHour = 4
+	MaxFeedMaxPostsPerHour     = 30
+	MaxFeedsPerChannel         = 20
+
+	// MaxFeedItemAge is how old an item can be and still be shared. Older items, such as those held back by a rate cap
+	// or quiet hours for too long, are skipped.
+	MaxFeedItemAge = 24 * time.Hour
+
+	// maxFeedSeenItems is how many item identifiers a feed remembers to avoid sharing an item twice, which comfortably
+	// exceeds the length of most feeds.
+	maxFeedSeenItems = 250
+
+	feedIDLength     = 6
+	quietHoursFormat = "15:04"
+)
+
+// Feed is a channel's subscription to an RSS or Atom feed, or to a subreddit, Mastodon account or Bluesky account
+// through its feed. New items matching the filter are shared in the channel, no more than MaxPostsPerHour an hour and
+// not during quiet hours.
+type Feed struct {
+	ID              string      `firestore:"id" json:"id"`
+	URL             string      `firestore:"url" json:"url"`
+	FeedURL         string      `firestore:"feed_url" json:"feed_url"`
+	Kind            string      `firestore:"kind" json:"kind"`
+	Title           string      `firestore:"title" json:"title"`
+	Filter          string      `firestore:"filter,omitempty" json:"filter,omitempty"`
+	MaxPostsPerHour int         `firestore:"max_posts_per_hour" json:"max_posts_per_hour"`
+	QuietFrom       string      `firestore:"quiet_from,omitempty" json:"quiet_from,omitempty"`
+	QuietUntil      string      `firestore:"quiet_until,omitempty" json:"quiet_until,omitempty"`
+	TimeZone        string      `firestore:"time_zone,omitempty" json:"time_zone,omitempty"`
+	Seen            []string    `firestore:"seen" json:"seen"`
+	PostedAt        []time.Time `firestore:"posted_at" json:"posted_at"`
+	CreatedBy       string      `firestore:"created_by" json:"created_by"`
+	CreatedAt       time.Time   `firestore:"created_at" json:"created_at"`
+	PolledAt        time.Time   `firestore:"polled_at,omitempty" json:"polled_at,omitempty"`
+	LastError       string      `firestore:"last_error,omitempty" json:"last_error,omitempty"`
+}
+
+func NewFeed(url, feedURL, kind, title, filter, createdBy string) *Feed {
+	return &Feed{
+		ID:              uuid.NewString()[:feedIDLength],
+		URL:             url,
+		FeedURL:         feedURL,
+		Kind:            kind,
+		Title:           title,
+		Filter:          strings.TrimSpace(filter),
+		MaxPostsPerHour: DefaultFeedMaxPostsPerHour,
+		Seen:            make([]string, 0),
+		PostedAt:        make([]time.Time, 0),
+		CreatedBy:       createdBy,
+		CreatedAt:       time.Now(),
+	}
+}
+
+// Matches reports whether any of the texts contains any of the filter's alternatives, which are separated by |,
+// ignoring case. A feed without a filter matches everything.
+func (f *Feed) Matches(texts ...string) bool {
+	if len(f.Filter) == 0 {
+		return true
+	}
+
+	for _, alternative := range strings.Split(strings.ToLower(f.Filter), "|") {
+		alternative = strings.TrimSpace(alternative)
+		if len(alternative) == 0 {
+			continue
+		}
+		for _, text := range texts {
+			if strings.Contains(strings.ToLower(text), alternative) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (f *Feed) HasSeen(guid string) bool {
+	return slices.Contains(f.Seen, guid)
+}
+
+// MarkSeen records items as seen, forgetting the oldest once the feed remembers too many.
+func (f *Feed) MarkSeen(guids ...string) {
+	for _, guid := range guids {
+		if len(guid) > 0 && !f.HasSeen(guid) {
+			f.Seen = append(f.Seen, guid)
+		}
+	}
+	if len(f.Seen) > maxFeedSeenItems {
+		f.Seen = f.Seen[len(f.Seen)-maxFeedSeenItems:]
+	}
+}
+
+// RemainingPosts returns how many more items can be shared in the hour before now.
+func (f *Feed) RemainingPosts(now time.Time) int {
+	posted := 0
+	for _, t := range f.PostedAt {
+		if now.Sub(t) < time.Hour {
+			posted++
+		}
+	}
+	return max(f.MaxPostsPerHour-posted, 0)
+}
+
+// RecordPost records an item being shared, forgetting posts from over an hour ago.
+func (f *Feed) RecordPost(now time.Time) {
+	f.PostedAt = slices.DeleteFunc(f.PostedAt, func(t time.Time) bool { return now.Sub(t) >= time.Hour })
+	f.PostedAt = append(f.PostedAt, now)
+}
+
+// SetQuietHours sets the daily hours during which nothing is shared, in the given time zone. The hours can span
+// midnight.
+func (f *Feed) SetQuietHours(fromHour, fromMinute, untilHour, untilMinute int, loc *time.Location) error {
+	if fromHour == untilHour && fromMinute == untilMinute {
+		return fmt.Errorf("quiet hours must start and end at different times")
+	}
+
+	f.QuietFrom = fmt.Sprintf("%02d:%02d", fromHour, fromMinute)
+	f.QuietUntil = fmt.Sprintf("%02d:%02d", untilHour, untilMinute)
+	f.TimeZone = loc.String()
+	return nil
+}
+
+func (f *Feed) ClearQuietHours() {
+	f.QuietFrom = ""
+	f.QuietUntil = ""
+	f.TimeZone = ""
+}
+
+func (f *Feed) HasQuietHours() bool {
+	return len(f.QuietFrom) > 0 && len(f.QuietUntil) > 0
+}
+
+// QuietHours describes the feed's quiet hours, e.g. "22:00-07:00 America/New_York".
+func (f *Feed) QuietHours() string {
+	if !f.HasQuietHours() {
+		return ""
+	}
+	return fmt.Sprintf("%s-%s %s", f.QuietFrom, f.QuietUntil, f.location())
+}
+
+// InQuietHours reports whether now falls within the feed's quiet hours.
+func (f *Feed) InQuietHours(now time.Time) bool {
+	if !f.HasQuietHours() {
+		return false
+	}
+
+	from, err := time.Parse(quietHoursFormat, f.QuietFrom)
+	if err != nil {
+		return false
+	}
+	until, err := time.Parse(quietHoursFormat, f.QuietUntil)
+	if err != nil {
+		return false
+	}
+
+	local := now.In(f.location())
+	minute := local.Hour()*60 + local.Minute()
+	start := from.Hour()*60 + from.Minute()
+	end := until.Hour()*60 + until.Minute()
+
+	if start < end {
+		return minute >= start && minute < end
+	}
+	return minute >= start || minute < end
+}
+
+func (f *Feed) location() *time.Location {
+	if len(f.TimeZone) == 0 {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(f.TimeZone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
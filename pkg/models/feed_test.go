@@ -0,0 +1,118 @@
+package models
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestFeedMatches(t *testing.T) {
+	f := NewFeed("https://example.com/feed", "https://example.com/feed", "rss", "Example", "", "nick")
+	if !f.Matches("anything") {
+		t.Errorf("feed without a filter should match everything")
+	}
+
+	f.Filter = "Go | rust"
+	tests := []struct {
+		texts []string
+		want  bool
+	}{
+		{[]string{"Why go is great"}, true},
+		{[]string{"nothing here", "RUST 2.0 released"}, true},
+		{[]string{"python news"}, false},
+		{[]string{""}, false},
+	}
+
+	for _, tt := range tests {
+		if got := f.Matches(tt.texts...); got != tt.want {
+			t.Errorf("Matches(%q) = %v, want %v", tt.texts, got, tt.want)
+		}
+	}
+}
+
+func TestFeedMarkSeen(t *testing.T) {
+	f := NewFeed("u", "u", "rss", "t", "", "nick")
+	f.MarkSeen("a", "b", "a")
+	if len(f.Seen) != 2 || !f.HasSeen("a") || !f.HasSeen("b") || f.HasSeen("c") {
+		t.Fatalf("unexpected seen items %v", f.Seen)
+	}
+
+	for i := 0; i < maxFeedSeenItems; i++ {
+		f.MarkSeen(fmt.Sprintf("item-%d", i))
+	}
+	if len(f.Seen) != maxFeedSeenItems {
+		t.Errorf("got %d seen items, want %d", len(f.Seen), maxFeedSeenItems)
+	}
+	if f.HasSeen("a") || !f.HasSeen(fmt.Sprintf("item-%d", maxFeedSeenItems-1)) {
+		t.Errorf("oldest items should be forgotten first")
+	}
+}
+
+func TestFeedRemainingPosts(t *testing.T) {
+	now := time.Now()
+	f := NewFeed("u", "u", "rss", "t", "", "nick")
+	f.MaxPostsPerHour = 2
+
+	f.PostedAt = []time.Time{now.Add(-2 * time.Hour)}
+	if got := f.RemainingPosts(now); got != 2 {
+		t.Errorf("RemainingPosts = %d, want 2", got)
+	}
+
+	f.RecordPost(now.Add(-time.Minute))
+	if len(f.PostedAt) != 1 {
+		t.Errorf("posts from over an hour ago should be forgotten, got %v", f.PostedAt)
+	}
+
+	f.RecordPost(now)
+	if got := f.RemainingPosts(now); got != 0 {
+		t.Errorf("RemainingPosts = %d, want 0", got)
+	}
+}
+
+func TestFeedInQuietHours(t *testing.T) {
+	f := NewFeed("u", "u", "rss", "t", "", "nick")
+	at := func(hour, minute int) time.Time {
+		return time.Date(2025, 6, 10, hour, minute, 0, 0, time.UTC)
+	}
+
+	if f.InQuietHours(at(3, 0)) {
+		t.Errorf("feed without quiet hours should never be quiet")
+	}
+
+	if err := f.SetQuietHours(22, 0, 7, 30, time.UTC); err != nil {
+		t.Fatalf("SetQuietHours returned error: %s", err)
+	}
+
+	tests := []struct {
+		hour, minute int
+		want         bool
+	}{
+		{21, 59, false},
+		{22, 0, true},
+		{3, 0, true},
+		{7, 29, true},
+		{7, 30, false},
+		{12, 0, false},
+	}
+	for _, tt := range tests {
+		if got := f.InQuietHours(at(tt.hour, tt.minute)); got != tt.want {
+			t.Errorf("InQuietHours(%02d:%02d) = %v, want %v", tt.hour, tt.minute, got, tt.want)
+		}
+	}
+
+	if err := f.SetQuietHours(9, 0, 17, 0, time.UTC); err != nil {
+		t.Fatalf("SetQuietHours returned error: %s", err)
+	}
+	if !f.InQuietHours(at(12, 0)) || f.InQuietHours(at(18, 0)) {
+		t.Errorf("daytime quiet hours not applied")
+	}
+
+	if err := f.SetQuietHours(9, 0, 9, 0, time.UTC); err == nil {
+		t.Errorf("quiet hours that start and end together should be rejected")
+	}
+
+	f.ClearQuietHours()
+	if f.HasQuietHours() || f.InQuietHours(at(12, 0)) {
+		t.Errorf("quiet hours should be cleared")
+	}
+}
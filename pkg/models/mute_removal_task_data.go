@@ -2,11 +2,14 @@ package models
 
 import "time"
 
+// MuteRemovalTaskData says whose mute to lift. A mute set as a quiet is lifted by removing the quiet on its mask,
+// otherwise the nick and the users sharing its host are voiced again.
 type MuteRemovalTaskData struct {
 	Nick      string `firestore:"nick" json:"nick"`
 	Host      string `firestore:"host" json:"host"`
 	Channel   string `firestore:"channel" json:"channel"`
 	AutoVoice bool   `firestore:"auto_voice" json:"auto_voice"`
+	Quiet     string `firestore:"quiet" json:"quiet"`
 }
 
 func NewMuteRemovalTask(dueAt time.Time, channel, nick, host string, autoVoice bool) *Task {
@@ -17,3 +20,10 @@ func NewMuteRemovalTask(dueAt time.Time, channel, nick, host string, autoVoice b
 		AutoVoice: autoVoice,
 	})
 }
+
+func NewQuietRemovalTask(dueAt time.Time, channel, mask string) *Task {
+	return newTask(TaskTypeMuteRemoval, dueAt, MuteRemovalTaskData{
+		Channel: channel,
+		Quiet:   mask,
+	})
+}
@@ -0,0 +1,149 @@
+package models
+
+import (
+	"assistant/pkg/api/irc"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	PollStatusOpen   = "open"
+	PollStatusClosed = "closed"
+)
+
+const (
+	MinPollOptions = 2
+	MaxPollOptions = 10
+
+	// MaxPollDuration is the longest a poll can stay open before it closes automatically.
+	MaxPollDuration = 7 * 24 * time.Hour
+
+	pollIDLength = 6
+)
+
+// Poll is a channel vote on a question. Each voter, identified by account or by user ID and host so that changing
+// nick doesn't give another vote, has one vote, which can pick several options if the poll allows it.
+type Poll struct {
+	ID          string     `firestore:"id" json:"id"`
+	Question    string     `firestore:"question" json:"question"`
+	Options     []string   `firestore:"options" json:"options"`
+	Multiple    bool       `firestore:"multiple" json:"multiple"`
+	Votes       []PollVote `firestore:"votes" json:"votes"`
+	Status      string     `firestore:"status" json:"status"`
+	CreatedBy   string     `firestore:"created_by" json:"created_by"`
+	CreatedAt   time.Time  `firestore:"created_at" json:"created_at"`
+	ClosesAt    time.Time  `firestore:"closes_at,omitempty" json:"closes_at,omitempty"`
+	ClosedBy    string     `firestore:"closed_by,omitempty" json:"closed_by,omitempty"`
+	ClosedAt    time.Time  `firestore:"closed_at,omitempty" json:"closed_at,omitempty"`
+	CloseTaskID string     `firestore:"close_task_id,omitempty" json:"close_task_id,omitempty"`
+}
+
+// PollVote is a voter's choices, numbered from 1.
+type PollVote struct {
+	Voter   string    `firestore:"voter" json:"voter"`
+	Nick    string    `firestore:"nick" json:"nick"`
+	Choices []int     `firestore:"choices" json:"choices"`
+	CastAt  time.Time `firestore:"cast_at" json:"cast_at"`
+}
+
+func NewPoll(question string, options []string, multiple bool, createdBy string) *Poll {
+	return &Poll{
+		ID:        uuid.NewString()[:pollIDLength],
+		Question:  strings.TrimSpace(question),
+		Options:   options,
+		Multiple:  multiple,
+		Votes:     make([]PollVote, 0),
+		Status:    PollStatusOpen,
+		CreatedBy: createdBy,
+		CreatedAt: time.Now(),
+	}
+}
+
+// PollVoter returns the identity a user votes as: their account if they're logged in, otherwise their user ID and
+// host.
+func PollVoter(mask *irc.Mask) string {
+	if len(mask.Account) > 0 {
+		return "account:" + strings.ToLower(mask.Account)
+	}
+	return strings.ToLower(fmt.Sprintf("%s@%s", strings.TrimPrefix(mask.UserID, "~"), mask.Host))
+}
+
+func (p *Poll) IsOpen() bool {
+	return p.Status == PollStatusOpen
+}
+
+// Vote records the voter's choices, replacing any earlier vote, and returns whether they had voted before.
+func (p *Poll) Vote(voter, nick string, choices []int) (bool, error) {
+	if !p.IsOpen() {
+		return false, fmt.Errorf("poll %s is closed", p.ID)
+	}
+
+	if len(choices) == 0 {
+		return false, fmt.Errorf("no choices")
+	}
+
+	if len(choices) > 1 && !p.Multiple {
+		return false, fmt.Errorf("poll %s allows only one choice", p.ID)
+	}
+
+	choices = slices.Clone(choices)
+	slices.Sort(choices)
+	choices = slices.Compact(choices)
+	for _, choice := range choices {
+		if choice < 1 || choice > len(p.Options) {
+			return false, fmt.Errorf("invalid choice %d", choice)
+		}
+	}
+
+	vote := PollVote{Voter: voter, Nick: nick, Choices: choices, CastAt: time.Now()}
+
+	for i, v := range p.Votes {
+		if v.Voter == voter {
+			p.Votes[i] = vote
+			return true, nil
+		}
+	}
+
+	p.Votes = append(p.Votes, vote)
+	return false, nil
+}
+
+// Tally returns the number of votes for each option.
+func (p *Poll) Tally() []int {
+	counts := make([]int, len(p.Options))
+	for _, v := range p.Votes {
+		for _, choice := range v.Choices {
+			if choice >= 1 && choice <= len(counts) {
+				counts[choice-1]++
+			}
+		}
+	}
+	return counts
+}
+
+// Winners returns the numbers of the options with the most votes, or none if nobody voted.
+func (p *Poll) Winners() []int {
+	counts := p.Tally()
+	most := slices.Max(counts)
+	if most == 0 {
+		return nil
+	}
+
+	winners := make([]int, 0)
+	for i, count := range counts {
+		if count == most {
+			winners = append(winners, i+1)
+		}
+	}
+	return winners
+}
+
+func (p *Poll) Close(by string) {
+	p.Status = PollStatusClosed
+	p.ClosedBy = by
+	p.ClosedAt = time.Now()
+}
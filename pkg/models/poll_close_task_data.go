@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+type PollCloseTaskData struct {
+	Channel string `firestore:"channel" json:"channel"`
+	PollID  string `firestore:"poll_id" json:"poll_id"`
+}
+
+func NewPollCloseTask(dueAt time.Time, channel, pollID string) *Task {
+	return newTask(TaskTypePollClose, dueAt, PollCloseTaskData{
+		Channel: channel,
+		PollID:  pollID,
+	})
+}
@@ -0,0 +1,81 @@
+package models
+
+import (
+	"assistant/pkg/api/irc"
+	"slices"
+	"testing"
+)
+
+func TestPollVote(t *testing.T) {
+	p := NewPoll("Lunch?", []string{"pizza", "sushi", "tacos"}, false, "alice")
+
+	if changed, err := p.Vote("bob@host", "bob", []int{1}); err != nil || changed {
+		t.Fatalf("Vote() = %v, %v, want first vote", changed, err)
+	}
+	if changed, err := p.Vote("bob@host", "bobby", []int{2}); err != nil || !changed {
+		t.Fatalf("Vote() = %v, %v, want changed vote", changed, err)
+	}
+	if _, err := p.Vote("carol@host", "carol", []int{1, 2}); err == nil {
+		t.Errorf("Vote() with two choices in a single choice poll should fail")
+	}
+	if _, err := p.Vote("carol@host", "carol", []int{4}); err == nil {
+		t.Errorf("Vote() for a missing option should fail")
+	}
+	if _, err := p.Vote("carol@host", "carol", []int{2}); err != nil {
+		t.Fatalf("Vote() error = %v", err)
+	}
+
+	if got := p.Tally(); !slices.Equal(got, []int{0, 2, 0}) {
+		t.Errorf("Tally() = %v, want [0 2 0]", got)
+	}
+	if got := p.Winners(); !slices.Equal(got, []int{2}) {
+		t.Errorf("Winners() = %v, want [2]", got)
+	}
+
+	p.Close("alice")
+	if _, err := p.Vote("dave@host", "dave", []int{1}); err == nil {
+		t.Errorf("Vote() in a closed poll should fail")
+	}
+}
+
+func TestPollMultipleChoice(t *testing.T) {
+	p := NewPoll("Which days?", []string{"mon", "tue", "wed"}, true, "alice")
+
+	if p.Winners() != nil {
+		t.Errorf("Winners() of a poll without votes = %v, want none", p.Winners())
+	}
+
+	if _, err := p.Vote("bob@host", "bob", []int{3, 1, 3}); err != nil {
+		t.Fatalf("Vote() error = %v", err)
+	}
+	if _, err := p.Vote("carol@host", "carol", []int{1}); err != nil {
+		t.Fatalf("Vote() error = %v", err)
+	}
+	if _, err := p.Vote("dave@host", "dave", []int{3}); err != nil {
+		t.Fatalf("Vote() error = %v", err)
+	}
+
+	if got := p.Tally(); !slices.Equal(got, []int{2, 0, 2}) {
+		t.Errorf("Tally() = %v, want [2 0 2]", got)
+	}
+	if got := p.Winners(); !slices.Equal(got, []int{1, 3}) {
+		t.Errorf("Winners() = %v, want [1 3]", got)
+	}
+}
+
+func TestPollVoter(t *testing.T) {
+	tests := []struct {
+		mask *irc.Mask
+		want string
+	}{
+		{&irc.Mask{Nick: "bob", UserID: "~bob", Host: "Example.COM"}, "bob@example.com"},
+		{&irc.Mask{Nick: "bob_", UserID: "bob", Host: "example.com"}, "bob@example.com"},
+		{&irc.Mask{Nick: "bob", UserID: "~bob", Host: "example.com", Account: "Bob"}, "account:bob"},
+	}
+
+	for _, tt := range tests {
+		if got := PollVoter(tt.mask); got != tt.want {
+			t.Errorf("PollVoter(%s) = %q, want %q", tt.mask, got, tt.want)
+		}
+	}
+}
@@ -1,11 +1,28 @@
 package models
 
-import "time"
+import (
+	"strings"
+	"time"
+)
+
+// ReminderIDLength is the length of the short reminder IDs shown to users.
+const ReminderIDLength = 6
 
 type ReminderTaskData struct {
 	User        string `firestore:"user" json:"user"`
 	Destination string `firestore:"destination" json:"destination"`
 	Content     string `firestore:"content" json:"content"`
+
+	// Recurrence is the schedule a recurring reminder repeats on, as accepted by elapse.ParseSchedule, and TimeZone is
+	// the time zone it is evaluated in.
+	Recurrence string `firestore:"recurrence,omitempty" json:"recurrence,omitempty"`
+	TimeZone   string `firestore:"time_zone,omitempty" json:"time_zone,omitempty"`
+
+	// ForChannel marks reminders addressed to everyone in the destination channel rather than to the user who set them.
+	ForChannel bool `firestore:"for_channel,omitempty" json:"for_channel,omitempty"`
+
+	// DeliveredAt is when the reminder was last delivered, which lets it be snoozed.
+	DeliveredAt time.Time `firestore:"delivered_at,omitempty" json:"delivered_at,omitempty"`
 }
 
 func NewReminderTask(dueAt time.Time, user, destination, content string) *Task {
@@ -15,3 +32,28 @@ func NewReminderTask(dueAt time.Time, user, destination, content string) *Task {
 		Content:     content,
 	})
 }
+
+// NewRecurringReminderTask creates a reminder first due at dueAt that repeats on the given schedule in the time zone.
+func NewRecurringReminderTask(dueAt time.Time, user, destination, content, recurrence, timeZone string) *Task {
+	return newTask(TaskTypeReminder, dueAt, ReminderTaskData{
+		User:        user,
+		Destination: destination,
+		Content:     content,
+		Recurrence:  recurrence,
+		TimeZone:    timeZone,
+	})
+}
+
+// IsRecurring reports whether the reminder repeats.
+func (d ReminderTaskData) IsRecurring() bool {
+	return len(d.Recurrence) > 0
+}
+
+// ReminderID returns the short ID users refer to a reminder task by.
+func ReminderID(task *Task) string {
+	id := strings.TrimPrefix(task.ID, taskIDPrefix+"-")
+	if len(id) > ReminderIDLength {
+		id = id[:ReminderIDLength]
+	}
+	return id
+}
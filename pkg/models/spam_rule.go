@@ -0,0 +1,98 @@
+package models
+
+import (
+	"assistant/pkg/api/elapse"
+	"fmt"
+	"slices"
+	"time"
+)
+
+const (
+	SpamRuleFlood     = "flood"
+	SpamRuleRepeat    = "repeat"
+	SpamRuleHighlight = "highlight"
+	SpamRuleCaps      = "caps"
+)
+
+var SpamRules = []string{SpamRuleFlood, SpamRuleRepeat, SpamRuleHighlight, SpamRuleCaps}
+
+// MaxSpamRuleWindow is the longest window a rule can look back over, which bounds the lines kept for each user.
+const MaxSpamRuleWindow = 10 * time.Minute
+
+// SpamRule configures one of a channel's spam detection rules. What Threshold counts depends on the rule: a user's
+// lines within Window for flood, their identical lines within Window for repeat, the channel members named in one line
+// for highlight, and the percentage of capital letters in a line for caps. Duration applies to mutes and bans, which
+// are indefinite without one.
+type SpamRule struct {
+	Rule      string `firestore:"rule" json:"rule"`
+	Enabled   bool   `firestore:"enabled" json:"enabled"`
+	Threshold int    `firestore:"threshold" json:"threshold"`
+	Window    string `firestore:"window" json:"window"`
+	Action    string `firestore:"action" json:"action"`
+	Duration  string `firestore:"duration" json:"duration"`
+}
+
+// DefaultSpamRules returns the settings each rule starts with, all disabled.
+func DefaultSpamRules() []SpamRule {
+	return []SpamRule{
+		{Rule: SpamRuleFlood, Threshold: 6, Window: "10s", Action: ModerationActionMute, Duration: "10m"},
+		{Rule: SpamRuleRepeat, Threshold: 3, Window: "1m", Action: ModerationActionWarn},
+		{Rule: SpamRuleHighlight, Threshold: 6, Action: ModerationActionKick},
+		{Rule: SpamRuleCaps, Threshold: 80, Action: ModerationActionWarn},
+	}
+}
+
+func (r SpamRule) Validate() error {
+	if !slices.Contains(SpamRules, r.Rule) {
+		return fmt.Errorf("invalid rule, %s", r.Rule)
+	}
+	if !slices.Contains(ModerationActions, r.Action) {
+		return fmt.Errorf("invalid action, %s", r.Action)
+	}
+	if r.Threshold < 1 {
+		return fmt.Errorf("invalid threshold, %d", r.Threshold)
+	}
+	if r.Rule == SpamRuleCaps && r.Threshold > 100 {
+		return fmt.Errorf("caps threshold is a percentage, %d", r.Threshold)
+	}
+	if r.Rule == SpamRuleFlood || r.Rule == SpamRuleRepeat {
+		if !elapse.IsDuration(r.Window) {
+			return fmt.Errorf("invalid window, %s", r.Window)
+		}
+		if r.WindowDuration() > MaxSpamRuleWindow {
+			return fmt.Errorf("window can be at most %s", MaxSpamRuleWindow)
+		}
+	}
+	if len(r.Duration) > 0 && !elapse.IsDuration(r.Duration) {
+		return fmt.Errorf("invalid duration, %s", r.Duration)
+	}
+	return nil
+}
+
+func (r SpamRule) WindowDuration() time.Duration {
+	d, _ := elapse.ParseDuration(r.Window)
+	return d
+}
+
+// SpamRuleSettings returns the channel's settings for every rule, in the order of SpamRules, falling back to the
+// defaults for rules that haven't been configured.
+func (ch *Channel) SpamRuleSettings() []SpamRule {
+	rules := DefaultSpamRules()
+	for i, r := range rules {
+		if j := slices.IndexFunc(ch.SpamRules, func(s SpamRule) bool { return s.Rule == r.Rule }); j >= 0 {
+			rules[i] = ch.SpamRules[j]
+		}
+	}
+	return rules
+}
+
+// SetSpamRule saves the rule's settings, replacing any for the same rule.
+func (ch *Channel) SetSpamRule(rule SpamRule) {
+	for i, r := range ch.SpamRules {
+		if r.Rule == rule.Rule {
+			ch.SpamRules[i] = rule
+			return
+		}
+	}
+	ch.SpamRules = append(ch.SpamRules, rule)
+}
@@ -0,0 +1,221 @@
+package models
+
+import (
+	"assistant/pkg/api/elapse"
+	"assistant/pkg/api/irc"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ModerationActionWarn = "warn"
+	ModerationActionMute = "mute"
+	ModerationActionKick = "kick"
+	ModerationActionBan  = "ban"
+)
+
+var ModerationActions = []string{ModerationActionWarn, ModerationActionMute, ModerationActionKick, ModerationActionBan}
+
+var moderationSeverity = map[string]int{
+	ModerationActionWarn: 1,
+	ModerationActionMute: 2,
+	ModerationActionKick: 3,
+	ModerationActionBan:  4,
+}
+
+// ModerationSeverity orders moderation actions from warnings to bans, with 0 for unknown actions.
+func ModerationSeverity(action string) int {
+	return moderationSeverity[action]
+}
+
+const (
+	StrikeSourceBannedWord     = "banned_word"
+	StrikeSourceSpam           = "spam"
+	StrikeSourceDisinformation = "disinformation"
+	StrikeSourceManual         = "manual"
+)
+
+// DefaultStrikeExpiry is how long strikes count towards the ladder in channels that haven't set their own expiry.
+const DefaultStrikeExpiry = "30d"
+
+const strikeIDLength = 8
+
+var strikeWeights = map[string]int{
+	StrikeSourceBannedWord:     2,
+	StrikeSourceSpam:           1,
+	StrikeSourceDisinformation: 1,
+	StrikeSourceManual:         1,
+}
+
+// StrikeWeight returns how many strikes a moderation source adds by default.
+func StrikeWeight(source string) int {
+	if w, ok := strikeWeights[source]; ok {
+		return w
+	}
+	return 1
+}
+
+// Strike is a mark against a user in a channel from one of the moderation sources. Strikes count towards the
+// channel's strike ladder, by their weight, until they expire.
+type Strike struct {
+	ID        string    `firestore:"id" json:"id"`
+	Nick      string    `firestore:"nick" json:"nick"`
+	Host      string    `firestore:"host" json:"host"`
+	Account   string    `firestore:"account" json:"account"`
+	Source    string    `firestore:"source" json:"source"`
+	Reason    string    `firestore:"reason" json:"reason"`
+	Weight    int       `firestore:"weight" json:"weight"`
+	CreatedBy string    `firestore:"created_by" json:"created_by"`
+	CreatedAt time.Time `firestore:"created_at" json:"created_at"`
+	ExpiresAt time.Time `firestore:"expires_at" json:"expires_at"`
+}
+
+func NewStrike(nick, host, account, source, reason string, weight int, expiry time.Duration, createdBy string) *Strike {
+	now := time.Now()
+	return &Strike{
+		ID:        uuid.NewString()[:strikeIDLength],
+		Nick:      nick,
+		Host:      host,
+		Account:   account,
+		Source:    source,
+		Reason:    reason,
+		Weight:    weight,
+		CreatedBy: createdBy,
+		CreatedAt: now,
+		ExpiresAt: now.Add(expiry),
+	}
+}
+
+// IsFor returns whether the strike was recorded against the user, by account when both are known, otherwise by host,
+// so that changing nick doesn't escape the ladder. Users of a shared host, such as a web gateway, are told apart by
+// nick as well, so that they don't pool their strikes.
+func (s *Strike) IsFor(nick, host, account string) bool {
+	if len(s.Account) > 0 && len(account) > 0 {
+		return strings.EqualFold(s.Account, account)
+	}
+
+	if len(s.Host) > 0 && len(host) > 0 {
+		if irc.IsSharedHost(host) {
+			return s.Host == host && strings.EqualFold(s.Nick, nick)
+		}
+		return s.Host == host
+	}
+
+	return strings.EqualFold(s.Nick, nick)
+}
+
+func (s *Strike) IsActive(now time.Time) bool {
+	return now.Before(s.ExpiresAt)
+}
+
+// ActiveStrikes returns the total weight of the strikes that haven't expired.
+func ActiveStrikes(strikes []*Strike, now time.Time) int {
+	total := 0
+	for _, s := range strikes {
+		if s.IsActive(now) {
+			total += s.Weight
+		}
+	}
+	return total
+}
+
+// StrikeStep is a rung of a channel's strike ladder, taking Action once a user's active strikes reach Strikes.
+// Duration applies to mutes and bans, which are indefinite without one.
+type StrikeStep struct {
+	Strikes  int    `firestore:"strikes" json:"strikes"`
+	Action   string `firestore:"action" json:"action"`
+	Duration string `firestore:"duration" json:"duration"`
+}
+
+// DefaultStrikeLadder returns a ladder that warns, then mutes for longer each time, then bans for a day.
+func DefaultStrikeLadder() []StrikeStep {
+	return []StrikeStep{
+		{Strikes: 1, Action: ModerationActionWarn},
+		{Strikes: 2, Action: ModerationActionMute, Duration: "10m"},
+		{Strikes: 3, Action: ModerationActionMute, Duration: "1h"},
+		{Strikes: 4, Action: ModerationActionBan, Duration: "24h"},
+	}
+}
+
+// Outranks returns whether the step's action is more severe than the other's, or the same mute or ban for longer.
+// Mutes and bans without a duration are indefinite, so they outrank those with one.
+func (s StrikeStep) Outranks(other StrikeStep) bool {
+	if ModerationSeverity(s.Action) != ModerationSeverity(other.Action) {
+		return ModerationSeverity(s.Action) > ModerationSeverity(other.Action)
+	}
+
+	if (s.Action != ModerationActionMute && s.Action != ModerationActionBan) || s.Duration == other.Duration {
+		return false
+	}
+	if len(s.Duration) == 0 || len(other.Duration) == 0 {
+		return len(s.Duration) == 0
+	}
+
+	d, err := elapse.ParseDuration(s.Duration)
+	if err != nil {
+		return false
+	}
+	o, err := elapse.ParseDuration(other.Duration)
+	if err != nil {
+		return true
+	}
+	return d > o
+}
+
+func (s StrikeStep) Validate() error {
+	if s.Strikes < 1 {
+		return fmt.Errorf("invalid strikes, %d", s.Strikes)
+	}
+	if !slices.Contains(ModerationActions, s.Action) {
+		return fmt.Errorf("invalid action, %s", s.Action)
+	}
+	if len(s.Duration) > 0 && !elapse.IsDuration(s.Duration) {
+		return fmt.Errorf("invalid duration, %s", s.Duration)
+	}
+	return nil
+}
+
+// ValidateStrikeLadder checks each step, and that no two steps are for the same number of strikes.
+func ValidateStrikeLadder(steps []StrikeStep) error {
+	seen := make(map[int]bool)
+	for _, s := range steps {
+		if err := s.Validate(); err != nil {
+			return err
+		}
+		if seen[s.Strikes] {
+			return fmt.Errorf("more than one step for %d strikes", s.Strikes)
+		}
+		seen[s.Strikes] = true
+	}
+	return nil
+}
+
+// HasStrikeLadder reports whether strikes in the channel escalate through a ladder rather than leaving each
+// moderation source to take its own action.
+func (ch *Channel) HasStrikeLadder() bool {
+	return len(ch.StrikeLadder) > 0
+}
+
+// StrikeLadderStep returns the highest step the active strikes reach, or nil if they don't reach the first.
+func (ch *Channel) StrikeLadderStep(strikes int) *StrikeStep {
+	var step *StrikeStep
+	for i, s := range ch.StrikeLadder {
+		if s.Strikes <= strikes && (step == nil || s.Strikes > step.Strikes) {
+			step = &ch.StrikeLadder[i]
+		}
+	}
+	return step
+}
+
+// StrikeExpiryDuration returns how long strikes in the channel stay active.
+func (ch *Channel) StrikeExpiryDuration() time.Duration {
+	if d, err := elapse.ParseDuration(ch.StrikeExpiry); err == nil && d > 0 {
+		return d
+	}
+	d, _ := elapse.ParseDuration(DefaultStrikeExpiry)
+	return d
+}
@@ -0,0 +1,140 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStrikeLadderStep(t *testing.T) {
+	ch := NewChannel("#channel", "")
+	if ch.HasStrikeLadder() {
+		t.Fatal("new channel shouldn't have a strike ladder")
+	}
+
+	ch.StrikeLadder = []StrikeStep{
+		{Strikes: 4, Action: ModerationActionBan},
+		{Strikes: 2, Action: ModerationActionMute, Duration: "10m"},
+		{Strikes: 1, Action: ModerationActionWarn},
+	}
+
+	tests := []struct {
+		strikes int
+		want    string
+	}{
+		{0, ""},
+		{1, ModerationActionWarn},
+		{2, ModerationActionMute},
+		{3, ModerationActionMute},
+		{7, ModerationActionBan},
+	}
+
+	for _, tt := range tests {
+		step := ch.StrikeLadderStep(tt.strikes)
+		got := ""
+		if step != nil {
+			got = step.Action
+		}
+		if got != tt.want {
+			t.Errorf("StrikeLadderStep(%d) = %q, want %q", tt.strikes, got, tt.want)
+		}
+	}
+}
+
+func TestActiveStrikes(t *testing.T) {
+	now := time.Now()
+	strikes := []*Strike{
+		NewStrike("nick", "host", "", StrikeSourceBannedWord, "", StrikeWeight(StrikeSourceBannedWord), time.Hour, "assistant"),
+		NewStrike("nick", "host", "", StrikeSourceManual, "", 1, time.Hour, "owner"),
+		NewStrike("nick", "host", "", StrikeSourceSpam, "", 1, time.Minute, "assistant"),
+	}
+
+	if got := ActiveStrikes(strikes, now); got != 4 {
+		t.Errorf("ActiveStrikes() = %d, want 4", got)
+	}
+	if got := ActiveStrikes(strikes, now.Add(10*time.Minute)); got != 3 {
+		t.Errorf("ActiveStrikes() after the spam strike expired = %d, want 3", got)
+	}
+	if got := ActiveStrikes(strikes, now.Add(2*time.Hour)); got != 0 {
+		t.Errorf("ActiveStrikes() after every strike expired = %d, want 0", got)
+	}
+}
+
+func TestValidateStrikeLadder(t *testing.T) {
+	if err := ValidateStrikeLadder(DefaultStrikeLadder()); err != nil {
+		t.Fatalf("default ladder is invalid: %v", err)
+	}
+
+	invalid := [][]StrikeStep{
+		{{Strikes: 0, Action: ModerationActionWarn}},
+		{{Strikes: 1, Action: "shame"}},
+		{{Strikes: 2, Action: ModerationActionMute, Duration: "soon"}},
+		{{Strikes: 2, Action: ModerationActionMute}, {Strikes: 2, Action: ModerationActionKick}},
+	}
+	for _, steps := range invalid {
+		if err := ValidateStrikeLadder(steps); err == nil {
+			t.Errorf("ValidateStrikeLadder(%v) = nil, want an error", steps)
+		}
+	}
+}
+
+func TestStrikeExpiryDuration(t *testing.T) {
+	ch := NewChannel("#channel", "")
+	if got := ch.StrikeExpiryDuration(); got != 30*24*time.Hour {
+		t.Errorf("default expiry = %s, want 30 days", got)
+	}
+
+	ch.StrikeExpiry = "12h"
+	if got := ch.StrikeExpiryDuration(); got != 12*time.Hour {
+		t.Errorf("expiry = %s, want 12h", got)
+	}
+}
+
+func TestStrikeIsFor(t *testing.T) {
+	byHost := NewStrike("nick", "host", "", StrikeSourceSpam, "", 1, time.Hour, "assistant")
+	byAccount := NewStrike("nick", "host", "account", StrikeSourceSpam, "", 1, time.Hour, "assistant")
+	noHost := NewStrike("nick", "", "", StrikeSourceManual, "", 1, time.Hour, "owner")
+	gateway := NewStrike("nick", "gateway/web/kiwiirc/ip.192.0.2.1", "", StrikeSourceSpam, "", 1, time.Hour, "assistant")
+
+	tests := []struct {
+		name                string
+		strike              *Strike
+		nick, host, account string
+		want                bool
+	}{
+		{"same host, new nick", byHost, "other", "host", "", true},
+		{"same nick, other host", byHost, "nick", "elsewhere", "", false},
+		{"same account, other host", byAccount, "other", "elsewhere", "ACCOUNT", true},
+		{"other account, same host", byAccount, "nick", "host", "someone", false},
+		{"unidentified, same host", byAccount, "other", "host", "", true},
+		{"no host recorded, same nick", noHost, "NICK", "host", "", true},
+		{"shared host, other nick", gateway, "other", "gateway/web/kiwiirc/ip.192.0.2.1", "", false},
+		{"shared host, same nick", gateway, "Nick", "gateway/web/kiwiirc/ip.192.0.2.1", "", true},
+	}
+
+	for _, tt := range tests {
+		if got := tt.strike.IsFor(tt.nick, tt.host, tt.account); got != tt.want {
+			t.Errorf("%s: IsFor() = %t, want %t", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestStrikeStepOutranks(t *testing.T) {
+	tests := []struct {
+		step, other StrikeStep
+		want        bool
+	}{
+		{StrikeStep{Action: ModerationActionBan}, StrikeStep{Action: ModerationActionMute, Duration: "1h"}, true},
+		{StrikeStep{Action: ModerationActionWarn}, StrikeStep{Action: ModerationActionKick}, false},
+		{StrikeStep{Action: ModerationActionMute, Duration: "1h"}, StrikeStep{Action: ModerationActionMute, Duration: "10m"}, true},
+		{StrikeStep{Action: ModerationActionMute, Duration: "10m"}, StrikeStep{Action: ModerationActionMute, Duration: "1h"}, false},
+		{StrikeStep{Action: ModerationActionBan}, StrikeStep{Action: ModerationActionBan, Duration: "24h"}, true},
+		{StrikeStep{Action: ModerationActionBan, Duration: "24h"}, StrikeStep{Action: ModerationActionBan}, false},
+		{StrikeStep{Action: ModerationActionKick}, StrikeStep{Action: ModerationActionKick}, false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.step.Outranks(tt.other); got != tt.want {
+			t.Errorf("%+v.Outranks(%+v) = %t, want %t", tt.step, tt.other, got, tt.want)
+		}
+	}
+}
@@ -30,7 +30,9 @@ const (
 	TaskTypeDashboardRequest                 = "dashboard_request"
 	TaskTypeDashboardResponse                = "dashboard_response"
 	TaskTypePersistentChannelStats           = "persistent_channel_stats"
+	TaskTypePersistentChannelFeeds           = "persistent_channel_feeds"
 	TaskTypeTriviaStart                      = "trivia_start"
+	TaskTypePollClose                        = "poll_close"
 )
 
 const (
@@ -48,6 +50,7 @@ type Task struct {
 	CreatedAt     time.Time `firestore:"created_at,omitempty" json:"created_at,omitempty"`
 	DueAt         time.Time `firestore:"due_at" json:"due_at"`
 	Status        string    `firestore:"status,omitempty" json:"status,omitempty"`
+	Network       string    `firestore:"network,omitempty" json:"network,omitempty"`
 	CloudTaskName string    `firestore:"cloud_task_name,omitempty" json:"cloud_task_name,omitempty"`
 	Data          any       `firestore:"data,omitempty" json:"data,omitempty"`
 }
@@ -148,10 +151,18 @@ func DeserializeTask(data []byte) (*Task, error) {
 		if task.Data, err = deserializeTaskData[PersistentTaskData](d); err != nil {
 			return nil, err
 		}
+	case TaskTypePersistentChannelFeeds:
+		if task.Data, err = deserializeTaskData[PersistentTaskData](d); err != nil {
+			return nil, err
+		}
 	case TaskTypeTriviaStart:
 		if task.Data, err = deserializeTaskData[TriviaStartTaskData](d); err != nil {
 			return nil, err
 		}
+	case TaskTypePollClose:
+		if task.Data, err = deserializeTaskData[PollCloseTaskData](d); err != nil {
+			return nil, err
+		}
 	}
 
 	return &task, nil
@@ -181,7 +192,8 @@ func (t *Task) IsDurable() bool {
 		TaskTypeBanRemoval,
 		TaskTypeMuteRemoval,
 		TaskTypeDisinformationMutePenaltyRemoval,
-		TaskTypeDisinformationBanPenaltyRemoval:
+		TaskTypeDisinformationBanPenaltyRemoval,
+		TaskTypePollClose:
 		return true
 	case TaskTypeDashboardRequest:
 		data, ok := t.Data.(DashboardRequestTaskData)
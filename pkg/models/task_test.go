@@ -12,6 +12,7 @@ func TestTaskIsDurable(t *testing.T) {
 		TaskTypeMuteRemoval,
 		TaskTypeDisinformationMutePenaltyRemoval,
 		TaskTypeDisinformationBanPenaltyRemoval,
+		TaskTypePollClose,
 	}
 	for _, taskType := range durableTypes {
 		t.Run(taskType, func(t *testing.T) {
@@ -35,6 +36,7 @@ func TestTaskIsDurable(t *testing.T) {
 		TaskTypeProxyRedditSearchResponse,
 		TaskTypeDashboardResponse,
 		TaskTypePersistentChannelStats,
+		TaskTypePersistentChannelFeeds,
 		TaskTypeTriviaStart,
 	}
 	for _, taskType := range ephemeralTypes {
@@ -0,0 +1,68 @@
+package models
+
+import (
+	"assistant/pkg/api/irc"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const PrefixTell = "tell"
+
+const (
+	// MaxPendingTellsPerRecipient is how many undelivered messages a user can have waiting in a channel.
+	MaxPendingTellsPerRecipient = 10
+
+	// MaxPendingTellsPerSender is how many undelivered messages a user can leave for the same recipient.
+	MaxPendingTellsPerSender = 3
+)
+
+const (
+	TellDeliveryChannel = "channel"
+	TellDeliveryPrivate = "private"
+)
+
+// Tell is a message left for a user, delivered the next time they speak or join the channel. The recipient's mask and
+// account, when known, are kept alongside the nick so that the message still reaches them after a nick change.
+type Tell struct {
+	ID        string    `firestore:"id"`
+	From      string    `firestore:"from"`
+	To        string    `firestore:"to"`
+	ToUserID  string    `firestore:"to_user_id"`
+	ToHost    string    `firestore:"to_host"`
+	ToAccount string    `firestore:"to_account"`
+	Message   string    `firestore:"message"`
+	CreatedAt time.Time `firestore:"created_at"`
+}
+
+func NewTell(from string, to *User, message string) *Tell {
+	return &Tell{
+		ID:        fmt.Sprintf("%s-%s", PrefixTell, uuid.NewString()),
+		From:      from,
+		To:        to.Nick,
+		ToUserID:  to.UserID,
+		ToHost:    to.Host,
+		ToAccount: to.Account,
+		Message:   strings.TrimSpace(message),
+		CreatedAt: time.Now(),
+	}
+}
+
+// IsFor returns whether the tell is addressed to the user with the mask, by account, nick, or user ID and host.
+func (t *Tell) IsFor(mask *irc.Mask) bool {
+	if len(t.ToAccount) > 0 && len(mask.Account) > 0 {
+		return strings.EqualFold(t.ToAccount, mask.Account)
+	}
+
+	if strings.EqualFold(t.To, mask.Nick) {
+		return true
+	}
+
+	return len(t.ToHost) > 0 && len(t.ToUserID) > 0 && t.ToHost == mask.Host && strings.TrimPrefix(t.ToUserID, "~") == strings.TrimPrefix(mask.UserID, "~")
+}
+
+func IsValidTellDelivery(delivery string) bool {
+	return delivery == TellDeliveryChannel || delivery == TellDeliveryPrivate
+}
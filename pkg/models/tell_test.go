@@ -0,0 +1,36 @@
+package models
+
+import (
+	"assistant/pkg/api/irc"
+	"testing"
+)
+
+func TestTellIsFor(t *testing.T) {
+	tell := NewTell("alice", &User{Nick: "bob", UserID: "~bob", Host: "bob.example.com"}, " see you ")
+	if tell.Message != "see you" {
+		t.Errorf("Message = %q, want %q", tell.Message, "see you")
+	}
+
+	tests := []struct {
+		name string
+		mask *irc.Mask
+		want bool
+	}{
+		{"same nick", &irc.Mask{Nick: "Bob", UserID: "other", Host: "other.example.com"}, true},
+		{"changed nick", &irc.Mask{Nick: "bob_away", UserID: "bob", Host: "bob.example.com"}, true},
+		{"other user", &irc.Mask{Nick: "carol", UserID: "~bob", Host: "carol.example.com"}, false},
+	}
+	for _, tt := range tests {
+		if got := tell.IsFor(tt.mask); got != tt.want {
+			t.Errorf("%s: IsFor(%s) = %v, want %v", tt.name, tt.mask, got, tt.want)
+		}
+	}
+
+	tell.ToAccount = "bobby"
+	if tell.IsFor(&irc.Mask{Nick: "bob", Account: "mallory"}) {
+		t.Errorf("IsFor() = true for another account using the nick, want false")
+	}
+	if !tell.IsFor(&irc.Mask{Nick: "robert", Account: "bobby"}) {
+		t.Errorf("IsFor() = false for the account under another nick, want true")
+	}
+}
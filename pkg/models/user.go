@@ -11,14 +11,17 @@ type User struct {
 	Nick                 string          `firestore:"nick"`
 	UserID               string          `firestore:"user_id"`
 	Host                 string          `firestore:"host"`
+	Account              string          `firestore:"account"`
 	Karma                int             `firestore:"karma"`
 	Penalty              int             `firestore:"penalty"`
 	ExtendedPenalty      int             `firestore:"extended_penalty"`
 	Location             string          `firestore:"location"`
+	TimeZone             string          `firestore:"time_zone"`
 	IsAutoVoiced         bool            `firestore:"is_auto_voiced"`
 	HighCredibilityCount int             `firestore:"high_credibility_count"`
 	LowCredibilityCount  int             `firestore:"low_credibility_count"`
 	RecentMessages       []RecentMessage `firestore:"recent_messages"`
+	TellDelivery         string          `firestore:"tell_delivery"`
 	CreatedAt            time.Time       `firestore:"created_at"`
 	UpdatedAt            time.Time       `firestore:"updated_at"`
 }
@@ -33,6 +36,7 @@ func NewUser(mask *irc.Mask) *User {
 		Nick:            mask.Nick,
 		UserID:          mask.UserID,
 		Host:            mask.Host,
+		Account:         mask.Account,
 		Karma:           0,
 		Penalty:         0,
 		ExtendedPenalty: 0,
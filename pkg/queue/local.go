@@ -0,0 +1,304 @@
+package queue
+
+import (
+	"assistant/pkg/config"
+	"assistant/pkg/localdb"
+	"assistant/pkg/log"
+	"assistant/pkg/models"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+const defaultLocalQueuePath = "queue"
+const localPollInterval = 250 * time.Millisecond
+const localAckDeadline = 10 * time.Minute
+const localRetryDelay = 10 * time.Second
+const localMaxRetryDelay = 5 * time.Minute
+const localMaxDeliveries = 10
+const localReceiveWorkers = 4
+
+// localIdleSettle is how long after its last write a queue file is trusted to be unchanged while its modification time
+// stays the same. File times are coarse, so a write moments after a poll could leave it as it was.
+const localIdleSettle = time.Second
+
+var bucketMessages = []byte("messages")
+
+var localNotifiersMu sync.Mutex
+var localNotifiers = map[string]chan struct{}{}
+
+// localQueue is a disk-backed queue shared by processes on the same host. Each topic is a bbolt file whose messages
+// are keyed by the time they next become available, so a received message is leased by moving it forward by the ack
+// deadline and is redelivered if the receiver exits without acknowledging it.
+type localQueue struct {
+	ctx          context.Context
+	cfg          *config.Config
+	db           *localdb.DB
+	subscription string
+	startedAt    time.Time
+	notify       chan struct{}
+
+	// idleModTime is the file's modification time when it was last found to have nothing available, and idleUntil
+	// when its first message becomes available, so that polls can skip opening a file that hasn't changed.
+	idleModTime time.Time
+	idleUntil   time.Time
+}
+
+type localMessage struct {
+	Data       []byte `json:"data"`
+	Deliveries int    `json:"deliveries"`
+}
+
+func newLocalQueue(ctx context.Context, cfg *config.Config, topic, subscription string) (*localQueue, error) {
+	dir := cfg.Queue.Path
+	if len(dir) == 0 {
+		dir = defaultLocalQueuePath
+	}
+
+	db, err := localdb.OpenShared(filepath.Join(dir, topic+".db"), bucketMessages)
+	if err != nil {
+		return nil, fmt.Errorf("error opening local queue %s, %s", topic, err)
+	}
+
+	return &localQueue{
+		ctx:          ctx,
+		cfg:          cfg,
+		db:           db,
+		subscription: subscription,
+		startedAt:    time.Now(),
+		notify:       localNotifier(db.Path()),
+	}, nil
+}
+
+// localNotifier returns the channel used to wake receivers of a queue in this process when a message is published,
+// rather than waiting for the next poll.
+func localNotifier(path string) chan struct{} {
+	localNotifiersMu.Lock()
+	defer localNotifiersMu.Unlock()
+
+	if n, ok := localNotifiers[path]; ok {
+		return n
+	}
+
+	n := make(chan struct{}, 1)
+	localNotifiers[path] = n
+	return n
+}
+
+func (q *localQueue) StartedAt() time.Time {
+	return q.startedAt
+}
+
+func (q *localQueue) Close() error {
+	return nil
+}
+
+func (q *localQueue) Publish(task *models.Task) error {
+	logger := log.Logger()
+
+	data, err := task.Serialize()
+	if err != nil {
+		return fmt.Errorf("error serializing task, %s", err)
+	}
+
+	value, err := json.Marshal(localMessage{Data: data})
+	if err != nil {
+		return fmt.Errorf("error encoding message, %s", err)
+	}
+
+	var seq uint64
+	err = q.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketMessages)
+		seq, err = b.NextSequence()
+		if err != nil {
+			return err
+		}
+		return b.Put(messageKey(time.Now(), seq), value)
+	})
+	if err != nil {
+		return fmt.Errorf("error publishing task %s: %w", task.ID, err)
+	}
+
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+
+	logger.Debugf(nil, "published %d: %s", seq, string(data))
+	return nil
+}
+
+func (q *localQueue) Receive(callback func(*models.Task) error) error {
+	if len(q.subscription) == 0 {
+		return fmt.Errorf("queue has no subscription configured")
+	}
+
+	workers := make(chan struct{}, localReceiveWorkers)
+	ticker := time.NewTicker(localPollInterval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			workers <- struct{}{}
+
+			key, msg, err := q.claim()
+			if err != nil {
+				log.Logger().Errorf(nil, "error receiving from local queue, %s", err)
+			}
+			if key == nil {
+				<-workers
+				break
+			}
+
+			go func() {
+				defer func() { <-workers }()
+				q.deliver(key, msg, callback)
+			}()
+		}
+
+		select {
+		case <-q.ctx.Done():
+			return nil
+		case <-ticker.C:
+		case <-q.notify:
+		}
+	}
+}
+
+// claim leases the next available message by moving it forward by the ack deadline.
+func (q *localQueue) claim() ([]byte, *localMessage, error) {
+	now := time.Now()
+
+	modTime, err := q.db.ModTime()
+	if err == nil && !q.idleModTime.IsZero() && modTime.Equal(q.idleModTime) && (q.idleUntil.IsZero() || now.Before(q.idleUntil)) {
+		return nil, nil, nil
+	}
+
+	available := false
+	err = q.db.View(func(tx *bolt.Tx) error {
+		k, _ := tx.Bucket(bucketMessages).Cursor().First()
+		available = k != nil && !messageAvailableAt(k).After(now)
+
+		q.idleModTime, q.idleUntil = time.Time{}, time.Time{}
+		if !available && now.Sub(modTime) > localIdleSettle {
+			q.idleModTime = modTime
+			if k != nil {
+				q.idleUntil = messageAvailableAt(k)
+			}
+		}
+		return nil
+	})
+	if err != nil || !available {
+		return nil, nil, err
+	}
+
+	var key []byte
+	var msg *localMessage
+	err = q.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketMessages)
+		k, v := b.Cursor().First()
+		if k == nil || messageAvailableAt(k).After(now) {
+			return nil
+		}
+
+		m := &localMessage{}
+		if err := json.Unmarshal(v, m); err != nil {
+			log.Logger().Errorf(nil, "discarding undecodable message, %s", err)
+			return b.Delete(k)
+		}
+		m.Deliveries++
+
+		value, err := json.Marshal(m)
+		if err != nil {
+			return err
+		}
+
+		leased := messageKey(now.Add(localAckDeadline), messageSequence(k))
+		if err = b.Delete(k); err != nil {
+			return err
+		}
+		if err = b.Put(leased, value); err != nil {
+			return err
+		}
+
+		key, msg = leased, m
+		return nil
+	})
+
+	return key, msg, err
+}
+
+func (q *localQueue) deliver(key []byte, msg *localMessage, callback func(*models.Task) error) {
+	logger := log.Logger()
+	logger.Debugf(nil, "received: %s", string(msg.Data))
+
+	err := processMessage(msg.Data, callback)
+	if err == nil {
+		q.ack(key, nil)
+		return
+	}
+
+	logger.Errorf(nil, "%s", err)
+
+	if msg.Deliveries >= localMaxDeliveries {
+		logger.Errorf(nil, "dropping message after %d deliveries: %s", msg.Deliveries, string(msg.Data))
+		q.ack(key, nil)
+		return
+	}
+
+	q.ack(key, msg)
+}
+
+// ack removes a leased message, or when retry is set, makes it available again after a delay that grows with the
+// number of deliveries.
+func (q *localQueue) ack(key []byte, retry *localMessage) {
+	err := q.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketMessages)
+		if err := b.Delete(key); err != nil {
+			return err
+		}
+
+		if retry == nil {
+			return nil
+		}
+
+		value, err := json.Marshal(retry)
+		if err != nil {
+			return err
+		}
+
+		return b.Put(messageKey(time.Now().Add(retryDelay(retry.Deliveries)), messageSequence(key)), value)
+	})
+	if err != nil {
+		log.Logger().Errorf(nil, "error acknowledging local queue message, %s", err)
+	}
+}
+
+func retryDelay(deliveries int) time.Duration {
+	delay := time.Duration(deliveries) * localRetryDelay
+	if delay > localMaxRetryDelay {
+		return localMaxRetryDelay
+	}
+	return delay
+}
+
+func messageKey(availableAt time.Time, seq uint64) []byte {
+	k := make([]byte, 16)
+	binary.BigEndian.PutUint64(k[:8], uint64(availableAt.UnixNano()))
+	binary.BigEndian.PutUint64(k[8:], seq)
+	return k
+}
+
+func messageAvailableAt(key []byte) time.Time {
+	return time.Unix(0, int64(binary.BigEndian.Uint64(key[:8])))
+}
+
+func messageSequence(key []byte) uint64 {
+	return binary.BigEndian.Uint64(key[8:])
+}
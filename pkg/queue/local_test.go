@@ -0,0 +1,138 @@
+package queue
+
+import (
+	"assistant/pkg/config"
+	"assistant/pkg/log"
+	"assistant/pkg/models"
+	"context"
+	"errors"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+func newTestLocalQueue(t *testing.T, ctx context.Context) *localQueue {
+	t.Helper()
+	log.InitializeConsoleLogger()
+
+	cfg := &config.Config{Queue: config.QueueConfig{Backend: config.QueueBackendLocal, Path: t.TempDir()}}
+	q, err := newLocalQueue(ctx, cfg, "topic", "subscription")
+	if err != nil {
+		t.Fatalf("newLocalQueue() error = %v", err)
+	}
+
+	return q
+}
+
+func TestLocalQueuePublishReceive(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	q := newTestLocalQueue(t, ctx)
+	task := models.NewReminderTask(time.Now(), "nick", "#channel", "remember this")
+	if err := q.Publish(task); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	received := make(chan *models.Task, 1)
+	go func() {
+		_ = q.Receive(func(task *models.Task) error {
+			received <- task
+			return nil
+		})
+	}()
+
+	select {
+	case got := <-received:
+		if got.ID != task.ID {
+			t.Fatalf("received task %q, want %q", got.ID, task.ID)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("task was not received")
+	}
+}
+
+func TestLocalQueueRedeliversFailedMessage(t *testing.T) {
+	q := newTestLocalQueue(t, context.Background())
+	task := models.NewReminderTask(time.Now(), "nick", "#channel", "remember this")
+	if err := q.Publish(task); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	key, msg, err := q.claim()
+	if err != nil || key == nil {
+		t.Fatalf("claim() = %v, %v, want message", key, err)
+	}
+
+	if again, _, _ := q.claim(); again != nil {
+		t.Fatal("claim() returned a leased message")
+	}
+
+	var calls atomic.Int32
+	q.deliver(key, msg, func(*models.Task) error {
+		calls.Add(1)
+		return errors.New("temporary failure")
+	})
+
+	if calls.Load() != 1 {
+		t.Fatalf("callback called %d times, want 1", calls.Load())
+	}
+
+	if again, _, _ := q.claim(); again != nil {
+		t.Fatal("claim() returned a failed message before its retry delay")
+	}
+
+	count := 0
+	if err = q.db.View(func(tx *bolt.Tx) error {
+		count = tx.Bucket(bucketMessages).Stats().KeyN
+		return nil
+	}); err != nil {
+		t.Fatalf("View() error = %v", err)
+	}
+
+	if count != 1 {
+		t.Fatalf("queue has %d messages, want failed message requeued", count)
+	}
+}
+
+func TestLocalQueueSkipsUnchangedFile(t *testing.T) {
+	q := newTestLocalQueue(t, context.Background())
+
+	settled := time.Now().Add(-time.Minute)
+	if err := os.Chtimes(q.db.Path(), settled, settled); err != nil {
+		t.Fatalf("Chtimes() error = %v", err)
+	}
+
+	if key, _, err := q.claim(); key != nil || err != nil {
+		t.Fatalf("claim() of an empty queue = %v, %v, want nothing", key, err)
+	}
+	if !q.idleModTime.Equal(settled) {
+		t.Fatalf("idleModTime = %s, want %s", q.idleModTime, settled)
+	}
+
+	// another process publishing changes the file, which the next poll must notice
+	other, err := newLocalQueue(context.Background(), q.cfg, "topic", "")
+	if err != nil {
+		t.Fatalf("newLocalQueue() error = %v", err)
+	}
+	if err = other.Publish(models.NewReminderTask(time.Now(), "nick", "#channel", "remember this")); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	if key, _, err := q.claim(); key == nil || err != nil {
+		t.Fatalf("claim() after a publish = %v, %v, want a message", key, err)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	if got := retryDelay(1); got != localRetryDelay {
+		t.Fatalf("retryDelay(1) = %s, want %s", got, localRetryDelay)
+	}
+
+	if got := retryDelay(100); got != localMaxRetryDelay {
+		t.Fatalf("retryDelay(100) = %s, want %s", got, localMaxRetryDelay)
+	}
+}
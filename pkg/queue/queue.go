@@ -72,6 +72,15 @@ func initializeNamed(ctx context.Context, cfg *config.Config, name, topic, subsc
 		return q, nil
 	}
 
+	if cfg.Queue.IsLocal() {
+		q, err := newLocalQueue(ctx, cfg, topic, subscription)
+		if err != nil {
+			return nil, err
+		}
+		instances[name] = q
+		return q, nil
+	}
+
 	client, err := pubsub.NewClient(ctx, cfg.GoogleCloud.ProjectID, option.WithCredentialsFile(cfg.GoogleCloud.ServiceAccountFilename))
 	if err != nil {
 		return nil, fmt.Errorf("error creating pubsub client, %s", err)
@@ -0,0 +1,310 @@
+package scheduler
+
+import (
+	"assistant/pkg/config"
+	"assistant/pkg/localdb"
+	"assistant/pkg/log"
+	"assistant/pkg/models"
+	"assistant/pkg/queue"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+const defaultLocalPath = "queue"
+const localFilename = "scheduler.db"
+const localMaxWait = time.Second
+const localPublishRetryDelay = 30 * time.Second
+
+var bucketTimers = []byte("timers")
+var bucketNames = []byte("names")
+
+// local schedules tasks without Cloud Tasks. Timers are kept in a bbolt file ordered by due time, so pending tasks
+// are reloaded after a restart and anything that came due while the assistant was down fires immediately. A due task
+// is published to the default queue, where the usual run tracking and ScheduledTaskMaxRuns retries apply.
+type local struct {
+	ctx     context.Context
+	cfg     *config.Config
+	db      *localdb.DB
+	wake    chan struct{}
+	publish func(task *models.Task) error
+}
+
+func newLocal(ctx context.Context, cfg *config.Config) (*local, error) {
+	dir := cfg.Queue.Path
+	if len(dir) == 0 {
+		dir = defaultLocalPath
+	}
+
+	db, err := localdb.Open(filepath.Join(dir, localFilename), bucketTimers, bucketNames)
+	if err != nil {
+		return nil, fmt.Errorf("error opening local scheduler, %s", err)
+	}
+
+	return &local{
+		ctx:  ctx,
+		cfg:  cfg,
+		db:   db,
+		wake: make(chan struct{}, 1),
+		publish: func(task *models.Task) error {
+			return queue.GetDefault().Publish(task)
+		},
+	}, nil
+}
+
+func (l *local) Close() error {
+	return l.db.Close()
+}
+
+func (l *local) CreateTask(task *models.Task) (string, error) {
+	logger := log.Logger()
+
+	data, err := task.Serialize()
+	if err != nil {
+		return "", fmt.Errorf("error serializing task: %w", err)
+	}
+
+	name := timerName(task)
+	exists := false
+
+	err = l.db.Update(func(tx *bolt.Tx) error {
+		names := tx.Bucket(bucketNames)
+		if names.Get([]byte(name)) != nil {
+			exists = true
+			return nil
+		}
+
+		timers := tx.Bucket(bucketTimers)
+		seq, err := timers.NextSequence()
+		if err != nil {
+			return err
+		}
+
+		key := timerKey(task.DueAt, seq)
+		if err = timers.Put(key, data); err != nil {
+			return err
+		}
+
+		return names.Put([]byte(name), key)
+	})
+	if err != nil {
+		return "", fmt.Errorf("error scheduling task %s: %w", task.ID, err)
+	}
+
+	if exists {
+		logger.Debugf(nil, "scheduled task already exists (dedup): %s", name)
+		return name, nil
+	}
+
+	select {
+	case l.wake <- struct{}{}:
+	default:
+	}
+
+	logger.Debugf(nil, "scheduled task %s for %s", name, task.DueAt)
+	return name, nil
+}
+
+func (l *local) DeleteTask(taskNameOrID string) error {
+	found := false
+
+	err := l.db.Update(func(tx *bolt.Tx) error {
+		names := tx.Bucket(bucketNames)
+
+		key := names.Get([]byte(taskNameOrID))
+		if key == nil {
+			return nil
+		}
+		found = true
+
+		if err := tx.Bucket(bucketTimers).Delete(bytes.Clone(key)); err != nil {
+			return err
+		}
+		return names.Delete([]byte(taskNameOrID))
+	})
+	if err != nil {
+		return fmt.Errorf("error deleting scheduled task %s: %w", taskNameOrID, err)
+	}
+
+	if !found {
+		return fmt.Errorf("scheduled task %s not found", taskNameOrID)
+	}
+
+	log.Logger().Debugf(nil, "deleted scheduled task: %s", taskNameOrID)
+	return nil
+}
+
+func (l *local) run() {
+	for {
+		next := l.fireDue(time.Now())
+
+		wait := localMaxWait
+		if !next.IsZero() {
+			if until := time.Until(next); until < wait {
+				wait = until
+			}
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-l.ctx.Done():
+			timer.Stop()
+			return
+		case <-l.wake:
+			timer.Stop()
+		case <-timer.C:
+		}
+	}
+}
+
+// fireDue publishes every task due at or before now and returns when the next timer is due. Due timers are removed
+// before their tasks are published, so that a failed commit can't fire a task twice, and a task that fails to publish
+// is put back to be retried.
+func (l *local) fireDue(now time.Time) time.Time {
+	logger := log.Logger()
+
+	var next time.Time
+	due := make([]dueTimer, 0)
+	err := l.db.Update(func(tx *bolt.Tx) error {
+		timers := tx.Bucket(bucketTimers)
+
+		keys := make([][]byte, 0)
+		c := timers.Cursor()
+		for k, _ := c.First(); k != nil; k, _ = c.Next() {
+			if timerDueAt(k).After(now) {
+				next = timerDueAt(k)
+				break
+			}
+			keys = append(keys, bytes.Clone(k))
+		}
+
+		for _, k := range keys {
+			task, err := models.DeserializeTask(timers.Get(k))
+			if err != nil {
+				logger.Errorf(nil, "discarding undecodable scheduled task, %s", err)
+			}
+
+			name, err := l.removeTimer(tx, k)
+			if err != nil {
+				return err
+			}
+
+			if task != nil {
+				due = append(due, dueTimer{task: task, name: name})
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		logger.Errorf(nil, "error firing scheduled tasks, %s", err)
+		return next
+	}
+
+	for _, d := range due {
+		task := d.task
+		if err = l.publish(task); err != nil {
+			logger.Errorf(nil, "error publishing scheduled task %s, retrying: %s", task.ID, err)
+			retryAt := now.Add(localPublishRetryDelay)
+			if err = l.retry(d, retryAt); err != nil {
+				logger.Errorf(nil, "error rescheduling scheduled task %s, %s", task.ID, err)
+				continue
+			}
+			if next.IsZero() || retryAt.Before(next) {
+				next = retryAt
+			}
+			continue
+		}
+
+		logger.Debugf(nil, "fired scheduled task %s: %s", task.ID, task.Type)
+	}
+
+	return next
+}
+
+// dueTimer is a task removed from the timers to be published, with the name it was scheduled under.
+type dueTimer struct {
+	task *models.Task
+	name []byte
+}
+
+// retry puts back a task that failed to publish, due again at retryAt under the same name.
+func (l *local) retry(d dueTimer, retryAt time.Time) error {
+	data, err := d.task.Serialize()
+	if err != nil {
+		return fmt.Errorf("error serializing task: %w", err)
+	}
+
+	return l.db.Update(func(tx *bolt.Tx) error {
+		timers := tx.Bucket(bucketTimers)
+		seq, err := timers.NextSequence()
+		if err != nil {
+			return err
+		}
+
+		key := timerKey(retryAt, seq)
+		if err = timers.Put(key, data); err != nil {
+			return err
+		}
+
+		if d.name == nil {
+			return nil
+		}
+		return tx.Bucket(bucketNames).Put(d.name, key)
+	})
+}
+
+// removeTimer deletes the timer and returns the name it was scheduled under, if any.
+func (l *local) removeTimer(tx *bolt.Tx, key []byte) ([]byte, error) {
+	if err := tx.Bucket(bucketTimers).Delete(key); err != nil {
+		return nil, err
+	}
+
+	names := tx.Bucket(bucketNames)
+	c := names.Cursor()
+	for k, v := c.First(); k != nil; k, v = c.Next() {
+		if bytes.Equal(v, key) {
+			name := bytes.Clone(k)
+			return name, names.Delete(name)
+		}
+	}
+
+	return nil, nil
+}
+
+// timerName mirrors the Cloud Tasks naming: persistent tasks are named by their due time so that rescheduling the
+// same occurrence more than once is deduplicated.
+func timerName(task *models.Task) string {
+	if task.Type == models.TaskTypePersistentChannel || task.Type == models.TaskTypePersistentChannelStats || task.Type == models.TaskTypePersistentChannelFeeds {
+		channel := task.Data.(models.PersistentTaskData).Channel
+		return fmt.Sprintf("%s-%s-%d", task.ID, strings.ReplaceAll(channel, "#", ""), task.DueAt.UnixMilli())
+	}
+
+	return fmt.Sprintf("%s-%d", task.ID, time.Now().UnixNano())
+}
+
+func timerKey(dueAt time.Time, seq uint64) []byte {
+	due := dueAt.UnixNano()
+	if due < 0 {
+		due = 0
+	}
+
+	k := make([]byte, 16)
+	binary.BigEndian.PutUint64(k[:8], uint64(due))
+	binary.BigEndian.PutUint64(k[8:], seq)
+	return k
+}
+
+func timerDueAt(key []byte) time.Time {
+	return time.Unix(0, int64(binary.BigEndian.Uint64(key[:8])))
+}
+
+func timerSequence(key []byte) uint64 {
+	return binary.BigEndian.Uint64(key[8:])
+}
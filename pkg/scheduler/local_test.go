@@ -0,0 +1,173 @@
+package scheduler
+
+import (
+	"assistant/pkg/config"
+	"assistant/pkg/log"
+	"assistant/pkg/models"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func newTestLocal(t *testing.T, dir string, published *[]*models.Task) *local {
+	t.Helper()
+	log.InitializeConsoleLogger()
+
+	cfg := &config.Config{Queue: config.QueueConfig{Backend: config.QueueBackendLocal, Path: dir}}
+	l, err := newLocal(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("newLocal() error = %v", err)
+	}
+	t.Cleanup(func() { _ = l.Close() })
+
+	l.publish = func(task *models.Task) error {
+		*published = append(*published, task)
+		return nil
+	}
+
+	return l
+}
+
+func TestLocalFiresDueTasks(t *testing.T) {
+	var published []*models.Task
+	l := newTestLocal(t, t.TempDir(), &published)
+
+	now := time.Now()
+	due := models.NewReminderTask(now.Add(-time.Second), "nick", "#channel", "due")
+	later := models.NewReminderTask(now.Add(time.Hour), "nick", "#channel", "later")
+
+	for _, task := range []*models.Task{later, due} {
+		if _, err := l.CreateTask(task); err != nil {
+			t.Fatalf("CreateTask() error = %v", err)
+		}
+	}
+
+	next := l.fireDue(now)
+	if len(published) != 1 || published[0].ID != due.ID {
+		t.Fatalf("published %d tasks, want only %s", len(published), due.ID)
+	}
+
+	if !next.Equal(later.DueAt) {
+		t.Fatalf("next = %s, want %s", next, later.DueAt)
+	}
+
+	if data, ok := published[0].Data.(models.ReminderTaskData); !ok || data.Content != "due" {
+		t.Fatalf("published data = %#v, want ReminderTaskData", published[0].Data)
+	}
+
+	l.fireDue(now)
+	if len(published) != 1 {
+		t.Fatalf("task fired %d times, want once", len(published))
+	}
+}
+
+func TestLocalReloadsAfterRestart(t *testing.T) {
+	dir := t.TempDir()
+
+	var before []*models.Task
+	l := newTestLocal(t, dir, &before)
+	task := models.NewReminderTask(time.Now().Add(time.Minute), "nick", "#channel", "content")
+	if _, err := l.CreateTask(task); err != nil {
+		t.Fatalf("CreateTask() error = %v", err)
+	}
+
+	var after []*models.Task
+	restarted := newTestLocal(t, dir, &after)
+	restarted.fireDue(time.Now().Add(2 * time.Minute))
+
+	if len(after) != 1 || after[0].ID != task.ID {
+		t.Fatalf("published %d tasks after restart, want %s", len(after), task.ID)
+	}
+}
+
+func TestLocalDeleteTask(t *testing.T) {
+	var published []*models.Task
+	l := newTestLocal(t, t.TempDir(), &published)
+
+	name, err := l.CreateTask(models.NewReminderTask(time.Now(), "nick", "#channel", "content"))
+	if err != nil {
+		t.Fatalf("CreateTask() error = %v", err)
+	}
+
+	if err = l.DeleteTask(name); err != nil {
+		t.Fatalf("DeleteTask() error = %v", err)
+	}
+
+	if err = l.DeleteTask(name); err == nil {
+		t.Fatal("DeleteTask() of a deleted task succeeded, want error")
+	}
+
+	l.fireDue(time.Now().Add(time.Minute))
+	if len(published) != 0 {
+		t.Fatalf("published %d deleted tasks, want 0", len(published))
+	}
+}
+
+func TestLocalPersistentTasksAreDeduplicated(t *testing.T) {
+	var published []*models.Task
+	l := newTestLocal(t, t.TempDir(), &published)
+
+	dueAt := time.Now().Add(-time.Second)
+	for i := 0; i < 2; i++ {
+		task := models.NewPersistentTask(models.ChannelStatsTaskID, "#channel", models.TaskTypePersistentChannelStats, dueAt)
+		if _, err := l.CreateTask(task); err != nil {
+			t.Fatalf("CreateTask() error = %v", err)
+		}
+	}
+
+	l.fireDue(time.Now())
+	if len(published) != 1 {
+		t.Fatalf("published %d persistent tasks, want 1", len(published))
+	}
+}
+
+func TestLocalRetriesFailedPublish(t *testing.T) {
+	var published []*models.Task
+	l := newTestLocal(t, t.TempDir(), &published)
+	l.publish = func(*models.Task) error { return errors.New("queue unavailable") }
+
+	now := time.Now()
+	if _, err := l.CreateTask(models.NewReminderTask(now, "nick", "#channel", "content")); err != nil {
+		t.Fatalf("CreateTask() error = %v", err)
+	}
+
+	next := l.fireDue(now)
+	if want := now.Add(localPublishRetryDelay); !next.Equal(want) {
+		t.Fatalf("next = %s, want retry at %s", next, want)
+	}
+
+	l.publish = func(task *models.Task) error {
+		published = append(published, task)
+		return nil
+	}
+
+	l.fireDue(now)
+	if len(published) != 0 {
+		t.Fatalf("published %d tasks before the retry was due, want 0", len(published))
+	}
+
+	l.fireDue(next)
+	if len(published) != 1 {
+		t.Fatalf("published %d tasks after the retry was due, want 1", len(published))
+	}
+}
+
+func TestLocalRetriedPersistentTaskKeepsItsName(t *testing.T) {
+	var published []*models.Task
+	l := newTestLocal(t, t.TempDir(), &published)
+	l.publish = func(*models.Task) error { return errors.New("queue unavailable") }
+
+	now := time.Now()
+	task := models.NewPersistentTask(models.ChannelStatsTaskID, "#channel", models.TaskTypePersistentChannelStats, now)
+	name, err := l.CreateTask(task)
+	if err != nil {
+		t.Fatalf("CreateTask() error = %v", err)
+	}
+
+	l.fireDue(now)
+
+	if err = l.DeleteTask(name); err != nil {
+		t.Fatalf("DeleteTask() of a retried task error = %v", err)
+	}
+}
@@ -0,0 +1,52 @@
+package scheduler
+
+import (
+	"assistant/pkg/cloudtasks"
+	"assistant/pkg/config"
+	"assistant/pkg/models"
+	"context"
+)
+
+var instance Scheduler
+
+// Scheduler delivers a task to the default queue once it is due. Cloud Tasks is used unless the local queue
+// backend is configured, in which case tasks are scheduled in-process.
+type Scheduler interface {
+	CreateTask(task *models.Task) (string, error)
+	DeleteTask(taskNameOrID string) error
+	Close() error
+}
+
+var _ Scheduler = (*cloudtasks.CloudTasks)(nil)
+var _ Scheduler = (*local)(nil)
+
+func Get() Scheduler {
+	if instance == nil {
+		panic("scheduler is not initialized")
+	}
+	return instance
+}
+
+func Initialize(ctx context.Context, cfg *config.Config) (Scheduler, error) {
+	if instance != nil {
+		return instance, nil
+	}
+
+	if cfg.Queue.IsLocal() {
+		l, err := newLocal(ctx, cfg)
+		if err != nil {
+			return nil, err
+		}
+		go l.run()
+		instance = l
+		return instance, nil
+	}
+
+	ct, err := cloudtasks.Initialize(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	instance = ct
+	return instance, nil
+}
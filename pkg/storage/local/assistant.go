@@ -0,0 +1,26 @@
+package local
+
+import (
+	"assistant/pkg/models"
+)
+
+func (l *Local) Assistant() (*models.Assistant, error) {
+	path := l.root()
+	return get[models.Assistant](l, path)
+}
+
+func (l *Local) CreateAssistant() (*models.Assistant, error) {
+	path := l.root()
+	assistant := models.NewAssistant(l.cfg.IRC.Nick)
+	return assistant, create(l, path, assistant)
+}
+
+func (l *Local) SetAssistant(assistant *models.Assistant) error {
+	path := l.root()
+	return set(l, path, assistant)
+}
+
+func (l *Local) UpdateAssistant(fields map[string]any) error {
+	path := l.root()
+	return update(l, path, fields)
+}
@@ -0,0 +1,34 @@
+package local
+
+import (
+	"assistant/pkg/models"
+	"fmt"
+	"time"
+)
+
+const pathAuditLog = "audit-log"
+
+func (l *Local) AddAuditEntry(channel string, entry *models.AuditEntry) error {
+	path := fmt.Sprintf("%s/%s/%s/%s/%s", l.root(), pathChannels, channel, pathAuditLog, entry.ID)
+	return create(l, path, entry)
+}
+
+func (l *Local) AuditEntries(channel string) ([]*models.AuditEntry, error) {
+	path := fmt.Sprintf("%s/%s/%s/%s", l.root(), pathChannels, channel, pathAuditLog)
+
+	return query(l, QueryCriteria[models.AuditEntry]{
+		Path: path,
+		Less: func(a, b *models.AuditEntry) bool { return a.CreatedAt.After(b.CreatedAt) },
+	})
+}
+
+// AuditEntriesSince returns the channel's audit entries created at or after since, newest first.
+func (l *Local) AuditEntriesSince(channel string, since time.Time) ([]*models.AuditEntry, error) {
+	path := fmt.Sprintf("%s/%s/%s/%s", l.root(), pathChannels, channel, pathAuditLog)
+
+	return query(l, QueryCriteria[models.AuditEntry]{
+		Path:   path,
+		Filter: func(a *models.AuditEntry) bool { return !a.CreatedAt.Before(since) },
+		Less:   func(a, b *models.AuditEntry) bool { return a.CreatedAt.After(b.CreatedAt) },
+	})
+}
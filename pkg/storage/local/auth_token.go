@@ -0,0 +1,21 @@
+package local
+
+import (
+	"assistant/pkg/models"
+	"fmt"
+)
+
+func (l *Local) CreateAuthToken(token *models.AuthToken) error {
+	path := fmt.Sprintf("%s/%s/%s", l.root(), pathAuthTokens, token.Token)
+	return create(l, path, token)
+}
+
+func (l *Local) GetAuthToken(token string) (*models.AuthToken, error) {
+	path := fmt.Sprintf("%s/%s/%s", l.root(), pathAuthTokens, token)
+	return get[models.AuthToken](l, path)
+}
+
+func (l *Local) MarkAuthTokenUsed(token string) error {
+	path := fmt.Sprintf("%s/%s/%s", l.root(), pathAuthTokens, token)
+	return update(l, path, map[string]any{"used": true})
+}
@@ -0,0 +1,98 @@
+package local
+
+import (
+	"assistant/pkg/log"
+	"assistant/pkg/models"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+func (l *Local) pathToBannedWords(channel string) string {
+	return fmt.Sprintf("%s/%s/%s/%s", l.root(), pathChannels, channel, pathBannedWords)
+}
+
+func (l *Local) BannedWords(channel string) ([]*models.BannedWord, error) {
+	return list[models.BannedWord](l, l.pathToBannedWords(channel))
+}
+
+// AddBannedWord stores the banned word, replacing the settings of any entry for the same word.
+func (l *Local) AddBannedWord(channel string, bw *models.BannedWord) error {
+	bw.Normalize()
+
+	existing, err := l.findBannedWord(channel, bw.Word)
+	if err != nil {
+		return err
+	}
+
+	if existing != nil {
+		bw.ID = existing.ID
+		path := fmt.Sprintf("%s/%s", l.pathToBannedWords(channel), bw.ID)
+		return update(l, path, map[string]any{"kind": bw.Kind, "action": bw.Action, "duration": bw.Duration, "dry_run": bw.DryRun})
+	}
+
+	bw.ID = fmt.Sprintf("%s-%s", models.PrefixBannedWord, uuid.NewString())
+	path := fmt.Sprintf("%s/%s", l.pathToBannedWords(channel), bw.ID)
+	return create(l, path, bw)
+}
+
+// findBannedWord returns the entry for the word, which is stored lowercase unless it's a regex.
+func (l *Local) findBannedWord(channel, word string) (*models.BannedWord, error) {
+	lower := strings.ToLower(word)
+	bannedWords, err := query(l, QueryCriteria[models.BannedWord]{
+		Path:   l.pathToBannedWords(channel),
+		Filter: func(bw *models.BannedWord) bool { return bw.Word == word || bw.Word == lower },
+		Limit:  1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(bannedWords) == 0 {
+		return nil, nil
+	}
+
+	return bannedWords[0], nil
+}
+
+func (l *Local) UpdateBannedWord(channel, oldWord, newWord string) error {
+	bw, err := l.findBannedWord(channel, oldWord)
+	if err != nil {
+		return err
+	}
+
+	if bw == nil {
+		return fmt.Errorf("banned word not found")
+	}
+
+	bw.Word = newWord
+	bw.Normalize()
+
+	log.Logger().Debugf(nil, "updating banned word %s to %s", oldWord, newWord)
+	path := fmt.Sprintf("%s/%s", l.pathToBannedWords(channel), bw.ID)
+	return update(l, path, map[string]any{"word": bw.Word})
+}
+
+func (l *Local) IsBannedWord(channel, word string) (bool, error) {
+	bw, err := l.findBannedWord(channel, word)
+	return bw != nil, err
+}
+
+func (l *Local) RemoveBannedWord(channel, word string) error {
+	logger := log.Logger()
+
+	bw, err := l.findBannedWord(channel, word)
+	if err != nil {
+		logger.Rawf(log.Warning, "error querying banned words, %s", err)
+		return err
+	}
+
+	if bw == nil {
+		logger.Rawf(log.Debug, "no matching banned words found")
+		return nil
+	}
+
+	logger.Rawf(log.Debug, "removing %s", bw.ID)
+	return remove(l, fmt.Sprintf("%s/%s", l.pathToBannedWords(channel), bw.ID))
+}
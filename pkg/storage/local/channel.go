@@ -0,0 +1,26 @@
+package local
+
+import (
+	"assistant/pkg/models"
+	"fmt"
+)
+
+func (l *Local) Channels() ([]*models.Channel, error) {
+	path := fmt.Sprintf("%s/%s", l.root(), pathChannels)
+	return list[models.Channel](l, path)
+}
+
+func (l *Local) Channel(channel string) (*models.Channel, error) {
+	path := fmt.Sprintf("%s/%s/%s", l.root(), pathChannels, channel)
+	return get[models.Channel](l, path)
+}
+
+func (l *Local) UpdateChannel(channel string, fields map[string]any) error {
+	path := fmt.Sprintf("%s/%s/%s", l.root(), pathChannels, channel)
+	return update(l, path, fields)
+}
+
+func (l *Local) CreateChannel(channel *models.Channel) error {
+	path := fmt.Sprintf("%s/%s/%s", l.root(), pathChannels, channel.Name)
+	return create(l, path, channel)
+}
@@ -0,0 +1,24 @@
+package local
+
+import (
+	"assistant/pkg/models"
+	"fmt"
+	"time"
+)
+
+const pathStats = "stats"
+
+func (l *Local) AddChannelStats(channel string, stats *models.ChannelStats) error {
+	path := fmt.Sprintf("%s/%s/%s/%s/%d", l.root(), pathChannels, channel, pathStats, stats.Timestamp.Unix())
+	return set(l, path, stats)
+}
+
+func (l *Local) GetChannelStats(channel string, since time.Time) ([]*models.ChannelStats, error) {
+	path := fmt.Sprintf("%s/%s/%s/%s", l.root(), pathChannels, channel, pathStats)
+
+	return query(l, QueryCriteria[models.ChannelStats]{
+		Path:   path,
+		Filter: func(s *models.ChannelStats) bool { return !s.Timestamp.Before(since) },
+		Less:   func(a, b *models.ChannelStats) bool { return a.Timestamp.Before(b.Timestamp) },
+	})
+}
@@ -0,0 +1,296 @@
+package local
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// encode converts a model into its stored JSON form. Fields are keyed by their firestore tag so documents written by
+// either backend share the same shape, and callers can keep passing firestore field names to update.
+func encode(v any) ([]byte, error) {
+	doc, err := toDocument(reflect.ValueOf(v))
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(doc)
+}
+
+// decode populates t from a stored JSON document.
+func decode(data []byte, t any) error {
+	raw, err := decodeRaw(data)
+	if err != nil {
+		return err
+	}
+	return fromDocument(raw, reflect.ValueOf(t).Elem())
+}
+
+func decodeRaw(data []byte) (map[string]any, error) {
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+
+	raw := make(map[string]any)
+	if err := d.Decode(&raw); err != nil {
+		return nil, fmt.Errorf("error decoding document, %s", err)
+	}
+
+	return raw, nil
+}
+
+func toDocument(v reflect.Value) (any, error) {
+	if !v.IsValid() {
+		return nil, nil
+	}
+
+	if v.Type() == timeType {
+		return v.Interface(), nil
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil, nil
+		}
+		return toDocument(v.Elem())
+	case reflect.Struct:
+		doc := make(map[string]any)
+		for i := 0; i < v.NumField(); i++ {
+			f := v.Type().Field(i)
+			if !f.IsExported() {
+				continue
+			}
+
+			name, omitEmpty := fieldName(f)
+			if name == "-" {
+				continue
+			}
+
+			fv := v.Field(i)
+			if omitEmpty && fv.IsZero() {
+				continue
+			}
+
+			e, err := toDocument(fv)
+			if err != nil {
+				return nil, err
+			}
+			doc[name] = e
+		}
+		return doc, nil
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil, nil
+		}
+		items := make([]any, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			e, err := toDocument(v.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, e)
+		}
+		return items, nil
+	case reflect.Map:
+		if v.IsNil() {
+			return nil, nil
+		}
+		if v.Type().Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("unsupported map key type, %s", v.Type().Key())
+		}
+		m := make(map[string]any, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			e, err := toDocument(iter.Value())
+			if err != nil {
+				return nil, err
+			}
+			m[iter.Key().String()] = e
+		}
+		return m, nil
+	case reflect.Func, reflect.Chan, reflect.Complex64, reflect.Complex128, reflect.UnsafePointer:
+		return nil, fmt.Errorf("unsupported field type, %s", v.Type())
+	default:
+		return v.Interface(), nil
+	}
+}
+
+func fromDocument(raw any, v reflect.Value) error {
+	if raw == nil {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+
+	if v.Type() == timeType {
+		s, ok := raw.(string)
+		if !ok {
+			return fmt.Errorf("expected time string, got %T", raw)
+		}
+		t, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Interface:
+		v.Set(reflect.ValueOf(plain(raw)))
+	case reflect.Pointer:
+		p := reflect.New(v.Type().Elem())
+		if err := fromDocument(raw, p.Elem()); err != nil {
+			return err
+		}
+		v.Set(p)
+	case reflect.Struct:
+		m, ok := raw.(map[string]any)
+		if !ok {
+			return fmt.Errorf("expected object for %s, got %T", v.Type(), raw)
+		}
+		for i := 0; i < v.NumField(); i++ {
+			f := v.Type().Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			name, _ := fieldName(f)
+			if name == "-" {
+				continue
+			}
+			if fv, ok := m[name]; ok {
+				if err := fromDocument(fv, v.Field(i)); err != nil {
+					return fmt.Errorf("%s: %s", name, err)
+				}
+			}
+		}
+	case reflect.Slice:
+		items, ok := raw.([]any)
+		if !ok {
+			return fmt.Errorf("expected array for %s, got %T", v.Type(), raw)
+		}
+		s := reflect.MakeSlice(v.Type(), len(items), len(items))
+		for i, item := range items {
+			if err := fromDocument(item, s.Index(i)); err != nil {
+				return err
+			}
+		}
+		v.Set(s)
+	case reflect.Map:
+		m, ok := raw.(map[string]any)
+		if !ok {
+			return fmt.Errorf("expected object for %s, got %T", v.Type(), raw)
+		}
+		out := reflect.MakeMapWithSize(v.Type(), len(m))
+		for k, item := range m {
+			e := reflect.New(v.Type().Elem()).Elem()
+			if err := fromDocument(item, e); err != nil {
+				return err
+			}
+			out.SetMapIndex(reflect.ValueOf(k).Convert(v.Type().Key()), e)
+		}
+		v.Set(out)
+	case reflect.String:
+		s, ok := raw.(string)
+		if !ok {
+			return fmt.Errorf("expected string, got %T", raw)
+		}
+		v.SetString(s)
+	case reflect.Bool:
+		b, ok := raw.(bool)
+		if !ok {
+			return fmt.Errorf("expected bool, got %T", raw)
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, ok := raw.(json.Number)
+		if !ok {
+			return fmt.Errorf("expected number, got %T", raw)
+		}
+		i, err := n.Int64()
+		if err != nil {
+			f, ferr := n.Float64()
+			if ferr != nil {
+				return err
+			}
+			i = int64(f)
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, ok := raw.(json.Number)
+		if !ok {
+			return fmt.Errorf("expected number, got %T", raw)
+		}
+		i, err := n.Int64()
+		if err != nil {
+			return err
+		}
+		v.SetUint(uint64(i))
+	case reflect.Float32, reflect.Float64:
+		n, ok := raw.(json.Number)
+		if !ok {
+			return fmt.Errorf("expected number, got %T", raw)
+		}
+		f, err := n.Float64()
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported field type, %s", v.Type())
+	}
+
+	return nil
+}
+
+// plain converts decoded JSON into the types firestore hands back for untyped fields: int64 for whole numbers and
+// float64 otherwise.
+func plain(raw any) any {
+	switch r := raw.(type) {
+	case json.Number:
+		if i, err := r.Int64(); err == nil {
+			return i
+		}
+		f, _ := r.Float64()
+		return f
+	case []any:
+		items := make([]any, len(r))
+		for i, item := range r {
+			items[i] = plain(item)
+		}
+		return items
+	case map[string]any:
+		m := make(map[string]any, len(r))
+		for k, item := range r {
+			m[k] = plain(item)
+		}
+		return m
+	default:
+		return raw
+	}
+}
+
+func fieldName(f reflect.StructField) (string, bool) {
+	tag := f.Tag.Get("firestore")
+	if len(tag) == 0 {
+		return f.Name, false
+	}
+
+	parts := strings.Split(tag, ",")
+	name := parts[0]
+	if len(name) == 0 {
+		name = f.Name
+	}
+
+	omitEmpty := false
+	for _, p := range parts[1:] {
+		if p == "omitempty" {
+			omitEmpty = true
+		}
+	}
+
+	return name, omitEmpty
+}
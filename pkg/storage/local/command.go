@@ -0,0 +1,20 @@
+package local
+
+import (
+	"assistant/pkg/models"
+	"fmt"
+)
+
+func (l *Local) IncrementCommandUsage(channel, commandName string) error {
+	path := fmt.Sprintf("%s/%s/%s/%s/%s", l.root(), pathChannels, channel, pathCommandUsage, commandName)
+	return modify(l, path, true, func(doc map[string]any) error {
+		doc["name"] = commandName
+		increment(doc, "count", 1)
+		return nil
+	})
+}
+
+func (l *Local) ListCommandUsage(channel string) ([]*models.CommandUsage, error) {
+	path := fmt.Sprintf("%s/%s/%s/%s", l.root(), pathChannels, channel, pathCommandUsage)
+	return list[models.CommandUsage](l, path)
+}
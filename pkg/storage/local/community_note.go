@@ -0,0 +1,56 @@
+package local
+
+import (
+	"assistant/pkg/models"
+	"fmt"
+	"slices"
+)
+
+func (l *Local) pathToCommunityNotes(channel string) string {
+	return fmt.Sprintf("%s/%s/%s/%s", l.root(), pathChannels, channel, pathNotes)
+}
+
+func (l *Local) CommunityNote(channel, id string) (*models.CommunityNote, error) {
+	return get[models.CommunityNote](l, fmt.Sprintf("%s/%s", l.pathToCommunityNotes(channel), id))
+}
+
+func (l *Local) CommunityNotes(channel string) ([]*models.CommunityNote, error) {
+	return query(l, QueryCriteria[models.CommunityNote]{
+		Path: l.pathToCommunityNotes(channel),
+		Less: communityNotesNewestFirst,
+	})
+}
+
+func (l *Local) CommunityNoteForSource(channel, source string) (*models.CommunityNote, error) {
+	notes, err := query(l, QueryCriteria[models.CommunityNote]{
+		Path:   l.pathToCommunityNotes(channel),
+		Filter: func(n *models.CommunityNote) bool { return slices.Contains(n.Sources, source) },
+		Less:   communityNotesNewestFirst,
+		Limit:  1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(notes) == 0 {
+		return nil, nil
+	}
+
+	return notes[0], nil
+}
+
+func (l *Local) CreateCommunityNote(channel string, note *models.CommunityNote) error {
+	return create(l, fmt.Sprintf("%s/%s", l.pathToCommunityNotes(channel), note.ID), note)
+}
+
+func (l *Local) SetCommunityNote(channel string, note *models.CommunityNote) error {
+	return set(l, fmt.Sprintf("%s/%s", l.pathToCommunityNotes(channel), note.ID), note)
+}
+
+func (l *Local) DeleteCommunityNote(channel, id string) error {
+	return remove(l, fmt.Sprintf("%s/%s", l.pathToCommunityNotes(channel), id))
+}
+
+func communityNotesNewestFirst(a, b *models.CommunityNote) bool {
+	return a.NotedAt.After(b.NotedAt)
+}
@@ -0,0 +1,20 @@
+package local
+
+const (
+	pathAssistants            = "assistants"
+	pathNetworks              = "networks"
+	pathChannels              = "channels"
+	pathBannedWords           = "banned-words"
+	pathUsers                 = "users"
+	pathQuotes                = "quotes"
+	pathNotes                 = "notes"
+	pathKarmaHistory          = "karma-history"
+	pathTasks                 = "tasks"
+	pathSources               = "sources"
+	pathDisinformationSources = "disinformation-sources"
+	pathUnknownSources        = "unknown-sources"
+	pathCommandUsage          = "command-usage"
+	pathShortcuts             = "shortcuts"
+	pathLLMResponses          = "llm-responses"
+	pathAuthTokens            = "auth-tokens"
+)
@@ -0,0 +1,268 @@
+package local
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var bucketDocuments = []byte("documents")
+
+func normalize(path string) string {
+	return strings.Trim(path, "/")
+}
+
+func create[T any](l *Local, documentPath string, t *T) error {
+	key := []byte(normalize(documentPath))
+
+	data, err := encode(t)
+	if err != nil {
+		return fmt.Errorf("error encoding document, %s", err)
+	}
+
+	return l.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketDocuments)
+		if b.Get(key) != nil {
+			return fmt.Errorf("error creating document, %s already exists", documentPath)
+		}
+		return b.Put(key, data)
+	})
+}
+
+func get[T any](l *Local, documentPath string) (*T, error) {
+	var data []byte
+	err := l.db.View(func(tx *bolt.Tx) error {
+		if v := tx.Bucket(bucketDocuments).Get([]byte(normalize(documentPath))); v != nil {
+			data = bytes.Clone(v)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if data == nil {
+		return nil, nil
+	}
+
+	t := new(T)
+	if err = decode(data, t); err != nil {
+		return nil, fmt.Errorf("error decoding document, %s", err)
+	}
+
+	return t, nil
+}
+
+func set[T any](l *Local, documentPath string, t *T) error {
+	data, err := encode(t)
+	if err != nil {
+		return fmt.Errorf("error encoding document, %s", err)
+	}
+
+	return l.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketDocuments).Put([]byte(normalize(documentPath)), data)
+	})
+}
+
+// update merges fields into an existing document. As with firestore, updating a missing document is an error.
+func update(l *Local, documentPath string, fields map[string]any) error {
+	return modify(l, documentPath, false, func(doc map[string]any) error {
+		for k, v := range fields {
+			e, err := encodeValue(v)
+			if err != nil {
+				return err
+			}
+			doc[k] = e
+		}
+		return nil
+	})
+}
+
+// modify applies fn to the raw document at documentPath inside a single transaction. When upsert is set a missing
+// document is created, which is how the firestore backend's merge writes behave.
+func modify(l *Local, documentPath string, upsert bool, fn func(doc map[string]any) error) error {
+	key := []byte(normalize(documentPath))
+
+	return l.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketDocuments)
+
+		doc := make(map[string]any)
+		if v := b.Get(key); v != nil {
+			raw, err := decodeRaw(v)
+			if err != nil {
+				return err
+			}
+			doc = raw
+		} else if !upsert {
+			return fmt.Errorf("error updating document, %s not found", documentPath)
+		}
+
+		if err := fn(doc); err != nil {
+			return err
+		}
+
+		data, err := json.Marshal(doc)
+		if err != nil {
+			return fmt.Errorf("error encoding document, %s", err)
+		}
+
+		return b.Put(key, data)
+	})
+}
+
+// increment adds delta to a numeric field of a raw document.
+func increment(doc map[string]any, field string, delta int64) {
+	var current int64
+	if n, ok := doc[field].(json.Number); ok {
+		if i, err := n.Int64(); err == nil {
+			current = i
+		}
+	}
+	doc[field] = current + delta
+}
+
+func encodeValue(v any) (any, error) {
+	data, err := encode(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var e any
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	if err = d.Decode(&e); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// remove deletes the document or collection at path along with everything nested beneath it.
+func remove(l *Local, path string) error {
+	path = normalize(path)
+	prefix := []byte(path + "/")
+
+	return l.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketDocuments)
+
+		keys := make([][]byte, 0)
+		if b.Get([]byte(path)) != nil {
+			keys = append(keys, []byte(path))
+		}
+
+		c := b.Cursor()
+		for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+			keys = append(keys, bytes.Clone(k))
+		}
+
+		for _, k := range keys {
+			if err := b.Delete(k); err != nil {
+				return fmt.Errorf("error deleting document, %s", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// children returns the raw documents directly within a collection, keyed by document ID.
+func children(l *Local, collectionPath string) (map[string][]byte, error) {
+	prefix := []byte(normalize(collectionPath) + "/")
+	documents := make(map[string][]byte)
+
+	err := l.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(bucketDocuments).Cursor()
+		for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+			id := string(k[len(prefix):])
+			if strings.Contains(id, "/") {
+				continue
+			}
+			documents[id] = bytes.Clone(v)
+		}
+		return nil
+	})
+
+	return documents, err
+}
+
+func list[T any](l *Local, collectionPath string) ([]*T, error) {
+	documents, err := children(l, collectionPath)
+	if err != nil {
+		return nil, fmt.Errorf("error listing documents, %s", err)
+	}
+
+	ids := make([]string, 0, len(documents))
+	for id := range documents {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	results := make([]*T, 0, len(ids))
+	for _, id := range ids {
+		t := new(T)
+		if err = decode(documents[id], t); err != nil {
+			return nil, fmt.Errorf("error decoding document, %s", err)
+		}
+		results = append(results, t)
+	}
+
+	return results, nil
+}
+
+// QueryCriteria mirrors the firestore query criteria, with filtering and ordering expressed as Go functions over the
+// decoded documents.
+type QueryCriteria[T any] struct {
+	Path   string
+	Filter func(t *T) bool
+	Less   func(a, b *T) bool
+	Limit  int
+	Offset int
+}
+
+func query[T any](l *Local, criteria QueryCriteria[T]) ([]*T, error) {
+	documents, err := list[T](l, criteria.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*T, 0, len(documents))
+	for _, t := range documents {
+		if criteria.Filter == nil || criteria.Filter(t) {
+			results = append(results, t)
+		}
+	}
+
+	if criteria.Less != nil {
+		sort.SliceStable(results, func(i, j int) bool {
+			return criteria.Less(results[i], results[j])
+		})
+	}
+
+	if criteria.Offset > 0 {
+		if criteria.Offset >= len(results) {
+			return make([]*T, 0), nil
+		}
+		results = results[criteria.Offset:]
+	}
+
+	if criteria.Limit > 0 && len(results) > criteria.Limit {
+		results = results[:criteria.Limit]
+	}
+
+	return results, nil
+}
+
+func containsAny(values, candidates []string) bool {
+	for _, c := range candidates {
+		for _, v := range values {
+			if v == c {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,133 @@
+package local
+
+import (
+	"assistant/pkg/log"
+	"assistant/pkg/models"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+func (l *Local) pathToDisinformationSources(channel string) string {
+	return fmt.Sprintf("%s/%s/%s/%s", l.root(), pathChannels, channel, pathDisinformationSources)
+}
+
+func (l *Local) pathToDisinformationSource(channel string, source *models.DisinformationSource) string {
+	return fmt.Sprintf("%s/%s", l.pathToDisinformationSources(channel), source.ID)
+}
+
+func (l *Local) DisinformationSources(channel string) ([]*models.DisinformationSource, error) {
+	return list[models.DisinformationSource](l, l.pathToDisinformationSources(channel))
+}
+
+func (l *Local) DisinformationSource(channel, source string) (*models.DisinformationSource, error) {
+	source = strings.TrimSpace(strings.ToLower(source))
+
+	sources, err := query(l, QueryCriteria[models.DisinformationSource]{
+		Path:   l.pathToDisinformationSources(channel),
+		Filter: func(ds *models.DisinformationSource) bool { return ds.Source == source },
+		Limit:  1,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sources) == 0 {
+		return nil, nil
+	}
+
+	return sources[0], nil
+}
+
+func (l *Local) AddDisinformationSource(channel, source string) error {
+	source = strings.TrimSpace(strings.ToLower(source))
+
+	if l.IsDisinformationSource(channel, source) {
+		log.Logger().Debugf(nil, "disinformation source already exists: %s", source)
+		return nil
+	}
+
+	l.mu.Lock()
+	if l.disinformationSources == nil {
+		l.disinformationSources = make(map[string][]string)
+	}
+	l.disinformationSources[channel] = append(l.disinformationSources[channel], source)
+	l.mu.Unlock()
+
+	ds := models.NewDisinformationSource(source)
+	return create(l, l.pathToDisinformationSource(channel, ds), ds)
+}
+
+func (l *Local) DeleteDisinformationSource(channel, source string) error {
+	source = strings.TrimSpace(strings.ToLower(source))
+
+	if !l.IsDisinformationSource(channel, source) {
+		log.Logger().Debugf(nil, "disinformation source does not exist: %s", source)
+		return nil
+	}
+
+	l.mu.Lock()
+	l.disinformationSources[channel] = slices.DeleteFunc(l.disinformationSources[channel], func(s string) bool {
+		return s == source
+	})
+	l.mu.Unlock()
+
+	ds, err := l.DisinformationSource(channel, source)
+	if err != nil {
+		return err
+	}
+
+	if ds == nil {
+		return nil
+	}
+
+	return remove(l, l.pathToDisinformationSource(channel, ds))
+}
+
+func (l *Local) IsDisinformationSource(channel, source string) bool {
+	l.mu.RLock()
+	_, loaded := l.disinformationSources[channel]
+	l.mu.RUnlock()
+
+	if !loaded {
+		if err := l.ReloadDisinformationSources(channel); err != nil {
+			log.Logger().Errorf(nil, "failed to reload disinformation sources for channel %s: %v", channel, err)
+			return false
+		}
+	}
+
+	source = strings.TrimSpace(strings.ToLower(source))
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	for _, prefix := range l.disinformationSources[channel] {
+		if strings.HasPrefix(source, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (l *Local) ReloadDisinformationSources(channel string) error {
+	disinformation, err := l.DisinformationSources(channel)
+	if err != nil {
+		return err
+	}
+
+	sources := make([]string, 0, len(disinformation))
+	for _, d := range disinformation {
+		sources = append(sources, d.Source)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.disinformationSources == nil {
+		l.disinformationSources = make(map[string][]string)
+	}
+	l.disinformationSources[channel] = sources
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package local
+
+import (
+	"assistant/pkg/models"
+	"fmt"
+)
+
+const pathFactoids = "factoids"
+
+func (l *Local) pathToFactoids(channel string) string {
+	return fmt.Sprintf("%s/%s/%s/%s", l.root(), pathChannels, channel, pathFactoids)
+}
+
+func (l *Local) Factoid(channel, key string) (*models.Factoid, error) {
+	return get[models.Factoid](l, fmt.Sprintf("%s/%s", l.pathToFactoids(channel), key))
+}
+
+func (l *Local) Factoids(channel string) ([]*models.Factoid, error) {
+	return query(l, QueryCriteria[models.Factoid]{
+		Path: l.pathToFactoids(channel),
+		Less: factoidsByKey,
+	})
+}
+
+func (l *Local) FindFactoids(channel string, keywords []string) ([]*models.Factoid, error) {
+	return query(l, QueryCriteria[models.Factoid]{
+		Path:   l.pathToFactoids(channel),
+		Filter: func(f *models.Factoid) bool { return containsAny(f.Keywords, keywords) },
+		Less:   factoidsByKey,
+	})
+}
+
+func (l *Local) SetFactoid(channel string, factoid *models.Factoid) error {
+	return set(l, fmt.Sprintf("%s/%s", l.pathToFactoids(channel), factoid.Key), factoid)
+}
+
+func (l *Local) DeleteFactoid(channel, key string) error {
+	return remove(l, fmt.Sprintf("%s/%s", l.pathToFactoids(channel), key))
+}
+
+func factoidsByKey(a, b *models.Factoid) bool {
+	return a.Key < b.Key
+}
@@ -0,0 +1,31 @@
+package local
+
+import (
+	"assistant/pkg/models"
+	"fmt"
+)
+
+const pathFeeds = "feeds"
+
+func (l *Local) pathToFeeds(channel string) string {
+	return fmt.Sprintf("%s/%s/%s/%s", l.root(), pathChannels, channel, pathFeeds)
+}
+
+func (l *Local) Feed(channel, id string) (*models.Feed, error) {
+	return get[models.Feed](l, fmt.Sprintf("%s/%s", l.pathToFeeds(channel), id))
+}
+
+func (l *Local) Feeds(channel string) ([]*models.Feed, error) {
+	return query(l, QueryCriteria[models.Feed]{
+		Path: l.pathToFeeds(channel),
+		Less: func(a, b *models.Feed) bool { return a.CreatedAt.Before(b.CreatedAt) },
+	})
+}
+
+func (l *Local) SetFeed(channel string, feed *models.Feed) error {
+	return set(l, fmt.Sprintf("%s/%s", l.pathToFeeds(channel), feed.ID), feed)
+}
+
+func (l *Local) DeleteFeed(channel, id string) error {
+	return remove(l, fmt.Sprintf("%s/%s", l.pathToFeeds(channel), id))
+}
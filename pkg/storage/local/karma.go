@@ -0,0 +1,19 @@
+package local
+
+import (
+	"assistant/pkg/models"
+	"fmt"
+)
+
+func (l *Local) KarmaHistory(channel, nick string) ([]*models.KarmaHistory, error) {
+	path := fmt.Sprintf("%s/%s/%s/%s/%s/%s", l.root(), pathChannels, channel, pathUsers, nick, pathKarmaHistory)
+	return query(l, QueryCriteria[models.KarmaHistory]{
+		Path: path,
+		Less: func(a, b *models.KarmaHistory) bool { return a.CreatedAt.After(b.CreatedAt) },
+	})
+}
+
+func (l *Local) SaveKarmaHistory(channel, nick string, kh *models.KarmaHistory) error {
+	path := fmt.Sprintf("%s/%s/%s/%s/%s/%s/%s", l.root(), pathChannels, channel, pathUsers, nick, pathKarmaHistory, kh.ID)
+	return set(l, path, kh)
+}
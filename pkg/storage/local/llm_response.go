@@ -0,0 +1,33 @@
+package local
+
+import (
+	"assistant/pkg/models"
+	"fmt"
+)
+
+func (l *Local) CreateLLMResponse(r *models.LLMResponse) error {
+	path := fmt.Sprintf("%s/%s/%s", l.root(), pathLLMResponses, r.ID)
+	return create(l, path, r)
+}
+
+func (l *Local) LLMResponse(id string) (*models.LLMResponse, error) {
+	path := fmt.Sprintf("%s/%s/%s", l.root(), pathLLMResponses, id)
+	return get[models.LLMResponse](l, path)
+}
+
+func (l *Local) UpdateLLMResponse(id, content string) error {
+	path := fmt.Sprintf("%s/%s/%s", l.root(), pathLLMResponses, id)
+	return update(l, path, map[string]any{
+		"content":  content,
+		"complete": true,
+	})
+}
+
+func (l *Local) LLMResponsesBySession(sessionID string) ([]*models.LLMResponse, error) {
+	path := fmt.Sprintf("%s/%s", l.root(), pathLLMResponses)
+	return query(l, QueryCriteria[models.LLMResponse]{
+		Path:   path,
+		Filter: func(r *models.LLMResponse) bool { return r.SessionID == sessionID },
+		Less:   func(a, b *models.LLMResponse) bool { return a.CreatedAt.Before(b.CreatedAt) },
+	})
+}
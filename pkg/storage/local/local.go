@@ -0,0 +1,78 @@
+package local
+
+import (
+	"assistant/pkg/config"
+	"assistant/pkg/localdb"
+	"context"
+	"fmt"
+	"sync"
+)
+
+const defaultPath = "assistant.db"
+
+var instance *Local
+
+// Local is an embedded storage backend for running the assistant without Google Cloud. Documents are stored in a
+// single bbolt file keyed by the same paths the firestore backend uses, shared by the assistant and the web server.
+type Local struct {
+	ctx context.Context
+	cfg *config.Config
+	db  *localdb.DB
+
+	mu                    sync.RWMutex
+	disinformationSources map[string][]string
+}
+
+func Initialize(ctx context.Context, cfg *config.Config) (*Local, error) {
+	if instance != nil {
+		return instance, nil
+	}
+
+	l, err := Open(ctx, cfg, cfg.Storage.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	instance = l
+	return instance, nil
+}
+
+// Open opens (or creates) a local store at the given path.
+func Open(ctx context.Context, cfg *config.Config, path string) (*Local, error) {
+	if len(path) == 0 {
+		path = defaultPath
+	}
+
+	db, err := localdb.OpenShared(path, bucketDocuments)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Local{
+		ctx: ctx,
+		cfg: cfg,
+		db:  db,
+	}, nil
+}
+
+// WithNetwork returns a store sharing the same database whose documents are kept under the network named in cfg.
+func (l *Local) WithNetwork(cfg *config.Config) *Local {
+	return &Local{
+		ctx: l.ctx,
+		cfg: cfg,
+		db:  l.db,
+	}
+}
+
+// root returns the document everything is stored under. Named networks get their own document below the assistant so
+// that channels of the same name on different networks don't collide.
+func (l *Local) root() string {
+	if len(l.cfg.IRC.Name) == 0 {
+		return fmt.Sprintf("%s/%s", pathAssistants, l.cfg.IRC.Nick)
+	}
+	return fmt.Sprintf("%s/%s/%s/%s", pathAssistants, l.cfg.IRC.Nick, pathNetworks, l.cfg.IRC.Name)
+}
+
+func (l *Local) Close() error {
+	return nil
+}
@@ -0,0 +1,287 @@
+package local
+
+import (
+	"assistant/pkg/api/irc"
+	"assistant/pkg/config"
+	"assistant/pkg/models"
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTestStore(t *testing.T) *Local {
+	t.Helper()
+
+	cfg := &config.Config{IRC: config.IRCConfig{Nick: "assistant"}}
+	l, err := Open(context.Background(), cfg, filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+
+	return l
+}
+
+func TestUserRoundTrip(t *testing.T) {
+	l := newTestStore(t)
+
+	user := models.NewUser(&irc.Mask{Nick: "nick", UserID: "ident", Host: "example.com"})
+	user.Karma = 3
+	user.RecentMessages = []models.RecentMessage{{Message: "hello", At: time.Now().Truncate(time.Second)}}
+
+	if err := l.CreateUser("#channel", user); err != nil {
+		t.Fatalf("CreateUser() error = %v", err)
+	}
+
+	if err := l.CreateUser("#channel", user); err == nil {
+		t.Fatalf("CreateUser() on existing document should fail")
+	}
+
+	if err := l.UpdateUser("#channel", user, map[string]any{"karma": 5, "location": "nowhere"}); err != nil {
+		t.Fatalf("UpdateUser() error = %v", err)
+	}
+
+	got, err := l.GetUserByNick("#channel", "nick")
+	if err != nil {
+		t.Fatalf("GetUserByNick() error = %v", err)
+	}
+
+	if got == nil {
+		t.Fatalf("GetUserByNick() = nil, want user")
+	}
+
+	if got.Karma != 5 || got.Location != "nowhere" || got.Host != "example.com" {
+		t.Fatalf("GetUserByNick() = %+v, want updated user", got)
+	}
+
+	if len(got.RecentMessages) != 1 || !got.RecentMessages[0].At.Equal(user.RecentMessages[0].At) {
+		t.Fatalf("RecentMessages = %+v, want %+v", got.RecentMessages, user.RecentMessages)
+	}
+
+	users, err := l.GetUsersByMask("#channel", "*", "ident", "example.com")
+	if err != nil {
+		t.Fatalf("GetUsersByMask() error = %v", err)
+	}
+
+	if len(users) != 1 {
+		t.Fatalf("GetUsersByMask() returned %d users, want 1", len(users))
+	}
+
+	missing, err := l.GetUserByNick("#channel", "other")
+	if err != nil || missing != nil {
+		t.Fatalf("GetUserByNick(missing) = %v, %v, want nil, nil", missing, err)
+	}
+}
+
+func TestListOnlyReturnsDirectChildren(t *testing.T) {
+	l := newTestStore(t)
+
+	if err := l.CreateChannel(models.NewChannel("#one", "")); err != nil {
+		t.Fatalf("CreateChannel() error = %v", err)
+	}
+
+	if err := l.CreateUser("#one", models.NewUserWithNick("nick")); err != nil {
+		t.Fatalf("CreateUser() error = %v", err)
+	}
+
+	channels, err := l.Channels()
+	if err != nil {
+		t.Fatalf("Channels() error = %v", err)
+	}
+
+	if len(channels) != 1 || channels[0].Name != "#one" {
+		t.Fatalf("Channels() = %+v, want only #one", channels)
+	}
+}
+
+func TestIncrementCommandUsage(t *testing.T) {
+	l := newTestStore(t)
+
+	for i := 0; i < 3; i++ {
+		if err := l.IncrementCommandUsage("#channel", "help"); err != nil {
+			t.Fatalf("IncrementCommandUsage() error = %v", err)
+		}
+	}
+
+	usage, err := l.ListCommandUsage("#channel")
+	if err != nil {
+		t.Fatalf("ListCommandUsage() error = %v", err)
+	}
+
+	if len(usage) != 1 || usage[0].Name != "help" || usage[0].Count != 3 {
+		t.Fatalf("ListCommandUsage() = %+v, want help=3", usage)
+	}
+}
+
+func TestQueryOrdering(t *testing.T) {
+	l := newTestStore(t)
+
+	now := time.Now()
+	for i, content := range []string{"first", "second", "third"} {
+		q := models.NewQuote("author", "quoter", content, now.Add(time.Duration(i)*time.Minute))
+		q.Keywords = []string{content}
+		if err := l.CreateQuote("#channel", q); err != nil {
+			t.Fatalf("CreateQuote() error = %v", err)
+		}
+	}
+
+	quotes, err := l.FindQuotes("#channel", []string{"first", "third"})
+	if err != nil {
+		t.Fatalf("FindQuotes() error = %v", err)
+	}
+
+	if len(quotes) != 2 || quotes[0].Quote != "third" || quotes[1].Quote != "first" {
+		t.Fatalf("FindQuotes() returned %d quotes, want third then first", len(quotes))
+	}
+}
+
+func TestRemoveDeletesNestedDocuments(t *testing.T) {
+	l := newTestStore(t)
+
+	note := models.NewPersonalNote("content", "https://example.com")
+	if err := l.CreatePersonalNote("nick", note); err != nil {
+		t.Fatalf("CreatePersonalNote() error = %v", err)
+	}
+
+	if err := remove(l, "assistants/assistant/users/nick"); err != nil {
+		t.Fatalf("remove() error = %v", err)
+	}
+
+	notes, err := l.PersonalNotes("nick")
+	if err != nil {
+		t.Fatalf("PersonalNotes() error = %v", err)
+	}
+
+	if len(notes) != 0 {
+		t.Fatalf("PersonalNotes() returned %d notes, want 0", len(notes))
+	}
+}
+
+func TestGetPendingTasks(t *testing.T) {
+	l := newTestStore(t)
+
+	due := time.Now().Add(time.Hour)
+	pending := models.NewReminderTask(due, "nick", "#channel", "content")
+	complete := models.NewReminderTask(due.Add(-time.Minute), "nick", "#channel", "done")
+	complete.Status = models.TaskStatusComplete
+
+	for _, task := range []*models.Task{pending, complete} {
+		if err := l.SetTask(task); err != nil {
+			t.Fatalf("SetTask() error = %v", err)
+		}
+	}
+
+	tasks, err := l.GetPendingTasks("nick", "#channel", models.TaskTypeReminder)
+	if err != nil {
+		t.Fatalf("GetPendingTasks() error = %v", err)
+	}
+
+	if len(tasks) != 1 || tasks[0].ID != pending.ID {
+		t.Fatalf("GetPendingTasks() = %+v, want only %s", tasks, pending.ID)
+	}
+
+	data, ok := tasks[0].Data.(models.ReminderTaskData)
+	if !ok || data.Content != "content" {
+		t.Fatalf("task data = %#v, want ReminderTaskData", tasks[0].Data)
+	}
+}
+
+func TestLastDeliveredReminder(t *testing.T) {
+	l := newTestStore(t)
+
+	now := time.Now()
+	tasks := make([]*models.Task, 0, 3)
+	for i, deliveredAt := range []time.Time{now.Add(-2 * time.Hour), now.Add(-10 * time.Minute), {}} {
+		task := models.NewReminderTask(now, "nick", "#channel", fmt.Sprintf("reminder %d", i))
+		data := task.Data.(models.ReminderTaskData)
+		data.DeliveredAt = deliveredAt
+		task.Data = data
+		tasks = append(tasks, task)
+
+		if err := l.SetTask(task); err != nil {
+			t.Fatalf("SetTask() error = %v", err)
+		}
+	}
+
+	last, err := l.LastDeliveredReminder("nick", "#channel", now.Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("LastDeliveredReminder() error = %v", err)
+	}
+	if last == nil || last.ID != tasks[1].ID {
+		t.Fatalf("LastDeliveredReminder() = %+v, want %s", last, tasks[1].ID)
+	}
+
+	last, err = l.LastDeliveredReminder("nick", "#channel", now)
+	if err != nil {
+		t.Fatalf("LastDeliveredReminder() error = %v", err)
+	}
+	if last != nil {
+		t.Fatalf("LastDeliveredReminder() = %+v, want none", last)
+	}
+}
+
+func TestNetworksDoNotShareChannels(t *testing.T) {
+	l := newTestStore(t)
+
+	cfg := &config.Config{IRC: config.IRCConfig{Nick: "assistant", Name: "other"}}
+	other := l.WithNetwork(cfg)
+
+	if err := l.CreateUser("#channel", models.NewUser(&irc.Mask{Nick: "nick", UserID: "ident", Host: "example.com"})); err != nil {
+		t.Fatalf("CreateUser() error = %v", err)
+	}
+
+	got, err := other.GetUserByNick("#channel", "nick")
+	if err != nil {
+		t.Fatalf("GetUserByNick() error = %v", err)
+	}
+
+	if got != nil {
+		t.Fatalf("GetUserByNick() on another network = %+v, want nil", got)
+	}
+}
+
+func TestUserStrikesOnlyReturnsActiveStrikes(t *testing.T) {
+	l := newTestStore(t)
+
+	active := models.NewStrike("nick", "example.com", "", models.StrikeSourceSpam, "", 1, time.Hour, "assistant")
+	expired := models.NewStrike("nick", "example.com", "", models.StrikeSourceSpam, "", 1, -time.Hour, "assistant")
+	other := models.NewStrike("other", "elsewhere.com", "", models.StrikeSourceSpam, "", 1, time.Hour, "assistant")
+	for _, s := range []*models.Strike{active, expired, other} {
+		if err := l.AddStrike("#channel", s); err != nil {
+			t.Fatalf("AddStrike() error = %v", err)
+		}
+	}
+
+	strikes, err := l.UserStrikes("#channel", "renamed", "example.com", "")
+	if err != nil {
+		t.Fatalf("UserStrikes() error = %v", err)
+	}
+
+	if len(strikes) != 1 || strikes[0].ID != active.ID {
+		t.Fatalf("UserStrikes() = %+v, want only %s", strikes, active.ID)
+	}
+}
+
+func TestAuditEntriesSince(t *testing.T) {
+	l := newTestStore(t)
+
+	now := time.Now()
+	recent := models.NewAuditEntry(models.AuditActionBan, "owner", models.AuditSourceCommand, "*!*@recent.com", "", "")
+	old := models.NewAuditEntry(models.AuditActionBan, "owner", models.AuditSourceCommand, "*!*@old.com", "", "")
+	old.CreatedAt = now.Add(-48 * time.Hour)
+	for _, entry := range []*models.AuditEntry{recent, old} {
+		if err := l.AddAuditEntry("#channel", entry); err != nil {
+			t.Fatalf("AddAuditEntry() error = %v", err)
+		}
+	}
+
+	entries, err := l.AuditEntriesSince("#channel", now.Add(-24*time.Hour))
+	if err != nil {
+		t.Fatalf("AuditEntriesSince() error = %v", err)
+	}
+
+	if len(entries) != 1 || entries[0].ID != recent.ID {
+		t.Fatalf("AuditEntriesSince() = %+v, want only %s", entries, recent.ID)
+	}
+}
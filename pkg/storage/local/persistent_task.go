@@ -0,0 +1,32 @@
+package local
+
+import (
+	"assistant/pkg/models"
+	"fmt"
+	"time"
+)
+
+func (l *Local) PersistentChannelTaskPath(channel, id string) string {
+	return fmt.Sprintf("%s/%s/%s/%s/%s", l.root(), pathChannels, channel, pathTasks, id)
+}
+
+func (l *Local) SetPersistentChannelTaskDue(channel, id string, duration time.Duration) error {
+	path := l.PersistentChannelTaskPath(channel, id)
+	task, err := l.Task(path)
+	if err != nil {
+		return err
+	}
+
+	if task == nil {
+		task = models.NewPersistentTask(id, channel, models.TaskTypePersistentChannel, time.Now().Add(duration))
+		task.Network = l.cfg.IRC.Name
+		return create(l, path, task)
+	}
+
+	return update(l, path, map[string]any{"due_at": time.Now().Add(duration), "updated_at": time.Now()})
+}
+
+func (l *Local) UpdatePersistentChannelTaskDue(channel, id string, dueAt time.Time) error {
+	path := l.PersistentChannelTaskPath(channel, id)
+	return update(l, path, map[string]any{"due_at": dueAt, "updated_at": time.Now()})
+}
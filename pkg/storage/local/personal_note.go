@@ -0,0 +1,54 @@
+package local
+
+import (
+	"assistant/pkg/models"
+	"fmt"
+	"strings"
+)
+
+func (l *Local) pathToPersonalNotes(nick string) string {
+	return fmt.Sprintf("%s/%s/%s/%s", l.root(), pathUsers, nick, pathNotes)
+}
+
+func (l *Local) PersonalNote(nick, id string) (*models.PersonalNote, error) {
+	return get[models.PersonalNote](l, fmt.Sprintf("%s/%s", l.pathToPersonalNotes(nick), id))
+}
+
+func (l *Local) PersonalNotes(nick string) ([]*models.PersonalNote, error) {
+	return query(l, QueryCriteria[models.PersonalNote]{
+		Path: l.pathToPersonalNotes(nick),
+		Less: personalNotesNewestFirst,
+	})
+}
+
+func (l *Local) PersonalNotesMatchingKeywords(nick string, keywords []string) ([]*models.PersonalNote, error) {
+	return query(l, QueryCriteria[models.PersonalNote]{
+		Path:   l.pathToPersonalNotes(nick),
+		Filter: func(n *models.PersonalNote) bool { return containsAny(n.Keywords, keywords) },
+		Less:   personalNotesNewestFirst,
+	})
+}
+
+func (l *Local) PersonalNotesMatchingSource(nick, source string) ([]*models.PersonalNote, error) {
+	return query(l, QueryCriteria[models.PersonalNote]{
+		Path:   l.pathToPersonalNotes(nick),
+		Filter: func(n *models.PersonalNote) bool { return strings.HasPrefix(n.Source, source) },
+		Less:   personalNotesNewestFirst,
+	})
+}
+
+func (l *Local) CreatePersonalNote(nick string, note *models.PersonalNote) error {
+	return create(l, fmt.Sprintf("%s/%s", l.pathToPersonalNotes(nick), note.ID), note)
+}
+
+func (l *Local) SetPersonalNote(nick string, note *models.PersonalNote) error {
+	return set(l, fmt.Sprintf("%s/%s", l.pathToPersonalNotes(nick), note.ID), note)
+}
+
+func (l *Local) DeletePersonalNote(nick, id string) error {
+	return remove(l, fmt.Sprintf("%s/%s", l.pathToPersonalNotes(nick), id))
+}
+
+func personalNotesNewestFirst(a, b *models.PersonalNote) bool {
+	return a.NotedAt.After(b.NotedAt)
+}
@@ -0,0 +1,31 @@
+package local
+
+import (
+	"assistant/pkg/models"
+	"fmt"
+)
+
+const pathPolls = "polls"
+
+func (l *Local) pathToPolls(channel string) string {
+	return fmt.Sprintf("%s/%s/%s/%s", l.root(), pathChannels, channel, pathPolls)
+}
+
+func (l *Local) Poll(channel, id string) (*models.Poll, error) {
+	return get[models.Poll](l, fmt.Sprintf("%s/%s", l.pathToPolls(channel), id))
+}
+
+func (l *Local) Polls(channel string) ([]*models.Poll, error) {
+	return query(l, QueryCriteria[models.Poll]{
+		Path: l.pathToPolls(channel),
+		Less: func(a, b *models.Poll) bool { return a.CreatedAt.After(b.CreatedAt) },
+	})
+}
+
+func (l *Local) CreatePoll(channel string, poll *models.Poll) error {
+	return create(l, fmt.Sprintf("%s/%s", l.pathToPolls(channel), poll.ID), poll)
+}
+
+func (l *Local) UpdatePoll(channel string, poll *models.Poll, fields map[string]any) error {
+	return update(l, fmt.Sprintf("%s/%s", l.pathToPolls(channel), poll.ID), fields)
+}
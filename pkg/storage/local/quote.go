@@ -0,0 +1,50 @@
+package local
+
+import (
+	"assistant/pkg/models"
+	"fmt"
+)
+
+func (l *Local) pathToQuotes(channel string) string {
+	return fmt.Sprintf("%s/%s/%s/%s", l.root(), pathChannels, channel, pathQuotes)
+}
+
+func (l *Local) Quotes(channel string) ([]*models.Quote, error) {
+	return list[models.Quote](l, l.pathToQuotes(channel))
+}
+
+func (l *Local) FindUserQuotes(channel, nick string) ([]*models.Quote, error) {
+	return query(l, QueryCriteria[models.Quote]{
+		Path:   l.pathToQuotes(channel),
+		Filter: func(q *models.Quote) bool { return q.Author == nick },
+		Less:   quotesNewestFirst,
+	})
+}
+
+func (l *Local) FindUserQuotesWithContent(channel, nick string, keywords []string) ([]*models.Quote, error) {
+	return query(l, QueryCriteria[models.Quote]{
+		Path:   l.pathToQuotes(channel),
+		Filter: func(q *models.Quote) bool { return q.Author == nick && containsAny(q.Keywords, keywords) },
+		Less:   quotesNewestFirst,
+	})
+}
+
+func (l *Local) FindQuotes(channel string, keywords []string) ([]*models.Quote, error) {
+	return query(l, QueryCriteria[models.Quote]{
+		Path:   l.pathToQuotes(channel),
+		Filter: func(q *models.Quote) bool { return containsAny(q.Keywords, keywords) },
+		Less:   quotesNewestFirst,
+	})
+}
+
+func (l *Local) CreateQuote(channel string, quote *models.Quote) error {
+	return create(l, fmt.Sprintf("%s/%s", l.pathToQuotes(channel), quote.ID), quote)
+}
+
+func (l *Local) UpdateQuote(channel string, quote *models.Quote, fields map[string]any) error {
+	return update(l, fmt.Sprintf("%s/%s", l.pathToQuotes(channel), quote.ID), fields)
+}
+
+func quotesNewestFirst(a, b *models.Quote) bool {
+	return a.QuotedAt.After(b.QuotedAt)
+}
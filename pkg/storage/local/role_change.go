@@ -0,0 +1,22 @@
+package local
+
+import (
+	"assistant/pkg/models"
+	"fmt"
+)
+
+const pathRoleChanges = "role-changes"
+
+func (l *Local) AddRoleChange(channel string, change *models.RoleChange) error {
+	path := fmt.Sprintf("%s/%s/%s/%s/%s", l.root(), pathChannels, channel, pathRoleChanges, change.ID)
+	return create(l, path, change)
+}
+
+func (l *Local) RoleChanges(channel string) ([]*models.RoleChange, error) {
+	path := fmt.Sprintf("%s/%s/%s/%s", l.root(), pathChannels, channel, pathRoleChanges)
+
+	return query(l, QueryCriteria[models.RoleChange]{
+		Path: path,
+		Less: func(a, b *models.RoleChange) bool { return a.CreatedAt.After(b.CreatedAt) },
+	})
+}
@@ -0,0 +1,26 @@
+package local
+
+import (
+	"assistant/pkg/models"
+	"fmt"
+)
+
+func (l *Local) Shortcut(id string) (*models.Shortcut, error) {
+	path := fmt.Sprintf("%s/%s/%s", l.root(), pathShortcuts, id)
+	return get[models.Shortcut](l, path)
+}
+
+func (l *Local) Shortcuts() ([]*models.Shortcut, error) {
+	path := fmt.Sprintf("%s/%s", l.root(), pathShortcuts)
+	return list[models.Shortcut](l, path)
+}
+
+func (l *Local) CreateShortcut(shortcut *models.Shortcut) error {
+	path := fmt.Sprintf("%s/%s/%s", l.root(), pathShortcuts, shortcut.ID)
+	return create(l, path, shortcut)
+}
+
+func (l *Local) RemoveShortcut(id string) error {
+	path := fmt.Sprintf("%s/%s/%s", l.root(), pathShortcuts, id)
+	return remove(l, path)
+}
@@ -0,0 +1,94 @@
+package local
+
+import (
+	"assistant/pkg/models"
+	"fmt"
+	"slices"
+	"time"
+)
+
+func (l *Local) ListSources() ([]*models.Source, error) {
+	return list[models.Source](l, l.pathToSources())
+}
+
+func (l *Local) GetSource(id string) (*models.Source, error) {
+	return get[models.Source](l, l.pathToSource(id))
+}
+
+func (l *Local) SetSource(source *models.Source) error {
+	return set(l, l.pathToSource(source.ID), source)
+}
+
+func (l *Local) UpdateSource(id string, fields map[string]any) error {
+	return update(l, l.pathToSource(id), fields)
+}
+
+func (l *Local) CreateSource(source *models.Source) error {
+	return create(l, l.pathToSource(source.ID), source)
+}
+
+func (l *Local) DeleteSource(id string) error {
+	return remove(l, l.pathToSource(id))
+}
+
+func (l *Local) IncrementSourceCitations(id string) error {
+	return modify(l, l.pathToSource(id), false, func(doc map[string]any) error {
+		increment(doc, "citations", 1)
+		doc["updated_at"] = time.Now()
+		return nil
+	})
+}
+
+func (l *Local) IncrementUnknownSource(domain string) error {
+	return modify(l, l.pathToUnknownSource(domain), true, func(doc map[string]any) error {
+		doc["domain"] = domain
+		increment(doc, "citations", 1)
+		doc["updated_at"] = time.Now()
+		return nil
+	})
+}
+
+func (l *Local) ListUnknownSources() ([]*models.UnknownSource, error) {
+	return list[models.UnknownSource](l, l.pathToUnknownSources())
+}
+
+func (l *Local) DeleteUnknownSource(domain string) error {
+	return remove(l, l.pathToUnknownSource(domain))
+}
+
+func (l *Local) pathToUnknownSources() string {
+	return fmt.Sprintf("%s/%s", l.root(), pathUnknownSources)
+}
+
+func (l *Local) pathToUnknownSource(domain string) string {
+	return fmt.Sprintf("%s/%s", l.pathToUnknownSources(), domain)
+}
+
+func (l *Local) FindSourcesByDomain(input string) ([]*models.Source, error) {
+	return query(l, QueryCriteria[models.Source]{
+		Path:   l.pathToSources(),
+		Filter: func(s *models.Source) bool { return slices.Contains(s.URLs, input) },
+	})
+}
+
+func (l *Local) FindSourcesByIdentities(input []string) ([]*models.Source, error) {
+	return query(l, QueryCriteria[models.Source]{
+		Path:   l.pathToSources(),
+		Filter: func(s *models.Source) bool { return containsAny(s.URLs, input) },
+	})
+}
+
+func (l *Local) FindSourcesByKeywords(input []string) ([]*models.Source, error) {
+	return query(l, QueryCriteria[models.Source]{
+		Path:   l.pathToSources(),
+		Filter: func(s *models.Source) bool { return containsAny(s.Keywords, input) },
+	})
+}
+
+func (l *Local) pathToSources() string {
+	return fmt.Sprintf("%s/%s", l.root(), pathSources)
+}
+
+func (l *Local) pathToSource(id string) string {
+	return fmt.Sprintf("%s/%s", l.pathToSources(), id)
+}
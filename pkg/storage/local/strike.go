@@ -0,0 +1,38 @@
+package local
+
+import (
+	"assistant/pkg/models"
+	"fmt"
+	"time"
+)
+
+const pathStrikes = "strikes"
+
+func (l *Local) pathToStrikes(channel string) string {
+	return fmt.Sprintf("%s/%s/%s/%s", l.root(), pathChannels, channel, pathStrikes)
+}
+
+func (l *Local) Strikes(channel string) ([]*models.Strike, error) {
+	return query(l, QueryCriteria[models.Strike]{
+		Path: l.pathToStrikes(channel),
+		Less: func(a, b *models.Strike) bool { return a.CreatedAt.After(b.CreatedAt) },
+	})
+}
+
+// UserStrikes returns the active strikes recorded against the user, by account or host, newest first.
+func (l *Local) UserStrikes(channel, nick, host, account string) ([]*models.Strike, error) {
+	now := time.Now()
+	return query(l, QueryCriteria[models.Strike]{
+		Path:   l.pathToStrikes(channel),
+		Filter: func(s *models.Strike) bool { return s.IsActive(now) && s.IsFor(nick, host, account) },
+		Less:   func(a, b *models.Strike) bool { return a.CreatedAt.After(b.CreatedAt) },
+	})
+}
+
+func (l *Local) AddStrike(channel string, strike *models.Strike) error {
+	return create(l, fmt.Sprintf("%s/%s", l.pathToStrikes(channel), strike.ID), strike)
+}
+
+func (l *Local) DeleteStrike(channel, id string) error {
+	return remove(l, fmt.Sprintf("%s/%s", l.pathToStrikes(channel), id))
+}
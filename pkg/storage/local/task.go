@@ -0,0 +1,193 @@
+package local
+
+import (
+	"assistant/pkg/api/irc"
+	"assistant/pkg/log"
+	"assistant/pkg/models"
+	"assistant/pkg/scheduler"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+func (l *Local) Task(path string) (*models.Task, error) {
+	task, err := get[models.Task](l, path)
+	if task != nil {
+		// tasks stored before networks were configured don't record one
+		task.Network = l.cfg.IRC.Name
+	}
+	return task, err
+}
+
+func (l *Local) SetTask(task *models.Task) error {
+	task.Network = l.cfg.IRC.Name
+	return set(l, l.TaskPath(task), task)
+}
+
+func (l *Local) TaskPath(task *models.Task) string {
+	switch task.Type {
+	case models.TaskTypeReconnect:
+		return fmt.Sprintf("%s/%s/%s", l.root(), pathTasks, task.ID)
+	case models.TaskTypeReminder:
+		data := task.Data.(models.ReminderTaskData)
+		return fmt.Sprintf("%s/%s", l.tasksPath(data.User, data.Destination, task.Type), task.ID)
+	case models.TaskTypeBanRemoval:
+		data := task.Data.(models.BanRemovalTaskData)
+		return fmt.Sprintf("%s/%s", l.tasksPath("", data.Channel, task.Type), task.ID)
+	case models.TaskTypeMuteRemoval:
+		data := task.Data.(models.MuteRemovalTaskData)
+		return fmt.Sprintf("%s/%s", l.tasksPath("", data.Channel, task.Type), task.ID)
+	case models.TaskTypeNotifyVoiceRequests:
+		data := task.Data.(models.NotifyVoiceRequestsTaskData)
+		return fmt.Sprintf("%s/%s", l.tasksPath("", data.Channel, task.Type), task.ID)
+	case models.TaskTypePersistentChannel, models.TaskTypePersistentChannelStats, models.TaskTypePersistentChannelFeeds:
+		data := task.Data.(models.PersistentTaskData)
+		return l.PersistentChannelTaskPath(data.Channel, task.ID)
+	case models.TaskTypeDisinformationMutePenaltyRemoval:
+		data := task.Data.(models.DisinformationMutePenaltyRemovalTaskData)
+		return fmt.Sprintf("%s/%s", l.tasksPath("", data.Channel, task.Type), task.ID)
+	case models.TaskTypeDisinformationBanPenaltyRemoval:
+		data := task.Data.(models.DisinformationBanPenaltyRemovalTaskData)
+		return fmt.Sprintf("%s/%s", l.tasksPath("", data.Channel, task.Type), task.ID)
+	case models.TaskTypePollClose:
+		data := task.Data.(models.PollCloseTaskData)
+		return fmt.Sprintf("%s/%s", l.tasksPath("", data.Channel, task.Type), task.ID)
+	}
+	return "unknown"
+}
+
+func (l *Local) tasksPath(user, destination, taskType string) string {
+	switch taskType {
+	case models.TaskTypeReminder:
+		if !irc.IsChannel(destination) {
+			return fmt.Sprintf("%s/%s/%s/%s", l.root(), pathUsers, user, pathTasks)
+		} else {
+			return fmt.Sprintf("%s/%s/%s/%s/%s/%s", l.root(), pathChannels, destination, pathUsers, user, pathTasks)
+		}
+	case models.TaskTypeBanRemoval, models.TaskTypeMuteRemoval, models.TaskTypeNotifyVoiceRequests, models.TaskTypeDisinformationMutePenaltyRemoval, models.TaskTypeDisinformationBanPenaltyRemoval, models.TaskTypePollClose:
+		return fmt.Sprintf("%s/%s/%s/%s", l.root(), pathChannels, destination, pathTasks)
+	default:
+		log.Logger().Errorf(nil, "can't create path for unknown task type: %s", taskType)
+		return "unknown"
+	}
+}
+
+func (l *Local) AddTask(task *models.Task) error {
+	logger := log.Logger()
+
+	task.Network = l.cfg.IRC.Name
+	path := l.TaskPath(task)
+	logger.Debugf(nil, "creating task %s: %s", task.Type, path)
+
+	if err := create(l, path, task); err != nil {
+		logger.Warningf(nil, "error creating task, %s", err)
+		return err
+	}
+
+	cloudTaskName, err := scheduler.Get().CreateTask(task)
+	if err != nil {
+		logger.Errorf(nil, "error creating cloud task %s: %s", task.ID, err)
+		return err
+	}
+
+	task.CloudTaskName = cloudTaskName
+	if err := update(l, path, map[string]any{"cloud_task_name": cloudTaskName}); err != nil {
+		logger.Warningf(nil, "error storing cloud task name for %s: %s", task.ID, err)
+	}
+
+	return nil
+}
+
+func (l *Local) CompleteTask(task *models.Task) error {
+	logger := log.Logger()
+
+	path := l.TaskPath(task)
+	logger.Debugf(nil, "completing task %s: %s", task.ID, path)
+
+	if task.Status == models.TaskStatusCancelled && len(task.CloudTaskName) > 0 {
+		if err := scheduler.Get().DeleteTask(task.CloudTaskName); err != nil {
+			logger.Warningf(nil, "error deleting cloud task %s: %s", task.CloudTaskName, err)
+		}
+	}
+
+	return update(l, path, map[string]any{"status": task.Status, "runs": task.Runs})
+}
+
+// RescheduleTask replaces the scheduled run of an existing task with one at its current due time and stores the task.
+func (l *Local) RescheduleTask(task *models.Task) error {
+	logger := log.Logger()
+
+	if len(task.CloudTaskName) > 0 {
+		if err := scheduler.Get().DeleteTask(task.CloudTaskName); err != nil {
+			logger.Warningf(nil, "error deleting cloud task %s: %s", task.CloudTaskName, err)
+		}
+	}
+
+	cloudTaskName, err := scheduler.Get().CreateTask(task)
+	if err != nil {
+		logger.Errorf(nil, "error creating cloud task %s: %s", task.ID, err)
+		return err
+	}
+
+	task.CloudTaskName = cloudTaskName
+	return l.SetTask(task)
+}
+
+func (l *Local) GetPendingTasks(user, destination, taskType string) ([]*models.Task, error) {
+	tasks, err := query(l, QueryCriteria[models.Task]{
+		Path: l.tasksPath(user, destination, taskType),
+		Filter: func(t *models.Task) bool {
+			return t.Type == taskType && t.Status == models.TaskStatusPending
+		},
+		Less: func(a, b *models.Task) bool { return a.DueAt.Before(b.DueAt) },
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return populateTaskData(tasks)
+}
+
+// LastDeliveredReminder returns the user's reminder in the destination that was most recently delivered, if that was
+// after since.
+func (l *Local) LastDeliveredReminder(user, destination string, since time.Time) (*models.Task, error) {
+	tasks, err := query(l, QueryCriteria[models.Task]{
+		Path: l.tasksPath(user, destination, models.TaskTypeReminder),
+		Filter: func(t *models.Task) bool {
+			return t.Type == models.TaskTypeReminder
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if tasks, err = populateTaskData(tasks); err != nil {
+		return nil, err
+	}
+
+	var last *models.Task
+	for _, task := range tasks {
+		deliveredAt := task.Data.(models.ReminderTaskData).DeliveredAt
+		if deliveredAt.After(since) && (last == nil || deliveredAt.After(last.Data.(models.ReminderTaskData).DeliveredAt)) {
+			last = task
+		}
+	}
+
+	return last, nil
+}
+
+// populateTaskData replaces the untyped data of stored tasks with their typed task data.
+func populateTaskData(tasks []*models.Task) ([]*models.Task, error) {
+	for i, task := range tasks {
+		d, err := json.Marshal(task)
+		if err != nil {
+			return nil, err
+		}
+
+		if tasks[i], err = models.DeserializeTask(d); err != nil {
+			return nil, err
+		}
+	}
+
+	return tasks, nil
+}
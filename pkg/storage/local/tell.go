@@ -0,0 +1,27 @@
+package local
+
+import (
+	"assistant/pkg/models"
+	"fmt"
+)
+
+const pathTells = "tells"
+
+func (l *Local) pathToTells(channel string) string {
+	return fmt.Sprintf("%s/%s/%s/%s", l.root(), pathChannels, channel, pathTells)
+}
+
+func (l *Local) Tells(channel string) ([]*models.Tell, error) {
+	return query(l, QueryCriteria[models.Tell]{
+		Path: l.pathToTells(channel),
+		Less: func(a, b *models.Tell) bool { return a.CreatedAt.Before(b.CreatedAt) },
+	})
+}
+
+func (l *Local) CreateTell(channel string, tell *models.Tell) error {
+	return create(l, fmt.Sprintf("%s/%s", l.pathToTells(channel), tell.ID), tell)
+}
+
+func (l *Local) DeleteTell(channel, id string) error {
+	return remove(l, fmt.Sprintf("%s/%s", l.pathToTells(channel), id))
+}
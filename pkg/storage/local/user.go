@@ -0,0 +1,90 @@
+package local
+
+import (
+	"assistant/pkg/api/irc"
+	"assistant/pkg/models"
+	"fmt"
+	"strings"
+)
+
+func (l *Local) pathToUsers(channel string) string {
+	return fmt.Sprintf("%s/%s/%s/%s", l.root(), pathChannels, channel, pathUsers)
+}
+
+func (l *Local) GetUser(channel string, mask *irc.Mask) (*models.User, error) {
+	users, err := l.GetAllMatchingUsers(channel, mask)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(users) == 0 {
+		return nil, nil
+	}
+
+	return users[0], nil
+}
+
+func (l *Local) GetAllMatchingUsers(channel string, mask *irc.Mask) ([]*models.User, error) {
+	return query(l, QueryCriteria[models.User]{
+		Path:   l.pathToUsers(channel),
+		Filter: func(u *models.User) bool { return u.Nick == mask.Nick || u.Host == mask.Host },
+	})
+}
+
+func (l *Local) GetUsersByHost(channel, host string) ([]*models.User, error) {
+	return query(l, QueryCriteria[models.User]{
+		Path:   l.pathToUsers(channel),
+		Filter: func(u *models.User) bool { return u.Host == host },
+	})
+}
+
+func (l *Local) GetUsersByUserID(channel, userID string) ([]*models.User, error) {
+	return query(l, QueryCriteria[models.User]{
+		Path:   l.pathToUsers(channel),
+		Filter: func(u *models.User) bool { return u.UserID == userID },
+	})
+}
+
+func (l *Local) GetUsersByAccount(channel, account string) ([]*models.User, error) {
+	return query(l, QueryCriteria[models.User]{
+		Path:   l.pathToUsers(channel),
+		Filter: func(u *models.User) bool { return u.Account == account },
+	})
+}
+
+func (l *Local) GetUsersByMask(channel, nick, userID, host string) ([]*models.User, error) {
+	isWild := func(s string) bool { return s == "" || s == "*" || strings.Contains(s, "*") }
+
+	if isWild(nick) && isWild(userID) && isWild(host) {
+		return nil, nil
+	}
+
+	return query(l, QueryCriteria[models.User]{
+		Path: l.pathToUsers(channel),
+		Filter: func(u *models.User) bool {
+			return (isWild(nick) || u.Nick == nick) &&
+				(isWild(userID) || u.UserID == userID) &&
+				(isWild(host) || u.Host == host)
+		},
+	})
+}
+
+func (l *Local) GetAllUsers(channel string) ([]*models.User, error) {
+	return list[models.User](l, l.pathToUsers(channel))
+}
+
+func (l *Local) GetUserByNick(channel, nick string) (*models.User, error) {
+	return get[models.User](l, fmt.Sprintf("%s/%s", l.pathToUsers(channel), nick))
+}
+
+func (l *Local) CreateUser(channel string, user *models.User) error {
+	return create(l, fmt.Sprintf("%s/%s", l.pathToUsers(channel), user.Nick), user)
+}
+
+func (l *Local) SetUser(channel string, user *models.User) error {
+	return set(l, fmt.Sprintf("%s/%s", l.pathToUsers(channel), user.Nick), user)
+}
+
+func (l *Local) UpdateUser(channel string, user *models.User, fields map[string]any) error {
+	return update(l, fmt.Sprintf("%s/%s", l.pathToUsers(channel), user.Nick), fields)
+}
@@ -0,0 +1,213 @@
+package storage
+
+import (
+	"assistant/pkg/api/irc"
+	"assistant/pkg/config"
+	"assistant/pkg/firestore"
+	"assistant/pkg/models"
+	"assistant/pkg/storage/local"
+	"context"
+	"fmt"
+	"time"
+)
+
+var instance Store
+var networks = make(map[string]Store)
+
+// Store is the persistence surface used by the assistant. It is implemented by the Cloud Firestore backend and by an
+// embedded local backend, selected with the storage.backend config key.
+type Store interface {
+	Assistant() (*models.Assistant, error)
+	CreateAssistant() (*models.Assistant, error)
+	SetAssistant(assistant *models.Assistant) error
+	UpdateAssistant(fields map[string]any) error
+
+	CreateAuthToken(token *models.AuthToken) error
+	GetAuthToken(token string) (*models.AuthToken, error)
+	MarkAuthTokenUsed(token string) error
+
+	BannedWords(channel string) ([]*models.BannedWord, error)
+	AddBannedWord(channel string, bw *models.BannedWord) error
+	UpdateBannedWord(channel, oldWord, newWord string) error
+	IsBannedWord(channel, word string) (bool, error)
+	RemoveBannedWord(channel, word string) error
+
+	Channels() ([]*models.Channel, error)
+	Channel(channel string) (*models.Channel, error)
+	UpdateChannel(channel string, fields map[string]any) error
+	CreateChannel(channel *models.Channel) error
+
+	AddChannelStats(channel string, stats *models.ChannelStats) error
+	GetChannelStats(channel string, since time.Time) ([]*models.ChannelStats, error)
+
+	IncrementCommandUsage(channel, commandName string) error
+	ListCommandUsage(channel string) ([]*models.CommandUsage, error)
+
+	CommunityNote(channel, id string) (*models.CommunityNote, error)
+	CommunityNotes(channel string) ([]*models.CommunityNote, error)
+	CommunityNoteForSource(channel, source string) (*models.CommunityNote, error)
+	CreateCommunityNote(channel string, note *models.CommunityNote) error
+	SetCommunityNote(channel string, note *models.CommunityNote) error
+	DeleteCommunityNote(channel, id string) error
+
+	DisinformationSources(channel string) ([]*models.DisinformationSource, error)
+	DisinformationSource(channel, source string) (*models.DisinformationSource, error)
+	AddDisinformationSource(channel, source string) error
+	DeleteDisinformationSource(channel, source string) error
+	IsDisinformationSource(channel, source string) bool
+	ReloadDisinformationSources(channel string) error
+
+	Factoid(channel, key string) (*models.Factoid, error)
+	Factoids(channel string) ([]*models.Factoid, error)
+	FindFactoids(channel string, keywords []string) ([]*models.Factoid, error)
+	SetFactoid(channel string, factoid *models.Factoid) error
+	DeleteFactoid(channel, key string) error
+
+	KarmaHistory(channel, nick string) ([]*models.KarmaHistory, error)
+	SaveKarmaHistory(channel, nick string, kh *models.KarmaHistory) error
+
+	CreateLLMResponse(r *models.LLMResponse) error
+	LLMResponse(id string) (*models.LLMResponse, error)
+	UpdateLLMResponse(id, content string) error
+	LLMResponsesBySession(sessionID string) ([]*models.LLMResponse, error)
+
+	PersistentChannelTaskPath(channel, id string) string
+	SetPersistentChannelTaskDue(channel, id string, duration time.Duration) error
+	UpdatePersistentChannelTaskDue(channel, id string, dueAt time.Time) error
+
+	PersonalNote(nick, id string) (*models.PersonalNote, error)
+	PersonalNotes(nick string) ([]*models.PersonalNote, error)
+	PersonalNotesMatchingKeywords(nick string, keywords []string) ([]*models.PersonalNote, error)
+	PersonalNotesMatchingSource(nick, source string) ([]*models.PersonalNote, error)
+	CreatePersonalNote(nick string, note *models.PersonalNote) error
+	SetPersonalNote(nick string, note *models.PersonalNote) error
+	DeletePersonalNote(nick, id string) error
+
+	AddRoleChange(channel string, change *models.RoleChange) error
+	RoleChanges(channel string) ([]*models.RoleChange, error)
+
+	Quotes(channel string) ([]*models.Quote, error)
+	FindUserQuotes(channel, nick string) ([]*models.Quote, error)
+	FindUserQuotesWithContent(channel, nick string, keywords []string) ([]*models.Quote, error)
+	FindQuotes(channel string, keywords []string) ([]*models.Quote, error)
+	CreateQuote(channel string, quote *models.Quote) error
+	UpdateQuote(channel string, quote *models.Quote, fields map[string]any) error
+
+	Shortcut(id string) (*models.Shortcut, error)
+	Shortcuts() ([]*models.Shortcut, error)
+	CreateShortcut(shortcut *models.Shortcut) error
+	RemoveShortcut(id string) error
+
+	ListSources() ([]*models.Source, error)
+	GetSource(id string) (*models.Source, error)
+	SetSource(source *models.Source) error
+	UpdateSource(id string, fields map[string]any) error
+	CreateSource(source *models.Source) error
+	DeleteSource(id string) error
+	IncrementSourceCitations(id string) error
+	IncrementUnknownSource(domain string) error
+	ListUnknownSources() ([]*models.UnknownSource, error)
+	DeleteUnknownSource(domain string) error
+	FindSourcesByDomain(input string) ([]*models.Source, error)
+	FindSourcesByIdentities(input []string) ([]*models.Source, error)
+	FindSourcesByKeywords(input []string) ([]*models.Source, error)
+
+	Task(path string) (*models.Task, error)
+	SetTask(task *models.Task) error
+	TaskPath(task *models.Task) string
+	AddTask(task *models.Task) error
+	CompleteTask(task *models.Task) error
+	RescheduleTask(task *models.Task) error
+	GetPendingTasks(user, destination, taskType string) ([]*models.Task, error)
+	LastDeliveredReminder(user, destination string, since time.Time) (*models.Task, error)
+
+	Poll(channel, id string) (*models.Poll, error)
+	Polls(channel string) ([]*models.Poll, error)
+	CreatePoll(channel string, poll *models.Poll) error
+	UpdatePoll(channel string, poll *models.Poll, fields map[string]any) error
+
+	Feed(channel, id string) (*models.Feed, error)
+	Feeds(channel string) ([]*models.Feed, error)
+	SetFeed(channel string, feed *models.Feed) error
+	DeleteFeed(channel, id string) error
+
+	Strikes(channel string) ([]*models.Strike, error)
+	UserStrikes(channel, nick, host, account string) ([]*models.Strike, error)
+	AddStrike(channel string, strike *models.Strike) error
+	DeleteStrike(channel, id string) error
+
+	AddAuditEntry(channel string, entry *models.AuditEntry) error
+	AuditEntries(channel string) ([]*models.AuditEntry, error)
+	AuditEntriesSince(channel string, since time.Time) ([]*models.AuditEntry, error)
+
+	Tells(channel string) ([]*models.Tell, error)
+	CreateTell(channel string, tell *models.Tell) error
+	DeleteTell(channel, id string) error
+
+	GetUser(channel string, mask *irc.Mask) (*models.User, error)
+	GetAllMatchingUsers(channel string, mask *irc.Mask) ([]*models.User, error)
+	GetUsersByHost(channel, host string) ([]*models.User, error)
+	GetUsersByUserID(channel, userID string) ([]*models.User, error)
+	GetUsersByAccount(channel, account string) ([]*models.User, error)
+	GetUsersByMask(channel, nick, userID, host string) ([]*models.User, error)
+	GetAllUsers(channel string) ([]*models.User, error)
+	GetUserByNick(channel, nick string) (*models.User, error)
+	CreateUser(channel string, user *models.User) error
+	SetUser(channel string, user *models.User) error
+	UpdateUser(channel string, user *models.User, fields map[string]any) error
+
+	Close() error
+}
+
+var _ Store = (*firestore.Firestore)(nil)
+var _ Store = (*local.Local)(nil)
+
+func Get() Store {
+	if instance == nil {
+		panic("storage is not initialized")
+	}
+
+	return instance
+}
+
+// Network returns the store for a network's channels, users, notes and tasks. Data shared by every network, such as
+// sources, shortcuts and dashboard auth tokens, is kept in the store returned by Get, which is also what Network
+// returns when only one network is configured.
+func Network(name string) Store {
+	if s, ok := networks[name]; ok {
+		return s
+	}
+
+	return Get()
+}
+
+func Initialize(ctx context.Context, cfg *config.Config) (Store, error) {
+	if instance != nil {
+		return instance, nil
+	}
+
+	switch cfg.Storage.Backend {
+	case "", config.StorageBackendFirestore:
+		fs, err := firestore.Initialize(ctx, cfg)
+		if err != nil {
+			return nil, err
+		}
+		instance = fs
+		for _, n := range cfg.IRC.Networks {
+			networks[n.Name] = fs.WithNetwork(cfg.Network(n.Name))
+		}
+	case config.StorageBackendLocal:
+		l, err := local.Initialize(ctx, cfg)
+		if err != nil {
+			return nil, err
+		}
+		instance = l
+		for _, n := range cfg.IRC.Networks {
+			networks[n.Name] = l.WithNetwork(cfg.Network(n.Name))
+		}
+	default:
+		return nil, fmt.Errorf("unknown storage backend, %s", cfg.Storage.Backend)
+	}
+
+	return instance, nil
+}